@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"math"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/logger"
@@ -28,6 +31,31 @@ func NewAICoreHandler(aicoreService service.AICoreServiceInterface, validator *v
 
 // handleAICoreError handles common AI Core service errors and returns appropriate HTTP responses
 func (h *AICoreHandler) handleAICoreError(c *gin.Context, err error) {
+	if rateErr, ok := errors.AsAICoreRateLimitError(err); ok {
+		if rateErr.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateErr.RetryAfter.Seconds()))))
+		}
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": rateErr.Error()})
+		return
+	}
+
+	if errors.IsUploadRateLimited(err) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+
+	if apiErr, ok := errors.AsAICoreAPIError(err); ok {
+		// Upstream client errors are passed through; auth failures against AI Core are our
+		// credentials' problem, not the caller's, so they are reported as server errors
+		status := http.StatusInternalServerError
+		if apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 &&
+			apiErr.StatusCode != http.StatusUnauthorized && apiErr.StatusCode != http.StatusForbidden {
+			status = apiErr.StatusCode
+		}
+		c.JSON(status, gin.H{"error": apiErr.Message, "upstreamStatus": apiErr.StatusCode})
+		return
+	}
+
 	switch {
 	case errors.IsAuthentication(err):
 		c.JSON(http.StatusUnauthorized, gin.H{"error": errors.ErrAuthenticationRequired.Error()})
@@ -37,6 +65,8 @@ func (h *AICoreHandler) handleAICoreError(c *gin.Context, err error) {
 		c.JSON(http.StatusForbidden, gin.H{"error": errors.ErrAICoreCredentialsNotConfigured.Message})
 	case errors.IsNotFound(err):
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 	default:
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 	}
@@ -48,6 +78,8 @@ func (h *AICoreHandler) handleAICoreError(c *gin.Context, err error) {
 // @Tags ai-core
 // @Accept json
 // @Produce json
+// @Param status query string false "Comma-separated deployment statuses to keep, e.g. RUNNING,PENDING"
+// @Param executableIds query string false "Comma-separated executable IDs to keep, filtered by AI Core"
 // @Success 200 {object} service.AICoreDeploymentsResponse "Successfully retrieved deployments"
 // @Failure 400 {object} map[string]interface{} "Bad request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
@@ -56,7 +88,15 @@ func (h *AICoreHandler) handleAICoreError(c *gin.Context, err error) {
 // @Security BearerAuth
 // @Router /ai-core/deployments [get]
 func (h *AICoreHandler) GetDeployments(c *gin.Context) {
-	deployments, err := h.aicoreService.GetDeployments(c)
+	var filter service.AICoreDeploymentFilter
+	if status := c.Query("status"); status != "" {
+		filter.Statuses = strings.Split(status, ",")
+	}
+	if executableIDs := c.Query("executableIds"); executableIDs != "" {
+		filter.ExecutableIDs = strings.Split(executableIDs, ",")
+	}
+
+	deployments, err := h.aicoreService.GetDeploymentsByStatus(c, filter)
 	if err != nil {
 		logger.FromGinContext(c).WithField("handler", "GetDeployments").
 			Errorf("AI Core: GetDeployments failed: %v", err)
@@ -67,6 +107,27 @@ func (h *AICoreHandler) GetDeployments(c *gin.Context) {
 	c.JSON(http.StatusOK, deployments)
 }
 
+// GetDeploymentSummaries handles GET /ai-core/deployment-summaries
+// @Summary Get AI Core deployment summaries
+// @Description Get the ID, model name, scenario and status of every deployment the user can access, for model selection
+// @Tags ai-core
+// @Accept json
+// @Produce json
+// @Success 200 {array} service.DeploymentSummary "Successfully retrieved deployment summaries"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "User not assigned to team or team credentials not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /ai-core/deployment-summaries [get]
+func (h *AICoreHandler) GetDeploymentSummaries(c *gin.Context) {
+	summaries, err := h.aicoreService.GetDeploymentSummaries(c)
+	if err != nil {
+		h.handleAICoreError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summaries)
+}
+
 // GetModels handles GET /ai-core/models
 // @Summary Get AI Core models
 // @Description Get all available models from AI Core for a specific scenario
@@ -126,6 +187,37 @@ func (h *AICoreHandler) GetConfigurations(c *gin.Context) {
 	c.JSON(http.StatusOK, configurations)
 }
 
+// GetConfigurationDetails handles GET /ai-core/configurations/{configurationId}
+// @Summary Get AI Core configuration details
+// @Description Get a single configuration, including its parameter bindings, from AI Core for the authenticated user's team
+// @Tags ai-core
+// @Accept json
+// @Produce json
+// @Param configurationId path string true "Configuration ID"
+// @Success 200 {object} service.AICoreConfiguration "Successfully retrieved configuration"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "User not assigned to team or team credentials not found"
+// @Failure 404 {object} map[string]interface{} "Configuration not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /ai-core/configurations/{configurationId} [get]
+func (h *AICoreHandler) GetConfigurationDetails(c *gin.Context) {
+	configurationID := c.Param("configurationId")
+	if configurationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrMissingConfigurationID})
+		return
+	}
+
+	configuration, err := h.aicoreService.GetConfigurationDetails(c, configurationID)
+	if err != nil {
+		h.handleAICoreError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, configuration)
+}
+
 // CreateConfiguration handles POST /ai-core/configurations
 // @Summary Create AI Core configuration
 // @Description Create a new configuration in AI Core for the authenticated user's team
@@ -169,10 +261,12 @@ func (h *AICoreHandler) CreateConfiguration(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param deployment body service.AICoreDeploymentRequest true "Deployment data - either configurationId or configurationRequest must be provided"
+// @Success 200 {object} service.AICoreDeploymentResponse "Dry run succeeded, no deployment was created"
 // @Success 202 {object} service.AICoreDeploymentResponse "Successfully scheduled deployment"
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Failure 403 {object} map[string]interface{} "User not assigned to team or team credentials not found"
+// @Failure 404 {object} map[string]interface{} "Configuration not found (dry run)"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /ai-core/deployments [post]
@@ -217,6 +311,11 @@ func (h *AICoreHandler) CreateDeployment(c *gin.Context) {
 		return
 	}
 
+	if deployment.DryRun {
+		c.JSON(http.StatusOK, deployment)
+		return
+	}
+
 	c.JSON(http.StatusAccepted, deployment)
 }
 
@@ -285,6 +384,27 @@ func (h *AICoreHandler) GetMe(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// GetProfileSummary handles GET /ai-core/me/summary
+// @Summary Get current user profile summary
+// @Description Returns the current user's identity, AI instances, favorites and subscription counts and portal admin flag in one call
+// @Tags ai-core
+// @Accept json
+// @Produce json
+// @Success 200 {object} service.ProfileSummaryResponse "Successfully retrieved profile summary"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 403 {object} map[string]interface{} "Forbidden"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /ai-core/me/summary [get]
+func (h *AICoreHandler) GetProfileSummary(c *gin.Context) {
+	resp, err := h.aicoreService.GetProfileSummary(c)
+	if err != nil {
+		h.handleAICoreError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 // GetDeploymentDetails handles GET /ai-core/deployments/{deploymentId}
 // @Summary Get AI Core deployment details
 // @Description Get detailed information about a specific deployment from AI Core
@@ -323,6 +443,7 @@ func (h *AICoreHandler) GetDeploymentDetails(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param deploymentId path string true "Deployment ID"
+// @Param deleteConfiguration query bool false "Also delete the deployment's configuration once the deployment is gone; failures are reported in configurationError"
 // @Success 202 {object} service.AICoreDeploymentDeletionResponse "Successfully scheduled deployment deletion"
 // @Failure 400 {object} map[string]interface{} "Bad request"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
@@ -338,7 +459,17 @@ func (h *AICoreHandler) DeleteDeployment(c *gin.Context) {
 		return
 	}
 
-	response, err := h.aicoreService.DeleteDeployment(c, deploymentID)
+	deleteConfiguration := false
+	if raw := c.Query("deleteConfiguration"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid deleteConfiguration parameter"})
+			return
+		}
+		deleteConfiguration = parsed
+	}
+
+	response, err := h.aicoreService.DeleteDeployment(c, deploymentID, deleteConfiguration)
 	if err != nil {
 		h.handleAICoreError(c, err)
 		return
@@ -421,6 +552,7 @@ func (h *AICoreHandler) streamChatInference(c *gin.Context, req *service.AICoreI
 // @Success 200 {object} map[string]interface{} "Successfully uploaded files"
 // @Failure 400 {object} map[string]interface{} "Invalid files or size"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 429 {object} map[string]interface{} "Upload rate limit exceeded"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /ai-core/upload [post]
@@ -471,7 +603,7 @@ func (h *AICoreHandler) UploadAttachment(c *gin.Context) {
 	}
 
 	// Process all files
-	uploadedFiles := make([]map[string]interface{}, 0, len(fileHeaders))
+	uploadedFiles := make([]*service.AttachmentResponse, 0, len(fileHeaders))
 
 	for _, header := range fileHeaders {
 		file, err := header.Open()
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/mocks"
@@ -41,6 +42,7 @@ func (suite *AICoreHandlerTestSuite) SetupTest() {
 	suite.router.GET("/ai-core/deployments/:deploymentId", suite.handler.GetDeploymentDetails)
 	suite.router.GET("/ai-core/models", suite.handler.GetModels)
 	suite.router.GET("/ai-core/configurations", suite.handler.GetConfigurations)
+	suite.router.GET("/ai-core/configurations/:configurationId", suite.handler.GetConfigurationDetails)
 	suite.router.POST("/ai-core/configurations", suite.handler.CreateConfiguration)
 	suite.router.POST("/ai-core/deployments", suite.handler.CreateDeployment)
 	suite.router.PATCH("/ai-core/deployments/:deploymentId", suite.handler.UpdateDeployment)
@@ -87,7 +89,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_Success() {
 		},
 	}
 
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(expectedResponse, nil)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(expectedResponse, nil)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -108,6 +110,35 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_Success() {
 	suite.Equal("RUNNING", response.Deployments[0].Deployments[0].Status)
 }
 
+func (suite *AICoreHandlerTestSuite) TestGetDeployments_StatusFilter() {
+	// Setup - The comma-separated status query is passed on to the service
+	expectedResponse := &service.AICoreDeploymentsResponse{Deployments: []service.AICoreTeamDeployments{}}
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), service.AICoreDeploymentFilter{Statuses: []string{"RUNNING", "PENDING"}}).Return(expectedResponse, nil)
+
+	// Execute
+	req := httptest.NewRequest("GET", "/ai-core/deployments?status=RUNNING,PENDING", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *AICoreHandlerTestSuite) TestGetDeployments_ExecutableIDsFilter() {
+	// Setup - Status and executable filters are both passed on to the service
+	expectedResponse := &service.AICoreDeploymentsResponse{Deployments: []service.AICoreTeamDeployments{}}
+	expectedFilter := service.AICoreDeploymentFilter{Statuses: []string{"RUNNING"}, ExecutableIDs: []string{"azure-openai", "aws-bedrock"}}
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), expectedFilter).Return(expectedResponse, nil)
+
+	// Execute
+	req := httptest.NewRequest("GET", "/ai-core/deployments?status=RUNNING&executableIds=azure-openai,aws-bedrock", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusOK, w.Code)
+}
+
 func (suite *AICoreHandlerTestSuite) TestGetDeployments_PartialCredentials_Success() {
 	// Setup - Only one team has credentials, the other is skipped
 	expectedResponse := &service.AICoreDeploymentsResponse{
@@ -130,7 +161,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_PartialCredentials_Succe
 		},
 	}
 
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(expectedResponse, nil)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(expectedResponse, nil)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -155,7 +186,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_EmptyResult_Success() {
 		Deployments: []service.AICoreTeamDeployments{},
 	}
 
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(expectedResponse, nil)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(expectedResponse, nil)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -174,7 +205,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_EmptyResult_Success() {
 
 func (suite *AICoreHandlerTestSuite) TestGetDeployments_AuthenticationError() {
 	// Setup
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(nil, errors.ErrUserEmailNotFound)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(nil, errors.ErrUserEmailNotFound)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -192,7 +223,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_AuthenticationError() {
 
 func (suite *AICoreHandlerTestSuite) TestGetDeployments_UserNotFoundError() {
 	// Setup
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(nil, errors.ErrUserNotFoundInDB)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(nil, errors.ErrUserNotFoundInDB)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -210,7 +241,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_UserNotFoundError() {
 
 func (suite *AICoreHandlerTestSuite) TestGetDeployments_UserNotAssignedToTeamError() {
 	// Setup
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(nil, errors.ErrUserNotAssignedToTeam)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(nil, errors.ErrUserNotAssignedToTeam)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -229,7 +260,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_UserNotAssignedToTeamErr
 func (suite *AICoreHandlerTestSuite) TestGetDeployments_NoCredentialsError() {
 	// Setup
 	credentialsError := errors.NewAICoreCredentialsNotFoundError("team-alpha")
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(nil, credentialsError)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(nil, credentialsError)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -247,7 +278,7 @@ func (suite *AICoreHandlerTestSuite) TestGetDeployments_NoCredentialsError() {
 
 func (suite *AICoreHandlerTestSuite) TestGetDeployments_InternalServerError() {
 	// Setup
-	suite.aicoreService.EXPECT().GetDeployments(gomock.Any()).Return(nil, errors.ErrInternalError)
+	suite.aicoreService.EXPECT().GetDeploymentsByStatus(gomock.Any(), gomock.Any()).Return(nil, errors.ErrInternalError)
 
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/deployments", nil)
@@ -298,6 +329,47 @@ func (suite *AICoreHandlerTestSuite) TestGetModels_Success() {
 	suite.Equal("gpt-4", response.Resources[0].Model)
 }
 
+func (suite *AICoreHandlerTestSuite) TestGetModels_UpstreamAPIError() {
+	// Setup
+	scenarioID := "foundation-models"
+	apiErr := &errors.AICoreAPIError{StatusCode: http.StatusBadRequest, Message: "Scenario not found", Team: "team-alpha"}
+	suite.aicoreService.EXPECT().GetModels(gomock.Any(), scenarioID).Return(nil, apiErr)
+
+	// Execute
+	req := httptest.NewRequest("GET", fmt.Sprintf("/ai-core/models?scenarioId=%s", scenarioID), nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("Scenario not found", response["error"])
+	suite.Equal(float64(http.StatusBadRequest), response["upstreamStatus"])
+}
+
+func (suite *AICoreHandlerTestSuite) TestDeleteDeployment_RateLimited() {
+	// Setup
+	rateErr := &errors.AICoreRateLimitError{Team: "team-alpha", RetryAfter: 30 * time.Second}
+	suite.aicoreService.EXPECT().DeleteDeployment(gomock.Any(), "deployment-123", false).Return(nil, rateErr)
+
+	// Execute
+	req := httptest.NewRequest("DELETE", "/ai-core/deployments/deployment-123", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusTooManyRequests, w.Code)
+	suite.Equal("30", w.Header().Get("Retry-After"))
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(rateErr.Error(), response["error"])
+}
+
 func (suite *AICoreHandlerTestSuite) TestGetModels_MissingScenarioID() {
 	// Execute
 	req := httptest.NewRequest("GET", "/ai-core/models", nil)
@@ -476,6 +548,39 @@ func (suite *AICoreHandlerTestSuite) TestCreateDeployment_WithConfigurationReque
 	suite.Equal("Deployment created successfully", response.Message)
 }
 
+func (suite *AICoreHandlerTestSuite) TestCreateDeployment_DryRun_ReturnsOK() {
+	// Setup
+	configID := "config-1"
+	requestBody := service.AICoreDeploymentRequest{
+		ConfigurationID: &configID,
+		DryRun:          true,
+	}
+
+	suite.aicoreService.EXPECT().CreateDeployment(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(c *gin.Context, req *service.AICoreDeploymentRequest) (*service.AICoreDeploymentResponse, error) {
+			if !req.DryRun {
+				return nil, fmt.Errorf("unexpected request")
+			}
+			return &service.AICoreDeploymentResponse{ConfigurationID: configID, DryRun: true}, nil
+		})
+
+	// Execute
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/ai-core/deployments", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response service.AICoreDeploymentResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.True(response.DryRun)
+	suite.Equal("config-1", response.ConfigurationID)
+}
+
 func (suite *AICoreHandlerTestSuite) TestCreateDeployment_BothFieldsProvided_Error() {
 	// Setup - Test invalid scenario: both configurationId and configurationRequest provided
 	configID := "config-1"
@@ -632,7 +737,7 @@ func (suite *AICoreHandlerTestSuite) TestDeleteDeployment_Success() {
 		Message: "Deployment deleted successfully",
 	}
 
-	suite.aicoreService.EXPECT().DeleteDeployment(gomock.Any(), deploymentID).Return(expectedResponse, nil)
+	suite.aicoreService.EXPECT().DeleteDeployment(gomock.Any(), deploymentID, false).Return(expectedResponse, nil)
 
 	// Execute
 	req := httptest.NewRequest("DELETE", fmt.Sprintf("/ai-core/deployments/%s", deploymentID), nil)
@@ -649,6 +754,32 @@ func (suite *AICoreHandlerTestSuite) TestDeleteDeployment_Success() {
 	suite.Equal("Deployment deleted successfully", response.Message)
 }
 
+func (suite *AICoreHandlerTestSuite) TestDeleteDeployment_WithConfiguration() {
+	// Setup
+	deploymentID := "deployment-1"
+	expectedResponse := &service.AICoreDeploymentDeletionResponse{ID: deploymentID}
+
+	suite.aicoreService.EXPECT().DeleteDeployment(gomock.Any(), deploymentID, true).Return(expectedResponse, nil)
+
+	// Execute
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/ai-core/deployments/%s?deleteConfiguration=true", deploymentID), nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusAccepted, w.Code)
+}
+
+func (suite *AICoreHandlerTestSuite) TestDeleteDeployment_InvalidDeleteConfiguration() {
+	// Execute
+	req := httptest.NewRequest("DELETE", "/ai-core/deployments/deployment-1?deleteConfiguration=maybe", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
 func (suite *AICoreHandlerTestSuite) TestGetDeploymentDetails_Success() {
 	// Setup
 	deploymentID := "deployment-1"
@@ -705,6 +836,44 @@ func (suite *AICoreHandlerTestSuite) TestGetDeploymentDetails_NotFound() {
 	suite.Contains(response["error"].(string), "not found")
 }
 
+func (suite *AICoreHandlerTestSuite) TestGetConfigurationDetails_Success() {
+	// Setup
+	expectedResponse := &service.AICoreConfiguration{
+		ID:                "config-123",
+		Name:              "gpt-4o-config",
+		ExecutableID:      "azure-openai",
+		ScenarioID:        "foundation-models",
+		ParameterBindings: []map[string]string{{"key": "modelName", "value": "gpt-4o"}},
+	}
+	suite.aicoreService.EXPECT().GetConfigurationDetails(gomock.Any(), "config-123").Return(expectedResponse, nil)
+
+	// Execute
+	req := httptest.NewRequest("GET", "/ai-core/configurations/config-123", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response service.AICoreConfiguration
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(*expectedResponse, response)
+}
+
+func (suite *AICoreHandlerTestSuite) TestGetConfigurationDetails_NotFound() {
+	// Setup
+	suite.aicoreService.EXPECT().GetConfigurationDetails(gomock.Any(), "missing-config").Return(nil, errors.ErrAICoreConfigurationNotFound)
+
+	// Execute
+	req := httptest.NewRequest("GET", "/ai-core/configurations/missing-config", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
 func (suite *AICoreHandlerTestSuite) TestGetConfigurations_Success() {
 	// Setup
 	expectedResponse := &service.AICoreConfigurationsResponse{
@@ -873,6 +1042,59 @@ func (suite *AICoreHandlerTestSuite) TestGetMe_Success() {
 	suite.Equal("team-beta", response.AIInstances[1])
 }
 
+func (suite *AICoreHandlerTestSuite) TestGetProfileSummary_Success() {
+	// Setup
+	expectedResponse := &service.ProfileSummaryResponse{
+		User:            "john.doe",
+		PortalAdmin:     true,
+		AIInstances:     []string{"team-alpha"},
+		FavoritesCount:  2,
+		SubscribedCount: 1,
+	}
+
+	suite.aicoreService.EXPECT().GetProfileSummary(gomock.Any()).Return(expectedResponse, nil)
+
+	// Execute
+	req := httptest.NewRequest("GET", "/ai-core/me/summary", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.GET("/ai-core/me/summary", suite.handler.GetProfileSummary)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response service.ProfileSummaryResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(*expectedResponse, response)
+}
+
+func (suite *AICoreHandlerTestSuite) TestGetDeploymentSummaries_Success() {
+	// Setup
+	expected := []service.DeploymentSummary{
+		{ID: "deployment-1", ModelName: "gpt-4o", ScenarioID: "foundation-models", Status: "RUNNING"},
+		{ID: "deployment-2", ScenarioID: "orchestration", Status: "STOPPED"},
+	}
+
+	suite.aicoreService.EXPECT().GetDeploymentSummaries(gomock.Any()).Return(expected, nil)
+
+	// Execute
+	req := httptest.NewRequest("GET", "/ai-core/deployment-summaries", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.GET("/ai-core/deployment-summaries", suite.handler.GetDeploymentSummaries)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusOK, w.Code)
+
+	var response []service.DeploymentSummary
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal(expected, response)
+}
+
 func (suite *AICoreHandlerTestSuite) TestGetMe_AuthenticationError() {
 	// Setup
 	suite.aicoreService.EXPECT().GetMe(gomock.Any()).Return(nil, errors.ErrUserEmailNotFound)
@@ -1176,14 +1398,16 @@ func (suite *AICoreHandlerTestSuite) TestChatInference_Streaming_ServiceError()
 
 func (suite *AICoreHandlerTestSuite) TestUploadAttachment_Success_SingleFile() {
 	// Setup
-	expectedResponse := map[string]interface{}{
-		"id":       "file-123",
-		"filename": "test.txt",
-		"size":     100,
+	expectedResponse := &service.AttachmentResponse{
+		URL:      "data:text/plain;base64,dGVzdA==",
+		MIMEType: "text/plain",
+		Filename: "test.txt",
+		Size:     100,
+		Inline:   true,
 	}
 
 	suite.aicoreService.EXPECT().UploadAttachment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
-		func(c *gin.Context, file multipart.File, header *multipart.FileHeader) (map[string]interface{}, error) {
+		func(c *gin.Context, file multipart.File, header *multipart.FileHeader) (*service.AttachmentResponse, error) {
 			if header.Filename == "test.txt" {
 				return expectedResponse, nil
 			}
@@ -1213,24 +1437,24 @@ func (suite *AICoreHandlerTestSuite) TestUploadAttachment_Success_SingleFile() {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	suite.NoError(err)
 	suite.Equal(float64(1), response["count"])
-	suite.NotNil(response["files"])
+	files := response["files"].([]interface{})
+	suite.Require().Len(files, 1)
+	suite.Equal(map[string]interface{}{
+		"url":      "data:text/plain;base64,dGVzdA==",
+		"mimeType": "text/plain",
+		"filename": "test.txt",
+		"size":     float64(100),
+		"inline":   true,
+	}, files[0])
 }
 
 func (suite *AICoreHandlerTestSuite) TestUploadAttachment_Success_MultipleFiles() {
 	// Setup - Mock for multiple files
-	expectedResponse1 := map[string]interface{}{
-		"id":       "file-123",
-		"filename": "test1.txt",
-		"size":     100,
-	}
-	expectedResponse2 := map[string]interface{}{
-		"id":       "file-456",
-		"filename": "test2.txt",
-		"size":     200,
-	}
+	expectedResponse1 := &service.AttachmentResponse{Filename: "test1.txt", MIMEType: "text/plain", Size: 100}
+	expectedResponse2 := &service.AttachmentResponse{Filename: "test2.txt", MIMEType: "text/plain", Size: 200}
 
 	suite.aicoreService.EXPECT().UploadAttachment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
-		func(c *gin.Context, file multipart.File, header *multipart.FileHeader) (map[string]interface{}, error) {
+		func(c *gin.Context, file multipart.File, header *multipart.FileHeader) (*service.AttachmentResponse, error) {
 			if header.Filename == "test1.txt" {
 				return expectedResponse1, nil
 			}
@@ -1349,6 +1573,53 @@ func (suite *AICoreHandlerTestSuite) TestUploadAttachment_ServiceError() {
 	suite.Equal(errors.ErrInternalError.Error(), response["error"])
 }
 
+func (suite *AICoreHandlerTestSuite) TestUploadAttachment_RateLimited() {
+	// Setup
+	suite.aicoreService.EXPECT().UploadAttachment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.ErrUploadRateLimited)
+
+	// Create multipart form
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "test.txt")
+	part.Write([]byte("test content"))
+	writer.Close()
+
+	// Execute
+	req := httptest.NewRequest("POST", "/ai-core/upload", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	suite.router.POST("/ai-core/upload", suite.handler.UploadAttachment)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusTooManyRequests, w.Code)
+}
+
+func (suite *AICoreHandlerTestSuite) TestUploadAttachment_TypeNotAllowed() {
+	// Setup
+	suite.aicoreService.EXPECT().UploadAttachment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.ErrAttachmentTypeNotAllowed)
+
+	// Create multipart form
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "page.html")
+	part.Write([]byte("<html><script>alert(1)</script></html>"))
+	writer.Close()
+
+	// Execute
+	req := httptest.NewRequest("POST", "/ai-core/upload", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	suite.router.POST("/ai-core/upload", suite.handler.UploadAttachment)
+	suite.router.ServeHTTP(w, req)
+
+	// Assert
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "HTML and SVG attachments are not allowed")
+}
+
 func TestAICoreHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(AICoreHandlerTestSuite))
 }
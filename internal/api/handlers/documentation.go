@@ -54,7 +54,9 @@ func (h *DocumentationHandler) CreateDocumentation(c *gin.Context) {
 
 	doc, err := h.docService.CreateDocumentation(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !writeAppError(c, err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -161,7 +163,9 @@ func (h *DocumentationHandler) UpdateDocumentation(c *gin.Context) {
 
 	doc, err := h.docService.UpdateDocumentation(id, &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !writeAppError(c, err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"errors"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"developer-portal-backend/internal/auth"
 	apperrors "developer-portal-backend/internal/errors"
@@ -22,6 +24,28 @@ func NewGitHubHandler(s service.GitHubServiceInterface) *GitHubHandler {
 	return &GitHubHandler{service: s}
 }
 
+// writeGitHubError responds with 429 when err is a GitHub primary or secondary rate limit, or with 400 when no
+// provider was given and none is configured as default, and reports whether it responded.
+// Secondary limits advertise their Retry-After delay so clients can back off instead of waiting for the hourly reset.
+func writeGitHubError(c *gin.Context, err error) bool {
+	if rateErr, ok := apperrors.AsGitHubSecondaryRateLimitError(err); ok {
+		if rateErr.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateErr.RetryAfter.Seconds()))))
+		}
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": rateErr.Error()})
+		return true
+	}
+	if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return true
+	}
+	if errors.Is(err, apperrors.ErrNoDefaultProvider) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return true
+	}
+	return false
+}
+
 // getAuthClaims extracts and validates auth claims from the Gin context
 // Returns the claims if successful, or sends an error response and returns nil
 func getAuthClaims(c *gin.Context) *auth.AuthClaims {
@@ -49,7 +73,10 @@ func getAuthClaims(c *gin.Context) *auth.AuthClaims {
 // @Param sort query string false "Sort by: created, updated, popularity, long-running" default(created)
 // @Param direction query string false "Sort direction: asc, desc" default(desc)
 // @Param per_page query int false "Results per page (1-100)" default(30)
-// @Param page query int false "Page number" default(1)
+// @Param page query int false "Page number; next_page in the response points at the following page" default(1)
+// @Param org query string false "Only pull requests in repositories of this organization"
+// @Param repo query string false "Only pull requests in this repository (owner/name)"
+// @Param exclude query string false "Comma-separated labels whose pull requests are excluded"
 // @Success 200 {object} service.PullRequestsResponse
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 502 {object} ErrorResponse "GitHub API error"
@@ -102,15 +129,22 @@ func (h *GitHubHandler) GetMyPullRequests(c *gin.Context) {
 		return
 	}
 
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
+
+	filters := service.QueryFilters{
+		Org:  c.Query("org"),
+		Repo: c.Query("repo"),
+	}
+	if exclude := c.Query("exclude"); exclude != "" {
+		filters.Exclude = strings.Split(exclude, ",")
+	}
 
 	// Call service to get pull requests
-	response, err := h.service.GetUserOpenPullRequests(c.Request.Context(), claims.UUID, provider, state, sort, direction, perPage, page)
+	response, err := h.service.GetUserOpenPullRequestsWithFilters(c.Request.Context(), claims.UUID, provider, state, sort, direction, perPage, page, filters)
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch pull requests: " + err.Error()})
@@ -142,15 +176,14 @@ func (h *GitHubHandler) GetUserTotalContributions(c *gin.Context) {
 	// Get query parameter for period (empty = use GitHub's default)
 	period := c.Query("period")
 
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Call service to get total contributions
 	response, err := h.service.GetUserTotalContributions(c.Request.Context(), claims.UUID, provider, period)
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		// Check if it's a validation error (invalid period format)
@@ -171,8 +204,10 @@ func (h *GitHubHandler) GetUserTotalContributions(c *gin.Context) {
 // @Tags github
 // @Produce json
 // @Param provider path string true "GitHub provider (must be configured in auth.yaml, e.g., 'githubtools', 'githubwdf')"
-// @Param period query string false "Time period in days (e.g., '30d', '90d', '365d'). If omitted, uses GitHub's default period. Maximum: 365 days"
+// @Param period query string false "Time period: one of '7d', '30d', '90d', '1y'. If omitted, uses GitHub's default period"
+// @Param If-None-Match header string false "ETag from a previous response; returns 304 when the heatmap is unchanged"
 // @Success 200 {object} service.ContributionsHeatmapResponse
+// @Success 304 "Heatmap unchanged since the given ETag"
 // @Failure 400 {object} ErrorResponse "Invalid period parameter or provider not configured"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 429 {object} ErrorResponse "Rate limit exceeded"
@@ -185,18 +220,22 @@ func (h *GitHubHandler) GetContributionsHeatmap(c *gin.Context) {
 		return
 	}
 
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Get query parameter for period (empty = use GitHub's default)
 	period := c.Query("period")
 
-	// Call service to get contribution heatmap
-	response, err := h.service.GetContributionsHeatmap(c.Request.Context(), claims.UUID, provider, period)
+	// Call service to get contribution heatmap, honouring a conditional request
+	response, err := h.service.GetContributionsHeatmapIfNoneMatch(c.Request.Context(), claims.UUID, provider, period, c.GetHeader("If-None-Match"))
+	if errors.Is(err, apperrors.ErrNotModified) {
+		c.Header("ETag", response.ETag)
+		c.Status(http.StatusNotModified)
+		return
+	}
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		// Check if it's a provider configuration error
@@ -213,6 +252,9 @@ func (h *GitHubHandler) GetContributionsHeatmap(c *gin.Context) {
 		return
 	}
 
+	if response.ETag != "" {
+		c.Header("ETag", response.ETag)
+	}
 	c.JSON(http.StatusOK, response)
 }
 
@@ -221,7 +263,7 @@ func (h *GitHubHandler) GetContributionsHeatmap(c *gin.Context) {
 // @Description Returns the average time to merge pull requests for the authenticated user over a specified period (default 30 days). The response includes both aggregate metrics (overall average and PR count) and a time series breakdown by date for visualization. The time is calculated as the duration between PR creation and merge (mergedAt - createdAt) in hours.
 // @Tags github
 // @Produce json
-// @Param period query string false "Time period in days (e.g., '30d', '90d', '180d'). Default: '30d'"
+// @Param period query string false "Time period: one of '7d', '30d', '90d', '1y'. Default: '30d'"
 // @Success 200 {object} service.AveragePRMergeTimeResponse
 // @Failure 400 {object} ErrorResponse "Invalid period parameter"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
@@ -238,15 +280,14 @@ func (h *GitHubHandler) GetAveragePRMergeTime(c *gin.Context) {
 	// Get query parameter for period (default to 30d)
 	period := c.DefaultQuery("period", "30d")
 
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Call service to get average PR merge time
 	response, err := h.service.GetAveragePRMergeTime(c.Request.Context(), claims.UUID, provider, period)
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		// Check if it's a validation error (invalid period format)
@@ -261,6 +302,44 @@ func (h *GitHubHandler) GetAveragePRMergeTime(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetActivityTimeline returns the authenticated user's merged activity timeline
+// @Summary Get user activity timeline
+// @Description Returns the pull requests opened by the authenticated user and their contribution days for a period, merged newest first. When one source fails, the other is still returned and the failure is listed in partial_errors.
+// @Tags github
+// @Produce json
+// @Param period query string false "Time period: one of '7d', '30d', '90d', '1y'. Default: '30d'"
+// @Success 200 {object} service.ActivityTimelineResponse
+// @Failure 400 {object} ErrorResponse "Invalid period parameter"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 429 {object} ErrorResponse "Rate limit exceeded"
+// @Failure 502 {object} ErrorResponse "GitHub API error"
+// @Security BearerAuth
+// @Router /github/activity-timeline [get]
+func (h *GitHubHandler) GetActivityTimeline(c *gin.Context) {
+	claims := getAuthClaims(c)
+	if claims == nil {
+		return
+	}
+
+	period := c.DefaultQuery("period", "30d")
+	provider := c.Query("provider")
+
+	response, err := h.service.GetUserActivityTimeline(c.Request.Context(), claims.UUID, provider, period)
+	if err != nil {
+		if writeGitHubError(c, err) {
+			return
+		}
+		if errors.Is(err, apperrors.ErrInvalidPeriodFormat) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch activity timeline: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetRepositoryContent proxies GitHub repository content requests
 // @Summary Get repository file or directory content
 // @Description Proxies requests to GitHub API to fetch repository file or directory contents. Used by the documentation viewer.
@@ -273,6 +352,7 @@ func (h *GitHubHandler) GetAveragePRMergeTime(c *gin.Context) {
 // @Success 200 {object} interface{} "GitHub API response (array for directories, object for files)"
 // @Failure 401 {object} ErrorResponse "Unauthorized"
 // @Failure 404 {object} ErrorResponse "Repository or path not found"
+// @Failure 413 {object} ErrorResponse "File exceeds the maximum content size"
 // @Failure 502 {object} ErrorResponse "GitHub API error"
 // @Security BearerAuth
 // @Router /github/repos/{owner}/{repo}/contents/{path} [get]
@@ -287,21 +367,24 @@ func (h *GitHubHandler) GetRepositoryContent(c *gin.Context) {
 	repo := c.Param("repo")
 	path := c.Param("path")
 	ref := c.DefaultQuery("ref", "main")
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Call service to get repository content
 	content, err := h.service.GetRepositoryContent(c.Request.Context(), claims.UUID, provider, owner, repo, path, ref)
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		if apperrors.IsNotFound(err) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrFileTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch repository content: " + err.Error()})
 		return
 	}
@@ -333,15 +416,14 @@ func (h *GitHubHandler) GetGitHubAsset(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Asset URL is required"})
 		return
 	}
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Call service to fetch the asset
 	assetData, contentType, err := h.service.GetGitHubAsset(c.Request.Context(), claims.UUID, provider, assetURL)
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		if apperrors.IsNotFound(err) {
@@ -408,15 +490,14 @@ func (h *GitHubHandler) UpdateRepositoryFile(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Call service to update repository file
 	response, err := h.service.UpdateRepositoryFile(c.Request.Context(), claims.UUID, provider, owner, repo, path, req.Message, req.Content, req.SHA, req.Branch)
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		if apperrors.IsNotFound(err) {
@@ -483,8 +564,8 @@ func (h *GitHubHandler) ClosePullRequest(c *gin.Context) {
 	// Parse delete_branch flag
 	deleteBranch := req.DeleteBranch
 
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Close PR (and optionally delete branch)
 	updatedPR, err := h.service.ClosePullRequest(c.Request.Context(), claims.UUID, provider, req.Owner, req.Repo, prNumber, deleteBranch)
@@ -494,8 +575,7 @@ func (h *GitHubHandler) ClosePullRequest(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		if apperrors.IsNotFound(err) {
@@ -530,15 +610,14 @@ func (h *GitHubHandler) GetPRReviewComments(c *gin.Context) {
 
 	// Get query parameter for period (default to 30d)
 	period := c.DefaultQuery("period", "30d")
-	// get GitHub provider from param 'provider'. TODO set 'githubtools' if not found. prepare to support multiple providers in future - which client currently doesn't support. should be mandatory.
-	provider := c.DefaultQuery("provider", "githubtools")
+	// get GitHub provider from param 'provider', an empty value resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Call service to get PR review comments count
 	response, err := h.service.GetUserPRReviewComments(c.Request.Context(), claims.UUID, provider, period)
 	if err != nil {
 		// Check for specific error types
-		if errors.Is(err, apperrors.ErrGitHubAPIRateLimitExceeded) {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		if writeGitHubError(c, err) {
 			return
 		}
 		// Check if it's a validation error (invalid period format)
@@ -75,7 +75,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_Success() {
 	}
 
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", "open", "created", "desc", 30, 1).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
 		Return(expectedResponse, nil)
 
 	// Setup route
@@ -106,6 +106,28 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_Success() {
 	assert.Equal(suite.T(), "Add new feature", response.PullRequests[0].Title)
 }
 
+// TestGetMyPullRequests_Filters tests that org, repo and exclude query parameters reach the service
+func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_Filters() {
+	suite.mockGitHubSv.EXPECT().
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), "test-uuid", "", "open", "created", "desc", 30, 1, service.QueryFilters{
+			Org:     "platform",
+			Repo:    "platform/portal",
+			Exclude: []string{"wip", "do-not-merge"},
+		}).
+		Return(&service.PullRequestsResponse{PullRequests: []service.PullRequest{}}, nil)
+
+	suite.router.GET("/github/pull-requests", func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{UUID: "test-uuid"})
+		suite.handler.GetMyPullRequests(c)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/github/pull-requests?org=platform&repo=platform/portal&exclude=wip,do-not-merge", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
 // TestGetMyPullRequests_Unauthorized tests missing authentication
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_Unauthorized() {
 	// Setup route without auth claims
@@ -131,7 +153,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_Unauthorized() {
 // TestGetMyPullRequests_ServiceError tests service error handling
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", "open", "created", "desc", 30, 1).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
 		Return(nil, fmt.Errorf("failed to fetch pull requests"))
 
 	suite.router.GET("/github/pull-requests", func(c *gin.Context) {
@@ -159,7 +181,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_ServiceError() {
 // TestGetMyPullRequests_RateLimitError tests rate limit error handling
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_RateLimitError() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", "open", "created", "desc", 30, 1).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
 		Return(nil, apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.GET("/github/pull-requests", func(c *gin.Context) {
@@ -184,10 +206,57 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_RateLimitError() {
 	assert.Contains(suite.T(), response["error"], "rate limit exceeded")
 }
 
+// TestGetMyPullRequests_SecondaryRateLimitError tests that a secondary rate limit responds 429 with Retry-After
+func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_SecondaryRateLimitError() {
+	suite.mockGitHubSv.EXPECT().
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
+		Return(nil, &apperrors.GitHubSecondaryRateLimitError{RetryAfter: 60 * time.Second})
+
+	suite.router.GET("/github/pull-requests", func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{UUID: "test-uuid", Username: "testuser", Email: "test@example.com"})
+		suite.handler.GetMyPullRequests(c)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/github/pull-requests", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusTooManyRequests, w.Code)
+	assert.Equal(suite.T(), "60", w.Header().Get("Retry-After"))
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), response["error"], "secondary rate limit")
+}
+
+// TestGetMyPullRequests_NoDefaultProvider tests that a missing provider without a configured default responds 400
+func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_NoDefaultProvider() {
+	suite.mockGitHubSv.EXPECT().
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
+		Return(nil, apperrors.ErrNoDefaultProvider)
+
+	suite.router.GET("/github/pull-requests", func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{UUID: "test-uuid", Username: "testuser", Email: "test@example.com"})
+		suite.handler.GetMyPullRequests(c)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/github/pull-requests", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), response["error"], "no default provider configured")
+}
+
 // TestGetMyPullRequests_WithQueryParameters tests query parameter handling
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_WithQueryParameters() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), service.QueryFilters{}).
 		Return(&service.PullRequestsResponse{
 			PullRequests: []service.PullRequest{},
 			Total:        0,
@@ -260,7 +329,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_InvalidClaims() {
 // TestGetMyPullRequests_EmptyResponse tests empty PR list
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_EmptyResponse() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", "open", "created", "desc", 30, 1).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
 		Return(&service.PullRequestsResponse{
 			PullRequests: []service.PullRequest{},
 			Total:        0,
@@ -292,7 +361,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_EmptyResponse() {
 // TestGetMyPullRequests_MultiplePRs tests response with multiple PRs
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_MultiplePRs() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", "open", "created", "desc", 30, 1).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
 		Return(&service.PullRequestsResponse{
 			PullRequests: []service.PullRequest{
 				{
@@ -343,7 +412,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_MultiplePRs() {
 // TestGetMyPullRequests_DefaultParameters tests default parameter values
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_DefaultParameters() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", "open", "created", "desc", 30, 1).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
 		Return(&service.PullRequestsResponse{
 			PullRequests: []service.PullRequest{},
 			Total:        0,
@@ -370,7 +439,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_DefaultParameters() {
 // TestGetMyPullRequests_InvalidPerPage tests invalid per_page values
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_InvalidPerPage() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), service.QueryFilters{}).
 		Return(&service.PullRequestsResponse{
 			PullRequests: []service.PullRequest{},
 			Total:        0,
@@ -408,7 +477,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_InvalidPerPage() {
 // TestGetMyPullRequests_InvalidPage tests invalid page values
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_InvalidPage() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), service.QueryFilters{}).
 		Return(&service.PullRequestsResponse{
 			PullRequests: []service.PullRequest{},
 			Total:        0,
@@ -452,7 +521,7 @@ func (suite *GitHubHandlerTestSuite) TestNewGitHubHandler() {
 // TestGetMyPullRequests_DifferentProviders tests different provider values
 func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_DifferentProviders() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserOpenPullRequests(gomock.Any(), gomock.Any(), "githubtools", "open", "created", "desc", 30, 1).
+		GetUserOpenPullRequestsWithFilters(gomock.Any(), gomock.Any(), "", "open", "created", "desc", 30, 1, service.QueryFilters{}).
 		Return(&service.PullRequestsResponse{
 			PullRequests: []service.PullRequest{},
 			Total:        0,
@@ -486,7 +555,7 @@ func (suite *GitHubHandlerTestSuite) TestGetMyPullRequests_DifferentProviders()
 // TestGetUserTotalContributions_Success tests successful contribution retrieval
 func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_Success() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserTotalContributions(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetUserTotalContributions(gomock.Any(), gomock.Any(), "", "30d").
 		Return(&service.TotalContributionsResponse{
 			TotalContributions: 1234,
 			Period:             "30d",
@@ -565,7 +634,7 @@ func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_InvalidClaims
 // TestGetUserTotalContributions_InvalidPeriod tests invalid period format
 func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_InvalidPeriod() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserTotalContributions(gomock.Any(), gomock.Any(), "githubtools", gomock.Any()).
+		GetUserTotalContributions(gomock.Any(), gomock.Any(), "", gomock.Any()).
 		Return(nil, fmt.Errorf("%w: period must be in format '<number>d' (e.g., '30d', '90d', '365d')", apperrors.ErrInvalidPeriodFormat)).
 		AnyTimes()
 
@@ -600,7 +669,7 @@ func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_InvalidPeriod
 // TestGetUserTotalContributions_RateLimit tests rate limit error handling
 func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_RateLimit() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserTotalContributions(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetUserTotalContributions(gomock.Any(), gomock.Any(), "", "30d").
 		Return(nil, apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.GET("/github/contributions", func(c *gin.Context) {
@@ -628,7 +697,7 @@ func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_RateLimit() {
 // TestGetUserTotalContributions_ServiceError tests service error handling
 func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserTotalContributions(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetUserTotalContributions(gomock.Any(), gomock.Any(), "", "30d").
 		Return(nil, fmt.Errorf("failed to fetch contributions"))
 
 	suite.router.GET("/github/contributions", func(c *gin.Context) {
@@ -656,7 +725,7 @@ func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_ServiceError(
 // TestGetUserTotalContributions_ValidPeriods tests various valid period values
 func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_ValidPeriods() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserTotalContributions(gomock.Any(), gomock.Any(), "githubtools", gomock.Any()).
+		GetUserTotalContributions(gomock.Any(), gomock.Any(), "", gomock.Any()).
 		Return(&service.TotalContributionsResponse{
 			TotalContributions: 1234,
 			Period:             "30d",
@@ -732,7 +801,7 @@ func (suite *GitHubHandlerTestSuite) TestGetUserTotalContributions_DifferentProv
 // TestGetContributionsHeatmap_Success tests successful heatmap retrieval
 func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_Success() {
 	suite.mockGitHubSv.EXPECT().
-		GetContributionsHeatmap(gomock.Any(), gomock.Any(), "githubtools", "").
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "", "").
 		Return(&service.ContributionsHeatmapResponse{
 			TotalContributions: 1234,
 			Weeks: []service.ContributionWeek{
@@ -788,7 +857,7 @@ func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_Success() {
 // TestGetContributionsHeatmap_WithPeriod tests heatmap with period parameter
 func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_WithPeriod() {
 	suite.mockGitHubSv.EXPECT().
-		GetContributionsHeatmap(gomock.Any(), gomock.Any(), "githubtools", "90d").
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "90d", "").
 		Return(&service.ContributionsHeatmapResponse{
 			TotalContributions: 1234,
 			Weeks:              []service.ContributionWeek{},
@@ -841,7 +910,7 @@ func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_NoAuthClaims()
 func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_ProviderMismatch() {
 	// Set up mock expectation for the default provider (githubtools)
 	suite.mockGitHubSv.EXPECT().
-		GetContributionsHeatmap(gomock.Any(), gomock.Any(), "githubtools", "").
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "", "").
 		Return(&service.ContributionsHeatmapResponse{
 			TotalContributions: 1234,
 			Weeks:              []service.ContributionWeek{},
@@ -872,7 +941,7 @@ func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_ProviderMismatc
 // TestGetContributionsHeatmap_ServiceError tests service error handling
 func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		GetContributionsHeatmap(gomock.Any(), gomock.Any(), "githubtools", "").
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "", "").
 		Return(nil, fmt.Errorf("service error"))
 
 	suite.router.GET("/github/:provider/heatmap", func(c *gin.Context) {
@@ -895,7 +964,7 @@ func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_ServiceError()
 // TestGetContributionsHeatmap_RateLimitExceeded tests rate limit error
 func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_RateLimitExceeded() {
 	suite.mockGitHubSv.EXPECT().
-		GetContributionsHeatmap(gomock.Any(), gomock.Any(), "githubtools", "").
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "", "").
 		Return(nil, apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.GET("/github/:provider/heatmap", func(c *gin.Context) {
@@ -918,7 +987,7 @@ func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_RateLimitExceed
 // TestGetContributionsHeatmap_InvalidPeriod tests invalid period format
 func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_InvalidPeriod() {
 	suite.mockGitHubSv.EXPECT().
-		GetContributionsHeatmap(gomock.Any(), gomock.Any(), "githubtools", "invalid").
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "invalid", "").
 		Return(nil, fmt.Errorf("%w: period must be in format '<number>d'", apperrors.ErrInvalidPeriodFormat))
 
 	suite.router.GET("/github/:provider/heatmap", func(c *gin.Context) {
@@ -941,7 +1010,7 @@ func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_InvalidPeriod()
 // TestGetContributionsHeatmap_ProviderNotConfigured tests provider not configured error
 func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_ProviderNotConfigured() {
 	suite.mockGitHubSv.EXPECT().
-		GetContributionsHeatmap(gomock.Any(), gomock.Any(), "githubtools", "").
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "", "").
 		Return(nil, fmt.Errorf("%w: provider 'invalid'. Please check available providers in auth.yaml", apperrors.ErrProviderNotConfigured))
 
 	suite.router.GET("/github/:provider/heatmap", func(c *gin.Context) {
@@ -966,10 +1035,50 @@ func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_ProviderNotConf
 	assert.Contains(suite.T(), response["error"], apperrors.ErrProviderNotConfigured.Error())
 }
 
+// TestGetContributionsHeatmap_SetsETag tests that the ETag header is returned with the heatmap
+func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_SetsETag() {
+	suite.mockGitHubSv.EXPECT().
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "", "").
+		Return(&service.ContributionsHeatmapResponse{TotalContributions: 7, ETag: `"abc123"`}, nil)
+
+	suite.router.GET("/github/:provider/heatmap", func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{UUID: "test-uuid"})
+		suite.handler.GetContributionsHeatmap(c)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/github/githubtools/heatmap", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"abc123"`, w.Header().Get("ETag"))
+}
+
+// TestGetContributionsHeatmap_NotModified tests that a matching If-None-Match returns 304
+func (suite *GitHubHandlerTestSuite) TestGetContributionsHeatmap_NotModified() {
+	suite.mockGitHubSv.EXPECT().
+		GetContributionsHeatmapIfNoneMatch(gomock.Any(), gomock.Any(), "", "", `"abc123"`).
+		Return(&service.ContributionsHeatmapResponse{TotalContributions: 7, ETag: `"abc123"`}, apperrors.ErrNotModified)
+
+	suite.router.GET("/github/:provider/heatmap", func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{UUID: "test-uuid"})
+		suite.handler.GetContributionsHeatmap(c)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/github/githubtools/heatmap", nil)
+	req.Header.Set("If-None-Match", `"abc123"`)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotModified, w.Code)
+	assert.Equal(suite.T(), `"abc123"`, w.Header().Get("ETag"))
+	assert.Empty(suite.T(), w.Body.Bytes())
+}
+
 // TestGetAveragePRMergeTime_Success tests successful average PR merge time retrieval
 func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_Success() {
 	suite.mockGitHubSv.EXPECT().
-		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "", "30d").
 		Return(&service.AveragePRMergeTimeResponse{
 			AveragePRMergeTimeHours: 24.5,
 			PRCount:                 15,
@@ -1052,7 +1161,7 @@ func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_NoAuthClaims() {
 // TestGetAveragePRMergeTime_ServiceError tests service error handling
 func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "", "30d").
 		Return(nil, fmt.Errorf("GitHub API error"))
 
 	suite.router.GET("/github/average-pr-time", func(c *gin.Context) {
@@ -1075,7 +1184,7 @@ func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_ServiceError() {
 // TestGetAveragePRMergeTime_RateLimitExceeded tests rate limit error
 func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_RateLimitExceeded() {
 	suite.mockGitHubSv.EXPECT().
-		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "", "30d").
 		Return(nil, apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.GET("/github/average-pr-time", func(c *gin.Context) {
@@ -1098,7 +1207,7 @@ func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_RateLimitExceeded
 // TestGetAveragePRMergeTime_InvalidPeriod tests invalid period format
 func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_InvalidPeriod() {
 	suite.mockGitHubSv.EXPECT().
-		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "githubtools", "invalid").
+		GetAveragePRMergeTime(gomock.Any(), gomock.Any(), "", "invalid").
 		Return(nil, apperrors.ErrInvalidPeriodFormat)
 
 	suite.router.GET("/github/average-pr-time", func(c *gin.Context) {
@@ -1118,11 +1227,59 @@ func (suite *GitHubHandlerTestSuite) TestGetAveragePRMergeTime_InvalidPeriod() {
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 }
 
+// TestGetActivityTimeline_Success tests returning a timeline with a partial failure
+func (suite *GitHubHandlerTestSuite) TestGetActivityTimeline_Success() {
+	suite.mockGitHubSv.EXPECT().
+		GetUserActivityTimeline(gomock.Any(), "test-uuid", "", "30d").
+		Return(&service.ActivityTimelineResponse{
+			Events: []service.TimelineEvent{
+				{Type: service.TimelineEventPullRequest, PullRequest: &service.PullRequest{Number: 42}},
+			},
+			Period:        "30d",
+			PartialErrors: map[string]string{"contributions": "GraphQL query failed"},
+		}, nil)
+
+	suite.router.GET("/github/activity-timeline", func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{UUID: "test-uuid", Username: "testuser", Email: "test@example.com"})
+		suite.handler.GetActivityTimeline(c)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/github/activity-timeline", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response service.ActivityTimelineResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Require().Len(response.Events, 1)
+	assert.Equal(suite.T(), 42, response.Events[0].PullRequest.Number)
+	assert.Equal(suite.T(), "GraphQL query failed", response.PartialErrors["contributions"])
+}
+
+// TestGetActivityTimeline_InvalidPeriod tests invalid period handling
+func (suite *GitHubHandlerTestSuite) TestGetActivityTimeline_InvalidPeriod() {
+	suite.mockGitHubSv.EXPECT().
+		GetUserActivityTimeline(gomock.Any(), "test-uuid", "", "45d").
+		Return(nil, apperrors.ErrInvalidPeriod)
+
+	suite.router.GET("/github/activity-timeline", func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{UUID: "test-uuid", Username: "testuser", Email: "test@example.com"})
+		suite.handler.GetActivityTimeline(c)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/github/activity-timeline?period=45d", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
 // ClosePullRequest handler tests
 
 func (suite *GitHubHandlerTestSuite) TestClosePR_Success() {
 	suite.mockGitHubSv.EXPECT().
-		ClosePullRequest(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", 42, false).
+		ClosePullRequest(gomock.Any(), gomock.Any(), "", "owner", "repo", 42, false).
 		Return(&service.PullRequest{
 			ID:     1,
 			Number: 42,
@@ -1164,7 +1321,7 @@ func (suite *GitHubHandlerTestSuite) TestClosePR_Success() {
 
 func (suite *GitHubHandlerTestSuite) TestClosePR_NotFound() {
 	suite.mockGitHubSv.EXPECT().
-		ClosePullRequest(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", 99, false).
+		ClosePullRequest(gomock.Any(), gomock.Any(), "", "owner", "repo", 99, false).
 		Return(nil, apperrors.NewNotFoundError("pull request"))
 
 	// Route for ClosePullRequest
@@ -1202,7 +1359,7 @@ func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_Success() {
 	}
 
 	suite.mockGitHubSv.EXPECT().
-		GetRepositoryContent(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/README.md", "main").
+		GetRepositoryContent(gomock.Any(), gomock.Any(), "", "owner", "repo", "/README.md", "main").
 		Return(expectedContent, nil)
 
 	suite.router.GET("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1231,7 +1388,7 @@ func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_SuccessDirectory()
 	}
 
 	suite.mockGitHubSv.EXPECT().
-		GetRepositoryContent(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/src", "main").
+		GetRepositoryContent(gomock.Any(), gomock.Any(), "", "owner", "repo", "/src", "main").
 		Return(expectedContent, nil)
 
 	suite.router.GET("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1275,7 +1432,7 @@ func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_InvalidClaims() {
 // TestGetRepositoryContent_NotFound tests repository or path not found
 func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_NotFound() {
 	suite.mockGitHubSv.EXPECT().
-		GetRepositoryContent(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/notfound.txt", "main").
+		GetRepositoryContent(gomock.Any(), gomock.Any(), "", "owner", "repo", "/notfound.txt", "main").
 		Return(nil, apperrors.NewNotFoundError("file"))
 
 	suite.router.GET("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1294,7 +1451,7 @@ func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_NotFound() {
 // TestGetRepositoryContent_RateLimitExceeded tests rate limit error
 func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_RateLimitExceeded() {
 	suite.mockGitHubSv.EXPECT().
-		GetRepositoryContent(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/file.txt", "main").
+		GetRepositoryContent(gomock.Any(), gomock.Any(), "", "owner", "repo", "/file.txt", "main").
 		Return(nil, apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.GET("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1313,7 +1470,7 @@ func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_RateLimitExceeded(
 // TestGetRepositoryContent_ServiceError tests generic service error
 func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		GetRepositoryContent(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/file.txt", "main").
+		GetRepositoryContent(gomock.Any(), gomock.Any(), "", "owner", "repo", "/file.txt", "main").
 		Return(nil, fmt.Errorf("service error"))
 
 	suite.router.GET("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1336,7 +1493,7 @@ func (suite *GitHubHandlerTestSuite) TestGetRepositoryContent_EmptyPath() {
 	}
 
 	suite.mockGitHubSv.EXPECT().
-		GetRepositoryContent(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/", "main").
+		GetRepositoryContent(gomock.Any(), gomock.Any(), "", "owner", "repo", "/", "main").
 		Return(expectedContent, nil)
 
 	suite.router.GET("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1360,7 +1517,7 @@ func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_Success() {
 	contentType := "image/png"
 
 	suite.mockGitHubSv.EXPECT().
-		GetGitHubAsset(gomock.Any(), gomock.Any(), "githubtools", "https://github.com/owner/repo/raw/main/image.png").
+		GetGitHubAsset(gomock.Any(), gomock.Any(), "", "https://github.com/owner/repo/raw/main/image.png").
 		Return(assetData, contentType, nil)
 
 	suite.router.GET("/github/asset", func(c *gin.Context) {
@@ -1426,7 +1583,7 @@ func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_MissingURL() {
 // TestGetGitHubAsset_NotFound tests asset not found
 func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_NotFound() {
 	suite.mockGitHubSv.EXPECT().
-		GetGitHubAsset(gomock.Any(), gomock.Any(), "githubtools", "https://github.com/notfound.png").
+		GetGitHubAsset(gomock.Any(), gomock.Any(), "", "https://github.com/notfound.png").
 		Return(nil, "", apperrors.NewNotFoundError("asset"))
 
 	suite.router.GET("/github/asset", func(c *gin.Context) {
@@ -1445,7 +1602,7 @@ func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_NotFound() {
 // TestGetGitHubAsset_RateLimitExceeded tests rate limit error
 func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_RateLimitExceeded() {
 	suite.mockGitHubSv.EXPECT().
-		GetGitHubAsset(gomock.Any(), gomock.Any(), "githubtools", "https://github.com/test.png").
+		GetGitHubAsset(gomock.Any(), gomock.Any(), "", "https://github.com/test.png").
 		Return(nil, "", apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.GET("/github/asset", func(c *gin.Context) {
@@ -1464,7 +1621,7 @@ func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_RateLimitExceeded() {
 // TestGetGitHubAsset_ServiceError tests generic service error
 func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		GetGitHubAsset(gomock.Any(), gomock.Any(), "githubtools", "https://github.com/test.png").
+		GetGitHubAsset(gomock.Any(), gomock.Any(), "", "https://github.com/test.png").
 		Return(nil, "", fmt.Errorf("service error"))
 
 	suite.router.GET("/github/asset", func(c *gin.Context) {
@@ -1486,7 +1643,7 @@ func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_ContentType() {
 	contentType := "image/svg+xml"
 
 	suite.mockGitHubSv.EXPECT().
-		GetGitHubAsset(gomock.Any(), gomock.Any(), "githubtools", "https://github.com/test.svg").
+		GetGitHubAsset(gomock.Any(), gomock.Any(), "", "https://github.com/test.svg").
 		Return(assetData, contentType, nil)
 
 	suite.router.GET("/github/asset", func(c *gin.Context) {
@@ -1509,7 +1666,7 @@ func (suite *GitHubHandlerTestSuite) TestGetGitHubAsset_CacheHeaders() {
 	contentType := "image/png"
 
 	suite.mockGitHubSv.EXPECT().
-		GetGitHubAsset(gomock.Any(), gomock.Any(), "githubtools", "https://github.com/test.png").
+		GetGitHubAsset(gomock.Any(), gomock.Any(), "", "https://github.com/test.png").
 		Return(assetData, contentType, nil)
 
 	suite.router.GET("/github/asset", func(c *gin.Context) {
@@ -1538,7 +1695,7 @@ func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_Success() {
 	}
 
 	suite.mockGitHubSv.EXPECT().
-		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/file.txt", "Update file", "content", "sha123", "").
+		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "", "owner", "repo", "/file.txt", "Update file", "content", "sha123", "").
 		Return(expectedResponse, nil)
 
 	suite.router.PUT("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1567,7 +1724,7 @@ func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_WithBranch() {
 	expectedResponse := map[string]interface{}{"commit": map[string]interface{}{"sha": "abc123"}}
 
 	suite.mockGitHubSv.EXPECT().
-		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/file.txt", "Update", "content", "sha123", "develop").
+		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "", "owner", "repo", "/file.txt", "Update", "content", "sha123", "develop").
 		Return(expectedResponse, nil)
 
 	suite.router.PUT("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1701,7 +1858,7 @@ func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_MissingSHA() {
 // TestUpdateRepositoryFile_NotFound tests repository or path not found
 func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_NotFound() {
 	suite.mockGitHubSv.EXPECT().
-		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/notfound.txt", "Update", "content", "sha123", "").
+		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "", "owner", "repo", "/notfound.txt", "Update", "content", "sha123", "").
 		Return(nil, apperrors.NewNotFoundError("file"))
 
 	suite.router.PUT("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1724,7 +1881,7 @@ func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_NotFound() {
 // TestUpdateRepositoryFile_RateLimitExceeded tests rate limit error
 func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_RateLimitExceeded() {
 	suite.mockGitHubSv.EXPECT().
-		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/file.txt", "Update", "content", "sha123", "").
+		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "", "owner", "repo", "/file.txt", "Update", "content", "sha123", "").
 		Return(nil, apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.PUT("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1747,7 +1904,7 @@ func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_RateLimitExceeded(
 // TestUpdateRepositoryFile_ServiceError tests generic service error
 func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "githubtools", "owner", "repo", "/file.txt", "Update", "content", "sha123", "").
+		UpdateRepositoryFile(gomock.Any(), gomock.Any(), "", "owner", "repo", "/file.txt", "Update", "content", "sha123", "").
 		Return(nil, fmt.Errorf("service error"))
 
 	suite.router.PUT("/github/repos/:owner/:repo/contents/*path", func(c *gin.Context) {
@@ -1772,7 +1929,7 @@ func (suite *GitHubHandlerTestSuite) TestUpdateRepositoryFile_ServiceError() {
 // TestGetPRReviewComments_Success tests successful review comments count retrieval
 func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_Success() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "", "30d").
 		Return(&service.PRReviewCommentsResponse{
 			TotalComments: 42,
 			Period:        "30d",
@@ -1802,7 +1959,7 @@ func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_Success() {
 // TestGetPRReviewComments_WithPeriod tests custom period parameter
 func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_WithPeriod() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "githubtools", "90d").
+		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "", "90d").
 		Return(&service.PRReviewCommentsResponse{
 			TotalComments: 100,
 			Period:        "90d",
@@ -1851,7 +2008,7 @@ func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_InvalidClaims() {
 // TestGetPRReviewComments_InvalidPeriod tests invalid period format
 func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_InvalidPeriod() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "githubtools", "invalid").
+		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "", "invalid").
 		Return(nil, apperrors.ErrInvalidPeriodFormat)
 
 	suite.router.GET("/github/pr-review-comments", func(c *gin.Context) {
@@ -1870,7 +2027,7 @@ func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_InvalidPeriod() {
 // TestGetPRReviewComments_RateLimitExceeded tests rate limit error
 func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_RateLimitExceeded() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "", "30d").
 		Return(nil, apperrors.ErrGitHubAPIRateLimitExceeded)
 
 	suite.router.GET("/github/pr-review-comments", func(c *gin.Context) {
@@ -1889,7 +2046,7 @@ func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_RateLimitExceeded()
 // TestGetPRReviewComments_ServiceError tests generic service error
 func (suite *GitHubHandlerTestSuite) TestGetPRReviewComments_ServiceError() {
 	suite.mockGitHubSv.EXPECT().
-		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "githubtools", "30d").
+		GetUserPRReviewComments(gomock.Any(), gomock.Any(), "", "30d").
 		Return(nil, fmt.Errorf("service error"))
 
 	suite.router.GET("/github/pr-review-comments", func(c *gin.Context) {
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
 	"encoding/json"
 	"net/http"
 	"time"
@@ -12,13 +14,21 @@ import (
 
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	db *gorm.DB
+	db        *gorm.DB
+	readiness service.ReadinessServiceInterface
 }
 
-// NewHealthHandler creates a new health handler
+// NewHealthHandler creates a new health handler whose readiness probe checks the database with a timeout
+// and reports the AI Core credentials
 func NewHealthHandler(db *gorm.DB) *HealthHandler {
+	return NewHealthHandlerWithReadiness(db, service.NewReadinessService(repository.NewHealthChecker(db), nil))
+}
+
+// NewHealthHandlerWithReadiness creates a new health handler using the given readiness service
+func NewHealthHandlerWithReadiness(db *gorm.DB, readiness service.ReadinessServiceInterface) *HealthHandler {
 	return &HealthHandler{
-		db: db,
+		db:        db,
+		readiness: readiness,
 	}
 }
 
@@ -76,7 +86,7 @@ func (h *HealthHandler) Health(c *gin.Context) {
 
 // Ready returns the readiness status of the application
 // @Summary Readiness check
-// @Description Check if the application is ready to serve requests
+// @Description Check if the application is ready to serve requests. The database gates readiness; AI Core credentials are reported only.
 // @Tags health
 // @Accept json
 // @Produce json
@@ -84,34 +94,25 @@ func (h *HealthHandler) Health(c *gin.Context) {
 // @Failure 503 {object} map[string]interface{} "Application is not ready"
 // @Router /health/ready [get]
 func (h *HealthHandler) Ready(c *gin.Context) {
-	// Check if the application is ready to serve requests
-	// This could include checking if migrations are complete, external services are available, etc.
-
-	ready := true
-	services := make(map[string]string)
-
-	// Check database connection
-	sqlDB, err := h.db.DB()
-	if err != nil {
-		ready = false
-		services["database"] = "not ready: " + err.Error()
-	} else {
-		if err := sqlDB.Ping(); err != nil {
-			ready = false
-			services["database"] = "not ready: " + err.Error()
-		} else {
-			services["database"] = "ready"
+	status := h.readiness.Readiness(c.Request.Context())
+
+	// Each check is reported as its status, followed by the error when there is one
+	services := make(map[string]string, len(status.Checks))
+	for name, check := range status.Checks {
+		services[name] = check.Status
+		if check.Error != "" {
+			services[name] += ": " + check.Error
 		}
 	}
 
 	response := map[string]interface{}{
-		"ready":     ready,
-		"timestamp": time.Now(),
+		"ready":     status.Ready,
+		"timestamp": status.Timestamp,
 		"services":  services,
 	}
 
 	statusCode := http.StatusOK
-	if !ready {
+	if !status.Ready {
 		statusCode = http.StatusServiceUnavailable
 	}
 
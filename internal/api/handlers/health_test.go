@@ -11,11 +11,14 @@ import (
 	"time"
 
 	"developer-portal-backend/internal/api/handlers"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -162,8 +165,8 @@ func (suite *HealthHandlerTestSuite) TestHealth_DatabaseConnectionError() {
 func (suite *HealthHandlerTestSuite) TestReady_Success() {
 	router := suite.newRouter()
 
-	// Mock successful ping
-	suite.mock.ExpectPing()
+	// Mock successful health check query
+	suite.mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
 
 	req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
 	w := httptest.NewRecorder()
@@ -179,6 +182,7 @@ func (suite *HealthHandlerTestSuite) TestReady_Success() {
 
 	services := response["services"].(map[string]interface{})
 	assert.Equal(suite.T(), "ready", services["database"])
+	assert.Contains(suite.T(), services, "aicore_credentials")
 
 	// Verify all expectations were met
 	assert.NoError(suite.T(), suite.mock.ExpectationsWereMet())
@@ -188,8 +192,8 @@ func (suite *HealthHandlerTestSuite) TestReady_Success() {
 func (suite *HealthHandlerTestSuite) TestReady_DatabaseNotReady_PingFailure() {
 	router := suite.newRouter()
 
-	// Mock failed ping
-	suite.mock.ExpectPing().WillReturnError(errors.New("database not ready"))
+	// Mock failed health check query
+	suite.mock.ExpectExec("SELECT 1").WillReturnError(errors.New("database not ready"))
 
 	req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
 	w := httptest.NewRecorder()
@@ -251,6 +255,41 @@ func (suite *HealthHandlerTestSuite) TestReady_DatabaseConnectionError() {
 	assert.NoError(suite.T(), closedMock.ExpectationsWereMet())
 }
 
+// verifies the readiness endpoint reports missing AI Core credentials without failing the probe
+func (suite *HealthHandlerTestSuite) TestReady_AICoreNotConfigured() {
+	ctrl := gomock.NewController(suite.T())
+	defer ctrl.Finish()
+
+	readiness := mocks.NewMockReadinessServiceInterface(ctrl)
+	readiness.EXPECT().Readiness(gomock.Any()).Return(&service.ReadinessStatus{
+		Ready: true,
+		Checks: map[string]service.ReadinessCheck{
+			"database":           {Status: service.ReadinessStatusReady},
+			"aicore_credentials": {Status: service.ReadinessStatusNotConfigured, Error: "AI_CORE_CREDENTIALS is not set"},
+		},
+		Timestamp: time.Now(),
+	})
+	handler := handlers.NewHealthHandlerWithReadiness(suite.db, readiness)
+
+	router := gin.New()
+	router.GET("/health/ready", handler.Ready)
+
+	req := httptest.NewRequest(http.MethodGet, "/health/ready", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), true, response["ready"])
+
+	services := response["services"].(map[string]interface{})
+	assert.Equal(suite.T(), "ready", services["database"])
+	assert.Equal(suite.T(), "not configured: AI_CORE_CREDENTIALS is not set", services["aicore_credentials"])
+}
+
 /*************** Live ***************/
 
 // verifies the liveness endpoint returns alive status with valid timestamp
@@ -85,6 +85,7 @@ func (h *LinkHandler) ListLinks(c *gin.Context) {
 // @Success 201 {object} service.LinkResponse "Successfully created link"
 // @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
 // @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 409 {object} map[string]interface{} "Owner already has a link with this name"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /links [post]
@@ -105,6 +106,10 @@ func (h *LinkHandler) CreateLink(c *gin.Context) {
 
 	link, err := h.linkService.CreateLink(&req)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrLinkNameTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -125,6 +130,7 @@ func (h *LinkHandler) CreateLink(c *gin.Context) {
 // @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
 // @Failure 401 {object} map[string]interface{} "Authentication required"
 // @Failure 404 {object} map[string]interface{} "Link not found"
+// @Failure 409 {object} map[string]interface{} "Link name already used by the owner"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /links/{id} [put]
@@ -193,6 +199,14 @@ func (h *LinkHandler) UpdateLink(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrLinkNameTaken) {
+			log.WithFields(map[string]interface{}{
+				"link_id": id.String(),
+				"error":   err.Error(),
+			}).Warn("Link update failed: name already used by the owner")
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		if apperrors.IsValidation(err) {
 			log.WithFields(map[string]interface{}{
 				"link_id": id.String(),
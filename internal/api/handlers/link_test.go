@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/mocks"
 	"developer-portal-backend/internal/service"
 
@@ -240,6 +241,28 @@ func (suite *LinkHandlerTestSuite) TestCreateLink_BadRequest_FromService() {
 	assert.Contains(suite.T(), w.Body.String(), "validation failed")
 }
 
+func (suite *LinkHandlerTestSuite) TestCreateLink_Conflict_NameTaken() {
+	router := suite.newRouter(true, "cis.devops")
+
+	body := `{
+		"name":"Docs",
+		"owner":"` + uuid.New().String() + `",
+		"url":"https://example.com",
+		"category_id":"` + uuid.New().String() + `"
+	}`
+
+	suite.mockLink.EXPECT().
+		CreateLink(gomock.Any()).
+		Return(nil, apperrors.ErrLinkNameTaken)
+
+	req := httptest.NewRequest(http.MethodPost, "/links", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
 func (suite *LinkHandlerTestSuite) TestUpdateLink_InvalidUUID() {
 	router := suite.newRouter(false, "")
 
@@ -374,6 +397,30 @@ func (suite *LinkHandlerTestSuite) TestUpdateLink_BadRequest_FromService() {
 	assert.Contains(suite.T(), w.Body.String(), "failed")
 }
 
+func (suite *LinkHandlerTestSuite) TestUpdateLink_NameTaken() {
+	router := suite.newRouter(true, "cis.devops")
+
+	id := uuid.New()
+	categoryID := uuid.New().String()
+	body := `{
+		"name":"dashboard",
+		"url":"https://example.com/updated",
+		"category_id":"` + categoryID + `"
+	}`
+
+	suite.mockLink.EXPECT().
+		UpdateLink(id, gomock.Any()).
+		Return(nil, apperrors.ErrLinkNameTaken)
+
+	req := httptest.NewRequest(http.MethodPut, "/links/"+id.String(), bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "already exists")
+}
+
 func (suite *LinkHandlerTestSuite) TestDeleteLink_InvalidUUID() {
 	router := suite.newRouter(false, "")
 
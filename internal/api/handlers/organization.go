@@ -1,3 +1,56 @@
 package handlers
 
-// Currently there are no endpoints for 'organization' model. Placeholder file for consistency.
+import (
+	"net/http"
+
+	"developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OrganizationHandler handles HTTP requests for organizations
+type OrganizationHandler struct {
+	orgService service.OrganizationServiceInterface
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(orgService service.OrganizationServiceInterface) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+	}
+}
+
+// GetHierarchy handles GET /organizations/:id/hierarchy
+// @Summary Get an organization hierarchy
+// @Description Returns the organization with its groups, each with its teams, in a single response
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID (UUID)"
+// @Success 200 {object} service.OrgTree "Successfully retrieved organization hierarchy"
+// @Failure 400 {object} map[string]interface{} "Invalid organization ID"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/hierarchy [get]
+func (h *OrganizationHandler) GetHierarchy(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidOrganizationID.Error()})
+		return
+	}
+
+	tree, err := h.orgService.GetHierarchy(orgID)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get organization hierarchy", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
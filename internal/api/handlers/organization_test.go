@@ -1,7 +1,114 @@
 package handlers_test
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
 
-func TestOrganizationHandlersPlaceholder(t *testing.T) {
-	t.Skip("Placeholder test to keep package consisted and compiling.")
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OrganizationHandlerTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	mockOrgSvc *mocks.MockOrganizationServiceInterface
+	handler    *handlers.OrganizationHandler
+}
+
+func (suite *OrganizationHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOrgSvc = mocks.NewMockOrganizationServiceInterface(suite.ctrl)
+	suite.handler = handlers.NewOrganizationHandler(suite.mockOrgSvc)
+}
+
+func (suite *OrganizationHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *OrganizationHandlerTestSuite) newRouter() *gin.Engine {
+	r := gin.New()
+	r.GET("/organizations/:id/hierarchy", suite.handler.GetHierarchy)
+	return r
+}
+
+// TestGetHierarchy_Success tests retrieving an organization tree
+func (suite *OrganizationHandlerTestSuite) TestGetHierarchy_Success() {
+	router := suite.newRouter()
+	orgID := uuid.New()
+
+	suite.mockOrgSvc.EXPECT().GetHierarchy(orgID).Return(&service.OrgTree{
+		ID:   orgID,
+		Name: "acme",
+		Groups: []service.GroupNode{
+			{Name: "platform", Teams: []service.TeamNode{{Name: "team-a"}}},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/organizations/"+orgID.String()+"/hierarchy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var got service.OrgTree
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(suite.T(), orgID, got.ID)
+	assert.Len(suite.T(), got.Groups, 1)
+	assert.Equal(suite.T(), "team-a", got.Groups[0].Teams[0].Name)
+}
+
+// TestGetHierarchy_InvalidUUID tests invalid UUID format
+func (suite *OrganizationHandlerTestSuite) TestGetHierarchy_InvalidUUID() {
+	router := suite.newRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/organizations/invalid-uuid/hierarchy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), apperrors.ErrInvalidOrganizationID.Error())
+}
+
+// TestGetHierarchy_NotFound tests organization not found
+func (suite *OrganizationHandlerTestSuite) TestGetHierarchy_NotFound() {
+	router := suite.newRouter()
+	orgID := uuid.New()
+
+	suite.mockOrgSvc.EXPECT().GetHierarchy(orgID).Return(nil, apperrors.ErrOrganizationNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/organizations/"+orgID.String()+"/hierarchy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+// TestGetHierarchy_ServiceError tests an unexpected service failure
+func (suite *OrganizationHandlerTestSuite) TestGetHierarchy_ServiceError() {
+	router := suite.newRouter()
+	orgID := uuid.New()
+
+	suite.mockOrgSvc.EXPECT().GetHierarchy(orgID).Return(nil, errors.New("database error"))
+
+	req := httptest.NewRequest(http.MethodGet, "/organizations/"+orgID.String()+"/hierarchy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+}
+
+func TestOrganizationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationHandlerTestSuite))
 }
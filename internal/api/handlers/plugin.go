@@ -356,8 +356,8 @@ func (h *PluginHandler) GetPluginUI(c *gin.Context) {
 		return
 	}
 
-	// Default to "githubtools" provider - this could be made configurable
-	provider := c.DefaultQuery("provider", "githubtools")
+	// An empty provider resolves to the user's or the service's default provider
+	provider := c.Query("provider")
 
 	// Get plugin UI content
 	uiContent, err := h.pluginService.GetPluginUIContent(c.Request.Context(), id, h.githubService, claims.UUID, provider)
@@ -367,6 +367,9 @@ func (h *PluginHandler) GetPluginUI(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Plugin not found"})
 			return
 		}
+		if writeGitHubError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve plugin UI content", "details": err.Error()})
 		return
 	}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -33,6 +35,14 @@ func (m *MockPluginService) GetAllPluginsWithViewer(limit, offset int, viewerNam
 	return args.Get(0).(*service.PluginListResponse), args.Error(1)
 }
 
+func (m *MockPluginService) ListAvailablePlugins(userOrgID *uuid.UUID) ([]service.PluginResponse, error) {
+	args := m.Called(userOrgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.PluginResponse), args.Error(1)
+}
+
 func (m *MockPluginService) GetPluginByID(id uuid.UUID) (*service.PluginResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -41,6 +51,22 @@ func (m *MockPluginService) GetPluginByID(id uuid.UUID) (*service.PluginResponse
 	return args.Get(0).(*service.PluginResponse), args.Error(1)
 }
 
+func (m *MockPluginService) GetPluginByName(name string) (*service.PluginResponse, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PluginResponse), args.Error(1)
+}
+
+func (m *MockPluginService) SearchPlugins(query string, limit, offset int) (*service.PluginListResponse, error) {
+	args := m.Called(query, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PluginListResponse), args.Error(1)
+}
+
 func (m *MockPluginService) CreatePlugin(req *service.CreatePluginRequest) (*service.PluginResponse, error) {
 	args := m.Called(req)
 	if args.Get(0) == nil {
@@ -83,6 +109,62 @@ func (m *MockGitHubService) GetUserOpenPullRequests(ctx context.Context, uuid, p
 	return args.Get(0).(*service.PullRequestsResponse), args.Error(1)
 }
 
+func (m *MockGitHubService) GetUserOpenPullRequestsWithFilters(ctx context.Context, uuid, provider, state, sort, direction string, perPage, page int, filters service.QueryFilters) (*service.PullRequestsResponse, error) {
+	args := m.Called(ctx, uuid, provider, state, sort, direction, perPage, page, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PullRequestsResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserOpenPullRequestsAllProviders(ctx context.Context, uuid string, providers []string, state, sort, direction string, perPage, page int) (*service.PullRequestsResponse, error) {
+	args := m.Called(ctx, uuid, providers, state, sort, direction, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PullRequestsResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserReviewRequests(ctx context.Context, uuid, provider, state, sort, order string, perPage, page int) (*service.PullRequestsResponse, error) {
+	args := m.Called(ctx, uuid, provider, state, sort, order, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PullRequestsResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserAssignedIssues(ctx context.Context, uuid, provider, state, sort, order string, perPage, page int) (*service.IssuesResponse, error) {
+	args := m.Called(ctx, uuid, provider, state, sort, order, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.IssuesResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserRepositories(ctx context.Context, uuid, provider, sort string, perPage, page int) ([]service.Repository, error) {
+	args := m.Called(ctx, uuid, provider, sort, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.Repository), args.Error(1)
+}
+
+func (m *MockGitHubService) SearchRepositories(ctx context.Context, uuid, provider, query, sort string, perPage, page int) (*service.RepositoriesResponse, error) {
+	args := m.Called(ctx, uuid, provider, query, sort, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RepositoriesResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetPullRequestDetails(ctx context.Context, uuid, provider, owner, repo string, number int) (*service.PullRequestDetails, error) {
+	args := m.Called(ctx, uuid, provider, owner, repo, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PullRequestDetails), args.Error(1)
+}
+
 func (m *MockGitHubService) GetUserTotalContributions(ctx context.Context, uuid, provider, period string) (*service.TotalContributionsResponse, error) {
 	args := m.Called(ctx, uuid, provider, period)
 	if args.Get(0) == nil {
@@ -99,6 +181,22 @@ func (m *MockGitHubService) GetContributionsHeatmap(ctx context.Context, uuid, p
 	return args.Get(0).(*service.ContributionsHeatmapResponse), args.Error(1)
 }
 
+func (m *MockGitHubService) GetUserActivityTimeline(ctx context.Context, uuid, provider, period string) (*service.ActivityTimelineResponse, error) {
+	args := m.Called(ctx, uuid, provider, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ActivityTimelineResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetContributionsHeatmapIfNoneMatch(ctx context.Context, uuid, provider, period, ifNoneMatch string) (*service.ContributionsHeatmapResponse, error) {
+	args := m.Called(ctx, uuid, provider, period, ifNoneMatch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ContributionsHeatmapResponse), args.Error(1)
+}
+
 func (m *MockGitHubService) GetAveragePRMergeTime(ctx context.Context, uuid, provider, period string) (*service.AveragePRMergeTimeResponse, error) {
 	args := m.Called(ctx, uuid, provider, period)
 	if args.Get(0) == nil {
@@ -107,6 +205,14 @@ func (m *MockGitHubService) GetAveragePRMergeTime(ctx context.Context, uuid, pro
 	return args.Get(0).(*service.AveragePRMergeTimeResponse), args.Error(1)
 }
 
+func (m *MockGitHubService) GetCommitActivity(ctx context.Context, uuid, provider, owner, repo, period string) ([]service.WeeklyCommits, error) {
+	args := m.Called(ctx, uuid, provider, owner, repo, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.WeeklyCommits), args.Error(1)
+}
+
 func (m *MockGitHubService) GetUserPRReviewComments(ctx context.Context, uuid, provider, period string) (*service.PRReviewCommentsResponse, error) {
 	args := m.Called(ctx, uuid, provider, period)
 	if args.Get(0) == nil {
@@ -1179,6 +1285,15 @@ func TestPluginHandler_GetPluginUI(t *testing.T) {
 			mockError:      errors.New("service error"),
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:           "no default provider configured",
+			pluginID:       validID.String(),
+			setupAuth:      true,
+			mockResponse:   nil,
+			mockError:      fmt.Errorf("failed to fetch content from GitHub: %w", apperrors.ErrNoDefaultProvider),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"failed to fetch content from GitHub: ` + apperrors.ErrNoDefaultProvider.Error() + `"}`,
+		},
 	}
 
 	for _, tt := range tests {
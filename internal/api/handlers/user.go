@@ -51,6 +51,7 @@ type CreateUserBody struct {
 // @Param user body CreateUserBody true "User data"
 // @Success 201 {object} service.UserResponse "Successfully created user"
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 409 {object} map[string]interface{} "User with this email already exists"
 // @Security BearerAuth
 // @Router /users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
@@ -67,25 +68,15 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	// Validate team_domain against allowed values (optional; default handled in service)
-	if body.TeamDomain != nil {
-		switch models.TeamDomain(*body.TeamDomain) {
-		case models.TeamDomainDeveloper, models.TeamDomainDevOps, models.TeamDomainPO, models.TeamDomainArchitect:
-			// ok
-		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team_domain"})
-			return
-		}
+	if body.TeamDomain != nil && !models.TeamDomain(*body.TeamDomain).IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team_domain"})
+		return
 	}
 
 	// Validate team_role against allowed values (optional; default handled in service)
-	if body.TeamRole != nil {
-		switch models.TeamRole(*body.TeamRole) {
-		case models.TeamRoleMember, models.TeamRoleScM, models.TeamRoleManager, models.TeamRoleMMM:
-			// ok
-		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team_role"})
-			return
-		}
+	if body.TeamRole != nil && !models.TeamRole(*body.TeamRole).IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team_role"})
+		return
 	}
 
 	req := service.CreateUserRequest{
@@ -115,7 +106,9 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	user, err := h.memberService.CreateUser(&req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !writeAppError(c, err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -158,7 +151,8 @@ func (h *UserHandler) GetMemberByUserID(c *gin.Context) {
 // @Produce json
 // @Param limit query int false "Number of items to return" default(20)
 // @Param offset query int false "Number of items to skip" default(0)
-// @Param q query string false "Search query by name or title (case-insensitive)"
+// @Param q query string false "Search query by name, title or email (case-insensitive)"
+// @Param email_only query bool false "Restrict the q search to email addresses" default(false)
 // @Success 200 {object} service.UsersListResponse "Successfully retrieved users list"
 // @Failure 400 {object} map[string]interface{} "Invalid parameters"
 // @Security BearerAuth
@@ -179,9 +173,10 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	// If 'q' is provided, perform global search by name or title
+	// If 'q' is provided, perform global search by name, title or email
 	if q := c.Query("q"); q != "" {
-		users, total, err := h.memberService.SearchUsersGlobal(q, limit, offset)
+		emailOnly := c.DefaultQuery("email_only", "false") == "true"
+		users, total, err := h.memberService.SearchUsersGlobal(q, emailOnly, limit, offset)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -209,6 +204,61 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 	})
 }
 
+// maxMetadataAuditLimit caps the page size of GetMetadataAudit
+const maxMetadataAuditLimit = 100
+
+// GetMetadataAudit handles GET /users/:user_id/metadata-audit
+// @Summary Get a user's metadata change history
+// @Description Returns the append-only audit log of favorites and subscription changes for a user, newest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID (I/C/D user id, e.g. cis.devops)"
+// @Param limit query int false "Number of items to return, at most 100" default(20)
+// @Param offset query int false "Number of items to skip" default(0)
+// @Success 200 {object} map[string]interface{} "Successfully retrieved metadata audit entries"
+// @Failure 400 {object} map[string]interface{} "Invalid user_id, limit or offset"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /users/{user_id}/metadata-audit [get]
+func (h *UserHandler) GetMetadataAudit(c *gin.Context) {
+	userID := c.Param("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+		return
+	}
+	if limit > maxMetadataAuditLimit {
+		limit = maxMetadataAuditLimit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be non-negative"})
+		return
+	}
+
+	entries, total, err := h.memberService.GetMetadataAudit(userID, limit, offset)
+	if err != nil {
+		if writeAppError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get metadata audit", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
 // GetCurrentUser handles GET /users/me
 // @Summary Get current user
 // @Description Returns the user matching the bearer token 'username' claim, mapped to users.name
@@ -289,7 +339,9 @@ func (h *UserHandler) UpdateUserTeam(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 			return
 		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if !writeAppError(c, err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -324,12 +376,21 @@ func (h *UserHandler) AddFavoriteLink(c *gin.Context) {
 		return
 	}
 
-	user, err := h.memberService.AddFavoriteLinkByUserID(userID, linkID)
+	// Record the caller for the metadata audit trail
+	actor, _ := auth.GetUsername(c)
+	user, err := h.memberService.AddFavoriteLinkByUserID(userID, linkID, actor)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrUserNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrInvalidMetadata) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if writeAppError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add favorite", "details": err.Error()})
 		return
 	}
@@ -365,12 +426,21 @@ func (h *UserHandler) RemoveFavoriteLink(c *gin.Context) {
 		return
 	}
 
-	user, err := h.memberService.RemoveFavoriteLinkByUserID(userID, linkID)
+	// Record the caller for the metadata audit trail
+	actor, _ := auth.GetUsername(c)
+	user, err := h.memberService.RemoveFavoriteLinkByUserID(userID, linkID, actor)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrUserNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrInvalidMetadata) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if writeAppError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove favorite", "details": err.Error()})
 		return
 	}
@@ -388,7 +458,8 @@ func (h *UserHandler) RemoveFavoriteLink(c *gin.Context) {
 // @Param plugin_id path string true "Plugin ID (UUID)"
 // @Success 200 {object} service.UserResponse "Successfully added subscribed plugin"
 // @Failure 400 {object} map[string]interface{} "Invalid user_id or plugin_id"
-// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 403 {object} map[string]interface{} "Plugin not available to the user's organization"
+// @Failure 404 {object} map[string]interface{} "User or plugin not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /users/{user_id}/subscribed/{plugin_id} [post]
@@ -407,12 +478,25 @@ func (h *UserHandler) AddSubscribedPlugin(c *gin.Context) {
 		return
 	}
 
-	user, err := h.memberService.AddSubscribedPluginByUserID(userID, pluginID)
+	// Record the caller for the metadata audit trail
+	actor, _ := auth.GetUsername(c)
+	user, err := h.memberService.AddSubscribedPluginByUserID(userID, pluginID, actor)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrUserNotFound) {
+		if errors.Is(err, apperrors.ErrUserNotFound) || errors.Is(err, apperrors.ErrPluginNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrPluginNotAvailable) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, apperrors.ErrInvalidMetadata) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if writeAppError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add subscribed plugin", "details": err.Error()})
 		return
 	}
@@ -420,6 +504,42 @@ func (h *UserHandler) AddSubscribedPlugin(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// GetSubscribedPlugins handles GET /users/:user_id/plugins
+// @Summary Get a user's subscribed plugins
+// @Description Returns the plugins in the user's metadata.subscribed array, plus the subscribed IDs whose plugin no longer exists
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID (I/C/D user id, e.g. cis.devops)"
+// @Success 200 {object} service.SubscribedPluginsResponse "Successfully retrieved subscribed plugins"
+// @Failure 400 {object} map[string]interface{} "Invalid user_id"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /users/{user_id}/plugins [get]
+func (h *UserHandler) GetSubscribedPlugins(c *gin.Context) {
+	userID := c.Param("user_id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+		return
+	}
+
+	plugins, err := h.memberService.GetSubscribedPlugins(userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if writeAppError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get subscribed plugins", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plugins)
+}
+
 // RemoveSubscribedPlugin handles DELETE /users/:user_id/subscribed/:plugin_id and DELETE /users/:user_id/plugins/:plugin_id
 // @Summary Remove a subscribed plugin from a user
 // @Description Removes the given plugin_id from the user's metadata.subscribed array. Initializes metadata if missing. Idempotent if plugin not present.
@@ -449,15 +569,39 @@ func (h *UserHandler) RemoveSubscribedPlugin(c *gin.Context) {
 		return
 	}
 
-	user, err := h.memberService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	// Record the caller for the metadata audit trail
+	actor, _ := auth.GetUsername(c)
+	user, err := h.memberService.RemoveSubscribedPluginByUserID(userID, pluginID, actor)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrUserNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrInvalidMetadata) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if writeAppError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove subscribed plugin", "details": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, user)
 }
+
+// writeAppError responds with the HTTP status, message and failed fields of err's AppError mapping.
+// It reports false without writing when err has no mapping, leaving the response to the caller.
+func writeAppError(c *gin.Context, err error) bool {
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		return false
+	}
+	response := gin.H{"error": err.Error()}
+	if len(appErr.Fields) > 0 {
+		response["fields"] = appErr.Fields
+	}
+	c.JSON(appErr.HTTPStatus, response)
+	return true
+}
@@ -13,6 +13,7 @@ import (
 
 	"developer-portal-backend/internal/api/handlers"
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/mocks"
 
 	"github.com/gin-gonic/gin"
@@ -105,6 +106,8 @@ func (suite *UserHandlerTestSuite) newRouter(withUsername bool, username string)
 	r.GET("/users/:user_id", suite.handler.GetMemberByUserID)
 	r.POST("/users/:user_id/favorites/:link_id", suite.handler.AddFavoriteLink)
 	r.DELETE("/users/:user_id/favorites/:link_id", suite.handler.RemoveFavoriteLink)
+	r.GET("/users/:user_id/plugins", suite.handler.GetSubscribedPlugins)
+	r.GET("/users/:user_id/metadata-audit", suite.handler.GetMetadataAudit)
 	return r
 }
 
@@ -148,6 +151,33 @@ func (suite *UserHandlerTestSuite) TestCreateUser_Success() {
 	assert.Equal(suite.T(), "Doe", got["last_name"])
 }
 
+func (suite *UserHandlerTestSuite) TestCreateUser_DuplicateEmail_Conflict() {
+	router := suite.newRouter(true, "creator.user")
+	teamID := uuid.New()
+	suite.mockTeamService.EXPECT().GetByID(teamID).Return(&service.TeamResponse{}, nil)
+
+	// an existing user with the same email makes the service report a conflict
+	suite.mockUserRepo.EXPECT().GetByEmail("john.doe@example.com").Return(&models.User{Email: "john.doe@example.com"}, nil)
+	suite.mockUserRepo.EXPECT().Create(gomock.Any()).Times(0)
+
+	body := map[string]interface{}{
+		"id":         "i12345",
+		"first_name": "John",
+		"last_name":  "Doe",
+		"email":      "john.doe@example.com",
+		"team_id":    teamID,
+	}
+	data, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "user already exists")
+}
+
 func (suite *UserHandlerTestSuite) TestCreateUser_InvalidTeamID() {
 	router := suite.newRouter(true, "creator.user")
 	teamID := uuid.New()
@@ -314,7 +344,7 @@ func (suite *UserHandlerTestSuite) TestListUsers_SearchQ_Success() {
 		{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "i1", FirstName: "A", LastName: "B", Email: "a@b", TeamDomain: models.TeamDomainDeveloper, TeamRole: models.TeamRoleMember},
 		{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "i2", FirstName: "C", LastName: "D", Email: "c@d", TeamDomain: models.TeamDomainDeveloper, TeamRole: models.TeamRoleMember},
 	}
-	suite.mockUserRepo.EXPECT().SearchByNameOrTitleGlobal("abc", 10, 5).Return(users, int64(2), nil)
+	suite.mockUserRepo.EXPECT().SearchByNameOrTitleGlobal("abc", false, 10, 5).Return(users, int64(2), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/users?q=abc&limit=10&offset=5", nil)
 	w := httptest.NewRecorder()
@@ -491,7 +521,7 @@ func (suite *UserHandlerTestSuite) TestUpdateUserTeam_UserNotFound() {
 	teamUUID := uuid.New()
 
 	suite.mockTeamService.EXPECT().GetByID(teamUUID).Return(&service.TeamResponse{}, nil)
-	suite.mockUserRepo.EXPECT().GetByID(userUUID).Return(nil, errors.New("missing"))
+	suite.mockUserRepo.EXPECT().GetByID(userUUID).Return(nil, apperrors.ErrUserNotFound)
 
 	body := map[string]string{
 		"user_uuid":     userUUID.String(),
@@ -550,7 +580,7 @@ func (suite *UserHandlerTestSuite) TestAddFavoriteLink_UserNotFound() {
 	router := suite.newRouter(false, "")
 	linkID := uuid.New()
 
-	suite.mockUserRepo.EXPECT().GetByUserID("missing").Return(nil, errors.New("nope"))
+	suite.mockUserRepo.EXPECT().GetByUserID("missing").Return(nil, apperrors.ErrUserNotFound)
 
 	req := httptest.NewRequest(http.MethodPost, "/users/missing/favorites/"+linkID.String(), nil)
 	w := httptest.NewRecorder()
@@ -559,6 +589,56 @@ func (suite *UserHandlerTestSuite) TestAddFavoriteLink_UserNotFound() {
 	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
 }
 
+func (suite *UserHandlerTestSuite) TestAddFavoriteLink_InvalidStoredMetadata() {
+	router := suite.newRouter(false, "")
+	linkID := uuid.New()
+
+	suite.mockUserRepo.EXPECT().GetByUserID("iuser-1").Return(&models.User{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		UserID:    "iuser-1",
+		Metadata:  json.RawMessage(`{"favorites":["not-a-uuid"]}`),
+	}, nil)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Times(0)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/iuser-1/favorites/"+linkID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), apperrors.ErrInvalidMetadata.Error())
+}
+
+/*************** GetMetadataAudit ***************/
+
+func (suite *UserHandlerTestSuite) TestGetMetadataAudit_CapsLimit() {
+	router := suite.newRouter(false, "")
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	auditRepo.EXPECT().GetByUserID("iuser-1", 100, 0).Return([]models.MetadataAudit{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/iuser-1/metadata-audit?limit=100000", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var body map[string]interface{}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(suite.T(), float64(100), body["limit"])
+}
+
+func (suite *UserHandlerTestSuite) TestGetMetadataAudit_InvalidPagination() {
+	router := suite.newRouter(false, "")
+
+	for _, query := range []string{"limit=abc", "limit=0", "offset=-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/users/iuser-1/metadata-audit?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code, query)
+	}
+}
+
 func (suite *UserHandlerTestSuite) TestRemoveFavoriteLink_Success() {
 	router := suite.newRouter(false, "")
 	linkID := uuid.New()
@@ -584,6 +664,44 @@ func (suite *UserHandlerTestSuite) TestRemoveFavoriteLink_Success() {
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
 }
 
+/*************** Subscribed plugins ***************/
+
+func (suite *UserHandlerTestSuite) TestGetSubscribedPlugins_ReportsUnresolvedIDs() {
+	router := suite.newRouter(false, "")
+	validID := uuid.New()
+	deletedID := uuid.New()
+
+	suite.mockUserRepo.EXPECT().GetByUserID("iuser-3").Return(&models.User{
+		BaseModel: models.BaseModel{ID: uuid.New()},
+		UserID:    "iuser-3",
+		Metadata:  json.RawMessage(`{"subscribed":["` + validID.String() + `","` + deletedID.String() + `"]}`),
+	}, nil)
+	suite.mockPluginRepo.EXPECT().GetByIDs(gomock.Any()).Return([]models.Plugin{
+		{BaseModel: models.BaseModel{ID: validID, Name: "valid-plugin"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/iuser-3/plugins", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var resp service.SubscribedPluginsResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(suite.T(), resp.Plugins, 1)
+	assert.Equal(suite.T(), []uuid.UUID{deletedID}, resp.UnresolvedIDs)
+}
+
+func (suite *UserHandlerTestSuite) TestGetSubscribedPlugins_UserNotFound() {
+	router := suite.newRouter(false, "")
+	suite.mockUserRepo.EXPECT().GetByUserID("missing").Return(nil, apperrors.ErrUserNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/missing/plugins", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
 func TestUserHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(UserHandlerTestSuite))
 }
@@ -63,7 +63,10 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	pluginRepo := repository.NewPluginRepository(db)
 
 	// Initialize services
+	service.SetPageSizeLimits(cfg.DefaultPageSize, cfg.MaxPageSize)
 	userService := service.NewUserService(userRepo, linkRepo, pluginRepo, validator)
+	userService.SetMetadataAuditRepository(repository.NewMetadataAuditRepository(db))
+	userService.SetOrganizationRepositories(teamRepo, groupRepo)
 	teamService := service.NewTeamService(teamRepo, groupRepo, organizationRepo, userRepo, linkRepo, componentRepo, validator)
 	projectService := service.NewProjectService(projectRepo, validator)
 	componentService := service.NewComponentService(componentRepo, organizationRepo, projectRepo, validator)
@@ -81,6 +84,7 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	categoryService := service.NewCategoryService(categoryRepo, validator)
 	linkService := service.NewLinkService(linkRepo, userRepo, teamRepo, categoryRepo, validator)
 	docService := service.NewDocumentationService(docRepo, teamRepo, validator)
+	organizationService := service.NewOrganizationService(organizationRepo, groupRepo, teamRepo)
 
 	pluginService := service.NewPluginService(pluginRepo, userRepo, validator)
 
@@ -114,7 +118,9 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	}
 	jenkinsService := service.NewJenkinsService(cfg)
 	sonarService := service.NewSonarService(cfg)
-	aicoreService := service.NewAICoreService(userRepo, teamRepo, groupRepo, organizationRepo)
+	aicoreService := service.NewAICoreServiceWithCache(userRepo, teamRepo, groupRepo, organizationRepo, cacheService, ttlConfig)
+	// Team and role changes must not leave a stale GetMe team expansion behind
+	userService.RegisterCacheInvalidator(aicoreService)
 
 	// Initialize alert history client and service
 	alertHistoryClient := client.NewAlertHistoryClient(cfg.MonitoringServiceURL)
@@ -130,11 +136,22 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 	linkHandler := handlers.NewLinkHandler(linkService)
 	docHandler := handlers.NewDocumentationHandler(docService)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService)
 	ldapHandler := handlers.NewLDAPHandler(ldapService, userRepo)
 	jiraHandler := handlers.NewJiraHandler(jiraService)
 	jenkinsHandler := handlers.NewJenkinsHandler(jenkinsService)
 	sonarHandler := handlers.NewSonarHandler(sonarService)
-	githubService := service.NewGitHubService(authService)
+	githubService := service.NewGitHubServiceWithCache(authService, cacheService, ttlConfig)
+	githubService.SetUserRepository(userRepo)
+	githubService.SetDefaultProvider(cfg.DefaultVCSProvider)
+	if authConfig != nil {
+		// Route providers hosted on GitLab to the GitLab implementation
+		for providerName, providerConfig := range authConfig.Providers {
+			if providerConfig.Type == auth.ProviderTypeGitLab {
+				githubService.RegisterVCSProvider(providerName, service.NewGitLabService(service.NewAuthServiceAdapter(authService)))
+			}
+		}
+	}
 	githubHandler := handlers.NewGitHubHandler(githubService)
 	pluginHandler := handlers.NewPluginHandlerWithGitHub(pluginService, githubService)
 	aicoreHandler := handlers.NewAICoreHandler(aicoreService, validator)
@@ -187,13 +204,21 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			users.GET("/:user_id", userHandler.GetMemberByUserID)
 			users.POST("/:user_id/favorites/:link_id", userHandler.AddFavoriteLink)
 			users.DELETE("/:user_id/favorites/:link_id", userHandler.RemoveFavoriteLink)
+			users.GET("/:user_id/plugins", userHandler.GetSubscribedPlugins)
 			users.POST("/:user_id/plugins/:plugin_id", userHandler.AddSubscribedPlugin)
 			users.DELETE("/:user_id/plugins/:plugin_id", userHandler.RemoveSubscribedPlugin)
+			users.GET("/:user_id/metadata-audit", userHandler.GetMetadataAudit)
 		}
 
 		// Current user route: /users/me
 		v1.GET("/users/me", userHandler.GetCurrentUser)
 
+		// Organization routes
+		organizations := v1.Group("/organizations")
+		{
+			organizations.GET("/:id/hierarchy", organizationHandler.GetHierarchy) // Organization with nested groups and teams
+		}
+
 		// Team routes
 		teams := v1.Group("/teams")
 		{
@@ -251,6 +276,7 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			github.GET("/prs", githubHandler.GetMyPullRequests) // Convenient alias
 			github.GET("/contributions", githubHandler.GetUserTotalContributions)
 			github.GET("/average-pr-time", githubHandler.GetAveragePRMergeTime)
+			github.GET("/activity-timeline", githubHandler.GetActivityTimeline)
 			github.GET("/pr-review-comments", githubHandler.GetPRReviewComments)
 			github.GET("/:provider/heatmap", githubHandler.GetContributionsHeatmap)
 			// Repository content proxy for documentation viewer
@@ -284,6 +310,7 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 		{
 			// Deployment management
 			aicore.GET("/deployments", aicoreHandler.GetDeployments)
+			aicore.GET("/deployment-summaries", aicoreHandler.GetDeploymentSummaries)
 			aicore.GET("/deployments/:deploymentId", aicoreHandler.GetDeploymentDetails)
 			aicore.POST("/deployments", aicoreHandler.CreateDeployment)
 			aicore.PATCH("/deployments/:deploymentId", aicoreHandler.UpdateDeployment)
@@ -292,6 +319,8 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			// Model and configuration management
 			aicore.GET("/models", aicoreHandler.GetModels)
 			aicore.GET("/me", aicoreHandler.GetMe)
+			aicore.GET("/me/summary", aicoreHandler.GetProfileSummary)
+			aicore.GET("/configurations/:configurationId", aicoreHandler.GetConfigurationDetails)
 			aicore.POST("/configurations", aicoreHandler.CreateConfiguration)
 
 			// Chat inference
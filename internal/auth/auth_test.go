@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -120,6 +121,50 @@ func TestGitHubClientConfig(t *testing.T) {
 	assert.Contains(t, oauthConfig.Scopes, "user:email")
 }
 
+func TestGitLabClientConfig(t *testing.T) {
+	config := &ProviderConfig{
+		ClientID:          "test-client-id",
+		ClientSecret:      "test-client-secret",
+		EnterpriseBaseURL: "https://gitlab.example.com/",
+		Type:              ProviderTypeGitLab,
+	}
+
+	oauthConfig := NewGitHubClient(config).GetOAuth2Config("http://localhost:8080/callback")
+	assert.Equal(t, "https://gitlab.example.com/oauth/authorize", oauthConfig.Endpoint.AuthURL)
+	assert.Equal(t, "https://gitlab.example.com/oauth/token", oauthConfig.Endpoint.TokenURL)
+	assert.Equal(t, "test-client-id", oauthConfig.ClientID)
+	assert.Contains(t, oauthConfig.Scopes, "read_user")
+}
+
+func TestGitLabClientGetUserProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/user", r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer good-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":         42,
+			"username":   "jdoe",
+			"email":      "jdoe@example.com",
+			"name":       "John Doe",
+			"avatar_url": "https://gitlab.example.com/avatar.png",
+		})
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient(&ProviderConfig{EnterpriseBaseURL: server.URL, Type: ProviderTypeGitLab})
+
+	profile, err := client.GetUserProfile(context.Background(), "good-token")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), profile.ID)
+	assert.Equal(t, "jdoe", profile.Username)
+	assert.Equal(t, "jdoe@example.com", profile.Email)
+
+	_, err = client.GetUserProfile(context.Background(), "bad-token")
+	assert.EqualError(t, err, "invalid access token")
+}
+
 func TestJWTOperations(t *testing.T) {
 	config := &AuthConfig{
 		JWTSecret:   "test-signing-key-for-jwt-operations",
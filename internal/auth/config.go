@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -20,11 +21,16 @@ type AuthConfig struct {
 
 // ProviderConfig holds configuration for a specific provider
 type ProviderConfig struct {
-	ClientID          string `yaml:"client_id" json:"client_id"`
-	ClientSecret      string `yaml:"client_secret" json:"client_secret"`
-	EnterpriseBaseURL string `yaml:"enterprise_base_url,omitempty" json:"enterprise_base_url,omitempty"`
+	ClientID          string        `yaml:"client_id" json:"client_id"`
+	ClientSecret      string        `yaml:"client_secret" json:"client_secret"`
+	EnterpriseBaseURL string        `yaml:"enterprise_base_url,omitempty" json:"enterprise_base_url,omitempty"`
+	Type              string        `yaml:"type,omitempty" json:"type,omitempty"`                       // VCS host type: "github" (default) or "gitlab"
+	RequestTimeout    time.Duration `yaml:"request_timeout,omitempty" json:"request_timeout,omitempty"` // Per-request timeout for API calls; 0 uses the service default
 }
 
+// ProviderTypeGitLab marks a provider backed by a GitLab instance
+const ProviderTypeGitLab = "gitlab"
+
 // LoadAuthConfig loads and validates authentication configuration
 func LoadAuthConfig(configPath string) (*AuthConfig, error) {
 	// Create a new viper instance for auth config
@@ -71,6 +77,13 @@ func LoadAuthConfig(configPath string) (*AuthConfig, error) {
 				config.Providers[providerName] = provider
 			}
 		}
+		if provider.RequestTimeout == 0 {
+			viperKey := fmt.Sprintf("providers.%s.request_timeout", providerName)
+			if timeout := v.GetDuration(viperKey); timeout > 0 {
+				provider.RequestTimeout = timeout
+				config.Providers[providerName] = provider
+			}
+		}
 	}
 
 	// Override with environment variables for sensitive data
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/google/go-github/v57/github"
 	"golang.org/x/oauth2"
@@ -45,6 +46,10 @@ func NewGitHubClient(config *ProviderConfig) *GitHubClient {
 
 // GetUserProfile fetches user profile information from GitHub API
 func (c *GitHubClient) GetUserProfile(ctx context.Context, accessToken string) (*UserProfile, error) {
+	if c.isGitLab() {
+		return c.getGitLabUserProfile(ctx, accessToken)
+	}
+
 	// Create OAuth2 client with access token
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
@@ -112,6 +117,10 @@ func (c *GitHubClient) GetUserProfile(ctx context.Context, accessToken string) (
 
 // GetOAuth2Config returns the OAuth2 configuration for this GitHub client
 func (c *GitHubClient) GetOAuth2Config(redirectURL string) *oauth2.Config {
+	if c.isGitLab() {
+		return c.getGitLabOAuth2Config(redirectURL)
+	}
+
 	var endpoint oauth2.Endpoint
 
 	if c.config.EnterpriseBaseURL != "" {
@@ -155,3 +164,11 @@ func (c *GitHubClient) GetEnterpriseBaseURL() string {
 	}
 	return c.config.EnterpriseBaseURL
 }
+
+// GetRequestTimeout returns the configured per-request timeout, or 0 when unset
+func (c *GitHubClient) GetRequestTimeout() time.Duration {
+	if c.config == nil {
+		return 0
+	}
+	return c.config.RequestTimeout
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultGitLabBaseURL is used for GitLab providers without an enterprise_base_url
+const defaultGitLabBaseURL = "https://gitlab.com"
+
+// gitLabUser is the subset of the GitLab /user response used for the profile
+type gitLabUser struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// isGitLab reports whether this client's provider is hosted on GitLab
+func (c *GitHubClient) isGitLab() bool {
+	return c.config != nil && c.config.Type == ProviderTypeGitLab
+}
+
+// gitLabBaseURL returns the GitLab instance URL without a trailing slash
+func (c *GitHubClient) gitLabBaseURL() string {
+	if c.config.EnterpriseBaseURL == "" {
+		return defaultGitLabBaseURL
+	}
+	return strings.TrimSuffix(c.config.EnterpriseBaseURL, "/")
+}
+
+// getGitLabOAuth2Config returns the OAuth2 configuration using the GitLab OAuth endpoints
+func (c *GitHubClient) getGitLabOAuth2Config(redirectURL string) *oauth2.Config {
+	baseURL := c.gitLabBaseURL()
+	return &oauth2.Config{
+		ClientID:     c.config.ClientID,
+		ClientSecret: c.config.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"read_user", "read_api"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  baseURL + "/oauth/authorize",
+			TokenURL: baseURL + "/oauth/token",
+		},
+	}
+}
+
+// getGitLabUserProfile fetches the authenticated user's profile from the GitLab API
+func (c *GitHubClient) getGitLabUserProfile(ctx context.Context, accessToken string) (*UserProfile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.gitLabBaseURL()+"/api/v4/user", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user profile request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("invalid access token")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get user profile: status %d", resp.StatusCode)
+	}
+
+	var user gitLabUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("failed to decode user profile: %w", err)
+	}
+
+	return &UserProfile{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+	}, nil
+}
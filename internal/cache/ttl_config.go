@@ -19,6 +19,11 @@ type TTLConfig struct {
 	GitHubContributions time.Duration
 	SonarMeasures       time.Duration
 
+	// AI Core team expansion TTL (GetMe)
+	AICoreTeams time.Duration
+	// AI Core deployment idempotency key retention
+	AICoreIdempotency time.Duration
+
 	// Component service TTLs
 	ComponentList   time.Duration
 	ComponentByID   time.Duration
@@ -46,6 +51,11 @@ func DefaultTTLConfig() TTLConfig {
 		GitHubContributions: 10 * time.Minute,
 		SonarMeasures:       5 * time.Minute,
 
+		// Team membership changes are rare but should surface quickly
+		AICoreTeams: 1 * time.Minute,
+		// Long enough to cover client retries of a deployment request
+		AICoreIdempotency: 10 * time.Minute,
+
 		// Component data
 		ComponentList:   5 * time.Minute,
 		ComponentByID:   5 * time.Minute,
@@ -74,6 +84,10 @@ const (
 	KeyPrefixGitHubContrib   CacheKeyPrefix = "github:contributions"
 	KeyPrefixSonarMeasures   CacheKeyPrefix = "sonar:measures"
 
+	// AI Core cache key prefixes
+	KeyPrefixAICoreTeams       CacheKeyPrefix = "aicore:teams"
+	KeyPrefixAICoreIdempotency CacheKeyPrefix = "aicore:idempotency"
+
 	// Component cache key prefixes
 	KeyPrefixComponentList   CacheKeyPrefix = "component:list"
 	KeyPrefixComponentByID   CacheKeyPrefix = "component:id"
@@ -52,6 +52,13 @@ type Config struct {
 
 	// Monitoring service configuration
 	MonitoringServiceURL string `mapstructure:"MONITORING_SERVICE_URL"`
+
+	// VCS configuration
+	DefaultVCSProvider string `mapstructure:"DEFAULT_VCS_PROVIDER"`
+
+	// Pagination configuration
+	DefaultPageSize int `mapstructure:"DEFAULT_PAGE_SIZE"`
+	MaxPageSize     int `mapstructure:"MAX_PAGE_SIZE"`
 }
 
 // Load reads configuration from environment variables and config files
@@ -136,6 +143,13 @@ func setDefaults() {
 
 	// Monitoring service defaults
 	viper.SetDefault("MONITORING_SERVICE_URL", "http://localhost:8085")
+
+	// VCS defaults - used when neither the request nor the user's metadata names a provider
+	viper.SetDefault("DEFAULT_VCS_PROVIDER", "githubtools")
+
+	// Pagination defaults
+	viper.SetDefault("DEFAULT_PAGE_SIZE", 20)
+	viper.SetDefault("MAX_PAGE_SIZE", 100)
 }
 
 func buildDatabaseURL(config *Config) string {
@@ -86,6 +86,7 @@ func Initialize(dsn string, opts *Options) (*gorm.DB, error) {
 			&models.Link{},
 			&models.Plugin{},
 			&models.Token{},
+			&models.MetadataAudit{},
 		}
 		if err := db.AutoMigrate(all...); err != nil {
 			return nil, fmt.Errorf("auto-migrate: %w", err)
@@ -163,6 +164,22 @@ func CreateIndexes(db *gorm.DB) error {
 	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS links_name_category_id_unique ON links (name, category_id)`).Error; err != nil {
 		return fmt.Errorf("create unique index links.name+category_id: %w", err)
 	}
+	// Ensure unique index on link.name and owner, matching the per-owner name check.
+	// Links created before the check may share a name, so rename those first or the index cannot be built.
+	if err := dedupeLinkNames(db); err != nil {
+		return fmt.Errorf("dedupe links.owner+name: %w", err)
+	}
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS links_owner_name_unique ON links (owner, name)`).Error; err != nil {
+		return fmt.Errorf("create unique index links.owner+name: %w", err)
+	}
+	// Ensure unique index on users.email for active users only, so a soft-deleted user's email can be reused.
+	// Replaces the earlier non-partial idx_members_email_active index.
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_members_email_active`).Error; err != nil {
+		return fmt.Errorf("drop index users.email: %w", err)
+	}
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS users_email_active_unique ON users (email) WHERE deleted_at IS NULL`).Error; err != nil {
+		return fmt.Errorf("create unique index users.email: %w", err)
+	}
 	// Ensure unique index on category.name
 	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS categories_name_unique ON categories (name)`).Error; err != nil {
 		return fmt.Errorf("create unique index categories.name: %w", err)
@@ -171,6 +188,17 @@ func CreateIndexes(db *gorm.DB) error {
 	return nil
 }
 
+// dedupeLinkNames renames all but the oldest of an owner's links sharing a name, suffixing the name with
+// the start of the link ID so it stays within the 40 character limit and unique
+func dedupeLinkNames(db *gorm.DB) error {
+	return db.Exec(`UPDATE links SET name = LEFT(name, 31) || '-' || LEFT(id::text, 8)
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY owner, name ORDER BY created_at, id) AS rn FROM links
+			) ranked WHERE rn > 1
+		)`).Error
+}
+
 // ErrorsFix removes known erroneous data from early versions of the database.
 // most of those fixes can be removed after a version was deployed to 'dev' and 'prod' environments, as they are one-time fixes.
 func ErrorsFix(db *gorm.DB) error {
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MetadataAuditField identifies the user metadata field that was changed
+type MetadataAuditField string
+
+const (
+	MetadataAuditFieldFavorites  MetadataAuditField = "favorites"
+	MetadataAuditFieldSubscribed MetadataAuditField = "subscribed"
+)
+
+// MetadataAuditOperation identifies the change applied to a metadata field
+type MetadataAuditOperation string
+
+const (
+	MetadataAuditOperationAdd    MetadataAuditOperation = "add"
+	MetadataAuditOperationRemove MetadataAuditOperation = "remove"
+)
+
+// MetadataAudit is an append-only record of a change to a user's metadata
+type MetadataAudit struct {
+	ID        uuid.UUID              `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID    string                 `json:"user_id" gorm:"size:20;not null;index"`
+	Field     MetadataAuditField     `json:"field" gorm:"type:varchar(50);not null"`
+	Operation MetadataAuditOperation `json:"operation" gorm:"type:varchar(20);not null"`
+	TargetID  uuid.UUID              `json:"target_id" gorm:"type:uuid;not null"`
+	Actor     string                 `json:"actor" gorm:"size:100"`
+	CreatedAt time.Time              `json:"created_at" gorm:"not null;index"`
+}
+
+// TableName returns the table name for MetadataAudit
+func (MetadataAudit) TableName() string {
+	return "metadata_audit"
+}
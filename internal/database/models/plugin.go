@@ -1,5 +1,7 @@
 package models
 
+import "github.com/google/uuid"
+
 // Plugin represents a plugin in the developer portal
 type Plugin struct {
 	BaseModel
@@ -7,6 +9,8 @@ type Plugin struct {
 	ReactComponentPath string `json:"react_component_path" gorm:"size:500;not null" validate:"required,max=500"`
 	BackendServerURL   string `json:"backend_server_url" gorm:"size:500;not null" validate:"required,max=500"`
 	Owner              string `json:"owner" gorm:"size:100" validate:"max=100"`
+	// OrgID restricts the plugin to a single organization; nil makes it available to every organization
+	OrgID *uuid.UUID `json:"org_id,omitempty" gorm:"type:uuid;index"`
 }
 
 // TableName returns the table name for Plugin
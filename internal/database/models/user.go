@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type TeamDomain string
@@ -25,6 +26,30 @@ const (
 	TeamRoleMMM     TeamRole = "mmm"
 )
 
+// Defaults applied when a user is created without an explicit role or team role
+const (
+	DefaultTeamDomain = TeamDomainDeveloper
+	DefaultTeamRole   = TeamRoleMember
+)
+
+// IsValid checks if the TeamDomain is valid
+func (d TeamDomain) IsValid() bool {
+	switch d {
+	case TeamDomainDeveloper, TeamDomainDevOps, TeamDomainPO, TeamDomainArchitect:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if the TeamRole is valid
+func (r TeamRole) IsValid() bool {
+	switch r {
+	case TeamRoleMember, TeamRoleScM, TeamRoleManager, TeamRoleMMM:
+		return true
+	}
+	return false
+}
+
 // Member represents a member of an organization (replaces User)
 type User struct {
 	BaseModel
@@ -32,11 +57,13 @@ type User struct {
 	UserID      string          `gorm:"not null;size:20" validate:"required,min=5,max=20"` // I/C/D user
 	FirstName   string          `json:"first_name" gorm:"not null;size:100" validate:"required,max=100"`
 	LastName    string          `json:"last_name" gorm:"not null;size:100" validate:"required,max=100"`
-	Email       string          `json:"email" gorm:"uniqueIndex:idx_members_email_active;not null;size:255" validate:"required,email,max=255"`
+	Email       string          `json:"email" gorm:"not null;size:255" validate:"required,email,max=255"` // unique among active users, see CreateIndexes
 	Mobile      string          `json:"mobile" gorm:"size:20"`
+	PendingEmail string         `json:"pending_email,omitempty" gorm:"size:255"` // new email awaiting confirmation
 	TeamDomain  TeamDomain      `json:"role" gorm:"type:varchar(50);not null;default:'developer'" validate:"required"`
 	TeamRole    TeamRole        `json:"team_role" gorm:"type:varchar(50);not null;default:'member'"`
 	Metadata    json.RawMessage `json:"metadata" gorm:"type:jsonb"`
+	DeletedAt   gorm.DeletedAt  `json:"deleted_at,omitempty" gorm:"index"` // soft delete; excluded from queries by default
 }
 
 // TableName returns the table name for User
@@ -3,8 +3,47 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 )
 
+// Machine-readable AppError codes
+const (
+	CodeNotFound   = "not_found"
+	CodeValidation = "validation_failed"
+	CodeConflict   = "conflict"
+)
+
+// AppError is the handler-facing form of a service error: a machine-readable code,
+// a message and the HTTP status to respond with. NotFoundError, ValidationError and
+// AlreadyExistsError convert to it through errors.As, so services keep returning those.
+type AppError struct {
+	Code       string
+	Message    string
+	HTTPStatus int
+	Fields     map[string]string // Field -> failed rule, set for struct validation failures
+	Err        error             // Underlying typed error, reachable through errors.Is/As
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+// Unwrap exposes the underlying typed error
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// setAppError fills an errors.As target of type **AppError
+func setAppError(target interface{}, code string, status int, err error) bool {
+	appErr, ok := target.(**AppError)
+	if !ok {
+		return false
+	}
+	*appErr = &AppError{Code: code, Message: err.Error(), HTTPStatus: status, Err: err}
+	return true
+}
+
 // NotFoundError represents an error when an entity is not found
 type NotFoundError struct {
 	Entity string
@@ -23,6 +62,11 @@ func (e *NotFoundError) Is(target error) bool {
 	return e.Entity == t.Entity
 }
 
+// As converts a NotFoundError into a 404 AppError
+func (e *NotFoundError) As(target interface{}) bool {
+	return setAppError(target, CodeNotFound, http.StatusNotFound, e)
+}
+
 // AlreadyExistsError represents an error when an entity already exists
 type AlreadyExistsError struct {
 	Entity  string
@@ -45,19 +89,37 @@ func (e *AlreadyExistsError) Is(target error) bool {
 	return e.Entity == t.Entity
 }
 
+// As converts an AlreadyExistsError into a 409 AppError
+func (e *AlreadyExistsError) As(target interface{}) bool {
+	return setAppError(target, CodeConflict, http.StatusConflict, e)
+}
+
 // ValidationError represents a validation error
 type ValidationError struct {
 	Field   string
 	Message string
+	Fields  map[string]string // Field -> failed rule when a request failed struct validation; non-nil even if empty
 }
 
 func (e *ValidationError) Error() string {
+	if e.Fields != nil {
+		return fmt.Sprintf("validation failed: %s", e.Message)
+	}
 	if e.Field != "" {
 		return fmt.Sprintf("validation error: %s - %s", e.Field, e.Message)
 	}
 	return fmt.Sprintf("validation error: %s", e.Message)
 }
 
+// As converts a ValidationError into a 400 AppError carrying its field errors
+func (e *ValidationError) As(target interface{}) bool {
+	if !setAppError(target, CodeValidation, http.StatusBadRequest, e) {
+		return false
+	}
+	(*target.(**AppError)).Fields = e.Fields
+	return true
+}
+
 // AuthenticationError represents authentication-related errors
 type AuthenticationError struct {
 	Message string
@@ -85,6 +147,60 @@ func (e *ConfigurationError) Error() string {
 	return e.Message
 }
 
+// AICoreAPIError represents a non-success response returned by the AI Core API
+type AICoreAPIError struct {
+	StatusCode int
+	Message    string // Upstream error message parsed from the response body
+	Team       string
+}
+
+func (e *AICoreAPIError) Error() string {
+	return fmt.Sprintf("%s with status %d: %s", ErrAICoreAPIRequestFailed.Error(), e.StatusCode, e.Message)
+}
+
+// Unwrap keeps errors.Is(err, ErrAICoreAPIRequestFailed) working for AICoreAPIError
+func (e *AICoreAPIError) Unwrap() error {
+	return ErrAICoreAPIRequestFailed
+}
+
+// AICoreRateLimitError represents a rate limit hit for a team, either locally or reported by AI Core
+type AICoreRateLimitError struct {
+	Team       string
+	RetryAfter time.Duration // How long the caller should wait before retrying, zero if unknown
+}
+
+func (e *AICoreRateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("AI Core rate limit exceeded, retry after %s", e.RetryAfter)
+	}
+	return "AI Core rate limit exceeded, please retry later"
+}
+
+// Is enables errors.Is() comparison against ErrAICoreRateLimited
+func (e *AICoreRateLimitError) Is(target error) bool {
+	_, ok := target.(*AICoreRateLimitError)
+	return ok
+}
+
+// GitHubSecondaryRateLimitError represents a GitHub secondary (abuse) rate limit, which unlike the
+// primary hourly quota is lifted after the Retry-After delay
+type GitHubSecondaryRateLimitError struct {
+	RetryAfter time.Duration // How long the caller should wait before retrying, zero if unknown
+}
+
+func (e *GitHubSecondaryRateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("GitHub secondary rate limit exceeded, retry after %s", e.RetryAfter)
+	}
+	return "GitHub secondary rate limit exceeded, please retry later"
+}
+
+// Is enables errors.Is() comparison against ErrGitHubSecondaryRateLimit
+func (e *GitHubSecondaryRateLimitError) Is(target error) bool {
+	_, ok := target.(*GitHubSecondaryRateLimitError)
+	return ok
+}
+
 // Entity Not Found Errors
 var (
 	ErrOrganizationNotFound           = &NotFoundError{Entity: "organization"}
@@ -108,6 +224,8 @@ var (
 	ErrOutageCallAssigneeNotFound     = &NotFoundError{Entity: "outage call assignee"}
 	ErrDocumentationNotFound          = &NotFoundError{Entity: "documentation"}
 	ErrAlertNotFound                  = &NotFoundError{Entity: "alert"}
+	ErrPluginNotFound                 = &NotFoundError{Entity: "plugin"}
+	ErrPullRequestNotFound            = &NotFoundError{Entity: "pull request"}
 )
 
 // Already Exists Errors
@@ -120,6 +238,7 @@ var (
 	ErrLandscapeExists                 = &AlreadyExistsError{Entity: "landscape", Context: "with this name"}
 	ErrGroupExists                     = &AlreadyExistsError{Entity: "group", Context: "with this name in the organization"}
 	ErrLinkExists                      = &AlreadyExistsError{Entity: "link", Context: "with this URL"}
+	ErrLinkNameTaken                   = &AlreadyExistsError{Entity: "link", Context: "with this name for this owner"}
 	ErrComponentDeploymentExists       = &AlreadyExistsError{Entity: "component deployment", Context: "for this component and landscape"}
 	ErrActiveComponentDeploymentExists = &AlreadyExistsError{Entity: "active component deployment", Context: "for this component and landscape"}
 	ErrTeamComponentOwnershipExists    = &AlreadyExistsError{Entity: "team-component ownership", Context: ""}
@@ -137,6 +256,7 @@ var (
 	ErrMemberAlreadyAssigned      = errors.New("member is already assigned to this outage call")
 	ErrMemberNotAssigned          = errors.New("member is not assigned to this outage call")
 	ErrActiveDeploymentNotFound   = errors.New("active deployment not found")
+	ErrNoPendingEmailChange       = errors.New("no pending email change for this user")
 )
 
 // Business Logic Errors
@@ -151,15 +271,23 @@ var (
 	ErrNoMembersInTeam             = errors.New("team has no members")
 	ErrInvalidPaginationParams     = errors.New("invalid pagination parameters")
 	ErrGitHubAPIRateLimitExceeded  = errors.New("GitHub API rate limit exceeded")
+	ErrGitHubSecondaryRateLimit    = &GitHubSecondaryRateLimitError{}
+	ErrUpstreamTimeout             = errors.New("upstream request timed out")
+	ErrGitHubStatsNotReady         = errors.New("GitHub is still computing repository statistics")
 	ErrProviderNotConfigured       = errors.New("provider is not configured")
 	ErrInvalidPeriodFormat         = errors.New("invalid period format")
+	ErrInvalidPeriod               = fmt.Errorf("%w: unsupported period", ErrInvalidPeriodFormat) // Period outside the supported set; also matches ErrInvalidPeriodFormat
+	ErrFileTooLarge                = errors.New("file exceeds the maximum content size")
 	ErrInternalError               = errors.New("internal server error")
 	ErrInvalidJSON                 = errors.New("invalid JSON")
+	ErrInvalidMetadata             = errors.New("invalid metadata")
+	ErrNotModified                 = errors.New("resource not modified")
 	ErrInvalidJSONResponse         = errors.New("invalid JSON response")
 	ErrInvalidComponentID          = errors.New("invalid component-id")
 	ErrInvalidLandscapeID          = errors.New("invalid landscape-id")
 	ErrInvalidTeamID               = errors.New("invalid team ID")
 	ErrInvalidDocumentationID      = errors.New("invalid documentation ID")
+	ErrInvalidOrganizationID       = errors.New("invalid organization ID")
 	ErrFailedToDeleteDocumentation = errors.New("failed to delete documentation")
 )
 
@@ -178,6 +306,11 @@ var (
 	ErrMissingUsernameInToken = &AuthenticationError{Message: "missing username in token"}
 )
 
+// Authorization Errors
+var (
+	ErrPluginNotAvailable = &AuthorizationError{Message: "plugin is not available to the user's organization"}
+)
+
 // Configuration Errors
 var (
 	ErrJiraConfigMissing             = errors.New("jira configuration missing: JIRA_DOMAIN, JIRA_USER or JIRA_PASSWORD")
@@ -186,6 +319,7 @@ var (
 	ErrJenkinsQueueItemNotFound      = errors.New("jenkins queue item not found")
 	ErrJenkinsBuildNotFound          = errors.New("jenkins build not found")
 	ErrLandscapeNotConfigured        = &ConfigurationError{Message: "landscape service not configured"}
+	ErrOrgReposNotConfigured         = &ConfigurationError{Message: "organization repositories not configured"}
 	ErrAlertsRepositoryNotConfigured = &ConfigurationError{Message: "alerts repository not configured for this project"}
 	ErrDatabaseConnection            = &ConfigurationError{Message: "database connection failed"}
 	ErrTokenStoreNotInitialized      = &ConfigurationError{Message: "token store not initialized"}
@@ -198,6 +332,10 @@ var (
 	ErrAICoreCredentialsNotConfigured = &ConfigurationError{Message: "No AI Core credentials configured for your team"}
 	ErrAICoreAPIRequestFailed         = errors.New("AI Core API request failed")
 	ErrAICoreDeploymentNotFound       = &NotFoundError{Entity: "deployment"}
+	ErrAICoreConfigurationNotFound    = &NotFoundError{Entity: "configuration"}
+	ErrAICoreRateLimited              = &AICoreRateLimitError{}
+	ErrUploadRateLimited              = errors.New("attachment upload rate limit exceeded")
+	ErrDeploymentTimeout              = errors.New("timed out waiting for deployment status")
 	ErrBothConfigurationInputs        = &ConfigurationError{Message: "ConfigurationId and configurationRequest cannot both be provided"}
 	ErrMissingConfigurationInput      = &ConfigurationError{Message: "Either configurationId or configurationRequest must be provided"}
 
@@ -211,10 +349,16 @@ var (
 	// AI Core specific validation errors
 	ErrMissingScenarioID             = &ValidationError{Field: "scenarioId", Message: "scenarioId query parameter is required"}
 	ErrMissingDeploymentID           = &ValidationError{Field: "deployment", Message: "deploymentId parameter is required"}
+	ErrMissingConfigurationID        = &ValidationError{Field: "configuration", Message: "configurationId parameter is required"}
 	ErrMissingTargetStatusOrConfigID = &ValidationError{Message: "At least one of targetStatus or configurationId must be provided"}
 	ErrNoFilesProvided               = &ValidationError{Field: "files", Message: "No files provided"}
 	ErrFileSizeTooLarge              = &ValidationError{Field: "files", Message: "Files too large or invalid form data. Combined size limit is 5MB"}
 	ErrCombinedFileSizeExceeds       = &ValidationError{Field: "files", Message: "Combined file size exceeds 5MB limit"}
+	ErrAttachmentTypeNotAllowed      = &ValidationError{Field: "files", Message: "HTML and SVG attachments are not allowed"}
+	ErrPromptTooLarge                = &ValidationError{Field: "messages", Message: "prompt exceeds the maximum allowed size"}
+	ErrInvalidTTL                    = &ValidationError{Field: "ttl", Message: "invalid deployment ttl"}
+	ErrInvalidOrchestrationTemplate  = &ValidationError{Field: "orchestrationConfig", Message: "templating_module_config.template must be a list of messages"}
+	ErrOrchestrationModelOverride    = &ValidationError{Field: "orchestrationConfig", Message: "llm_module_config.model_name cannot override the deployment's model"}
 
 	// Component specific validation errors
 	ErrMissingHealthParams      = &ValidationError{Message: "component-id and landscape-id parameters are required"}
@@ -227,9 +371,11 @@ var (
 
 	//gitHub specific validation errors
 	ErrMissingUserUUIDAndProvider = &ValidationError{Message: "userUUID and provider are required"}
+	ErrNoDefaultProvider          = fmt.Errorf("%w: no provider given and no default provider configured", ErrMissingUserUUIDAndProvider)
 	ErrUserUUIDMissing            = &ValidationError{Field: "userUUID", Message: "userUUID cannot be empty"}
 	ErrProviderMissing            = &ValidationError{Field: "provider", Message: "provider cannot be empty"}
 	ErrOwnerAndRepositoryMissing  = &ValidationError{Message: "owner and repository are required"}
+	ErrSearchQueryMissing         = &ValidationError{Field: "q", Message: "search query cannot be empty"}
 )
 
 // Helper Functions
@@ -270,6 +416,60 @@ func IsConfiguration(err error) bool {
 	return errors.Is(err, &ConfigurationError{}) || errors.As(err, &configErr)
 }
 
+// IsAICoreRateLimited checks if an error is an AI Core rate limit error
+func IsAICoreRateLimited(err error) bool {
+	return errors.Is(err, ErrAICoreRateLimited)
+}
+
+// IsUploadRateLimited checks if an error reports an exceeded per-user attachment upload rate
+func IsUploadRateLimited(err error) bool {
+	return errors.Is(err, ErrUploadRateLimited)
+}
+
+// AsGitHubSecondaryRateLimitError returns the GitHubSecondaryRateLimitError wrapped in err, if any
+func AsGitHubSecondaryRateLimitError(err error) (*GitHubSecondaryRateLimitError, bool) {
+	var rateErr *GitHubSecondaryRateLimitError
+	if errors.As(err, &rateErr) {
+		return rateErr, true
+	}
+	return nil, false
+}
+
+// AsAICoreRateLimitError returns the AICoreRateLimitError wrapped in err, if any
+func AsAICoreRateLimitError(err error) (*AICoreRateLimitError, bool) {
+	var rateErr *AICoreRateLimitError
+	if errors.As(err, &rateErr) {
+		return rateErr, true
+	}
+	return nil, false
+}
+
+// AsAICoreAPIError returns the AICoreAPIError wrapped in err, if any
+func AsAICoreAPIError(err error) (*AICoreAPIError, bool) {
+	var apiErr *AICoreAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// AsAppError returns err as an AppError, converting NotFound, Validation and AlreadyExists errors
+func AsAppError(err error) (*AppError, bool) {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// HTTPStatus returns the HTTP status for err, falling back to 500 for errors without a mapping
+func HTTPStatus(err error) int {
+	if appErr, ok := AsAppError(err); ok {
+		return appErr.HTTPStatus
+	}
+	return http.StatusInternalServerError
+}
+
 // NewNotFoundError creates a new NotFoundError for a custom entity
 func NewNotFoundError(entity string) error {
 	return &NotFoundError{Entity: entity}
@@ -285,6 +485,15 @@ func NewValidationError(field, message string) error {
 	return &ValidationError{Field: field, Message: message}
 }
 
+// NewFieldValidationError creates a ValidationError for a failed request validation, reporting the failed rule
+// of each field. Its message reads "validation failed: <message>".
+func NewFieldValidationError(message string, fields map[string]string) error {
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	return &ValidationError{Message: message, Fields: fields}
+}
+
 // NewAuthenticationError creates a new AuthenticationError
 func NewAuthenticationError(message string) error {
 	return &AuthenticationError{Message: message}
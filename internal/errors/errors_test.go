@@ -2,6 +2,8 @@ package errors
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -70,6 +72,11 @@ func TestValidationError(t *testing.T) {
 		assert.Equal(t, "validation error: invalid format", err.Error())
 	})
 
+	t.Run("Error message of a request validation", func(t *testing.T) {
+		err := NewFieldValidationError("Key: 'Request.Email' failed on the 'email' tag", nil)
+		assert.Equal(t, "validation failed: Key: 'Request.Email' failed on the 'email' tag", err.Error())
+	})
+
 	t.Run("IsValidation helper", func(t *testing.T) {
 		err := NewValidationError("email", "invalid")
 		assert.True(t, IsValidation(err))
@@ -77,6 +84,39 @@ func TestValidationError(t *testing.T) {
 	})
 }
 
+func TestAppError(t *testing.T) {
+	t.Run("NotFound maps to 404", func(t *testing.T) {
+		appErr, ok := AsAppError(fmt.Errorf("lookup: %w", ErrUserNotFound))
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, appErr.HTTPStatus)
+		assert.Equal(t, CodeNotFound, appErr.Code)
+		assert.Equal(t, "user not found", appErr.Message)
+	})
+
+	t.Run("Validation maps to 400", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, HTTPStatus(NewValidationError("email", "invalid")))
+	})
+
+	t.Run("AlreadyExists maps to 409", func(t *testing.T) {
+		appErr, ok := AsAppError(ErrUserExists)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, appErr.HTTPStatus)
+		assert.Equal(t, CodeConflict, appErr.Code)
+	})
+
+	t.Run("Underlying error stays reachable", func(t *testing.T) {
+		appErr, _ := AsAppError(ErrUserNotFound)
+		assert.True(t, errors.Is(appErr, ErrUserNotFound))
+		assert.True(t, IsNotFound(appErr))
+	})
+
+	t.Run("Unmapped errors fall back to 500", func(t *testing.T) {
+		_, ok := AsAppError(errors.New("boom"))
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, HTTPStatus(errors.New("boom")))
+	})
+}
+
 func TestHelperFunctions(t *testing.T) {
 	t.Run("NewNotFoundError", func(t *testing.T) {
 		err := NewNotFoundError("custom entity")
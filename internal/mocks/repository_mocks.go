@@ -10,8 +10,10 @@
 package mocks
 
 import (
+	context "context"
 	models "developer-portal-backend/internal/database/models"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -258,6 +260,51 @@ func (m *MockUserRepositoryInterface) EXPECT() *MockUserRepositoryInterfaceMockR
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockUserRepositoryInterface) Count() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockUserRepositoryInterfaceMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Count))
+}
+
+// CountByDomain mocks base method.
+func (m *MockUserRepositoryInterface) CountByDomain(orgID uuid.UUID) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByDomain", orgID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByDomain indicates an expected call of CountByDomain.
+func (mr *MockUserRepositoryInterfaceMockRecorder) CountByDomain(orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByDomain", reflect.TypeOf((*MockUserRepositoryInterface)(nil).CountByDomain), orgID)
+}
+
+// CountByOrganization mocks base method.
+func (m *MockUserRepositoryInterface) CountByOrganization(orgID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByOrganization", orgID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByOrganization indicates an expected call of CountByOrganization.
+func (mr *MockUserRepositoryInterfaceMockRecorder) CountByOrganization(orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).CountByOrganization), orgID)
+}
+
 // Create mocks base method.
 func (m *MockUserRepositoryInterface) Create(member *models.User) error {
 	m.ctrl.T.Helper()
@@ -302,6 +349,22 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetActiveByOrganization(orgID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetActiveByOrganization), orgID, limit, offset)
 }
 
+// GetActiveByOrganizationSince mocks base method.
+func (m *MockUserRepositoryInterface) GetActiveByOrganizationSince(orgID uuid.UUID, since time.Time, limit, offset int) ([]models.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByOrganizationSince", orgID, since, limit, offset)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetActiveByOrganizationSince indicates an expected call of GetActiveByOrganizationSince.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetActiveByOrganizationSince(orgID, since, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByOrganizationSince", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetActiveByOrganizationSince), orgID, since, limit, offset)
+}
+
 // GetAll mocks base method.
 func (m *MockUserRepositoryInterface) GetAll(limit, offset int) ([]models.User, int64, error) {
 	m.ctrl.T.Helper()
@@ -318,6 +381,21 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetAll(limit, offset any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetAll), limit, offset)
 }
 
+// GetAllAfter mocks base method.
+func (m *MockUserRepositoryInterface) GetAllAfter(createdAt time.Time, id uuid.UUID, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllAfter", createdAt, id, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllAfter indicates an expected call of GetAllAfter.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetAllAfter(createdAt, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAfter", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetAllAfter), createdAt, id, limit)
+}
+
 // GetByEmail mocks base method.
 func (m *MockUserRepositoryInterface) GetByEmail(email string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -333,6 +411,21 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetByEmail(email any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetByEmail), email)
 }
 
+// GetByEmails mocks base method.
+func (m *MockUserRepositoryInterface) GetByEmails(emails []string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmails", emails)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmails indicates an expected call of GetByEmails.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetByEmails(emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmails", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetByEmails), emails)
+}
+
 // GetByID mocks base method.
 func (m *MockUserRepositoryInterface) GetByID(id uuid.UUID) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -348,6 +441,21 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetByID(id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetByID), id)
 }
 
+// GetByIDIncludingDeleted mocks base method.
+func (m *MockUserRepositoryInterface) GetByIDIncludingDeleted(id uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDIncludingDeleted", id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDIncludingDeleted indicates an expected call of GetByIDIncludingDeleted.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetByIDIncludingDeleted(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDIncludingDeleted", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetByIDIncludingDeleted), id)
+}
+
 // GetByName mocks base method.
 func (m *MockUserRepositoryInterface) GetByName(name string) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -455,10 +563,24 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetWithOrganization(id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetWithOrganization), id)
 }
 
+// Restore mocks base method.
+func (m *MockUserRepositoryInterface) Restore(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockUserRepositoryInterfaceMockRecorder) Restore(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Restore), id)
+}
+
 // SearchByNameOrTitleGlobal mocks base method.
-func (m *MockUserRepositoryInterface) SearchByNameOrTitleGlobal(query string, limit, offset int) ([]models.User, int64, error) {
+func (m *MockUserRepositoryInterface) SearchByNameOrTitleGlobal(query string, emailOnly bool, limit, offset int) ([]models.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByNameOrTitleGlobal", query, limit, offset)
+	ret := m.ctrl.Call(m, "SearchByNameOrTitleGlobal", query, emailOnly, limit, offset)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -466,15 +588,15 @@ func (m *MockUserRepositoryInterface) SearchByNameOrTitleGlobal(query string, li
 }
 
 // SearchByNameOrTitleGlobal indicates an expected call of SearchByNameOrTitleGlobal.
-func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByNameOrTitleGlobal(query, limit, offset any) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByNameOrTitleGlobal(query, emailOnly, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByNameOrTitleGlobal", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByNameOrTitleGlobal), query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByNameOrTitleGlobal", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByNameOrTitleGlobal), query, emailOnly, limit, offset)
 }
 
 // SearchByOrganization mocks base method.
-func (m *MockUserRepositoryInterface) SearchByOrganization(orgID uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
+func (m *MockUserRepositoryInterface) SearchByOrganization(orgID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]models.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByOrganization", orgID, query, limit, offset)
+	ret := m.ctrl.Call(m, "SearchByOrganization", orgID, query, emailOnly, limit, offset)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -482,9 +604,25 @@ func (m *MockUserRepositoryInterface) SearchByOrganization(orgID uuid.UUID, quer
 }
 
 // SearchByOrganization indicates an expected call of SearchByOrganization.
-func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByOrganization(orgID, query, limit, offset any) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByOrganization(orgID, query, emailOnly, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByOrganization), orgID, query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByOrganization), orgID, query, emailOnly, limit, offset)
+}
+
+// SearchByTeamIDs mocks base method.
+func (m *MockUserRepositoryInterface) SearchByTeamIDs(teamIDs []uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchByTeamIDs", teamIDs, query, limit, offset)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchByTeamIDs indicates an expected call of SearchByTeamIDs.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByTeamIDs(teamIDs, query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByTeamIDs", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByTeamIDs), teamIDs, query, limit, offset)
 }
 
 // Update mocks base method.
@@ -599,6 +737,21 @@ func (mr *MockGroupRepositoryInterfaceMockRecorder) GetByOrganizationID(orgID, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrganizationID", reflect.TypeOf((*MockGroupRepositoryInterface)(nil).GetByOrganizationID), orgID, limit, offset)
 }
 
+// GetByOwner mocks base method.
+func (m *MockGroupRepositoryInterface) GetByOwner(owner string) ([]models.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwner", owner)
+	ret0, _ := ret[0].([]models.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwner indicates an expected call of GetByOwner.
+func (mr *MockGroupRepositoryInterfaceMockRecorder) GetByOwner(owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwner", reflect.TypeOf((*MockGroupRepositoryInterface)(nil).GetByOwner), owner)
+}
+
 // GetWithOrganization mocks base method.
 func (m *MockGroupRepositoryInterface) GetWithOrganization(id uuid.UUID) (*models.Group, error) {
 	m.ctrl.T.Helper()
@@ -839,15 +992,6 @@ type MockProjectRepositoryInterface struct {
 	isgomock struct{}
 }
 
-func (m *MockProjectRepositoryInterface) GetHealthMetadata(projectID uuid.UUID) (string, string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHealthMetadata", projectID)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
-}
-
 // MockProjectRepositoryInterfaceMockRecorder is the mock recorder for MockProjectRepositoryInterface.
 type MockProjectRepositoryInterfaceMockRecorder struct {
 	mock *MockProjectRepositoryInterface
@@ -939,19 +1083,20 @@ func (mr *MockProjectRepositoryInterfaceMockRecorder) GetByOrganizationID(orgID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrganizationID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetByOrganizationID), orgID, limit, offset)
 }
 
-// GetHealthURL mocks base method.
-func (m *MockProjectRepositoryInterface) GetHealthURL(projectID uuid.UUID) (string, error) {
+// GetHealthMetadata mocks base method.
+func (m *MockProjectRepositoryInterface) GetHealthMetadata(projectID uuid.UUID) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHealthURL", projectID)
+	ret := m.ctrl.Call(m, "GetHealthMetadata", projectID)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetHealthURL indicates an expected call of GetHealthURL.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) GetHealthURL(projectID any) *gomock.Call {
+// GetHealthMetadata indicates an expected call of GetHealthMetadata.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) GetHealthMetadata(projectID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthURL", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetHealthURL), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthMetadata", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetHealthMetadata), projectID)
 }
 
 // Update mocks base method.
@@ -1532,6 +1677,36 @@ func (mr *MockLinkRepositoryInterfaceMockRecorder) GetByIDs(ids any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).GetByIDs), ids)
 }
 
+// GetByIDsStrict mocks base method.
+func (m *MockLinkRepositoryInterface) GetByIDsStrict(ids []uuid.UUID) ([]models.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDsStrict", ids)
+	ret0, _ := ret[0].([]models.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDsStrict indicates an expected call of GetByIDsStrict.
+func (mr *MockLinkRepositoryInterfaceMockRecorder) GetByIDsStrict(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDsStrict", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).GetByIDsStrict), ids)
+}
+
+// GetByName mocks base method.
+func (m *MockLinkRepositoryInterface) GetByName(owner uuid.UUID, name string) (*models.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", owner, name)
+	ret0, _ := ret[0].(*models.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockLinkRepositoryInterfaceMockRecorder) GetByName(owner, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).GetByName), owner, name)
+}
+
 // GetByOwner mocks base method.
 func (m *MockLinkRepositoryInterface) GetByOwner(owner uuid.UUID) ([]models.Link, error) {
 	m.ctrl.T.Helper()
@@ -1741,6 +1916,21 @@ func (mr *MockPluginRepositoryInterfaceMockRecorder) GetAll(limit, offset any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).GetAll), limit, offset)
 }
 
+// GetAvailableForOrganization mocks base method.
+func (m *MockPluginRepositoryInterface) GetAvailableForOrganization(orgID *uuid.UUID) ([]models.Plugin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailableForOrganization", orgID)
+	ret0, _ := ret[0].([]models.Plugin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailableForOrganization indicates an expected call of GetAvailableForOrganization.
+func (mr *MockPluginRepositoryInterfaceMockRecorder) GetAvailableForOrganization(orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableForOrganization", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).GetAvailableForOrganization), orgID)
+}
+
 // GetByID mocks base method.
 func (m *MockPluginRepositoryInterface) GetByID(id uuid.UUID) (*models.Plugin, error) {
 	m.ctrl.T.Helper()
@@ -1756,6 +1946,21 @@ func (mr *MockPluginRepositoryInterfaceMockRecorder) GetByID(id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).GetByID), id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockPluginRepositoryInterface) GetByIDs(ids []uuid.UUID) ([]models.Plugin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]models.Plugin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockPluginRepositoryInterfaceMockRecorder) GetByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).GetByIDs), ids)
+}
+
 // GetByName mocks base method.
 func (m *MockPluginRepositoryInterface) GetByName(name string) (*models.Plugin, error) {
 	m.ctrl.T.Helper()
@@ -1771,6 +1976,38 @@ func (mr *MockPluginRepositoryInterfaceMockRecorder) GetByName(name any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).GetByName), name)
 }
 
+// GetByOrganizationID mocks base method.
+func (m *MockPluginRepositoryInterface) GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Plugin, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrganizationID", orgID, limit, offset)
+	ret0, _ := ret[0].([]models.Plugin)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByOrganizationID indicates an expected call of GetByOrganizationID.
+func (mr *MockPluginRepositoryInterfaceMockRecorder) GetByOrganizationID(orgID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrganizationID", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).GetByOrganizationID), orgID, limit, offset)
+}
+
+// Search mocks base method.
+func (m *MockPluginRepositoryInterface) Search(query string, limit, offset int) ([]models.Plugin, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query, limit, offset)
+	ret0, _ := ret[0].([]models.Plugin)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockPluginRepositoryInterfaceMockRecorder) Search(query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).Search), query, limit, offset)
+}
+
 // Update mocks base method.
 func (m *MockPluginRepositoryInterface) Update(plugin *models.Plugin) error {
 	m.ctrl.T.Helper()
@@ -1784,3 +2021,95 @@ func (mr *MockPluginRepositoryInterfaceMockRecorder) Update(plugin any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPluginRepositoryInterface)(nil).Update), plugin)
 }
+
+// MockMetadataAuditRepositoryInterface is a mock of MetadataAuditRepositoryInterface interface.
+type MockMetadataAuditRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetadataAuditRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMetadataAuditRepositoryInterfaceMockRecorder is the mock recorder for MockMetadataAuditRepositoryInterface.
+type MockMetadataAuditRepositoryInterfaceMockRecorder struct {
+	mock *MockMetadataAuditRepositoryInterface
+}
+
+// NewMockMetadataAuditRepositoryInterface creates a new mock instance.
+func NewMockMetadataAuditRepositoryInterface(ctrl *gomock.Controller) *MockMetadataAuditRepositoryInterface {
+	mock := &MockMetadataAuditRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockMetadataAuditRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetadataAuditRepositoryInterface) EXPECT() *MockMetadataAuditRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMetadataAuditRepositoryInterface) Create(entry *models.MetadataAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMetadataAuditRepositoryInterfaceMockRecorder) Create(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMetadataAuditRepositoryInterface)(nil).Create), entry)
+}
+
+// GetByUserID mocks base method.
+func (m *MockMetadataAuditRepositoryInterface) GetByUserID(userID string, limit, offset int) ([]models.MetadataAudit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID, limit, offset)
+	ret0, _ := ret[0].([]models.MetadataAudit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockMetadataAuditRepositoryInterfaceMockRecorder) GetByUserID(userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockMetadataAuditRepositoryInterface)(nil).GetByUserID), userID, limit, offset)
+}
+
+// MockHealthCheckerInterface is a mock of HealthCheckerInterface interface.
+type MockHealthCheckerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthCheckerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockHealthCheckerInterfaceMockRecorder is the mock recorder for MockHealthCheckerInterface.
+type MockHealthCheckerInterfaceMockRecorder struct {
+	mock *MockHealthCheckerInterface
+}
+
+// NewMockHealthCheckerInterface creates a new mock instance.
+func NewMockHealthCheckerInterface(ctrl *gomock.Controller) *MockHealthCheckerInterface {
+	mock := &MockHealthCheckerInterface{ctrl: ctrl}
+	mock.recorder = &MockHealthCheckerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthCheckerInterface) EXPECT() *MockHealthCheckerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// HealthCheck mocks base method.
+func (m *MockHealthCheckerInterface) HealthCheck(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthCheck", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockHealthCheckerInterfaceMockRecorder) HealthCheck(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockHealthCheckerInterface)(nil).HealthCheck), ctx)
+}
@@ -16,6 +16,7 @@ import (
 	json "encoding/json"
 	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
 
 	gin "github.com/gin-gonic/gin"
 	uuid "github.com/google/uuid"
@@ -47,18 +48,18 @@ func (m *MockUserServiceInterface) EXPECT() *MockUserServiceInterfaceMockRecorde
 }
 
 // AddFavoriteLinkByUserID mocks base method.
-func (m *MockUserServiceInterface) AddFavoriteLinkByUserID(userID string, linkID uuid.UUID) (*service.UserResponse, error) {
+func (m *MockUserServiceInterface) AddFavoriteLinkByUserID(userID string, linkID uuid.UUID, actor string) (*service.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFavoriteLinkByUserID", userID, linkID)
+	ret := m.ctrl.Call(m, "AddFavoriteLinkByUserID", userID, linkID, actor)
 	ret0, _ := ret[0].(*service.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddFavoriteLinkByUserID indicates an expected call of AddFavoriteLinkByUserID.
-func (mr *MockUserServiceInterfaceMockRecorder) AddFavoriteLinkByUserID(userID, linkID any) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) AddFavoriteLinkByUserID(userID, linkID, actor any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavoriteLinkByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).AddFavoriteLinkByUserID), userID, linkID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavoriteLinkByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).AddFavoriteLinkByUserID), userID, linkID, actor)
 }
 
 // AddQuickLink mocks base method.
@@ -77,18 +78,123 @@ func (mr *MockUserServiceInterfaceMockRecorder) AddQuickLink(id, req any) *gomoc
 }
 
 // AddSubscribedPluginByUserID mocks base method.
-func (m *MockUserServiceInterface) AddSubscribedPluginByUserID(userID string, pluginID uuid.UUID) (*service.UserResponse, error) {
+func (m *MockUserServiceInterface) AddSubscribedPluginByUserID(userID string, pluginID uuid.UUID, actor string) (*service.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddSubscribedPluginByUserID", userID, pluginID)
+	ret := m.ctrl.Call(m, "AddSubscribedPluginByUserID", userID, pluginID, actor)
 	ret0, _ := ret[0].(*service.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddSubscribedPluginByUserID indicates an expected call of AddSubscribedPluginByUserID.
-func (mr *MockUserServiceInterfaceMockRecorder) AddSubscribedPluginByUserID(userID, pluginID any) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) AddSubscribedPluginByUserID(userID, pluginID, actor any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscribedPluginByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).AddSubscribedPluginByUserID), userID, pluginID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscribedPluginByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).AddSubscribedPluginByUserID), userID, pluginID, actor)
+}
+
+// ChangeUserRole mocks base method.
+func (m *MockUserServiceInterface) ChangeUserRole(userID uuid.UUID, newDomain, newRole, changedBy string) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUserRole", userID, newDomain, newRole, changedBy)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeUserRole indicates an expected call of ChangeUserRole.
+func (mr *MockUserServiceInterfaceMockRecorder) ChangeUserRole(userID, newDomain, newRole, changedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserRole", reflect.TypeOf((*MockUserServiceInterface)(nil).ChangeUserRole), userID, newDomain, newRole, changedBy)
+}
+
+// ClearFavoriteLinksByUserID mocks base method.
+func (m *MockUserServiceInterface) ClearFavoriteLinksByUserID(userID, actor string) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearFavoriteLinksByUserID", userID, actor)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearFavoriteLinksByUserID indicates an expected call of ClearFavoriteLinksByUserID.
+func (mr *MockUserServiceInterfaceMockRecorder) ClearFavoriteLinksByUserID(userID, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearFavoriteLinksByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).ClearFavoriteLinksByUserID), userID, actor)
+}
+
+// ClearSubscribedPluginsByUserID mocks base method.
+func (m *MockUserServiceInterface) ClearSubscribedPluginsByUserID(userID, actor string) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSubscribedPluginsByUserID", userID, actor)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearSubscribedPluginsByUserID indicates an expected call of ClearSubscribedPluginsByUserID.
+func (mr *MockUserServiceInterfaceMockRecorder) ClearSubscribedPluginsByUserID(userID, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSubscribedPluginsByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).ClearSubscribedPluginsByUserID), userID, actor)
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockUserServiceInterface) ConfirmEmailChange(id uuid.UUID) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", id)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockUserServiceInterfaceMockRecorder) ConfirmEmailChange(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockUserServiceInterface)(nil).ConfirmEmailChange), id)
+}
+
+// CountActiveUsers mocks base method.
+func (m *MockUserServiceInterface) CountActiveUsers(organizationID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveUsers", organizationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveUsers indicates an expected call of CountActiveUsers.
+func (mr *MockUserServiceInterfaceMockRecorder) CountActiveUsers(organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveUsers", reflect.TypeOf((*MockUserServiceInterface)(nil).CountActiveUsers), organizationID)
+}
+
+// CountUsers mocks base method.
+func (m *MockUserServiceInterface) CountUsers() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockUserServiceInterfaceMockRecorder) CountUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserServiceInterface)(nil).CountUsers))
+}
+
+// CountUsersByDomain mocks base method.
+func (m *MockUserServiceInterface) CountUsersByDomain(organizationID uuid.UUID) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsersByDomain", organizationID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsersByDomain indicates an expected call of CountUsersByDomain.
+func (mr *MockUserServiceInterfaceMockRecorder) CountUsersByDomain(organizationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsersByDomain", reflect.TypeOf((*MockUserServiceInterface)(nil).CountUsersByDomain), organizationID)
 }
 
 // CreateUser mocks base method.
@@ -136,6 +242,22 @@ func (mr *MockUserServiceInterfaceMockRecorder) GetActiveUsers(organizationID, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUsers", reflect.TypeOf((*MockUserServiceInterface)(nil).GetActiveUsers), organizationID, limit, offset)
 }
 
+// GetActiveUsersSince mocks base method.
+func (m *MockUserServiceInterface) GetActiveUsersSince(organizationID uuid.UUID, since time.Time, limit, offset int) ([]service.UserResponse, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveUsersSince", organizationID, since, limit, offset)
+	ret0, _ := ret[0].([]service.UserResponse)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetActiveUsersSince indicates an expected call of GetActiveUsersSince.
+func (mr *MockUserServiceInterfaceMockRecorder) GetActiveUsersSince(organizationID, since, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUsersSince", reflect.TypeOf((*MockUserServiceInterface)(nil).GetActiveUsersSince), organizationID, since, limit, offset)
+}
+
 // GetAllUsers mocks base method.
 func (m *MockUserServiceInterface) GetAllUsers(limit, offset int) ([]service.UserResponse, int64, error) {
 	m.ctrl.T.Helper()
@@ -152,6 +274,37 @@ func (mr *MockUserServiceInterfaceMockRecorder) GetAllUsers(limit, offset any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsers", reflect.TypeOf((*MockUserServiceInterface)(nil).GetAllUsers), limit, offset)
 }
 
+// GetAllUsersAfter mocks base method.
+func (m *MockUserServiceInterface) GetAllUsersAfter(cursor string, limit int) (*service.UsersCursorPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUsersAfter", cursor, limit)
+	ret0, _ := ret[0].(*service.UsersCursorPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUsersAfter indicates an expected call of GetAllUsersAfter.
+func (mr *MockUserServiceInterfaceMockRecorder) GetAllUsersAfter(cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUsersAfter", reflect.TypeOf((*MockUserServiceInterface)(nil).GetAllUsersAfter), cursor, limit)
+}
+
+// GetMetadataAudit mocks base method.
+func (m *MockUserServiceInterface) GetMetadataAudit(userID string, limit, offset int) ([]models.MetadataAudit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadataAudit", userID, limit, offset)
+	ret0, _ := ret[0].([]models.MetadataAudit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMetadataAudit indicates an expected call of GetMetadataAudit.
+func (mr *MockUserServiceInterfaceMockRecorder) GetMetadataAudit(userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadataAudit", reflect.TypeOf((*MockUserServiceInterface)(nil).GetMetadataAudit), userID, limit, offset)
+}
+
 // GetQuickLinks mocks base method.
 func (m *MockUserServiceInterface) GetQuickLinks(id uuid.UUID) (*service.QuickLinksResponse, error) {
 	m.ctrl.T.Helper()
@@ -167,6 +320,21 @@ func (mr *MockUserServiceInterfaceMockRecorder) GetQuickLinks(id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuickLinks", reflect.TypeOf((*MockUserServiceInterface)(nil).GetQuickLinks), id)
 }
 
+// GetSubscribedPlugins mocks base method.
+func (m *MockUserServiceInterface) GetSubscribedPlugins(userID string) (*service.SubscribedPluginsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribedPlugins", userID)
+	ret0, _ := ret[0].(*service.SubscribedPluginsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribedPlugins indicates an expected call of GetSubscribedPlugins.
+func (mr *MockUserServiceInterfaceMockRecorder) GetSubscribedPlugins(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribedPlugins", reflect.TypeOf((*MockUserServiceInterface)(nil).GetSubscribedPlugins), userID)
+}
+
 // GetUserByID mocks base method.
 func (m *MockUserServiceInterface) GetUserByID(id uuid.UUID) (*service.UserResponse, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +395,21 @@ func (mr *MockUserServiceInterfaceMockRecorder) GetUserByNameWithLinksAndPlugins
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByNameWithLinksAndPlugins", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserByNameWithLinksAndPlugins), name)
 }
 
+// GetUserByUUID mocks base method.
+func (m *MockUserServiceInterface) GetUserByUUID(id uuid.UUID) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUUID", id)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUUID indicates an expected call of GetUserByUUID.
+func (mr *MockUserServiceInterfaceMockRecorder) GetUserByUUID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUUID", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserByUUID), id)
+}
+
 // GetUserByUserID mocks base method.
 func (m *MockUserServiceInterface) GetUserByUserID(userID string) (*service.UserResponse, error) {
 	m.ctrl.T.Helper()
@@ -257,6 +440,36 @@ func (mr *MockUserServiceInterfaceMockRecorder) GetUserByUserIDWithLinks(userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUserIDWithLinks", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserByUserIDWithLinks), userID)
 }
 
+// GetUserByUserIDWithLinksAndPlugins mocks base method.
+func (m *MockUserServiceInterface) GetUserByUserIDWithLinksAndPlugins(userID string) (*service.UserWithLinksAndPluginsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUserIDWithLinksAndPlugins", userID)
+	ret0, _ := ret[0].(*service.UserWithLinksAndPluginsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUserIDWithLinksAndPlugins indicates an expected call of GetUserByUserIDWithLinksAndPlugins.
+func (mr *MockUserServiceInterfaceMockRecorder) GetUserByUserIDWithLinksAndPlugins(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUserIDWithLinksAndPlugins", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserByUserIDWithLinksAndPlugins), userID)
+}
+
+// GetUsersByEmails mocks base method.
+func (m *MockUserServiceInterface) GetUsersByEmails(emails []string) (map[string]*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByEmails", emails)
+	ret0, _ := ret[0].(map[string]*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByEmails indicates an expected call of GetUsersByEmails.
+func (mr *MockUserServiceInterfaceMockRecorder) GetUsersByEmails(emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByEmails", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUsersByEmails), emails)
+}
+
 // GetUsersByOrganization mocks base method.
 func (m *MockUserServiceInterface) GetUsersByOrganization(organizationID uuid.UUID, limit, offset int) ([]service.UserResponse, int64, error) {
 	m.ctrl.T.Helper()
@@ -273,19 +486,80 @@ func (mr *MockUserServiceInterfaceMockRecorder) GetUsersByOrganization(organizat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByOrganization", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUsersByOrganization), organizationID, limit, offset)
 }
 
+// GetUsersByTeam mocks base method.
+func (m *MockUserServiceInterface) GetUsersByTeam(teamID uuid.UUID, limit, offset int) ([]service.UserResponse, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByTeam", teamID, limit, offset)
+	ret0, _ := ret[0].([]service.UserResponse)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUsersByTeam indicates an expected call of GetUsersByTeam.
+func (mr *MockUserServiceInterfaceMockRecorder) GetUsersByTeam(teamID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeam", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUsersByTeam), teamID, limit, offset)
+}
+
+// IsFavoriteLink mocks base method.
+func (m *MockUserServiceInterface) IsFavoriteLink(userID string, linkID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFavoriteLink", userID, linkID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFavoriteLink indicates an expected call of IsFavoriteLink.
+func (mr *MockUserServiceInterfaceMockRecorder) IsFavoriteLink(userID, linkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFavoriteLink", reflect.TypeOf((*MockUserServiceInterface)(nil).IsFavoriteLink), userID, linkID)
+}
+
+// IsSubscribedToPlugin mocks base method.
+func (m *MockUserServiceInterface) IsSubscribedToPlugin(userID string, pluginID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSubscribedToPlugin", userID, pluginID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSubscribedToPlugin indicates an expected call of IsSubscribedToPlugin.
+func (mr *MockUserServiceInterfaceMockRecorder) IsSubscribedToPlugin(userID, pluginID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSubscribedToPlugin", reflect.TypeOf((*MockUserServiceInterface)(nil).IsSubscribedToPlugin), userID, pluginID)
+}
+
+// PruneUserMetadata mocks base method.
+func (m *MockUserServiceInterface) PruneUserMetadata(userID string) (*service.MetadataPruneReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneUserMetadata", userID)
+	ret0, _ := ret[0].(*service.MetadataPruneReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneUserMetadata indicates an expected call of PruneUserMetadata.
+func (mr *MockUserServiceInterfaceMockRecorder) PruneUserMetadata(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneUserMetadata", reflect.TypeOf((*MockUserServiceInterface)(nil).PruneUserMetadata), userID)
+}
+
 // RemoveFavoriteLinkByUserID mocks base method.
-func (m *MockUserServiceInterface) RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID) (*service.UserResponse, error) {
+func (m *MockUserServiceInterface) RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID, actor string) (*service.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveFavoriteLinkByUserID", userID, linkID)
+	ret := m.ctrl.Call(m, "RemoveFavoriteLinkByUserID", userID, linkID, actor)
 	ret0, _ := ret[0].(*service.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RemoveFavoriteLinkByUserID indicates an expected call of RemoveFavoriteLinkByUserID.
-func (mr *MockUserServiceInterfaceMockRecorder) RemoveFavoriteLinkByUserID(userID, linkID any) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) RemoveFavoriteLinkByUserID(userID, linkID, actor any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavoriteLinkByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).RemoveFavoriteLinkByUserID), userID, linkID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavoriteLinkByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).RemoveFavoriteLinkByUserID), userID, linkID, actor)
 }
 
 // RemoveQuickLink mocks base method.
@@ -304,24 +578,69 @@ func (mr *MockUserServiceInterfaceMockRecorder) RemoveQuickLink(id, linkURL any)
 }
 
 // RemoveSubscribedPluginByUserID mocks base method.
-func (m *MockUserServiceInterface) RemoveSubscribedPluginByUserID(userID string, pluginID uuid.UUID) (*service.UserResponse, error) {
+func (m *MockUserServiceInterface) RemoveSubscribedPluginByUserID(userID string, pluginID uuid.UUID, actor string) (*service.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveSubscribedPluginByUserID", userID, pluginID)
+	ret := m.ctrl.Call(m, "RemoveSubscribedPluginByUserID", userID, pluginID, actor)
 	ret0, _ := ret[0].(*service.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RemoveSubscribedPluginByUserID indicates an expected call of RemoveSubscribedPluginByUserID.
-func (mr *MockUserServiceInterfaceMockRecorder) RemoveSubscribedPluginByUserID(userID, pluginID any) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) RemoveSubscribedPluginByUserID(userID, pluginID, actor any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscribedPluginByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).RemoveSubscribedPluginByUserID), userID, pluginID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscribedPluginByUserID", reflect.TypeOf((*MockUserServiceInterface)(nil).RemoveSubscribedPluginByUserID), userID, pluginID, actor)
+}
+
+// RemoveUserFromTeam mocks base method.
+func (m *MockUserServiceInterface) RemoveUserFromTeam(userID uuid.UUID, updatedBy string) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromTeam", userID, updatedBy)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUserFromTeam indicates an expected call of RemoveUserFromTeam.
+func (mr *MockUserServiceInterfaceMockRecorder) RemoveUserFromTeam(userID, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromTeam", reflect.TypeOf((*MockUserServiceInterface)(nil).RemoveUserFromTeam), userID, updatedBy)
+}
+
+// RepairUserMetadata mocks base method.
+func (m *MockUserServiceInterface) RepairUserMetadata(userID string) (*service.MetadataRepairReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairUserMetadata", userID)
+	ret0, _ := ret[0].(*service.MetadataRepairReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairUserMetadata indicates an expected call of RepairUserMetadata.
+func (mr *MockUserServiceInterfaceMockRecorder) RepairUserMetadata(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairUserMetadata", reflect.TypeOf((*MockUserServiceInterface)(nil).RepairUserMetadata), userID)
+}
+
+// RestoreUser mocks base method.
+func (m *MockUserServiceInterface) RestoreUser(id uuid.UUID) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", id)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserServiceInterfaceMockRecorder) RestoreUser(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserServiceInterface)(nil).RestoreUser), id)
 }
 
 // SearchUsers mocks base method.
-func (m *MockUserServiceInterface) SearchUsers(organizationID uuid.UUID, query string, limit, offset int) ([]service.UserResponse, int64, error) {
+func (m *MockUserServiceInterface) SearchUsers(organizationID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]service.UserResponse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsers", organizationID, query, limit, offset)
+	ret := m.ctrl.Call(m, "SearchUsers", organizationID, query, emailOnly, limit, offset)
 	ret0, _ := ret[0].([]service.UserResponse)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -329,15 +648,31 @@ func (m *MockUserServiceInterface) SearchUsers(organizationID uuid.UUID, query s
 }
 
 // SearchUsers indicates an expected call of SearchUsers.
-func (mr *MockUserServiceInterfaceMockRecorder) SearchUsers(organizationID, query, limit, offset any) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) SearchUsers(organizationID, query, emailOnly, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserServiceInterface)(nil).SearchUsers), organizationID, query, emailOnly, limit, offset)
+}
+
+// SearchUsersByOwnedOrg mocks base method.
+func (m *MockUserServiceInterface) SearchUsersByOwnedOrg(organizationID uuid.UUID, query string, limit, offset int) ([]service.UserResponse, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsersByOwnedOrg", organizationID, query, limit, offset)
+	ret0, _ := ret[0].([]service.UserResponse)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchUsersByOwnedOrg indicates an expected call of SearchUsersByOwnedOrg.
+func (mr *MockUserServiceInterfaceMockRecorder) SearchUsersByOwnedOrg(organizationID, query, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserServiceInterface)(nil).SearchUsers), organizationID, query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersByOwnedOrg", reflect.TypeOf((*MockUserServiceInterface)(nil).SearchUsersByOwnedOrg), organizationID, query, limit, offset)
 }
 
 // SearchUsersGlobal mocks base method.
-func (m *MockUserServiceInterface) SearchUsersGlobal(query string, limit, offset int) ([]service.UserResponse, int64, error) {
+func (m *MockUserServiceInterface) SearchUsersGlobal(query string, emailOnly bool, limit, offset int) ([]service.UserResponse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsersGlobal", query, limit, offset)
+	ret := m.ctrl.Call(m, "SearchUsersGlobal", query, emailOnly, limit, offset)
 	ret0, _ := ret[0].([]service.UserResponse)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -345,9 +680,9 @@ func (m *MockUserServiceInterface) SearchUsersGlobal(query string, limit, offset
 }
 
 // SearchUsersGlobal indicates an expected call of SearchUsersGlobal.
-func (mr *MockUserServiceInterfaceMockRecorder) SearchUsersGlobal(query, limit, offset any) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) SearchUsersGlobal(query, emailOnly, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersGlobal", reflect.TypeOf((*MockUserServiceInterface)(nil).SearchUsersGlobal), query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsersGlobal", reflect.TypeOf((*MockUserServiceInterface)(nil).SearchUsersGlobal), query, emailOnly, limit, offset)
 }
 
 // UpdateUser mocks base method.
@@ -693,6 +1028,21 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetAveragePRMergeTime(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAveragePRMergeTime", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetAveragePRMergeTime), ctx, arg1, provider, period)
 }
 
+// GetCommitActivity mocks base method.
+func (m *MockGitHubServiceInterface) GetCommitActivity(ctx context.Context, arg1, provider, owner, repo, period string) ([]service.WeeklyCommits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitActivity", ctx, arg1, provider, owner, repo, period)
+	ret0, _ := ret[0].([]service.WeeklyCommits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitActivity indicates an expected call of GetCommitActivity.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetCommitActivity(ctx, arg1, provider, owner, repo, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitActivity", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetCommitActivity), ctx, arg1, provider, owner, repo, period)
+}
+
 // GetContributionsHeatmap mocks base method.
 func (m *MockGitHubServiceInterface) GetContributionsHeatmap(ctx context.Context, arg1, provider, period string) (*service.ContributionsHeatmapResponse, error) {
 	m.ctrl.T.Helper()
@@ -708,6 +1058,21 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetContributionsHeatmap(ctx, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributionsHeatmap", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetContributionsHeatmap), ctx, arg1, provider, period)
 }
 
+// GetContributionsHeatmapIfNoneMatch mocks base method.
+func (m *MockGitHubServiceInterface) GetContributionsHeatmapIfNoneMatch(ctx context.Context, arg1, provider, period, ifNoneMatch string) (*service.ContributionsHeatmapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContributionsHeatmapIfNoneMatch", ctx, arg1, provider, period, ifNoneMatch)
+	ret0, _ := ret[0].(*service.ContributionsHeatmapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContributionsHeatmapIfNoneMatch indicates an expected call of GetContributionsHeatmapIfNoneMatch.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetContributionsHeatmapIfNoneMatch(ctx, arg1, provider, period, ifNoneMatch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributionsHeatmapIfNoneMatch", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetContributionsHeatmapIfNoneMatch), ctx, arg1, provider, period, ifNoneMatch)
+}
+
 // GetGitHubAsset mocks base method.
 func (m *MockGitHubServiceInterface) GetGitHubAsset(ctx context.Context, arg1, provider, assetURL string) ([]byte, string, error) {
 	m.ctrl.T.Helper()
@@ -724,6 +1089,21 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetGitHubAsset(ctx, arg1, prov
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubAsset", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetGitHubAsset), ctx, arg1, provider, assetURL)
 }
 
+// GetPullRequestDetails mocks base method.
+func (m *MockGitHubServiceInterface) GetPullRequestDetails(ctx context.Context, arg1, provider, owner, repo string, number int) (*service.PullRequestDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestDetails", ctx, arg1, provider, owner, repo, number)
+	ret0, _ := ret[0].(*service.PullRequestDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestDetails indicates an expected call of GetPullRequestDetails.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetPullRequestDetails(ctx, arg1, provider, owner, repo, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestDetails", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetPullRequestDetails), ctx, arg1, provider, owner, repo, number)
+}
+
 // GetRepositoryContent mocks base method.
 func (m *MockGitHubServiceInterface) GetRepositoryContent(ctx context.Context, arg1, provider, owner, repo, path, ref string) (any, error) {
 	m.ctrl.T.Helper()
@@ -739,6 +1119,36 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetRepositoryContent(ctx, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryContent", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetRepositoryContent), ctx, arg1, provider, owner, repo, path, ref)
 }
 
+// GetUserActivityTimeline mocks base method.
+func (m *MockGitHubServiceInterface) GetUserActivityTimeline(ctx context.Context, arg1, provider, period string) (*service.ActivityTimelineResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserActivityTimeline", ctx, arg1, provider, period)
+	ret0, _ := ret[0].(*service.ActivityTimelineResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserActivityTimeline indicates an expected call of GetUserActivityTimeline.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserActivityTimeline(ctx, arg1, provider, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserActivityTimeline", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserActivityTimeline), ctx, arg1, provider, period)
+}
+
+// GetUserAssignedIssues mocks base method.
+func (m *MockGitHubServiceInterface) GetUserAssignedIssues(ctx context.Context, arg1, provider, state, sort, order string, perPage, page int) (*service.IssuesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAssignedIssues", ctx, arg1, provider, state, sort, order, perPage, page)
+	ret0, _ := ret[0].(*service.IssuesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAssignedIssues indicates an expected call of GetUserAssignedIssues.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserAssignedIssues(ctx, arg1, provider, state, sort, order, perPage, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAssignedIssues", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserAssignedIssues), ctx, arg1, provider, state, sort, order, perPage, page)
+}
+
 // GetUserOpenPullRequests mocks base method.
 func (m *MockGitHubServiceInterface) GetUserOpenPullRequests(ctx context.Context, arg1, provider, state, sort, direction string, perPage, page int) (*service.PullRequestsResponse, error) {
 	m.ctrl.T.Helper()
@@ -754,6 +1164,36 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserOpenPullRequests(ctx, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOpenPullRequests", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserOpenPullRequests), ctx, arg1, provider, state, sort, direction, perPage, page)
 }
 
+// GetUserOpenPullRequestsAllProviders mocks base method.
+func (m *MockGitHubServiceInterface) GetUserOpenPullRequestsAllProviders(ctx context.Context, arg1 string, providers []string, state, sort, direction string, perPage, page int) (*service.PullRequestsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOpenPullRequestsAllProviders", ctx, arg1, providers, state, sort, direction, perPage, page)
+	ret0, _ := ret[0].(*service.PullRequestsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOpenPullRequestsAllProviders indicates an expected call of GetUserOpenPullRequestsAllProviders.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserOpenPullRequestsAllProviders(ctx, arg1, providers, state, sort, direction, perPage, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOpenPullRequestsAllProviders", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserOpenPullRequestsAllProviders), ctx, arg1, providers, state, sort, direction, perPage, page)
+}
+
+// GetUserOpenPullRequestsWithFilters mocks base method.
+func (m *MockGitHubServiceInterface) GetUserOpenPullRequestsWithFilters(ctx context.Context, arg1, provider, state, sort, direction string, perPage, page int, filters service.QueryFilters) (*service.PullRequestsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOpenPullRequestsWithFilters", ctx, arg1, provider, state, sort, direction, perPage, page, filters)
+	ret0, _ := ret[0].(*service.PullRequestsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOpenPullRequestsWithFilters indicates an expected call of GetUserOpenPullRequestsWithFilters.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserOpenPullRequestsWithFilters(ctx, arg1, provider, state, sort, direction, perPage, page, filters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOpenPullRequestsWithFilters", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserOpenPullRequestsWithFilters), ctx, arg1, provider, state, sort, direction, perPage, page, filters)
+}
+
 // GetUserPRReviewComments mocks base method.
 func (m *MockGitHubServiceInterface) GetUserPRReviewComments(ctx context.Context, arg1, provider, period string) (*service.PRReviewCommentsResponse, error) {
 	m.ctrl.T.Helper()
@@ -769,6 +1209,36 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserPRReviewComments(ctx, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPRReviewComments", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserPRReviewComments), ctx, arg1, provider, period)
 }
 
+// GetUserRepositories mocks base method.
+func (m *MockGitHubServiceInterface) GetUserRepositories(ctx context.Context, arg1, provider, sort string, perPage, page int) ([]service.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRepositories", ctx, arg1, provider, sort, perPage, page)
+	ret0, _ := ret[0].([]service.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRepositories indicates an expected call of GetUserRepositories.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserRepositories(ctx, arg1, provider, sort, perPage, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRepositories", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserRepositories), ctx, arg1, provider, sort, perPage, page)
+}
+
+// GetUserReviewRequests mocks base method.
+func (m *MockGitHubServiceInterface) GetUserReviewRequests(ctx context.Context, arg1, provider, state, sort, order string, perPage, page int) (*service.PullRequestsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReviewRequests", ctx, arg1, provider, state, sort, order, perPage, page)
+	ret0, _ := ret[0].(*service.PullRequestsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReviewRequests indicates an expected call of GetUserReviewRequests.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserReviewRequests(ctx, arg1, provider, state, sort, order, perPage, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviewRequests", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserReviewRequests), ctx, arg1, provider, state, sort, order, perPage, page)
+}
+
 // GetUserTotalContributions mocks base method.
 func (m *MockGitHubServiceInterface) GetUserTotalContributions(ctx context.Context, arg1, provider, period string) (*service.TotalContributionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -784,6 +1254,21 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetUserTotalContributions(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTotalContributions", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetUserTotalContributions), ctx, arg1, provider, period)
 }
 
+// SearchRepositories mocks base method.
+func (m *MockGitHubServiceInterface) SearchRepositories(ctx context.Context, arg1, provider, query, sort string, perPage, page int) (*service.RepositoriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRepositories", ctx, arg1, provider, query, sort, perPage, page)
+	ret0, _ := ret[0].(*service.RepositoriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchRepositories indicates an expected call of SearchRepositories.
+func (mr *MockGitHubServiceInterfaceMockRecorder) SearchRepositories(ctx, arg1, provider, query, sort, perPage, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRepositories", reflect.TypeOf((*MockGitHubServiceInterface)(nil).SearchRepositories), ctx, arg1, provider, query, sort, perPage, page)
+}
+
 // UpdateRepositoryFile mocks base method.
 func (m *MockGitHubServiceInterface) UpdateRepositoryFile(ctx context.Context, arg1, provider, owner, repo, path, message, content, sha, branch string) (any, error) {
 	m.ctrl.T.Helper()
@@ -799,6 +1284,75 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) UpdateRepositoryFile(ctx, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepositoryFile", reflect.TypeOf((*MockGitHubServiceInterface)(nil).UpdateRepositoryFile), ctx, arg1, provider, owner, repo, path, message, content, sha, branch)
 }
 
+// MockVCSProvider is a mock of VCSProvider interface.
+type MockVCSProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockVCSProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockVCSProviderMockRecorder is the mock recorder for MockVCSProvider.
+type MockVCSProviderMockRecorder struct {
+	mock *MockVCSProvider
+}
+
+// NewMockVCSProvider creates a new mock instance.
+func NewMockVCSProvider(ctrl *gomock.Controller) *MockVCSProvider {
+	mock := &MockVCSProvider{ctrl: ctrl}
+	mock.recorder = &MockVCSProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVCSProvider) EXPECT() *MockVCSProviderMockRecorder {
+	return m.recorder
+}
+
+// GetContributionsHeatmap mocks base method.
+func (m *MockVCSProvider) GetContributionsHeatmap(ctx context.Context, arg1, provider, period string) (*service.ContributionsHeatmapResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContributionsHeatmap", ctx, arg1, provider, period)
+	ret0, _ := ret[0].(*service.ContributionsHeatmapResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContributionsHeatmap indicates an expected call of GetContributionsHeatmap.
+func (mr *MockVCSProviderMockRecorder) GetContributionsHeatmap(ctx, arg1, provider, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContributionsHeatmap", reflect.TypeOf((*MockVCSProvider)(nil).GetContributionsHeatmap), ctx, arg1, provider, period)
+}
+
+// GetOpenPullRequests mocks base method.
+func (m *MockVCSProvider) GetOpenPullRequests(ctx context.Context, arg1, provider, state, sort, direction string, perPage, page int) (*service.PullRequestsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenPullRequests", ctx, arg1, provider, state, sort, direction, perPage, page)
+	ret0, _ := ret[0].(*service.PullRequestsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenPullRequests indicates an expected call of GetOpenPullRequests.
+func (mr *MockVCSProviderMockRecorder) GetOpenPullRequests(ctx, arg1, provider, state, sort, direction, perPage, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenPullRequests", reflect.TypeOf((*MockVCSProvider)(nil).GetOpenPullRequests), ctx, arg1, provider, state, sort, direction, perPage, page)
+}
+
+// GetRepositoryContent mocks base method.
+func (m *MockVCSProvider) GetRepositoryContent(ctx context.Context, arg1, provider, owner, repo, path, ref string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryContent", ctx, arg1, provider, owner, repo, path, ref)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryContent indicates an expected call of GetRepositoryContent.
+func (mr *MockVCSProviderMockRecorder) GetRepositoryContent(ctx, arg1, provider, owner, repo, path, ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryContent", reflect.TypeOf((*MockVCSProvider)(nil).GetRepositoryContent), ctx, arg1, provider, owner, repo, path, ref)
+}
+
 // MockJenkinsServiceInterface is a mock of JenkinsServiceInterface interface.
 type MockJenkinsServiceInterface struct {
 	ctrl     *gomock.Controller
@@ -1029,6 +1583,20 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) ChatInferenceStream(c, req, wr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatInferenceStream", reflect.TypeOf((*MockAICoreServiceInterface)(nil).ChatInferenceStream), c, req, writer)
 }
 
+// CheckAICoreCredentials mocks base method.
+func (m *MockAICoreServiceInterface) CheckAICoreCredentials(team string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAICoreCredentials", team)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckAICoreCredentials indicates an expected call of CheckAICoreCredentials.
+func (mr *MockAICoreServiceInterfaceMockRecorder) CheckAICoreCredentials(team any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAICoreCredentials", reflect.TypeOf((*MockAICoreServiceInterface)(nil).CheckAICoreCredentials), team)
+}
+
 // CreateConfiguration mocks base method.
 func (m *MockAICoreServiceInterface) CreateConfiguration(c *gin.Context, req *service.AICoreConfigurationRequest) (*service.AICoreConfigurationResponse, error) {
 	m.ctrl.T.Helper()
@@ -1060,18 +1628,33 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) CreateDeployment(c, req any) *
 }
 
 // DeleteDeployment mocks base method.
-func (m *MockAICoreServiceInterface) DeleteDeployment(c *gin.Context, deploymentID string) (*service.AICoreDeploymentDeletionResponse, error) {
+func (m *MockAICoreServiceInterface) DeleteDeployment(c *gin.Context, deploymentID string, deleteConfiguration bool) (*service.AICoreDeploymentDeletionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDeployment", c, deploymentID)
+	ret := m.ctrl.Call(m, "DeleteDeployment", c, deploymentID, deleteConfiguration)
 	ret0, _ := ret[0].(*service.AICoreDeploymentDeletionResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteDeployment indicates an expected call of DeleteDeployment.
-func (mr *MockAICoreServiceInterfaceMockRecorder) DeleteDeployment(c, deploymentID any) *gomock.Call {
+func (mr *MockAICoreServiceInterfaceMockRecorder) DeleteDeployment(c, deploymentID, deleteConfiguration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockAICoreServiceInterface)(nil).DeleteDeployment), c, deploymentID, deleteConfiguration)
+}
+
+// GetConfigurationDetails mocks base method.
+func (m *MockAICoreServiceInterface) GetConfigurationDetails(c *gin.Context, configurationID string) (*service.AICoreConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigurationDetails", c, configurationID)
+	ret0, _ := ret[0].(*service.AICoreConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigurationDetails indicates an expected call of GetConfigurationDetails.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetConfigurationDetails(c, configurationID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockAICoreServiceInterface)(nil).DeleteDeployment), c, deploymentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigurationDetails", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetConfigurationDetails), c, configurationID)
 }
 
 // GetConfigurations mocks base method.
@@ -1104,6 +1687,21 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) GetDeploymentDetails(c, deploy
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentDetails", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetDeploymentDetails), c, deploymentID)
 }
 
+// GetDeploymentSummaries mocks base method.
+func (m *MockAICoreServiceInterface) GetDeploymentSummaries(c *gin.Context) ([]service.DeploymentSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentSummaries", c)
+	ret0, _ := ret[0].([]service.DeploymentSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentSummaries indicates an expected call of GetDeploymentSummaries.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetDeploymentSummaries(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentSummaries", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetDeploymentSummaries), c)
+}
+
 // GetDeployments mocks base method.
 func (m *MockAICoreServiceInterface) GetDeployments(c *gin.Context) (*service.AICoreDeploymentsResponse, error) {
 	m.ctrl.T.Helper()
@@ -1119,6 +1717,51 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) GetDeployments(c any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployments", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetDeployments), c)
 }
 
+// GetDeploymentsByIDs mocks base method.
+func (m *MockAICoreServiceInterface) GetDeploymentsByIDs(c *gin.Context, ids []string) (map[string]*service.AICoreDeploymentDetailsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentsByIDs", c, ids)
+	ret0, _ := ret[0].(map[string]*service.AICoreDeploymentDetailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentsByIDs indicates an expected call of GetDeploymentsByIDs.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetDeploymentsByIDs(c, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentsByIDs", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetDeploymentsByIDs), c, ids)
+}
+
+// GetDeploymentsByStatus mocks base method.
+func (m *MockAICoreServiceInterface) GetDeploymentsByStatus(c *gin.Context, filter service.AICoreDeploymentFilter) (*service.AICoreDeploymentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentsByStatus", c, filter)
+	ret0, _ := ret[0].(*service.AICoreDeploymentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeploymentsByStatus indicates an expected call of GetDeploymentsByStatus.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetDeploymentsByStatus(c, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentsByStatus", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetDeploymentsByStatus), c, filter)
+}
+
+// GetExecutables mocks base method.
+func (m *MockAICoreServiceInterface) GetExecutables(c *gin.Context, scenarioID string) (*service.AICoreExecutablesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutables", c, scenarioID)
+	ret0, _ := ret[0].(*service.AICoreExecutablesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutables indicates an expected call of GetExecutables.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetExecutables(c, scenarioID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutables", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetExecutables), c, scenarioID)
+}
+
 // GetMe mocks base method.
 func (m *MockAICoreServiceInterface) GetMe(c *gin.Context) (*service.AICoreMeResponse, error) {
 	m.ctrl.T.Helper()
@@ -1134,6 +1777,21 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) GetMe(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMe", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetMe), c)
 }
 
+// GetMeByEmail mocks base method.
+func (m *MockAICoreServiceInterface) GetMeByEmail(email string) (*service.AICoreMeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeByEmail", email)
+	ret0, _ := ret[0].(*service.AICoreMeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeByEmail indicates an expected call of GetMeByEmail.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetMeByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeByEmail", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetMeByEmail), email)
+}
+
 // GetModels mocks base method.
 func (m *MockAICoreServiceInterface) GetModels(c *gin.Context, scenarioID string) (*service.AICoreModelsResponse, error) {
 	m.ctrl.T.Helper()
@@ -1149,6 +1807,50 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) GetModels(c, scenarioID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetModels), c, scenarioID)
 }
 
+// GetProfileSummary mocks base method.
+func (m *MockAICoreServiceInterface) GetProfileSummary(c *gin.Context) (*service.ProfileSummaryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileSummary", c)
+	ret0, _ := ret[0].(*service.ProfileSummaryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileSummary indicates an expected call of GetProfileSummary.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetProfileSummary(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileSummary", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetProfileSummary), c)
+}
+
+// GetScenarios mocks base method.
+func (m *MockAICoreServiceInterface) GetScenarios(c *gin.Context) (*service.AICoreScenariosResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScenarios", c)
+	ret0, _ := ret[0].(*service.AICoreScenariosResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScenarios indicates an expected call of GetScenarios.
+func (mr *MockAICoreServiceInterfaceMockRecorder) GetScenarios(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScenarios", reflect.TypeOf((*MockAICoreServiceInterface)(nil).GetScenarios), c)
+}
+
+// InvalidateTeamExpansion mocks base method.
+func (m *MockAICoreServiceInterface) InvalidateTeamExpansion(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateTeamExpansion", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateTeamExpansion indicates an expected call of InvalidateTeamExpansion.
+func (mr *MockAICoreServiceInterfaceMockRecorder) InvalidateTeamExpansion(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTeamExpansion", reflect.TypeOf((*MockAICoreServiceInterface)(nil).InvalidateTeamExpansion), username)
+}
+
 // UpdateDeployment mocks base method.
 func (m *MockAICoreServiceInterface) UpdateDeployment(c *gin.Context, deploymentID string, req *service.AICoreDeploymentModificationRequest) (*service.AICoreDeploymentModificationResponse, error) {
 	m.ctrl.T.Helper()
@@ -1165,10 +1867,10 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) UpdateDeployment(c, deployment
 }
 
 // UploadAttachment mocks base method.
-func (m *MockAICoreServiceInterface) UploadAttachment(c *gin.Context, file multipart.File, header *multipart.FileHeader) (map[string]any, error) {
+func (m *MockAICoreServiceInterface) UploadAttachment(c *gin.Context, file multipart.File, header *multipart.FileHeader) (*service.AttachmentResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UploadAttachment", c, file, header)
-	ret0, _ := ret[0].(map[string]any)
+	ret0, _ := ret[0].(*service.AttachmentResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1179,6 +1881,21 @@ func (mr *MockAICoreServiceInterfaceMockRecorder) UploadAttachment(c, file, head
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockAICoreServiceInterface)(nil).UploadAttachment), c, file, header)
 }
 
+// WaitForDeploymentStatus mocks base method.
+func (m *MockAICoreServiceInterface) WaitForDeploymentStatus(c *gin.Context, deploymentID, target string, timeout time.Duration) (*service.AICoreDeploymentDetailsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForDeploymentStatus", c, deploymentID, target, timeout)
+	ret0, _ := ret[0].(*service.AICoreDeploymentDetailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForDeploymentStatus indicates an expected call of WaitForDeploymentStatus.
+func (mr *MockAICoreServiceInterfaceMockRecorder) WaitForDeploymentStatus(c, deploymentID, target, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForDeploymentStatus", reflect.TypeOf((*MockAICoreServiceInterface)(nil).WaitForDeploymentStatus), c, deploymentID, target, timeout)
+}
+
 // MockComponentServiceInterface is a mock of ComponentServiceInterface interface.
 type MockComponentServiceInterface struct {
 	ctrl     *gomock.Controller
@@ -1320,14 +2037,6 @@ func (m *MockProjectServiceInterface) GetAllProjects() ([]models.Project, error)
 	return ret0, ret1
 }
 
-func (m *MockProjectServiceInterface) GetHealthURL(projectID uuid.UUID) (string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHealthURL", projectID)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
 // GetAllProjects indicates an expected call of GetAllProjects.
 func (mr *MockProjectServiceInterfaceMockRecorder) GetAllProjects() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -1433,6 +2142,36 @@ func (mr *MockLinkServiceInterfaceMockRecorder) GetByOwnerUserIDWithViewer(owner
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerUserIDWithViewer", reflect.TypeOf((*MockLinkServiceInterface)(nil).GetByOwnerUserIDWithViewer), ownerUserID, viewerName)
 }
 
+// GetLinkByName mocks base method.
+func (m *MockLinkServiceInterface) GetLinkByName(owner uuid.UUID, name string) (*service.LinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkByName", owner, name)
+	ret0, _ := ret[0].(*service.LinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkByName indicates an expected call of GetLinkByName.
+func (mr *MockLinkServiceInterfaceMockRecorder) GetLinkByName(owner, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkByName", reflect.TypeOf((*MockLinkServiceInterface)(nil).GetLinkByName), owner, name)
+}
+
+// TransferLinkOwnership mocks base method.
+func (m *MockLinkServiceInterface) TransferLinkOwnership(linkID, newOwner uuid.UUID, transferredBy string) (*service.LinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferLinkOwnership", linkID, newOwner, transferredBy)
+	ret0, _ := ret[0].(*service.LinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferLinkOwnership indicates an expected call of TransferLinkOwnership.
+func (mr *MockLinkServiceInterfaceMockRecorder) TransferLinkOwnership(linkID, newOwner, transferredBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferLinkOwnership", reflect.TypeOf((*MockLinkServiceInterface)(nil).TransferLinkOwnership), linkID, newOwner, transferredBy)
+}
+
 // UpdateLink mocks base method.
 func (m *MockLinkServiceInterface) UpdateLink(id uuid.UUID, req *service.UpdateLinkRequest) (*service.LinkResponse, error) {
 	m.ctrl.T.Helper()
@@ -1644,6 +2383,21 @@ func (mr *MockPluginServiceInterfaceMockRecorder) GetPluginByID(id any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginByID", reflect.TypeOf((*MockPluginServiceInterface)(nil).GetPluginByID), id)
 }
 
+// GetPluginByName mocks base method.
+func (m *MockPluginServiceInterface) GetPluginByName(name string) (*service.PluginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPluginByName", name)
+	ret0, _ := ret[0].(*service.PluginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPluginByName indicates an expected call of GetPluginByName.
+func (mr *MockPluginServiceInterfaceMockRecorder) GetPluginByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginByName", reflect.TypeOf((*MockPluginServiceInterface)(nil).GetPluginByName), name)
+}
+
 // GetPluginUIContent mocks base method.
 func (m *MockPluginServiceInterface) GetPluginUIContent(ctx context.Context, pluginID uuid.UUID, githubService service.GitHubServiceInterface, userUUID, provider string) (*service.PluginUIResponse, error) {
 	m.ctrl.T.Helper()
@@ -1659,6 +2413,36 @@ func (mr *MockPluginServiceInterfaceMockRecorder) GetPluginUIContent(ctx, plugin
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginUIContent", reflect.TypeOf((*MockPluginServiceInterface)(nil).GetPluginUIContent), ctx, pluginID, githubService, userUUID, provider)
 }
 
+// ListAvailablePlugins mocks base method.
+func (m *MockPluginServiceInterface) ListAvailablePlugins(userOrgID *uuid.UUID) ([]service.PluginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailablePlugins", userOrgID)
+	ret0, _ := ret[0].([]service.PluginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailablePlugins indicates an expected call of ListAvailablePlugins.
+func (mr *MockPluginServiceInterfaceMockRecorder) ListAvailablePlugins(userOrgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailablePlugins", reflect.TypeOf((*MockPluginServiceInterface)(nil).ListAvailablePlugins), userOrgID)
+}
+
+// SearchPlugins mocks base method.
+func (m *MockPluginServiceInterface) SearchPlugins(query string, limit, offset int) (*service.PluginListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPlugins", query, limit, offset)
+	ret0, _ := ret[0].(*service.PluginListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPlugins indicates an expected call of SearchPlugins.
+func (mr *MockPluginServiceInterfaceMockRecorder) SearchPlugins(query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPlugins", reflect.TypeOf((*MockPluginServiceInterface)(nil).SearchPlugins), query, limit, offset)
+}
+
 // UpdatePlugin mocks base method.
 func (m *MockPluginServiceInterface) UpdatePlugin(id uuid.UUID, req *service.UpdatePluginRequest) (*service.PluginResponse, error) {
 	m.ctrl.T.Helper()
@@ -1766,3 +2550,80 @@ func (mr *MockAlertsServiceInterfaceMockRecorder) GetProjectAlerts(ctx, projectI
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectAlerts", reflect.TypeOf((*MockAlertsServiceInterface)(nil).GetProjectAlerts), ctx, projectIDStr, userUUID, provider)
 }
+
+// MockReadinessServiceInterface is a mock of ReadinessServiceInterface interface.
+type MockReadinessServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadinessServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockReadinessServiceInterfaceMockRecorder is the mock recorder for MockReadinessServiceInterface.
+type MockReadinessServiceInterfaceMockRecorder struct {
+	mock *MockReadinessServiceInterface
+}
+
+// NewMockReadinessServiceInterface creates a new mock instance.
+func NewMockReadinessServiceInterface(ctrl *gomock.Controller) *MockReadinessServiceInterface {
+	mock := &MockReadinessServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockReadinessServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadinessServiceInterface) EXPECT() *MockReadinessServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Readiness mocks base method.
+func (m *MockReadinessServiceInterface) Readiness(ctx context.Context) *service.ReadinessStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Readiness", ctx)
+	ret0, _ := ret[0].(*service.ReadinessStatus)
+	return ret0
+}
+
+// Readiness indicates an expected call of Readiness.
+func (mr *MockReadinessServiceInterfaceMockRecorder) Readiness(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Readiness", reflect.TypeOf((*MockReadinessServiceInterface)(nil).Readiness), ctx)
+}
+
+// MockOrganizationServiceInterface is a mock of OrganizationServiceInterface interface.
+type MockOrganizationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationServiceInterfaceMockRecorder is the mock recorder for MockOrganizationServiceInterface.
+type MockOrganizationServiceInterfaceMockRecorder struct {
+	mock *MockOrganizationServiceInterface
+}
+
+// NewMockOrganizationServiceInterface creates a new mock instance.
+func NewMockOrganizationServiceInterface(ctrl *gomock.Controller) *MockOrganizationServiceInterface {
+	mock := &MockOrganizationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockOrganizationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationServiceInterface) EXPECT() *MockOrganizationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetHierarchy mocks base method.
+func (m *MockOrganizationServiceInterface) GetHierarchy(orgID uuid.UUID) (*service.OrgTree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHierarchy", orgID)
+	ret0, _ := ret[0].(*service.OrgTree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHierarchy indicates an expected call of GetHierarchy.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) GetHierarchy(orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHierarchy", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).GetHierarchy), orgID)
+}
@@ -42,6 +42,16 @@ func (r *GroupRepository) GetByName(orgID uuid.UUID, name string) (*models.Group
 	return &group, nil
 }
 
+// GetByOwner retrieves all groups owned by the given user across organizations, ordered by name
+func (r *GroupRepository) GetByOwner(owner string) ([]models.Group, error) {
+	var groups []models.Group
+	err := r.db.Where("owner = ?", owner).Order("name ASC").Find(&groups).Error
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
 // GetByOrganizationID retrieves all groups for an organization with pagination
 func (r *GroupRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Group, int64, error) {
 	var groups []models.Group
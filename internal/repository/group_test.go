@@ -137,6 +137,40 @@ func (suite *GroupRepositoryTestSuite) TestGetByNameNotFound() {
 	suite.Nil(group)
 }
 
+// TestGetByOwner tests retrieving all groups owned by a user across organizations
+func (suite *GroupRepositoryTestSuite) TestGetByOwner() {
+	org := suite.createOrganization()
+	otherOrg := suite.factories.Organization.Create()
+	otherOrg.Name = "other-org"
+	suite.NoError(NewOrganizationRepository(suite.baseTestSuite.DB).Create(otherOrg))
+
+	owned := suite.factories.Group.WithName("zeta-group")
+	owned.OrgID = org.ID
+	owned.Owner = "I99999"
+	suite.NoError(suite.repo.Create(owned))
+
+	ownedElsewhere := suite.factories.Group.WithName("alpha-group")
+	ownedElsewhere.OrgID = otherOrg.ID
+	ownedElsewhere.Owner = "I99999"
+	suite.NoError(suite.repo.Create(ownedElsewhere))
+
+	notOwned := suite.factories.Group.WithName("beta-group")
+	notOwned.OrgID = org.ID
+	notOwned.Owner = "I11111"
+	suite.NoError(suite.repo.Create(notOwned))
+
+	groups, err := suite.repo.GetByOwner("I99999")
+
+	suite.NoError(err)
+	suite.Len(groups, 2)
+	suite.Equal(ownedElsewhere.ID, groups[0].ID)
+	suite.Equal(owned.ID, groups[1].ID)
+
+	groups, err = suite.repo.GetByOwner("I00000")
+	suite.NoError(err)
+	suite.Empty(groups)
+}
+
 // TestGetByOrganizationID tests listing groups by organization with pagination
 func (suite *GroupRepositoryTestSuite) TestGetByOrganizationID() {
 	// Create organization
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultHealthCheckTimeout bounds a health check so a hung connection cannot stall a readiness probe
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// HealthChecker verifies connectivity of the database shared by all repositories
+type HealthChecker struct {
+	db      *gorm.DB
+	timeout time.Duration
+}
+
+// Ensure HealthChecker implements HealthCheckerInterface
+var _ HealthCheckerInterface = (*HealthChecker)(nil)
+
+// NewHealthChecker creates a new database health checker
+func NewHealthChecker(db *gorm.DB) *HealthChecker {
+	return &HealthChecker{db: db, timeout: defaultHealthCheckTimeout}
+}
+
+// HealthCheck runs SELECT 1 against the database, failing if it does not answer within the timeout
+func (h *HealthChecker) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	return h.db.WithContext(ctx).Exec("SELECT 1").Error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newMockHealthChecker returns a HealthChecker backed by sqlmock
+func newMockHealthChecker(t *testing.T) (*HealthChecker, sqlmock.Sqlmock, func() error) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB, DriverName: "postgres"}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return NewHealthChecker(db), mock, sqlDB.Close
+}
+
+func TestHealthCheck_Healthy(t *testing.T) {
+	checker, mock, closeDB := newMockHealthChecker(t)
+	defer closeDB()
+
+	mock.ExpectExec(regexp.QuoteMeta("SELECT 1")).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, checker.HealthCheck(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHealthCheck_ClosedConnection(t *testing.T) {
+	checker, mock, closeDB := newMockHealthChecker(t)
+	mock.ExpectClose()
+	require.NoError(t, closeDB())
+
+	assert.Error(t, checker.HealthCheck(context.Background()))
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"developer-portal-backend/internal/database/models"
 
 	"github.com/google/uuid"
@@ -30,19 +33,28 @@ type UserRepositoryInterface interface {
 	Create(member *models.User) error
 	GetByID(id uuid.UUID) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
+	GetByEmails(emails []string) ([]models.User, error)
 	GetByName(name string) (*models.User, error)
 	GetByUserID(userID string) (*models.User, error)
 	GetAll(limit, offset int) ([]models.User, int64, error)
+	GetAllAfter(createdAt time.Time, id uuid.UUID, limit int) ([]models.User, error)
 	GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.User, int64, error)
 	GetByTeamID(teamID uuid.UUID, limit, offset int) ([]models.User, int64, error)
 	GetWithOrganization(id uuid.UUID) (*models.User, error)
-	SearchByOrganization(orgID uuid.UUID, query string, limit, offset int) ([]models.User, int64, error)
-	SearchByNameOrTitleGlobal(query string, limit, offset int) ([]models.User, int64, error)
+	SearchByOrganization(orgID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]models.User, int64, error)
+	SearchByNameOrTitleGlobal(query string, emailOnly bool, limit, offset int) ([]models.User, int64, error)
+	SearchByTeamIDs(teamIDs []uuid.UUID, query string, limit, offset int) ([]models.User, int64, error)
 	GetActiveByOrganization(orgID uuid.UUID, limit, offset int) ([]models.User, int64, error)
+	GetActiveByOrganizationSince(orgID uuid.UUID, since time.Time, limit, offset int) ([]models.User, int64, error)
+	Count() (int64, error)
+	CountByOrganization(orgID uuid.UUID) (int64, error)
+	CountByDomain(orgID uuid.UUID) (map[string]int64, error)
 	GetUserIDsByPrefix(prefix string) ([]string, error)
 	GetExistingUserIDs(ids []string) ([]string, error)
 	Update(member *models.User) error
 	Delete(id uuid.UUID) error
+	GetByIDIncludingDeleted(id uuid.UUID) (*models.User, error)
+	Restore(id uuid.UUID) error
 }
 
 // GroupRepositoryInterface defines the interface for group repository operations
@@ -50,6 +62,7 @@ type GroupRepositoryInterface interface {
 	Create(group *models.Group) error
 	GetByID(id uuid.UUID) (*models.Group, error)
 	GetByName(orgID uuid.UUID, name string) (*models.Group, error)
+	GetByOwner(owner string) ([]models.Group, error)
 	GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Group, int64, error)
 	Search(organizationID uuid.UUID, query string, limit, offset int) ([]models.Group, int64, error)
 	Update(id uuid.UUID, updates map[string]interface{}) error
@@ -126,9 +139,11 @@ type CategoryRepositoryInterface interface {
 type LinkRepositoryInterface interface {
 	GetByOwner(owner uuid.UUID) ([]models.Link, error)
 	GetByIDs(ids []uuid.UUID) ([]models.Link, error)
+	GetByIDsStrict(ids []uuid.UUID) ([]models.Link, error)
 	Create(link *models.Link) error
 	Delete(id uuid.UUID) error
 	GetByID(id uuid.UUID) (*models.Link, error)
+	GetByName(owner uuid.UUID, name string) (*models.Link, error)
 	Update(link *models.Link) error
 }
 
@@ -146,8 +161,23 @@ type DocumentationRepositoryInterface interface {
 type PluginRepositoryInterface interface {
 	Create(plugin *models.Plugin) error
 	GetByID(id uuid.UUID) (*models.Plugin, error)
+	GetByIDs(ids []uuid.UUID) ([]models.Plugin, error)
 	GetByName(name string) (*models.Plugin, error)
 	GetAll(limit, offset int) ([]models.Plugin, int64, error)
+	GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Plugin, int64, error)
+	GetAvailableForOrganization(orgID *uuid.UUID) ([]models.Plugin, error)
+	Search(query string, limit, offset int) ([]models.Plugin, int64, error)
 	Update(plugin *models.Plugin) error
 	Delete(id uuid.UUID) error
 }
+
+// MetadataAuditRepositoryInterface defines the interface for metadata audit repository operations
+type MetadataAuditRepositoryInterface interface {
+	Create(entry *models.MetadataAudit) error
+	GetByUserID(userID string, limit, offset int) ([]models.MetadataAudit, int64, error)
+}
+
+// HealthCheckerInterface defines the interface for database connectivity checks
+type HealthCheckerInterface interface {
+	HealthCheck(ctx context.Context) error
+}
@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"fmt"
+
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -29,7 +32,10 @@ func (r *LinkRepository) GetByOwner(owner uuid.UUID) ([]models.Link, error) {
 	return links, nil
 }
 
-// GetByIDs retrieves links by a set of UUID IDs
+// GetByIDs retrieves links by a set of UUID IDs, ordered by title.
+// IDs with no matching link are silently skipped, so the result may be shorter than ids;
+// callers resolving user favorites rely on this to tolerate links deleted since they were saved.
+// Use GetByIDsStrict when every requested ID must exist.
 func (r *LinkRepository) GetByIDs(ids []uuid.UUID) ([]models.Link, error) {
 	if len(ids) == 0 {
 		return []models.Link{}, nil
@@ -41,6 +47,37 @@ func (r *LinkRepository) GetByIDs(ids []uuid.UUID) ([]models.Link, error) {
 	return links, nil
 }
 
+// GetByIDsStrict retrieves links by a set of UUID IDs, ordered by title, and returns
+// ErrLinkNotFound listing the absent IDs if any requested link does not exist
+func (r *LinkRepository) GetByIDsStrict(ids []uuid.UUID) ([]models.Link, error) {
+	links, err := r.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingLinkIDs(ids, links); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %v", apperrors.ErrLinkNotFound, missing)
+	}
+	return links, nil
+}
+
+// missingLinkIDs returns the requested IDs, deduplicated and in request order, that have no matching link
+func missingLinkIDs(ids []uuid.UUID, links []models.Link) []uuid.UUID {
+	found := make(map[uuid.UUID]struct{}, len(links))
+	for _, link := range links {
+		found[link.ID] = struct{}{}
+	}
+
+	var missing []uuid.UUID
+	for _, id := range ids {
+		if _, ok := found[id]; ok {
+			continue
+		}
+		found[id] = struct{}{}
+		missing = append(missing, id)
+	}
+	return missing
+}
+
  // Create inserts a new link
 func (r *LinkRepository) Create(link *models.Link) error {
 	return r.db.Create(link).Error
@@ -60,6 +97,15 @@ func (r *LinkRepository) GetByID(id uuid.UUID) (*models.Link, error) {
 	return &link, nil
 }
 
+// GetByName retrieves the link with the given name among the links of an owner (user/team) UUID
+func (r *LinkRepository) GetByName(owner uuid.UUID, name string) (*models.Link, error) {
+	var link models.Link
+	if err := r.db.Where("owner = ? AND name = ?", owner, name).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
 // Update updates an existing link
 func (r *LinkRepository) Update(link *models.Link) error {
 	return r.db.Save(link).Error
@@ -2,12 +2,17 @@ package repository
 
 import (
 	"testing"
+	"time"
 
+	"developer-portal-backend/internal/database"
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/testutils"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 // LinkRepositoryTestSuite tests the LinkRepository
@@ -145,6 +150,56 @@ func (suite *LinkRepositoryTestSuite) TestGetByIDs_Empty() {
 	suite.Len(links, 0)
 }
 
+// TestGetByIDs_PartiallyMissing tests that missing IDs are dropped rather than reported
+func (suite *LinkRepositoryTestSuite) TestGetByIDs_PartiallyMissing() {
+	cat := suite.createCategory("cat-partial", "Category Partial", "icon-p", "blue")
+	owner := uuid.New()
+
+	l1 := suite.createLink(owner, "Kappa", "https://example.com/kappa", cat.ID, "")
+	l2 := suite.createLink(owner, "Iota", "https://example.com/iota", cat.ID, "")
+
+	links, err := suite.repo.GetByIDs([]uuid.UUID{l1.ID, uuid.New(), l2.ID})
+
+	suite.NoError(err)
+	suite.Len(links, 2)
+	suite.Equal("Iota", links[0].Title)
+	suite.Equal("Kappa", links[1].Title)
+}
+
+// TestGetByIDsStrict tests that the strict lookup succeeds when all IDs exist and errors otherwise
+func (suite *LinkRepositoryTestSuite) TestGetByIDsStrict() {
+	cat := suite.createCategory("cat-strict", "Category Strict", "icon-s", "purple")
+	owner := uuid.New()
+
+	l1 := suite.createLink(owner, "Lambda", "https://example.com/lambda", cat.ID, "")
+	l2 := suite.createLink(owner, "Mu", "https://example.com/mu", cat.ID, "")
+
+	links, err := suite.repo.GetByIDsStrict([]uuid.UUID{l2.ID, l1.ID})
+	suite.NoError(err)
+	suite.Len(links, 2)
+
+	missingID := uuid.New()
+	links, err = suite.repo.GetByIDsStrict([]uuid.UUID{l1.ID, missingID})
+	suite.ErrorIs(err, apperrors.ErrLinkNotFound)
+	suite.Contains(err.Error(), missingID.String())
+	suite.Nil(links)
+}
+
+// TestGetByName tests retrieving a link by name scoped to its owner
+func (suite *LinkRepositoryTestSuite) TestGetByName() {
+	cat := suite.createCategory("cat-name", "Category Name", "icon-n", "orange")
+	owner := uuid.New()
+
+	l := suite.createLink(owner, "Nu", "https://example.com/nu", cat.ID, "")
+
+	found, err := suite.repo.GetByName(owner, l.Name)
+	suite.NoError(err)
+	suite.Equal(l.ID, found.ID)
+
+	_, err = suite.repo.GetByName(uuid.New(), l.Name)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
 // TestDelete tests deleting a link
 func (suite *LinkRepositoryTestSuite) TestDelete() {
 	cat := suite.createCategory("cat-4", "Category 4", "icon-4", "yellow")
@@ -167,7 +222,59 @@ func (suite *LinkRepositoryTestSuite) TestDeleteNotFound() {
 	suite.NoError(err)
 }
 
+// TestCreateIndexesDedupesOwnerNames tests CreateIndexes renames an owner's duplicate link names before
+// enforcing their uniqueness
+func (suite *LinkRepositoryTestSuite) TestCreateIndexesDedupesOwnerNames() {
+	db := suite.baseTestSuite.DB
+	suite.Require().NoError(db.Exec(`DROP INDEX IF EXISTS links_owner_name_unique`).Error)
+
+	catA := suite.createCategory("cat-dup-a", "Category Dup A", "icon-a", "red")
+	catB := suite.createCategory("cat-dup-b", "Category Dup B", "icon-b", "blue")
+	owner := uuid.New()
+	kept := suite.createLink(owner, "Docs", "https://example.com/docs", catA.ID, "")
+	renamed := suite.createLink(owner, "Docs", "https://example.com/docs-2", catB.ID, "")
+	other := suite.createLink(uuid.New(), "Docs", "https://example.com/docs-3", catB.ID, "")
+	suite.Require().NoError(db.Model(&models.Link{}).Where("id = ?", renamed.ID).
+		UpdateColumn("created_at", kept.CreatedAt.Add(time.Minute)).Error)
+
+	suite.Require().NoError(database.CreateIndexes(db))
+	// Running again on a deduplicated table is a no-op
+	suite.Require().NoError(database.CreateIndexes(db))
+
+	found, err := suite.repo.GetByIDs([]uuid.UUID{kept.ID, renamed.ID, other.ID})
+	suite.Require().NoError(err)
+	names := make(map[uuid.UUID]string, len(found))
+	for _, l := range found {
+		names[l.ID] = l.Name
+	}
+	suite.Equal("Docs", names[kept.ID])
+	suite.Equal("Docs-"+renamed.ID.String()[:8], names[renamed.ID])
+	suite.Equal("Docs", names[other.ID])
+
+	duplicate := &models.Link{
+		BaseModel:  models.BaseModel{ID: uuid.New(), Name: "Docs", Title: "Docs"},
+		Owner:      owner,
+		URL:        "https://example.com/docs-4",
+		CategoryID: suite.createCategory("cat-dup-c", "Category Dup C", "icon-c", "green").ID,
+	}
+	suite.Error(db.Create(duplicate).Error)
+}
+
 // Run the test suite
 func TestLinkRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(LinkRepositoryTestSuite))
 }
+
+// TestMissingLinkIDs tests that missing IDs are reported once each, in request order
+func TestMissingLinkIDs(t *testing.T) {
+	present := uuid.New()
+	absentA := uuid.New()
+	absentB := uuid.New()
+
+	missing := missingLinkIDs(
+		[]uuid.UUID{absentA, present, absentB, absentA},
+		[]models.Link{{BaseModel: models.BaseModel{ID: present}}},
+	)
+
+	assert.Equal(t, []uuid.UUID{absentA, absentB}, missing)
+}
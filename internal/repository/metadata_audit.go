@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"developer-portal-backend/internal/database/models"
+
+	"gorm.io/gorm"
+)
+
+// MetadataAuditRepository handles database operations for the append-only metadata audit log
+type MetadataAuditRepository struct {
+	db *gorm.DB
+}
+
+// NewMetadataAuditRepository creates a new metadata audit repository
+func NewMetadataAuditRepository(db *gorm.DB) *MetadataAuditRepository {
+	return &MetadataAuditRepository{db: db}
+}
+
+// Create appends an audit entry
+func (r *MetadataAuditRepository) Create(entry *models.MetadataAudit) error {
+	return r.db.Create(entry).Error
+}
+
+// GetByUserID retrieves audit entries for a user, newest first, with pagination
+func (r *MetadataAuditRepository) GetByUserID(userID string, limit, offset int) ([]models.MetadataAudit, int64, error) {
+	var entries []models.MetadataAudit
+	var total int64
+
+	query := r.db.Model(&models.MetadataAudit{}).Where("user_id = ?", userID)
+
+	// Get total count
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetadataAuditRepositoryTestSuite struct {
+	suite.Suite
+	*testutils.BaseTestSuite
+	repo *MetadataAuditRepository
+}
+
+func (suite *MetadataAuditRepositoryTestSuite) SetupSuite() {
+	suite.BaseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewMetadataAuditRepository(suite.DB)
+}
+
+func (suite *MetadataAuditRepositoryTestSuite) TearDownTest() {
+	suite.CleanTestDB()
+}
+
+func (suite *MetadataAuditRepositoryTestSuite) TestCreateAndGetByUserID() {
+	base := time.Now().Add(-time.Hour)
+	for i, op := range []models.MetadataAuditOperation{
+		models.MetadataAuditOperationAdd,
+		models.MetadataAuditOperationRemove,
+		models.MetadataAuditOperationAdd,
+	} {
+		entry := &models.MetadataAudit{
+			UserID:    "I123456",
+			Field:     models.MetadataAuditFieldFavorites,
+			Operation: op,
+			TargetID:  uuid.New(),
+			Actor:     "I123456",
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		}
+		assert.NoError(suite.T(), suite.repo.Create(entry))
+		assert.NotEqual(suite.T(), uuid.Nil, entry.ID)
+	}
+
+	// Entries of other users are not returned
+	assert.NoError(suite.T(), suite.repo.Create(&models.MetadataAudit{
+		UserID:    "I999999",
+		Field:     models.MetadataAuditFieldSubscribed,
+		Operation: models.MetadataAuditOperationAdd,
+		TargetID:  uuid.New(),
+		CreatedAt: base,
+	}))
+
+	entries, total, err := suite.repo.GetByUserID("I123456", 2, 0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), total)
+	assert.Len(suite.T(), entries, 2)
+	// Newest first
+	assert.True(suite.T(), entries[0].CreatedAt.After(entries[1].CreatedAt))
+
+	entries, total, err = suite.repo.GetByUserID("I123456", 2, 2)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), total)
+	assert.Len(suite.T(), entries, 1)
+
+	entries, total, err = suite.repo.GetByUserID("unknown", 10, 0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Empty(suite.T(), entries)
+}
+
+func TestMetadataAuditRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MetadataAuditRepositoryTestSuite))
+}
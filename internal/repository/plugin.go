@@ -32,6 +32,18 @@ func (r *PluginRepository) GetByID(id uuid.UUID) (*models.Plugin, error) {
 	return &plugin, nil
 }
 
+// GetByIDs retrieves plugins by a set of UUID IDs
+func (r *PluginRepository) GetByIDs(ids []uuid.UUID) ([]models.Plugin, error) {
+	if len(ids) == 0 {
+		return []models.Plugin{}, nil
+	}
+	var plugins []models.Plugin
+	if err := r.db.Where("id IN ?", ids).Order("name ASC").Find(&plugins).Error; err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
 // GetByName retrieves a plugin by name
 func (r *PluginRepository) GetByName(name string) (*models.Plugin, error) {
 	var plugin models.Plugin
@@ -60,6 +72,64 @@ func (r *PluginRepository) GetAll(limit, offset int) ([]models.Plugin, int64, er
 	return plugins, total, nil
 }
 
+// GetByOrganizationID retrieves the plugins scoped to an organization with pagination
+func (r *PluginRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Plugin, int64, error) {
+	var plugins []models.Plugin
+	var total int64
+
+	// Get total count
+	if err := r.db.Model(&models.Plugin{}).Where("org_id = ?", orgID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := r.db.Where("org_id = ?", orgID).Order("name").Limit(limit).Offset(offset).Find(&plugins).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return plugins, total, nil
+}
+
+// GetAvailableForOrganization retrieves the global plugins together with those scoped to orgID;
+// a nil orgID yields only the global plugins
+func (r *PluginRepository) GetAvailableForOrganization(orgID *uuid.UUID) ([]models.Plugin, error) {
+	var plugins []models.Plugin
+
+	query := r.db.Model(&models.Plugin{})
+	if orgID == nil {
+		query = query.Where("org_id IS NULL")
+	} else {
+		query = query.Where("org_id IS NULL OR org_id = ?", *orgID)
+	}
+
+	if err := query.Order("name").Find(&plugins).Error; err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
+// Search searches for plugins by name, title or description
+func (r *PluginRepository) Search(query string, limit, offset int) ([]models.Plugin, int64, error) {
+	var plugins []models.Plugin
+	var total int64
+
+	pattern := "%" + query + "%"
+	searchQuery := r.db.Model(&models.Plugin{}).
+		Where("name ILIKE ? OR title ILIKE ? OR description ILIKE ?", pattern, pattern, pattern)
+
+	// Get total count
+	if err := searchQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := searchQuery.Order("name").Limit(limit).Offset(offset).Find(&plugins).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return plugins, total, nil
+}
+
 // Update updates a plugin
 func (r *PluginRepository) Update(plugin *models.Plugin) error {
 	return r.db.Save(plugin).Error
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"fmt"
 	"testing"
 
 	"developer-portal-backend/internal/database/models"
@@ -237,6 +238,122 @@ func (suite *PluginRepositoryTestSuite) TestGetAll() {
 	assert.Len(suite.T(), retrievedPlugins, 0)
 }
 
+func (suite *PluginRepositoryTestSuite) TestGetByIDs() {
+	ids := make([]uuid.UUID, 0, 3)
+	for _, name := range []string{"ids-charlie", "ids-alpha", "ids-bravo"} {
+		plugin := &models.Plugin{
+			BaseModel: models.BaseModel{
+				Name:        name,
+				Title:       name,
+				Description: "Plugin for testing GetByIDs",
+			},
+			Icon:               "BatchIcon",
+			ReactComponentPath: "/plugins/batch/Batch.jsx",
+			BackendServerURL:   "http://localhost:3006",
+		}
+		assert.NoError(suite.T(), suite.repo.Create(plugin))
+		ids = append(ids, plugin.ID)
+	}
+
+	// Unknown IDs are ignored and results are ordered by name
+	plugins, err := suite.repo.GetByIDs(append(ids[:2:2], uuid.New()))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), plugins, 2)
+	assert.Equal(suite.T(), "ids-alpha", plugins[0].Name)
+	assert.Equal(suite.T(), "ids-charlie", plugins[1].Name)
+
+	// Empty input returns an empty result without querying
+	plugins, err = suite.repo.GetByIDs([]uuid.UUID{})
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), plugins)
+}
+
+func (suite *PluginRepositoryTestSuite) TestOrganizationScopedPlugins() {
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+	scopes := map[string]*uuid.UUID{
+		"scope-global":    nil,
+		"scope-org-b":     &orgID,
+		"scope-org-a":     &orgID,
+		"scope-other-org": &otherOrgID,
+	}
+	for name, scope := range scopes {
+		plugin := &models.Plugin{
+			BaseModel: models.BaseModel{
+				Name:        name,
+				Title:       name,
+				Description: "Plugin for testing organization scoping",
+			},
+			Icon:               "ScopeIcon",
+			ReactComponentPath: "/plugins/scope/Scope.jsx",
+			BackendServerURL:   "http://localhost:3007",
+			OrgID:              scope,
+		}
+		assert.NoError(suite.T(), suite.repo.Create(plugin))
+	}
+
+	// Only the organization's own plugins, ordered by name
+	plugins, total, err := suite.repo.GetByOrganizationID(orgID, 1, 0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), total)
+	assert.Len(suite.T(), plugins, 1)
+	assert.Equal(suite.T(), "scope-org-a", plugins[0].Name)
+
+	// Global plugins unioned with the organization's plugins
+	plugins, err = suite.repo.GetAvailableForOrganization(&orgID)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), plugins, 3)
+	for _, plugin := range plugins {
+		assert.NotEqual(suite.T(), "scope-other-org", plugin.Name)
+	}
+
+	// Without an organization only global plugins are available
+	plugins, err = suite.repo.GetAvailableForOrganization(nil)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), plugins, 1)
+	assert.Equal(suite.T(), "scope-global", plugins[0].Name)
+}
+
+func (suite *PluginRepositoryTestSuite) TestSearch() {
+	for i, name := range []string{"search-alpha", "search-beta", "search-gamma", "other-plugin"} {
+		plugin := &models.Plugin{
+			BaseModel: models.BaseModel{
+				Name:        name,
+				Title:       fmt.Sprintf("Plugin %d", i),
+				Description: "Plugin for testing Search",
+			},
+			Icon:               "SearchIcon",
+			ReactComponentPath: "/plugins/search/Search.jsx",
+			BackendServerURL:   "http://localhost:3005",
+		}
+		assert.NoError(suite.T(), suite.repo.Create(plugin))
+	}
+
+	// Test matching by name with pagination
+	results, total, err := suite.repo.Search("search-", 2, 0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), total)
+	assert.Len(suite.T(), results, 2)
+	assert.Equal(suite.T(), "search-alpha", results[0].Name)
+
+	results, total, err = suite.repo.Search("search-", 2, 2)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), total)
+	assert.Len(suite.T(), results, 1)
+	assert.Equal(suite.T(), "search-gamma", results[0].Name)
+
+	// Test case-insensitive match on description
+	_, total, err = suite.repo.Search("TESTING SEARCH", 10, 0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(4), total)
+
+	// Test no matches
+	results, total, err = suite.repo.Search("nothing-matches", 10, 0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Empty(suite.T(), results)
+}
+
 func (suite *PluginRepositoryTestSuite) TestUpdate() {
 	// Create a plugin first
 	plugin := &models.Plugin{
@@ -2,7 +2,10 @@ package repository
 
 import (
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -28,7 +31,7 @@ func (r *UserRepository) GetByID(id uuid.UUID) (*models.User, error) {
 	var member models.User
 	err := r.db.First(&member, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
@@ -38,17 +41,34 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	var member models.User
 	err := r.db.First(&member, "email = ?", email).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
 
+// GetByEmails retrieves the members with any of the given emails in a single query.
+// Emails are matched case-insensitively; emails without a member are simply absent from the result.
+func (r *UserRepository) GetByEmails(emails []string) ([]models.User, error) {
+	if len(emails) == 0 {
+		return []models.User{}, nil
+	}
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+	var members []models.User
+	if err := r.db.Where("LOWER(email) IN ?", lowered).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
 // GetByName retrieves a member by BaseModel.Name column
 func (r *UserRepository) GetByName(name string) (*models.User, error) {
 	var member models.User
 	err := r.db.First(&member, "name = ?", name).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
@@ -58,7 +78,7 @@ func (r *UserRepository) GetByUserID(userID string) (*models.User, error) {
 	var member models.User
 	err := r.db.First(&member, "user_id = ?", userID).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
@@ -81,6 +101,21 @@ func (r *UserRepository) GetAll(limit, offset int) ([]models.User, int64, error)
 	return members, total, nil
 }
 
+// GetAllAfter retrieves up to limit members ordered by (created_at, id), strictly after the given keyset.
+// Unlike offset pagination, rows created or deleted between pages cannot shift the window;
+// a zero createdAt and uuid.Nil start from the beginning.
+func (r *UserRepository) GetAllAfter(createdAt time.Time, id uuid.UUID, limit int) ([]models.User, error) {
+	var members []models.User
+	if err := r.db.Model(&models.User{}).
+		Where("(created_at, id) > (?, ?)", createdAt, id).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
 // GetByOrganizationID retrieves all members for an organization with pagination
 func (r *UserRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.User, int64, error) {
 	var members []models.User
@@ -164,17 +199,39 @@ func (r *UserRepository) Update(member *models.User) error {
 	return r.db.Save(member).Error
 }
 
-// Delete deletes a member
+// Delete soft-deletes a member by setting DeletedAt
 func (r *UserRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.User{}, "id = ?", id).Error
 }
 
+// GetByIDIncludingDeleted retrieves a member by ID, including soft-deleted members
+func (r *UserRepository) GetByIDIncludingDeleted(id uuid.UUID) (*models.User, error) {
+	var member models.User
+	err := r.db.Unscoped().First(&member, "id = ?", id).Error
+	if err != nil {
+		return nil, translateUserError(err)
+	}
+	return &member, nil
+}
+
+// Restore clears DeletedAt on a soft-deleted member
+func (r *UserRepository) Restore(id uuid.UUID) error {
+	result := r.db.Unscoped().Model(&models.User{}).Where("id = ?", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
 // GetWithOrganization retrieves a member with organization details
 func (r *UserRepository) GetWithOrganization(id uuid.UUID) (*models.User, error) {
 	var member models.User
 	err := r.db.Preload("Organization").First(&member, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
@@ -184,7 +241,7 @@ func (r *UserRepository) GetWithTeam(id uuid.UUID) (*models.User, error) {
 	var member models.User
 	err := r.db.Preload("Team").First(&member, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
@@ -194,7 +251,7 @@ func (r *UserRepository) GetWithLeadingTeams(id uuid.UUID) (*models.User, error)
 	var member models.User
 	err := r.db.Preload("LeadingTeams").First(&member, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
@@ -204,7 +261,7 @@ func (r *UserRepository) GetWithDutySchedules(id uuid.UUID) (*models.User, error
 	var member models.User
 	err := r.db.Preload("DutySchedules").First(&member, "id = ?", id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateUserError(err)
 	}
 	return &member, nil
 }
@@ -232,13 +289,23 @@ func (r *UserRepository) SetActiveStatus(memberID uuid.UUID, isActive bool) erro
 
 // Search searches for members by name or email
 func (r *UserRepository) Search(orgID uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
+	return r.search(orgID, query, false, limit, offset)
+}
+
+// search runs the organization-scoped member search; emailOnly restricts matching to the email column
+func (r *UserRepository) search(orgID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]models.User, int64, error) {
 	var members []models.User
 	var total int64
 
+	pattern := "%" + query + "%"
 	searchQuery := r.db.Model(&models.User{}).
-		Joins("JOIN teams ON members.team_id = teams.id").
-		Joins("JOIN groups ON teams.group_id = groups.id").
-		Where("groups.org_id = ? AND (members.first_name ILIKE ? OR members.last_name ILIKE ? OR members.email ILIKE ?)", orgID, "%"+query+"%", "%"+query+"%", "%"+query+"%")
+		Joins("JOIN teams ON users.team_id = teams.id").
+		Joins("JOIN groups ON teams.group_id = groups.id")
+	if emailOnly {
+		searchQuery = searchQuery.Where("groups.org_id = ? AND users.email ILIKE ?", orgID, pattern)
+	} else {
+		searchQuery = searchQuery.Where("groups.org_id = ? AND (users.first_name ILIKE ? OR users.last_name ILIKE ? OR users.email ILIKE ?)", orgID, pattern, pattern, pattern)
+	}
 
 	// Get total count
 	if err := searchQuery.Count(&total).Error; err != nil {
@@ -254,9 +321,10 @@ func (r *UserRepository) Search(orgID uuid.UUID, query string, limit, offset int
 	return members, total, nil
 }
 
-// SearchByOrganization searches for members by name or email within an organization
-func (r *UserRepository) SearchByOrganization(orgID uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
-	return r.Search(orgID, query, limit, offset)
+// SearchByOrganization searches for members by name or email within an organization.
+// When emailOnly is true only the email column is matched.
+func (r *UserRepository) SearchByOrganization(orgID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]models.User, int64, error) {
+	return r.search(orgID, query, emailOnly, limit, offset)
 }
 
  // GetActiveByOrganization retrieves all active members for an organization
@@ -264,6 +332,99 @@ func (r *UserRepository) GetActiveByOrganization(orgID uuid.UUID, limit, offset
 	return r.GetByOrganizationID(orgID, limit, offset)
 }
 
+// SearchByTeamIDs searches members of the given teams by first name, last name or email.
+// An empty team list matches nobody.
+func (r *UserRepository) SearchByTeamIDs(teamIDs []uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
+	if len(teamIDs) == 0 {
+		return []models.User{}, 0, nil
+	}
+
+	var members []models.User
+	var total int64
+
+	pattern := "%" + query + "%"
+	searchQuery := r.db.Model(&models.User{}).
+		Joins("JOIN teams ON users.team_id = teams.id").
+		Where("teams.id IN ? AND (users.first_name ILIKE ? OR users.last_name ILIKE ? OR users.email ILIKE ?)", teamIDs, pattern, pattern, pattern)
+
+	if err := searchQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := searchQuery.Limit(limit).Offset(offset).Find(&members).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return members, total, nil
+}
+
+// GetActiveByOrganizationSince retrieves members of an organization whose record was updated after since,
+// most recently active first. Activity is tracked by updated_at, as the model has no last-login column.
+func (r *UserRepository) GetActiveByOrganizationSince(orgID uuid.UUID, since time.Time, limit, offset int) ([]models.User, int64, error) {
+	var members []models.User
+	var total int64
+
+	query := r.db.Model(&models.User{}).
+		Joins("JOIN teams ON users.team_id = teams.id").
+		Joins("JOIN groups ON teams.group_id = groups.id").
+		Where("groups.org_id = ? AND users.updated_at > ?", orgID, since)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("users.updated_at DESC").Limit(limit).Offset(offset).Find(&members).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return members, total, nil
+}
+
+// Count returns the total number of members
+func (r *UserRepository) Count() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Count(&count).Error
+	return count, err
+}
+
+// CountByOrganization returns the number of members in an organization
+func (r *UserRepository) CountByOrganization(orgID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).
+		Joins("JOIN teams ON users.team_id = teams.id").
+		Joins("JOIN groups ON teams.group_id = groups.id").
+		Where("groups.org_id = ?", orgID).
+		Count(&count).Error
+	return count, err
+}
+
+// CountByDomain returns the number of members in an organization grouped by team domain
+func (r *UserRepository) CountByDomain(orgID uuid.UUID) (map[string]int64, error) {
+	var rows []struct {
+		TeamDomain string
+		Count      int64
+	}
+
+	err := r.db.Model(&models.User{}).
+		Select("users.team_domain AS team_domain, COUNT(*) AS count").
+		Joins("JOIN teams ON users.team_id = teams.id").
+		Joins("JOIN groups ON teams.group_id = groups.id").
+		Where("groups.org_id = ?", orgID).
+		Group("users.team_domain").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.TeamDomain] = row.Count
+	}
+	return counts, nil
+}
+
  // GetUserIDsByPrefix returns user_ids with the given prefix (case-insensitive)
 func (r *UserRepository) GetUserIDsByPrefix(prefix string) ([]string, error) {
 	var ids []string
@@ -289,8 +450,9 @@ func (r *UserRepository) GetExistingUserIDs(ids []string) ([]string, error) {
 	return existing, nil
 }
 
-// SearchByNameOrTitleGlobal performs a case-insensitive search across users by BaseModel.Name or BaseModel.Title
-func (r *UserRepository) SearchByNameOrTitleGlobal(query string, limit, offset int) ([]models.User, int64, error) {
+// SearchByNameOrTitleGlobal performs a case-insensitive search across users by BaseModel.Name, BaseModel.Title or email.
+// When emailOnly is true only the email column is matched.
+func (r *UserRepository) SearchByNameOrTitleGlobal(query string, emailOnly bool, limit, offset int) ([]models.User, int64, error) {
 	var members []models.User
 	var total int64
 
@@ -306,8 +468,13 @@ func (r *UserRepository) SearchByNameOrTitleGlobal(query string, limit, offset i
 		return members, total, nil
 	}
 
-	searchQuery := r.db.Model(&models.User{}).
-		Where("name ILIKE ? OR title ILIKE ?", "%"+q+"%", "%"+q+"%")
+	pattern := "%" + q + "%"
+	searchQuery := r.db.Model(&models.User{})
+	if emailOnly {
+		searchQuery = searchQuery.Where("email ILIKE ?", pattern)
+	} else {
+		searchQuery = searchQuery.Where("(name ILIKE ? OR title ILIKE ? OR email ILIKE ?)", pattern, pattern, pattern)
+	}
 
 	// Get total count
 	if err := searchQuery.Count(&total).Error; err != nil {
@@ -321,3 +488,11 @@ func (r *UserRepository) SearchByNameOrTitleGlobal(query string, limit, offset i
 
 	return members, total, nil
 }
+
+// translateUserError maps gorm.ErrRecordNotFound to apperrors.ErrUserNotFound so services only see the domain error
+func translateUserError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ErrUserNotFound
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newMockUserRepository returns a UserRepository backed by sqlmock
+func newMockUserRepository(t *testing.T) (*UserRepository, sqlmock.Sqlmock, func() error) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB, DriverName: "postgres"}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return NewUserRepository(db), mock, sqlDB.Close
+}
+
+func TestSearchByTeamIDs_NoTeams(t *testing.T) {
+	repo, mock, closeDB := newMockUserRepository(t)
+	defer closeDB()
+
+	members, total, err := repo.SearchByTeamIDs(nil, "alice", 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, members)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByEmails(t *testing.T) {
+	repo, mock, closeDB := newMockUserRepository(t)
+	defer closeDB()
+
+	aliceID := uuid.New()
+
+	// Emails are lower-cased so the IN match is case-insensitive, in a single query
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE LOWER\(email\) IN \(\$1,\$2\)`).
+		WithArgs("alice@example.com", "missing@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(aliceID, "Alice@Example.com"))
+
+	members, err := repo.GetByEmails([]string{"ALICE@example.com", "missing@example.com"})
+
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, aliceID, members[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByEmails_Empty(t *testing.T) {
+	repo, mock, closeDB := newMockUserRepository(t)
+	defer closeDB()
+
+	members, err := repo.GetByEmails(nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, members)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
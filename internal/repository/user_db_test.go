@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// UserRepositoryDBTestSuite tests the UserRepository against a real database
+type UserRepositoryDBTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *UserRepository
+	factories     *testutils.FactorySet
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *UserRepositoryDBTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+
+	suite.repo = NewUserRepository(suite.baseTestSuite.DB)
+	suite.factories = testutils.NewFactorySet()
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *UserRepositoryDBTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *UserRepositoryDBTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *UserRepositoryDBTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+// TestGetByIDIncludingDeleted tests a soft-deleted member is only visible to GetByIDIncludingDeleted
+func (suite *UserRepositoryDBTestSuite) TestGetByIDIncludingDeleted() {
+	member := suite.factories.User.Create()
+	suite.Require().NoError(suite.repo.Create(member))
+	suite.Require().NoError(suite.repo.Delete(member.ID))
+
+	_, err := suite.repo.GetByID(member.ID)
+	suite.ErrorIs(err, apperrors.ErrUserNotFound)
+
+	deleted, err := suite.repo.GetByIDIncludingDeleted(member.ID)
+	suite.NoError(err)
+	suite.Equal(member.ID, deleted.ID)
+	suite.True(deleted.DeletedAt.Valid)
+
+	_, err = suite.repo.GetByIDIncludingDeleted(uuid.New())
+	suite.ErrorIs(err, apperrors.ErrUserNotFound)
+}
+
+// TestRestore tests restoring a soft-deleted member makes it visible again
+func (suite *UserRepositoryDBTestSuite) TestRestore() {
+	member := suite.factories.User.Create()
+	suite.Require().NoError(suite.repo.Create(member))
+	suite.Require().NoError(suite.repo.Delete(member.ID))
+
+	suite.NoError(suite.repo.Restore(member.ID))
+
+	restored, err := suite.repo.GetByID(member.ID)
+	suite.NoError(err)
+	suite.False(restored.DeletedAt.Valid)
+}
+
+// TestRestoreNotFound tests restoring an unknown member
+func (suite *UserRepositoryDBTestSuite) TestRestoreNotFound() {
+	err := suite.repo.Restore(uuid.New())
+	suite.ErrorIs(err, apperrors.ErrUserNotFound)
+}
+
+// TestCreateReusesDeletedEmail tests the email of a soft-deleted member can be taken by a new member
+func (suite *UserRepositoryDBTestSuite) TestCreateReusesDeletedEmail() {
+	member := suite.factories.User.WithEmail("reused@test.com")
+	suite.Require().NoError(suite.repo.Create(member))
+
+	// Active members still have unique emails
+	duplicate := suite.factories.User.WithEmail("reused@test.com")
+	suite.Error(suite.repo.Create(duplicate))
+
+	suite.Require().NoError(suite.repo.Delete(member.ID))
+
+	replacement := suite.factories.User.WithEmail("reused@test.com")
+	suite.NoError(suite.repo.Create(replacement))
+
+	found, err := suite.repo.GetByEmail("reused@test.com")
+	suite.NoError(err)
+	suite.Equal(replacement.ID, found.ID)
+
+	// The deleted member cannot come back while its email is in use
+	suite.Error(suite.repo.Restore(member.ID))
+}
+
+// createTeamInOrganization creates an organization with a group and a team under it
+func (suite *UserRepositoryDBTestSuite) createTeamInOrganization(name string) (*models.Organization, *models.Team) {
+	db := suite.baseTestSuite.DB
+
+	org := suite.factories.Organization.WithName(name)
+	suite.Require().NoError(NewOrganizationRepository(db).Create(org))
+
+	group := suite.factories.Group.WithOrganization(org.ID)
+	suite.Require().NoError(NewGroupRepository(db).Create(group))
+
+	team := suite.factories.Team.WithGroup(group.ID)
+	suite.Require().NoError(NewTeamRepository(db).Create(team))
+
+	return org, team
+}
+
+// createMember creates a member of the team with the given email
+func (suite *UserRepositoryDBTestSuite) createMember(teamID uuid.UUID, email string) *models.User {
+	member := suite.factories.User.WithTeam(teamID)
+	member.Email = email
+	suite.Require().NoError(suite.repo.Create(member))
+	return member
+}
+
+// TestGetActiveByOrganizationSince tests only members of the organization updated after the cutoff are returned
+func (suite *UserRepositoryDBTestSuite) TestGetActiveByOrganizationSince() {
+	org, team := suite.createTeamInOrganization("active-org")
+	_, otherTeam := suite.createTeamInOrganization("other-org")
+
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := map[*models.User]time.Time{
+		suite.createMember(team.ID, "recent@test.com"):     since.Add(48 * time.Hour),
+		suite.createMember(team.ID, "just-now@test.com"):   since.Add(time.Hour),
+		suite.createMember(team.ID, "stale@test.com"):      since.Add(-48 * time.Hour),
+		suite.createMember(otherTeam.ID, "other@test.com"): since.Add(72 * time.Hour),
+	}
+	for member, at := range updatedAt {
+		suite.Require().NoError(suite.baseTestSuite.DB.Model(&models.User{}).
+			Where("id = ?", member.ID).
+			UpdateColumn("updated_at", at).Error)
+	}
+
+	members, total, err := suite.repo.GetActiveByOrganizationSince(org.ID, since, 10, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Require().Len(members, 2)
+	// Most recently updated first
+	suite.Equal("recent@test.com", members[0].Email)
+	suite.Equal("just-now@test.com", members[1].Email)
+
+	members, total, err = suite.repo.GetActiveByOrganizationSince(org.ID, since, 1, 1)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Require().Len(members, 1)
+	suite.Equal("just-now@test.com", members[0].Email)
+}
+
+// TestSearchByTeamIDs tests only members of the given teams matching the query are returned
+func (suite *UserRepositoryDBTestSuite) TestSearchByTeamIDs() {
+	_, team := suite.createTeamInOrganization("owned-org")
+	_, otherTeam := suite.createTeamInOrganization("other-org")
+
+	inOrg := suite.createMember(team.ID, "alice.smith@test.com")
+	suite.createMember(team.ID, "bob.brown@test.com")
+	suite.createMember(otherTeam.ID, "alice.jones@test.com")
+
+	members, total, err := suite.repo.SearchByTeamIDs([]uuid.UUID{team.ID}, "alice", 20, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Require().Len(members, 1)
+	suite.Equal(inOrg.ID, members[0].ID)
+
+	members, total, err = suite.repo.SearchByTeamIDs([]uuid.UUID{team.ID, otherTeam.ID}, "ALICE", 20, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Len(members, 2)
+}
+
+// TestCountByOrganizationAndDomain tests members are counted per organization and team domain
+func (suite *UserRepositoryDBTestSuite) TestCountByOrganizationAndDomain() {
+	org, team := suite.createTeamInOrganization("counted-org")
+	_, otherTeam := suite.createTeamInOrganization("other-org")
+
+	suite.createMember(team.ID, "dev1@test.com")
+	suite.createMember(team.ID, "dev2@test.com")
+	devops := suite.factories.User.WithTeam(team.ID)
+	devops.Email = "devops@test.com"
+	devops.TeamDomain = models.TeamDomainDevOps
+	suite.Require().NoError(suite.repo.Create(devops))
+	suite.createMember(otherTeam.ID, "other@test.com")
+
+	count, err := suite.repo.CountByOrganization(org.ID)
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), count)
+
+	counts, err := suite.repo.CountByDomain(org.ID)
+	suite.Require().NoError(err)
+	suite.Equal(map[string]int64{
+		string(models.TeamDomainDeveloper): 2,
+		string(models.TeamDomainDevOps):    1,
+	}, counts)
+
+	count, err = suite.repo.CountByOrganization(uuid.New())
+	suite.Require().NoError(err)
+	suite.Zero(count)
+}
+
+// TestSearchByOrganizationEmailOnly tests emailOnly restricts the organization search to the email column
+func (suite *UserRepositoryDBTestSuite) TestSearchByOrganizationEmailOnly() {
+	org, team := suite.createTeamInOrganization("searched-org")
+	_, otherTeam := suite.createTeamInOrganization("other-org")
+
+	byEmail := suite.createMember(team.ID, "alice@test.com")
+	byName := suite.factories.User.WithTeam(team.ID)
+	byName.Email = "a.smith@test.com"
+	byName.FirstName = "Alice"
+	byName.LastName = "Smith"
+	suite.Require().NoError(suite.repo.Create(byName))
+	suite.createMember(otherTeam.ID, "alice.other@test.com")
+
+	members, total, err := suite.repo.SearchByOrganization(org.ID, "alice", true, 20, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Require().Len(members, 1)
+	suite.Equal(byEmail.ID, members[0].ID)
+
+	members, total, err = suite.repo.SearchByOrganization(org.ID, "ALICE", false, 20, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Require().Len(members, 2)
+	suite.ElementsMatch([]uuid.UUID{byEmail.ID, byName.ID}, []uuid.UUID{members[0].ID, members[1].ID})
+
+	members, total, err = suite.repo.Search(org.ID, "smith", 20, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Require().Len(members, 1)
+	suite.Equal(byName.ID, members[0].ID)
+}
+
+// TestSearchByNameOrTitleGlobalEmailOnly tests emailOnly restricts the global search to the email column
+// and that soft-deleted users stay excluded whichever column matches
+func (suite *UserRepositoryDBTestSuite) TestSearchByNameOrTitleGlobalEmailOnly() {
+	byEmail := suite.factories.User.WithEmail("carol@test.com")
+	suite.Require().NoError(suite.repo.Create(byEmail))
+	byTitle := suite.factories.User.WithEmail("c.jones@test.com")
+	byTitle.Title = "Carol Jones"
+	suite.Require().NoError(suite.repo.Create(byTitle))
+	deleted := suite.factories.User.WithEmail("deleted@test.com")
+	deleted.Name = "carol-deleted"
+	suite.Require().NoError(suite.repo.Create(deleted))
+	suite.Require().NoError(suite.repo.Delete(deleted.ID))
+
+	members, total, err := suite.repo.SearchByNameOrTitleGlobal("carol", true, 20, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Require().Len(members, 1)
+	suite.Equal(byEmail.ID, members[0].ID)
+
+	members, total, err = suite.repo.SearchByNameOrTitleGlobal("carol", false, 20, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Require().Len(members, 2)
+	suite.ElementsMatch([]uuid.UUID{byEmail.ID, byTitle.ID}, []uuid.UUID{members[0].ID, members[1].ID})
+}
+
+// TestUserRepositoryDBTestSuite runs the test suite
+func TestUserRepositoryDBTestSuite(t *testing.T) {
+	suite.Run(t, new(UserRepositoryDBTestSuite))
+}
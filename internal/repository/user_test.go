@@ -4,14 +4,16 @@
 package repository
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/testutils"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
-	"gorm.io/gorm"
 )
 
 // UserRepositoryTestSuite tests the UserRepository
@@ -130,7 +132,7 @@ func (suite *UserRepositoryTestSuite) TestGetByIDNotFound() {
 	member, err := suite.repo.GetByID(nonExistentID)
 
 	suite.Error(err)
-	suite.Equal(gorm.ErrRecordNotFound, err)
+	suite.Equal(apperrors.ErrUserNotFound, err)
 	suite.Nil(member)
 }
 
@@ -163,10 +165,66 @@ func (suite *UserRepositoryTestSuite) TestGetByEmailNotFound() {
 	member, err := suite.repo.GetByEmail("nonexistent@example.com")
 
 	suite.Error(err)
-	suite.Equal(gorm.ErrRecordNotFound, err)
+	suite.Equal(apperrors.ErrUserNotFound, err)
 	suite.Nil(member)
 }
 
+// TestLookupsNotFound tests that every single-member lookup translates a missing record into ErrUserNotFound
+func (suite *UserRepositoryTestSuite) TestLookupsNotFound() {
+	lookups := map[string]func() (*models.User, error){
+		"GetByName":               func() (*models.User, error) { return suite.repo.GetByName("nonexistent") },
+		"GetByUserID":             func() (*models.User, error) { return suite.repo.GetByUserID("I000000") },
+		"GetByIDIncludingDeleted": func() (*models.User, error) { return suite.repo.GetByIDIncludingDeleted(uuid.New()) },
+		"GetWithTeam":             func() (*models.User, error) { return suite.repo.GetWithTeam(uuid.New()) },
+	}
+
+	for name, lookup := range lookups {
+		suite.Run(name, func() {
+			member, err := lookup()
+			suite.ErrorIs(err, apperrors.ErrUserNotFound)
+			suite.Nil(member)
+		})
+	}
+}
+
+// TestGetAllAfter_InsertBetweenPages tests that keyset pages do not repeat rows when a member is inserted between fetches
+func (suite *UserRepositoryTestSuite) TestGetAllAfter_InsertBetweenPages() {
+	for i := 0; i < 3; i++ {
+		member := suite.factories.User.Create()
+		member.UserID = fmt.Sprintf("I90000%d", i)
+		member.Email = fmt.Sprintf("cursor%d@example.com", i)
+		suite.NoError(suite.repo.Create(member))
+	}
+
+	first, err := suite.repo.GetAllAfter(time.Time{}, uuid.Nil, 2)
+	suite.NoError(err)
+	suite.Len(first, 2)
+
+	inserted := suite.factories.User.Create()
+	inserted.UserID = "I900009"
+	inserted.Email = "cursor9@example.com"
+	suite.NoError(suite.repo.Create(inserted))
+
+	last := first[len(first)-1]
+	second, err := suite.repo.GetAllAfter(last.CreatedAt, last.ID, 10)
+	suite.NoError(err)
+
+	seen := make(map[uuid.UUID]bool)
+	for _, member := range append(first, second...) {
+		suite.False(seen[member.ID], "member %s returned twice", member.UserID)
+		seen[member.ID] = true
+	}
+	suite.Len(seen, 4)
+	suite.True(seen[inserted.ID])
+}
+
+// TestRestoreNotFound tests restoring a non-existent member
+func (suite *UserRepositoryTestSuite) TestRestoreNotFound() {
+	err := suite.repo.Restore(uuid.New())
+
+	suite.ErrorIs(err, apperrors.ErrUserNotFound)
+}
+
 // TestGetByOrganizationID tests listing members by organization
 func (suite *UserRepositoryTestSuite) TestGetByOrganizationID() {
 	// Create organization first
@@ -315,7 +373,7 @@ func (suite *UserRepositoryTestSuite) TestDelete() {
 	// Verify member is deleted
 	_, err = suite.repo.GetByID(member.ID)
 	suite.Error(err)
-	suite.Equal(gorm.ErrRecordNotFound, err)
+	suite.Equal(apperrors.ErrUserNotFound, err)
 }
 
 // TestDeleteNotFound tests deleting a non-existent member
@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -10,18 +11,22 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/cache"
 	"developer-portal-backend/internal/database/models"
 	"developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/logger"
 	"developer-portal-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 )
 
 // AICoreCredentials represents the credentials for a specific team
@@ -32,6 +37,8 @@ type AICoreCredentials struct {
 	OAuthURL      string `json:"oauthUrl"`
 	APIURL        string `json:"apiUrl"`
 	ResourceGroup string `json:"resourceGroup"`
+	// SystemPrompt is an optional prompt prepended to every chat of the team, e.g. a compliance disclaimer
+	SystemPrompt string `json:"systemPrompt,omitempty"`
 }
 
 // AICoreTokenResponse represents the OAuth token response
@@ -65,6 +72,7 @@ type AICoreDeployment struct {
 // AICoreTeamDeployments represents deployments for a specific team
 type AICoreTeamDeployments struct {
 	Team        string             `json:"team"`
+	Count       int                `json:"count"`
 	Deployments []AICoreDeployment `json:"deployments"`
 }
 
@@ -72,6 +80,8 @@ type AICoreTeamDeployments struct {
 type AICoreDeploymentsResponse struct {
 	Count       int                     `json:"count"`
 	Deployments []AICoreTeamDeployments `json:"deployments"`
+	// SkippedTeams lists the teams left out because their AI Core instance rate limited the request
+	SkippedTeams []string `json:"skippedTeams,omitempty"`
 }
 
 // AICoreModel represents a model from AI Core
@@ -108,6 +118,43 @@ type AICoreModelsResponse struct {
 	Resources []AICoreModel `json:"resources"`
 }
 
+// AICoreScenario represents a scenario from AI Core
+type AICoreScenario struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Labels      []map[string]interface{} `json:"labels,omitempty"`
+	CreatedAt   string                   `json:"createdAt"`
+	ModifiedAt  string                   `json:"modifiedAt"`
+}
+
+// AICoreScenariosResponse represents the response from AI Core scenarios API
+type AICoreScenariosResponse struct {
+	Count     int              `json:"count"`
+	Resources []AICoreScenario `json:"resources"`
+}
+
+// AICoreExecutable represents an executable of a scenario from AI Core
+type AICoreExecutable struct {
+	ID             string                   `json:"id"`
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description,omitempty"`
+	ScenarioID     string                   `json:"scenarioId"`
+	VersionID      string                   `json:"versionId,omitempty"`
+	Deployable     bool                     `json:"deployable"`
+	Parameters     []map[string]interface{} `json:"parameters,omitempty"`
+	InputArtifacts []map[string]interface{} `json:"inputArtifacts,omitempty"`
+	Labels         []map[string]interface{} `json:"labels,omitempty"`
+	CreatedAt      string                   `json:"createdAt"`
+	ModifiedAt     string                   `json:"modifiedAt"`
+}
+
+// AICoreExecutablesResponse represents the response from AI Core executables API
+type AICoreExecutablesResponse struct {
+	Count     int                `json:"count"`
+	Resources []AICoreExecutable `json:"resources"`
+}
+
 // AICoreConfigurationsResponse represents the response from AI Core configurations API
 type AICoreConfigurationsResponse struct {
 	Count     int                   `json:"count"`
@@ -153,6 +200,10 @@ type AICoreDeploymentRequest struct {
 	ConfigurationID      *string                     `json:"configurationId,omitempty"`
 	ConfigurationRequest *AICoreConfigurationRequest `json:"configurationRequest,omitempty"`
 	TTL                  string                      `json:"ttl,omitempty"`
+	// DryRun validates the configuration (creating it if configurationRequest is given) without creating the deployment
+	DryRun bool `json:"dryRun,omitempty"`
+	// IdempotencyKey makes retries safe: a repeated key within the same team returns the first result
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
 }
 
 // AICoreDeploymentResponse represents the response from creating a deployment
@@ -162,6 +213,9 @@ type AICoreDeploymentResponse struct {
 	DeploymentURL string `json:"deploymentUrl,omitempty"`
 	Status        string `json:"status,omitempty"`
 	TTL           string `json:"ttl,omitempty"`
+	// Set only for dry runs, which preview the deployment instead of creating it
+	ConfigurationID string `json:"configurationId,omitempty"`
+	DryRun          bool   `json:"dryRun,omitempty"`
 }
 
 // AICoreDeploymentModificationRequest represents a request to modify a deployment
@@ -183,6 +237,8 @@ type AICoreDeploymentModificationResponse struct {
 type AICoreDeploymentDeletionResponse struct {
 	ID      string `json:"id"`
 	Message string `json:"message"`
+	// ConfigurationError reports why the configuration of the deployment could not be deleted
+	ConfigurationError string `json:"configurationError,omitempty"`
 }
 
 // AICoreDeploymentDetailsResponse represents the detailed response for a specific deployment
@@ -210,33 +266,97 @@ type AICoreDeploymentDetailsResponse struct {
 
 // AICoreService handles AI Core operations
 type AICoreService struct {
-	userRepo        repository.UserRepositoryInterface
-	teamRepo        repository.TeamRepositoryInterface
-	groupRepo       repository.GroupRepositoryInterface
-	orgRepo         repository.OrganizationRepositoryInterface
-	httpClient      *http.Client
-	credentials     map[string]*AICoreCredentials // Cached credentials by team name
-	credentialsMux  sync.RWMutex                  // Protects credentials cache
-	tokenCache      map[string]*tokenCache        // Cached tokens by team name
-	tokenCacheMux   sync.RWMutex                  // Protects token cache
-	credentialsOnce sync.Once                     // Ensures credentials are loaded only once
-}
-
-/* NewAICoreService creates a new AI Core service */
-func NewAICoreService(userRepo repository.UserRepositoryInterface, teamRepo repository.TeamRepositoryInterface, groupRepo repository.GroupRepositoryInterface, orgRepo repository.OrganizationRepositoryInterface) AICoreServiceInterface {
+	userRepo      repository.UserRepositoryInterface
+	teamRepo      repository.TeamRepositoryInterface
+	groupRepo     repository.GroupRepositoryInterface
+	orgRepo       repository.OrganizationRepositoryInterface
+	httpClient    *http.Client
+	credentials   AICoreCredentialsProvider // Supplies credentials by team name
+	tokenCache    map[string]*tokenCache    // Cached tokens by team name
+	tokenCacheMux sync.RWMutex              // Protects token cache
+	tokenFlight   singleflight.Group        // Collapses concurrent token refreshes per team
+	deployFlight  singleflight.Group        // Collapses concurrent CreateDeployment calls per idempotency key
+	rateLimiter   *teamRateLimiter          // Throttles inference and deployment calls per team
+	uploadLimiter *teamRateLimiter          // Throttles attachment uploads per user
+	maxRetries    int                       // Retries for AI Core 429 responses
+	maxRetryDelay time.Duration             // Upper bound for a single Retry-After wait
+	throttleLimit int                       // Rate-limited responses tolerated per AI Core instance in a GetDeployments fan-out
+	cache         cache.CacheService        // Caches expanded team lists for GetMe
+	idempotency   cache.CacheService        // Deployments created per idempotency key; in-memory unless SetCache gives a real cache
+	ttlConfig     cache.TTLConfig
+	pollInterval  time.Duration                                   // Interval between WaitForDeploymentStatus polls
+	now           func() time.Time                                // Clock used for polling deadlines
+	sleep         func(c *gin.Context, delay time.Duration) error // Waits between polls
+	usageRecorder UsageRecorder                                   // Receives token usage of successful inferences; no-op by default
+	attachments   AttachmentStore                                 // Stores uploaded attachments; nil returns data URLs
+	attachPolicy  AttachmentPolicy                                // How HTML/SVG uploads are treated; permissive by default
+	promptLimits  promptLimits                                    // Pre-flight size guard for inference requests
+	ttlPolicy     deploymentTTLPolicy                             // Default and accepted range of deployment TTLs
+}
+
+/* NewAICoreService creates a new AI Core service; a nil credentialsProvider reads AI_CORE_CREDENTIALS */
+func NewAICoreService(userRepo repository.UserRepositoryInterface, teamRepo repository.TeamRepositoryInterface, groupRepo repository.GroupRepositoryInterface, orgRepo repository.OrganizationRepositoryInterface, credentialsProvider AICoreCredentialsProvider) AICoreServiceInterface {
+	if credentialsProvider == nil {
+		credentialsProvider = NewEnvCredentialsProvider()
+	}
 	return &AICoreService{
-		userRepo:    userRepo,
-		teamRepo:    teamRepo,
-		groupRepo:   groupRepo,
-		orgRepo:     orgRepo,
-		credentials: make(map[string]*AICoreCredentials),
-		tokenCache:  make(map[string]*tokenCache),
+		userRepo:      userRepo,
+		teamRepo:      teamRepo,
+		groupRepo:     groupRepo,
+		orgRepo:       orgRepo,
+		credentials:   credentialsProvider,
+		tokenCache:    make(map[string]*tokenCache),
+		rateLimiter:   newTeamRateLimiterFromEnv(),
+		uploadLimiter: newUploadRateLimiterFromEnv(),
+		maxRetries:    getAICoreMaxRetries(),
+		maxRetryDelay: getAICoreMaxRetryDelay(),
+		throttleLimit: getAICoreFanOutThrottleBudget(),
+		cache:         cache.NewNoOpCache(), // Default to no-op cache
+		idempotency:   cache.NewInMemoryCache(cache.DefaultCacheConfig()),
+		ttlConfig:     cache.DefaultTTLConfig(),
+		pollInterval:  defaultAICoreDeploymentPollInterval,
+		now:           time.Now,
+		sleep:         sleepWithContext,
+		usageRecorder: noopUsageRecorder{},
+		attachPolicy:  getAttachmentPolicy(),
+		promptLimits:  getPromptLimits(),
+		ttlPolicy:     getDeploymentTTLPolicy(),
 		httpClient: &http.Client{
 			Timeout: 120 * time.Second, // Increased timeout for AI inference requests (LLMs can take 30-60s)
 		},
 	}
 }
 
+// NewAICoreServiceWithCache creates a new AI Core service that caches GetMe team expansion
+func NewAICoreServiceWithCache(userRepo repository.UserRepositoryInterface, teamRepo repository.TeamRepositoryInterface, groupRepo repository.GroupRepositoryInterface, orgRepo repository.OrganizationRepositoryInterface, cacheService cache.CacheService, ttlConfig cache.TTLConfig) AICoreServiceInterface {
+	s := NewAICoreService(userRepo, teamRepo, groupRepo, orgRepo, nil).(*AICoreService)
+	s.SetCache(cacheService)
+	s.SetTTLConfig(ttlConfig)
+	return s
+}
+
+// SetCache sets the cache service (useful for testing or late initialization).
+// Idempotency keys move to it too, unless it is a no-op cache that would forget them.
+func (s *AICoreService) SetCache(cacheService cache.CacheService) {
+	if cacheService == nil {
+		cacheService = cache.NewNoOpCache()
+	}
+	s.cache = cacheService
+	if _, noop := cacheService.(*cache.NoOpCache); !noop {
+		s.idempotency = cacheService
+	}
+}
+
+// SetTTLConfig sets the TTL configuration
+func (s *AICoreService) SetTTLConfig(config cache.TTLConfig) {
+	s.ttlConfig = config
+}
+
+// InvalidateTeamExpansion drops the cached team list of a user, e.g. after team membership changes
+func (s *AICoreService) InvalidateTeamExpansion(username string) error {
+	return s.cache.Delete(cache.BuildKey(cache.KeyPrefixAICoreTeams, username))
+}
+
 // SetHTTPClient sets a custom HTTP client (useful for testing with shorter timeouts)
 func (s *AICoreService) SetHTTPClient(client *http.Client) {
 	s.httpClient = client
@@ -257,51 +377,43 @@ func (s *AICoreService) getTeamLimit() int {
 	return limit
 }
 
-// loadCredentials loads and caches all AI Core credentials from environment variable
-func (s *AICoreService) loadCredentials() error {
-	credentialsJSON := os.Getenv("AI_CORE_CREDENTIALS")
-	if credentialsJSON == "" {
-		return errors.ErrAICoreCredentialsNotSet
+// credentialsLoadError returns why the credentials source could not be loaded, if it reports one
+func (s *AICoreService) credentialsLoadError() error {
+	if provider, ok := s.credentials.(reloadableCredentialsProvider); ok {
+		return provider.Err()
 	}
-
-	var credentialsList []AICoreCredentials
-	if err := json.Unmarshal([]byte(credentialsJSON), &credentialsList); err != nil {
-		return fmt.Errorf("%w: %v", errors.ErrAICoreCredentialsInvalid, err)
-	}
-
-	s.credentialsMux.Lock()
-	defer s.credentialsMux.Unlock()
-
-	// Clear existing credentials and rebuild cache
-	s.credentials = make(map[string]*AICoreCredentials)
-	for i := range credentialsList {
-		cred := &credentialsList[i]
-		s.credentials[cred.Team] = cred
-	}
-
 	return nil
 }
 
-// getCredentialsForTeam retrieves AI Core credentials for a specific team from cache
+// getCredentialsForTeam retrieves AI Core credentials for a specific team from the credentials provider
 func (s *AICoreService) getCredentialsForTeam(teamName string) (*AICoreCredentials, error) {
-	// Load credentials once
-	var loadErr error
-	s.credentialsOnce.Do(func() {
-		loadErr = s.loadCredentials()
-	})
-	if loadErr != nil {
-		return nil, loadErr
+	cred, exists := s.credentials.GetForTeam(teamName)
+	if !exists {
+		if err := s.credentialsLoadError(); err != nil {
+			return nil, err
+		}
+		return nil, errors.NewAICoreCredentialsNotFoundError(teamName)
 	}
 
-	s.credentialsMux.RLock()
-	defer s.credentialsMux.RUnlock()
+	return cred, nil
+}
 
-	cred, exists := s.credentials[teamName]
+// CheckAICoreCredentials verifies a team's configured credentials by performing the OAuth token exchange.
+// The token cache is bypassed so that rotated or revoked secrets are detected.
+func (s *AICoreService) CheckAICoreCredentials(team string) error {
+	credentials, exists := s.credentials.GetForTeam(team)
 	if !exists {
-		return nil, errors.NewAICoreCredentialsNotFoundError(teamName)
+		if err := s.credentialsLoadError(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: no entry for team %s", errors.ErrAICoreCredentialsNotSet, team)
 	}
 
-	return cred, nil
+	if _, _, err := s.requestNewToken(context.Background(), credentials); err != nil {
+		return fmt.Errorf("AI Core authentication failed for team %s: %w", team, err)
+	}
+
+	return nil
 }
 
 // getUserTeam retrieves the team name for the authenticated user
@@ -353,47 +465,71 @@ func (s *AICoreService) getUserTeam(c *gin.Context) (string, error) {
 }
 
 // getAccessToken retrieves an access token for AI Core API with caching
-func (s *AICoreService) getAccessToken(credentials *AICoreCredentials) (string, error) {
+func (s *AICoreService) getAccessToken(ctx context.Context, credentials *AICoreCredentials) (string, error) {
 	teamName := credentials.Team
 
 	// Check cache first
-	s.tokenCacheMux.RLock()
-	if cached, exists := s.tokenCache[teamName]; exists {
-		if time.Now().Before(cached.expiresAt) {
-			s.tokenCacheMux.RUnlock()
-			return cached.token, nil
-		}
+	if token, ok := s.cachedToken(teamName); ok {
+		return token, nil
 	}
-	s.tokenCacheMux.RUnlock()
 
-	// Token not cached or expired, get new token
-	token, expiresIn, err := s.requestNewToken(credentials)
-	if err != nil {
-		return "", err
-	}
+	// Token not cached or expired; concurrent callers for the team share a single refresh.
+	// The refresh outlives a cancelled caller so the remaining waiters still get the token.
+	refresh := s.tokenFlight.DoChan(teamName, func() (interface{}, error) {
+		// A refresh that finished while this caller was queued has already cached a token
+		if token, ok := s.cachedToken(teamName); ok {
+			return token, nil
+		}
+
+		token, expiresIn, err := s.requestNewToken(context.WithoutCancel(ctx), credentials)
+		if err != nil {
+			return "", err
+		}
+
+		// Cache the token with a buffer (expire 5 minutes early to be safe)
+		expiresAt := time.Now().Add(time.Duration(expiresIn-300) * time.Second)
 
-	// Cache the token with a buffer (expire 5 minutes early to be safe)
-	expiresAt := time.Now().Add(time.Duration(expiresIn-300) * time.Second)
+		s.tokenCacheMux.Lock()
+		s.tokenCache[teamName] = &tokenCache{
+			token:     token,
+			expiresAt: expiresAt,
+		}
+		s.tokenCacheMux.Unlock()
 
-	s.tokenCacheMux.Lock()
-	s.tokenCache[teamName] = &tokenCache{
-		token:     token,
-		expiresAt: expiresAt,
+		return token, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-refresh:
+		if result.Err != nil {
+			return "", result.Err
+		}
+		return result.Val.(string), nil
 	}
-	s.tokenCacheMux.Unlock()
+}
+
+// cachedToken returns the team's cached access token if it has not expired
+func (s *AICoreService) cachedToken(teamName string) (string, bool) {
+	s.tokenCacheMux.RLock()
+	defer s.tokenCacheMux.RUnlock()
 
-	return token, nil
+	if cached, exists := s.tokenCache[teamName]; exists && time.Now().Before(cached.expiresAt) {
+		return cached.token, true
+	}
+	return "", false
 }
 
 // requestNewToken requests a new access token from the OAuth endpoint
-func (s *AICoreService) requestNewToken(credentials *AICoreCredentials) (string, int, error) {
+func (s *AICoreService) requestNewToken(ctx context.Context, credentials *AICoreCredentials) (string, int, error) {
 	// Use proper form encoding instead of string concatenation for security
 	data := url.Values{}
 	data.Set("grant_type", "client_credentials")
 	data.Set("client_id", credentials.ClientID)
 	data.Set("client_secret", credentials.ClientSecret)
 
-	req, err := http.NewRequest("POST", credentials.OAuthURL, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", credentials.OAuthURL, strings.NewReader(data.Encode()))
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to create token request: %w", err)
 	}
@@ -419,8 +555,26 @@ func (s *AICoreService) requestNewToken(credentials *AICoreCredentials) (string,
 	return tokenResp.AccessToken, tokenResp.ExpiresIn, nil
 }
 
-// makeAICoreRequest makes an authenticated request to AI Core API
-func (s *AICoreService) makeAICoreRequest(method, url, accessToken, resourceGroup string, body interface{}) (*http.Response, error) {
+// requestContext returns the context of the incoming request so that a client disconnect
+// cancels outbound AI Core calls; contexts without a request fall back to context.Background
+func requestContext(c *gin.Context) context.Context {
+	if c == nil || c.Request == nil {
+		return context.Background()
+	}
+	return c.Request.Context()
+}
+
+// detachedContext copies c for work shared with other requests, keeping its values but not its cancellation
+func detachedContext(c *gin.Context) *gin.Context {
+	detached := c.Copy()
+	if detached.Request != nil {
+		detached.Request = detached.Request.WithContext(context.WithoutCancel(detached.Request.Context()))
+	}
+	return detached
+}
+
+// makeAICoreRequest makes an authenticated request to AI Core API, aborting when ctx is cancelled
+func (s *AICoreService) makeAICoreRequest(ctx context.Context, method, url, accessToken, resourceGroup string, body interface{}) (*http.Response, error) {
 	var reqBody io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -430,7 +584,7 @@ func (s *AICoreService) makeAICoreRequest(method, url, accessToken, resourceGrou
 		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -442,8 +596,89 @@ func (s *AICoreService) makeAICoreRequest(method, url, accessToken, resourceGrou
 	return s.httpClient.Do(req)
 }
 
+// newAICoreAPIError builds an AICoreAPIError from a non-success AI Core response body.
+// AI Core usually returns {"error": {"code": "...", "message": "..."}}, but plain
+// {"error": "..."} and {"message": "..."} bodies are handled as well.
+func newAICoreAPIError(teamName string, statusCode int, body []byte) error {
+	message := strings.TrimSpace(string(body))
+
+	var parsed struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		var nested struct {
+			Message string `json:"message"`
+		}
+		var plain string
+		switch {
+		case len(parsed.Error) > 0 && json.Unmarshal(parsed.Error, &nested) == nil && nested.Message != "":
+			message = nested.Message
+		case len(parsed.Error) > 0 && json.Unmarshal(parsed.Error, &plain) == nil && plain != "":
+			message = plain
+		case parsed.Message != "":
+			message = parsed.Message
+		}
+	}
+
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+
+	return &errors.AICoreAPIError{
+		StatusCode: statusCode,
+		Message:    message,
+		Team:       teamName,
+	}
+}
+
 // GetDeployments retrieves deployments from AI Core based on user's role
-func (s *AICoreService) GetDeployments(c *gin.Context) (*AICoreDeploymentsResponse, error) {
+func (s *AICoreService) GetDeployments(c *gin.Context) (_ *AICoreDeploymentsResponse, err error) {
+	log, endCall := s.startCall(c, "GetDeployments")
+	defer func() { endCall(err) }()
+
+	return s.listDeployments(c, log, AICoreDeploymentFilter{})
+}
+
+// AICoreDeploymentFilter narrows a deployment listing; empty fields do not filter
+type AICoreDeploymentFilter struct {
+	Statuses      []string // Case-insensitive; a single status is also filtered by AI Core, which accepts only one
+	ExecutableIDs []string // Filtered by AI Core
+}
+
+// queryString renders the filters AI Core can apply server-side as a query string, "" when there are none
+func (f AICoreDeploymentFilter) queryString() string {
+	params := make([]string, 0, 2)
+	if len(f.Statuses) == 1 && strings.TrimSpace(f.Statuses[0]) != "" {
+		params = append(params, "status="+url.QueryEscape(strings.ToUpper(strings.TrimSpace(f.Statuses[0]))))
+	}
+	executableIDs := make([]string, 0, len(f.ExecutableIDs))
+	for _, id := range f.ExecutableIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			executableIDs = append(executableIDs, url.QueryEscape(id))
+		}
+	}
+	if len(executableIDs) > 0 {
+		params = append(params, "executableIds="+strings.Join(executableIDs, ","))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + strings.Join(params, "&")
+}
+
+// GetDeploymentsByStatus retrieves deployments like GetDeployments, keeping only those matching filter and
+// forwarding the filters AI Core supports as query parameters. Counts reflect the filtered deployments; an empty
+// filter returns everything.
+func (s *AICoreService) GetDeploymentsByStatus(c *gin.Context, filter AICoreDeploymentFilter) (_ *AICoreDeploymentsResponse, err error) {
+	log, endCall := s.startCall(c, "GetDeploymentsByStatus")
+	defer func() { endCall(err) }()
+
+	return s.listDeployments(c, log, filter)
+}
+
+// listDeployments aggregates the deployments of every team the user may access, optionally filtered
+func (s *AICoreService) listDeployments(c *gin.Context, log *logger.Logger, filter AICoreDeploymentFilter) (*AICoreDeploymentsResponse, error) {
 	// Get user email from auth context
 	email, exists := auth.GetUserEmail(c)
 	if !exists {
@@ -458,6 +693,7 @@ func (s *AICoreService) GetDeployments(c *gin.Context) (*AICoreDeploymentsRespon
 		}
 		return nil, fmt.Errorf("failed to get user from database: %w", err)
 	}
+	log.Debugf("AI Core user resolved: %s", member.Name)
 
 	// Determine user role and get appropriate teams (including metadata-based teams)
 	teamNames, err := s.getAllTeamsForUser(member)
@@ -469,6 +705,12 @@ func (s *AICoreService) GetDeployments(c *gin.Context) (*AICoreDeploymentsRespon
 	teamDeployments := make([]AICoreTeamDeployments, 0)
 	totalCount := 0
 
+	// Every team is listed with a single attempt. Rate-limited responses draw from a budget per AI Core
+	// instance; once it runs out the instance is treated as degraded and its remaining teams are skipped,
+	// while teams on other instances are still listed
+	budgets := make(map[string]*throttleBudget)
+	skippedTeams := make([]string, 0)
+
 	for _, teamName := range teamNames {
 		// Get credentials for the team
 		credentials, err := s.getCredentialsForTeam(teamName)
@@ -477,17 +719,31 @@ func (s *AICoreService) GetDeployments(c *gin.Context) (*AICoreDeploymentsRespon
 			continue
 		}
 
+		budget, ok := budgets[credentials.APIURL]
+		if !ok {
+			budget = newThrottleBudget(s.throttleLimit)
+			budgets[credentials.APIURL] = budget
+		}
+		if budget.isExhausted() {
+			skippedTeams = append(skippedTeams, teamName)
+			continue
+		}
+
 		// Get access token
-		accessToken, err := s.getAccessToken(credentials)
+		accessToken, err := s.getAccessToken(requestContext(c), credentials)
 		if err != nil {
 			// Skip teams with token issues instead of failing
 			continue
 		}
 
 		// Make request to AI Core
-		url := fmt.Sprintf("%s/v2/lm/deployments", credentials.APIURL)
-		resp, err := s.makeAICoreRequest("GET", url, accessToken, credentials.ResourceGroup, nil)
+		url := fmt.Sprintf("%s/v2/lm/deployments%s", credentials.APIURL, filter.queryString())
+		resp, err := s.makeAICoreRequest(requestContext(c), "GET", url, accessToken, credentials.ResourceGroup, nil)
 		if err != nil {
+			// A cancelled request aborts the whole listing
+			if ctxErr := requestContext(c).Err(); ctxErr != nil {
+				return nil, fmt.Errorf("failed to get deployments: %w", ctxErr)
+			}
 			// Skip teams with API issues instead of failing
 			continue
 		}
@@ -495,6 +751,15 @@ func (s *AICoreService) GetDeployments(c *gin.Context) (*AICoreDeploymentsRespon
 		// Ensure response body is always closed
 		defer resp.Body.Close()
 
+		if resp.StatusCode == http.StatusTooManyRequests {
+			budget.take()
+			if budget.isExhausted() {
+				log.WithField("api_url", credentials.APIURL).Warn("AI Core instance rate limited the listing, skipping its remaining teams")
+			}
+			skippedTeams = append(skippedTeams, teamName)
+			continue
+		}
+
 		if resp.StatusCode == http.StatusOK {
 			// We need to decode into a temporary structure that matches AI Core's actual response
 			var tempResp struct {
@@ -502,24 +767,46 @@ func (s *AICoreService) GetDeployments(c *gin.Context) (*AICoreDeploymentsRespon
 				Resources []AICoreDeployment `json:"resources"`
 			}
 			if err := json.NewDecoder(resp.Body).Decode(&tempResp); err == nil {
+				deployments, count := tempResp.Resources, tempResp.Count
+				if len(filter.Statuses) > 0 {
+					deployments = filterDeploymentsByStatus(deployments, filter.Statuses)
+					count = len(deployments)
+				}
+
 				// Create team deployment entry
 				teamDeployment := AICoreTeamDeployments{
 					Team:        teamName,
-					Deployments: tempResp.Resources,
+					Count:       count,
+					Deployments: deployments,
 				}
 				teamDeployments = append(teamDeployments, teamDeployment)
-				totalCount += tempResp.Count
+				totalCount += count
 			}
 		}
 	}
 
 	// Return aggregated response with new structure
 	return &AICoreDeploymentsResponse{
-		Count:       totalCount,
-		Deployments: teamDeployments,
+		Count:        totalCount,
+		Deployments:  teamDeployments,
+		SkippedTeams: skippedTeams,
 	}, nil
 }
 
+// filterDeploymentsByStatus keeps the deployments whose status matches one of statuses, ignoring case
+func filterDeploymentsByStatus(deployments []AICoreDeployment, statuses []string) []AICoreDeployment {
+	filtered := make([]AICoreDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		for _, status := range statuses {
+			if strings.EqualFold(deployment.Status, strings.TrimSpace(status)) {
+				filtered = append(filtered, deployment)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 // getTeamsForUser determines which teams a user should see deployments for based on their role
 func (s *AICoreService) getTeamsForUser(member *models.User) ([]string, error) {
 	var teamNames []string
@@ -554,32 +841,28 @@ func (s *AICoreService) getAICoreTeamsFromMetadata(member *models.User) []string
 		return teamNames
 	}
 
-	aiInstances, exists := metadata["ai_instances"]
-	if !exists {
-		return teamNames
-	}
+	return parseAIInstances(metadata["ai_instances"])
+}
+
+// parseAIInstances reads a metadata.ai_instances value defensively.
+// Only []string and []interface{} are accepted; non-string and empty entries are skipped
+// and any other shape yields an empty list.
+func parseAIInstances(value interface{}) []string {
+	teamNames := make([]string, 0)
 
-	// Handle different possible types for ai_instances
-	switch v := aiInstances.(type) {
+	switch v := value.(type) {
 	case []interface{}:
-		// Array of team names
-		for _, teamNameInterface := range v {
-			if teamName, ok := teamNameInterface.(string); ok && teamName != "" {
+		for _, item := range v {
+			if teamName, ok := item.(string); ok && teamName != "" {
 				teamNames = append(teamNames, teamName)
 			}
 		}
 	case []string:
-		// Array of strings (direct type)
 		for _, teamName := range v {
 			if teamName != "" {
 				teamNames = append(teamNames, teamName)
 			}
 		}
-	case string:
-		// Single team name as string
-		if v != "" {
-			teamNames = append(teamNames, v)
-		}
 	}
 
 	return teamNames
@@ -622,7 +905,10 @@ func (s *AICoreService) getAllTeamsForUser(member *models.User) ([]string, error
 }
 
 // GetMe resolves AI instances for the authenticated user based on role and metadata
-func (s *AICoreService) GetMe(c *gin.Context) (*AICoreMeResponse, error) {
+func (s *AICoreService) GetMe(c *gin.Context) (_ *AICoreMeResponse, err error) {
+	log, endCall := s.startCall(c, "GetMe")
+	defer func() { endCall(err) }()
+
 	// Get username from auth context
 	username, exists := auth.GetUsername(c)
 	if !exists || username == "" {
@@ -637,7 +923,31 @@ func (s *AICoreService) GetMe(c *gin.Context) (*AICoreMeResponse, error) {
 		}
 		return nil, fmt.Errorf("failed to get user by name: %w", err)
 	}
+	log.Debugf("AI Core user resolved: %s", member.Name)
+
+	return s.resolveMe(username, member)
+}
+
+// GetMeByEmail resolves AI instances like GetMe for a user identified by email
+func (s *AICoreService) GetMeByEmail(email string) (*AICoreMeResponse, error) {
+	if email == "" {
+		return nil, errors.ErrUserEmailNotFound
+	}
+
+	member, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ErrUserNotFoundInDB
+		}
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
 
+	// Group and organization ownership is recorded by username
+	return s.resolveMe(member.Name, member)
+}
+
+// resolveMe aggregates the AI instances for member, matching group/org ownership against username
+func (s *AICoreService) resolveMe(username string, member *models.User) (*AICoreMeResponse, error) {
 	// Prepare ai_instances with optional initialization from metadata.ai_instances
 	aiInstances := make([]string, 0)
 	seen := make(map[string]bool)
@@ -653,98 +963,138 @@ func (s *AICoreService) GetMe(c *gin.Context) (*AICoreMeResponse, error) {
 	}
 
 	// Prepare metadata instances (will be added after filtering)
-	metaInstances := make([]string, 0)
-	// Merge from metadata.ai_instances if present
-	if member.Metadata != nil {
-		var metadata map[string]interface{}
-		if err := json.Unmarshal(member.Metadata, &metadata); err == nil {
-			if v, ok := metadata["ai_instances"]; ok {
-				switch t := v.(type) {
-				case []interface{}:
-					for _, it := range t {
-						if sname, ok := it.(string); ok && sname != "" {
-							metaInstances = append(metaInstances, sname)
-						}
-					}
-				case []string:
-					for _, sname := range t {
-						if sname != "" {
-							metaInstances = append(metaInstances, sname)
-						}
-					}
-				case string:
-					if t != "" {
-						metaInstances = append(metaInstances, t)
-					}
-				}
-			}
+	metaInstances := s.getAICoreTeamsFromMetadata(member)
+
+	// Role-based teams filtered by credentials, cached per user to avoid repeated group/org expansion
+	cacheKey := cache.BuildKey(cache.KeyPrefixAICoreTeams, username)
+	wrapper := cache.NewCacheWrapper[[]string](s.cache)
+	// A failed expansion is returned rather than cached, so a partial team list does not stick for the TTL
+	teams, err := wrapper.GetOrFetch(cacheKey, s.ttlConfig.AICoreTeams, func() ([]string, error) {
+		return s.expandTeams(username, member)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand teams: %w", err)
+	}
+	for _, name := range teams {
+		add(name)
+	}
+
+	// Add metadata instances (union after filtering)
+	for _, name := range metaInstances {
+		add(name)
+	}
+
+	// Return a stable, alphabetical order regardless of discovery order
+	sort.Strings(aiInstances)
+
+	return &AICoreMeResponse{
+		User:        username,
+		AIInstances: aiInstances,
+	}, nil
+}
+
+// allTeamsInGroup pages through GetByGroupID until the reported total is covered
+func (s *AICoreService) allTeamsInGroup(groupID uuid.UUID) ([]models.Team, error) {
+	limit := s.getTeamLimit()
+	var all []models.Team
+	for offset := 0; ; offset += limit {
+		teams, total, err := s.teamRepo.GetByGroupID(groupID, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get teams of group %s: %w", groupID, err)
+		}
+		all = append(all, teams...)
+		if len(teams) == 0 || int64(offset+len(teams)) >= total {
+			return all, nil
 		}
 	}
+}
 
-	// Role-based aggregation
-	switch member.TeamRole {
-	case models.TeamRoleManager:
-		// Find the group where this manager is the owner
-		var targetGroup *models.Group
+// allGroupsInOrganization pages through GetByOrganizationID until the reported total is covered
+func (s *AICoreService) allGroupsInOrganization(orgID uuid.UUID) ([]models.Group, error) {
+	limit := s.getTeamLimit()
+	var all []models.Group
+	for offset := 0; ; offset += limit {
+		groups, total, err := s.groupRepo.GetByOrganizationID(orgID, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get groups of organization %s: %w", orgID, err)
+		}
+		all = append(all, groups...)
+		if len(groups) == 0 || int64(offset+len(groups)) >= total {
+			return all, nil
+		}
+	}
+}
 
-		// Try via user's team -> group, and verify ownership
-		if member.TeamID != nil {
-			if team, err := s.teamRepo.GetByID(*member.TeamID); err == nil {
-				if grp, err := s.groupRepo.GetByID(team.GroupID); err == nil {
-					if grp.Owner == username {
-						targetGroup = grp
-					} else {
-						// Search groups within the same org for ownership match
-						if groups, _, err := s.groupRepo.GetByOrganizationID(grp.OrgID, s.getTeamLimit(), 0); err == nil {
-							for i := range groups {
-								if groups[i].Owner == username {
-									g := groups[i]
-									targetGroup = &g
-									break
-								}
-							}
-						}
-					}
-				}
+// findOwnedOrganization pages through all organizations for the first one owned by username; nil when none is
+func (s *AICoreService) findOwnedOrganization(username string) (*models.Organization, error) {
+	limit := s.getTeamLimit()
+	for offset := 0; ; offset += limit {
+		orgs, total, err := s.orgRepo.GetAll(limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get organizations: %w", err)
+		}
+		for i := range orgs {
+			if orgs[i].Owner == username {
+				return &orgs[i], nil
 			}
 		}
+		if len(orgs) == 0 || int64(offset+len(orgs)) >= total {
+			return nil, nil
+		}
+	}
+}
 
-		// As a fallback, scan all orgs for a group owned by user
-		if targetGroup == nil && s.orgRepo != nil {
-			if orgs, _, err := s.orgRepo.GetAll(1000, 0); err == nil {
-				for _, org := range orgs {
-					if groups, _, err := s.groupRepo.GetByOrganizationID(org.ID, s.getTeamLimit(), 0); err == nil {
-						for i := range groups {
-							if groups[i].Owner == username {
-								g := groups[i]
-								targetGroup = &g
-								break
-							}
-						}
-					}
-					if targetGroup != nil {
-						break
-					}
-				}
-			}
+// allTeamsInGroups lists the teams of every group in turn
+func (s *AICoreService) allTeamsInGroups(groups []models.Group) ([]models.Team, error) {
+	var all []models.Team
+	for _, g := range groups {
+		teams, err := s.allTeamsInGroup(g.ID)
+		if err != nil {
+			return nil, err
 		}
+		all = append(all, teams...)
+	}
+	return all, nil
+}
+
+// expandTeams collects the team names a user may access based on role, filtered by AI_CORE_CREDENTIALS.
+// It fails rather than return a partial list when a group or organization page cannot be read.
+func (s *AICoreService) expandTeams(username string, member *models.User) ([]string, error) {
+	aiInstances := make([]string, 0)
+	seen := make(map[string]bool)
+
+	add := func(name string) {
+		if name == "" {
+			return
+		}
+		if !seen[name] {
+			aiInstances = append(aiInstances, name)
+			seen[name] = true
+		}
+	}
 
-		// Final fallback: use user's current team's group if available
-		if targetGroup == nil && member.TeamID != nil {
+	// Role-based aggregation
+	switch member.TeamRole {
+	case models.TeamRoleManager:
+		// A manager may own several groups, possibly across organizations
+		targetGroups, _ := s.groupRepo.GetByOwner(username)
+
+		// Fallback: use user's current team's group if the manager owns none
+		if len(targetGroups) == 0 && member.TeamID != nil {
 			if team, err := s.teamRepo.GetByID(*member.TeamID); err == nil {
 				if grp, err := s.groupRepo.GetByID(team.GroupID); err == nil {
-					targetGroup = grp
+					targetGroups = []models.Group{*grp}
 				}
 			}
 		}
 
-		// Collect all team names in the target group
-		if targetGroup != nil {
-			if teams, _, err := s.teamRepo.GetByGroupID(targetGroup.ID, s.getTeamLimit(), 0); err == nil {
-				for _, t := range teams {
-					add(t.Name)
-				}
-			}
+		// Collect all team names in the target groups
+		teams, err := s.allTeamsInGroups(targetGroups)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range teams {
+			add(t.Name)
 		}
 	case models.TeamRoleMMM:
 		// Find the organization where this MMM is the owner
@@ -765,27 +1115,25 @@ func (s *AICoreService) GetMe(c *gin.Context) (*AICoreMeResponse, error) {
 
 		// Fallback: scan all organizations for ownership match
 		if targetOrg == nil && s.orgRepo != nil {
-			if orgs, _, err := s.orgRepo.GetAll(1000, 0); err == nil {
-				for i := range orgs {
-					if orgs[i].Owner == username {
-						o := orgs[i]
-						targetOrg = &o
-						break
-					}
-				}
+			org, err := s.findOwnedOrganization(username)
+			if err != nil {
+				return nil, err
 			}
+			targetOrg = org
 		}
 
 		// Collect all team names across all groups in the target org
 		if targetOrg != nil {
-			if groups, _, err := s.groupRepo.GetByOrganizationID(targetOrg.ID, s.getTeamLimit(), 0); err == nil {
-				for _, g := range groups {
-					if teams, _, err := s.teamRepo.GetByGroupID(g.ID, s.getTeamLimit(), 0); err == nil {
-						for _, t := range teams {
-							add(t.Name)
-						}
-					}
-				}
+			groups, err := s.allGroupsInOrganization(targetOrg.ID)
+			if err != nil {
+				return nil, err
+			}
+			teams, err := s.allTeamsInGroups(groups)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range teams {
+				add(t.Name)
 			}
 		}
 	default:
@@ -803,20 +1151,18 @@ func (s *AICoreService) GetMe(c *gin.Context) (*AICoreMeResponse, error) {
 		log.WithField("ai_instances", aiInstances).Info("AI Core: initial discovered ai_instances")
 	}
 
-	// Filter discovered instances by teams present in AI_CORE_CREDENTIALS
+	// Filter discovered instances by teams that have credentials configured
 	filtered := make([]string, 0)
-	envTeams := make(map[string]bool)
 
-	if err := s.loadCredentials(); err == nil {
-		// Build env team set from credentials cache
-		s.credentialsMux.RLock()
-		for teamName := range s.credentials {
-			envTeams[teamName] = true
-		}
-		s.credentialsMux.RUnlock()
+	// Re-read reloadable sources (AI_CORE_CREDENTIALS) so rotations are picked up
+	var loadErr error
+	if provider, ok := s.credentials.(reloadableCredentialsProvider); ok {
+		loadErr = provider.Load()
+	}
 
+	if loadErr == nil {
 		for _, name := range aiInstances {
-			if envTeams[name] {
+			if _, ok := s.credentials.GetForTeam(name); ok {
 				filtered = append(filtered, name)
 			}
 		}
@@ -841,15 +1187,7 @@ func (s *AICoreService) GetMe(c *gin.Context) (*AICoreMeResponse, error) {
 		}
 	}
 
-	// Add metadata instances (union after filtering)
-	for _, name := range metaInstances {
-		add(name)
-	}
-
-	return &AICoreMeResponse{
-		User:        username,
-		AIInstances: aiInstances,
-	}, nil
+	return aiInstances, nil
 }
 
 // GetModels retrieves models from AI for the user's team
@@ -875,7 +1213,7 @@ func (s *AICoreService) GetModels(c *gin.Context, scenarioID string) (*AICoreMod
 	}
 
 	// Get access token
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		log.WithField("team_name", teamName).Errorf("AI Core: Failed to get access token: %v", err)
 		return nil, err
@@ -883,7 +1221,7 @@ func (s *AICoreService) GetModels(c *gin.Context, scenarioID string) (*AICoreMod
 
 	// Make request to AI Core
 	url := fmt.Sprintf("%s/v2/lm/scenarios/%s/models", credentials.APIURL, scenarioID)
-	resp, err := s.makeAICoreRequest("GET", url, accessToken, credentials.ResourceGroup, nil)
+	resp, err := s.makeAICoreRequest(requestContext(c), "GET", url, accessToken, credentials.ResourceGroup, nil)
 	if err != nil {
 		log.WithField("team_name", teamName).Errorf("AI Core: API request failed: %v", err)
 		return nil, err
@@ -897,7 +1235,7 @@ func (s *AICoreService) GetModels(c *gin.Context, scenarioID string) (*AICoreMod
 			"status_code": resp.StatusCode,
 			"response":    string(body),
 		}).Error("AI Core: AI Core API returned error")
-		return nil, fmt.Errorf("%w with status %d: %s", errors.ErrAICoreAPIRequestFailed, resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
 	}
 
 	var modelsResp AICoreModelsResponse
@@ -909,6 +1247,117 @@ func (s *AICoreService) GetModels(c *gin.Context, scenarioID string) (*AICoreMod
 	return &modelsResp, nil
 }
 
+// GetScenarios retrieves the scenarios available in AI Core for the user's team
+func (s *AICoreService) GetScenarios(c *gin.Context) (*AICoreScenariosResponse, error) {
+	// Get user email for logging context
+	email, _ := auth.GetUserEmail(c)
+	log := logger.New().WithField("user_email", email)
+
+	// Get user's team
+	teamName, err := s.getUserTeam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get credentials for the team
+	credentials, err := s.getCredentialsForTeam(teamName)
+	if err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: Failed to get credentials: %v", err)
+		return nil, err
+	}
+
+	// Get access token
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
+	if err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: Failed to get access token: %v", err)
+		return nil, err
+	}
+
+	// Make request to AI Core
+	url := fmt.Sprintf("%s/v2/lm/scenarios", credentials.APIURL)
+	resp, err := s.makeAICoreRequest(requestContext(c), "GET", url, accessToken, credentials.ResourceGroup, nil)
+	if err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: API request failed: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		log.WithFields(map[string]interface{}{
+			"team_name":   teamName,
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("AI Core: AI Core API returned error")
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
+	}
+
+	var scenariosResp AICoreScenariosResponse
+	if err := json.NewDecoder(resp.Body).Decode(&scenariosResp); err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: Failed to decode response: %v", err)
+		return nil, fmt.Errorf("failed to decode scenarios response: %w", err)
+	}
+
+	return &scenariosResp, nil
+}
+
+// GetExecutables retrieves the executables of a scenario from AI Core for the user's team
+func (s *AICoreService) GetExecutables(c *gin.Context, scenarioID string) (*AICoreExecutablesResponse, error) {
+	// Get user email for logging context
+	email, _ := auth.GetUserEmail(c)
+	log := logger.New().WithFields(map[string]interface{}{
+		"user_email":  email,
+		"scenario_id": scenarioID,
+	})
+
+	// Get user's team
+	teamName, err := s.getUserTeam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get credentials for the team
+	credentials, err := s.getCredentialsForTeam(teamName)
+	if err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: Failed to get credentials: %v", err)
+		return nil, err
+	}
+
+	// Get access token
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
+	if err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: Failed to get access token: %v", err)
+		return nil, err
+	}
+
+	// Make request to AI Core
+	url := fmt.Sprintf("%s/v2/lm/scenarios/%s/executables", credentials.APIURL, scenarioID)
+	resp, err := s.makeAICoreRequest(requestContext(c), "GET", url, accessToken, credentials.ResourceGroup, nil)
+	if err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: API request failed: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		log.WithFields(map[string]interface{}{
+			"team_name":   teamName,
+			"status_code": resp.StatusCode,
+			"response":    string(body),
+		}).Error("AI Core: AI Core API returned error")
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
+	}
+
+	var executablesResp AICoreExecutablesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&executablesResp); err != nil {
+		log.WithField("team_name", teamName).Errorf("AI Core: Failed to decode response: %v", err)
+		return nil, fmt.Errorf("failed to decode executables response: %w", err)
+	}
+
+	return &executablesResp, nil
+}
+
 // GetConfigurations retrieves configurations from AI Core for the user's team
 func (s *AICoreService) GetConfigurations(c *gin.Context) (*AICoreConfigurationsResponse, error) {
 	// Get user's team
@@ -924,30 +1373,75 @@ func (s *AICoreService) GetConfigurations(c *gin.Context) (*AICoreConfigurations
 	}
 
 	// Get access token
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make request to AI Core
+	url := fmt.Sprintf("%s/v2/lm/configurations", credentials.APIURL)
+	resp, err := s.makeAICoreRequest(requestContext(c), "GET", url, accessToken, credentials.ResourceGroup, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
+	}
+
+	var configurationsResp AICoreConfigurationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&configurationsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode configurations response: %w", err)
+	}
+
+	return &configurationsResp, nil
+}
+
+// GetConfigurationDetails retrieves a single configuration, including its parameter bindings, from AI Core
+func (s *AICoreService) GetConfigurationDetails(c *gin.Context, configurationID string) (*AICoreConfiguration, error) {
+	// Get user's team
+	teamName, err := s.getUserTeam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get credentials for the team
+	credentials, err := s.getCredentialsForTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get access token
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return nil, err
 	}
 
 	// Make request to AI Core
-	url := fmt.Sprintf("%s/v2/lm/configurations", credentials.APIURL)
-	resp, err := s.makeAICoreRequest("GET", url, accessToken, credentials.ResourceGroup, nil)
+	url := fmt.Sprintf("%s/v2/lm/configurations/%s", credentials.APIURL, configurationID)
+	resp, err := s.makeAICoreRequest(requestContext(c), "GET", url, accessToken, credentials.ResourceGroup, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.ErrAICoreConfigurationNotFound
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w with status %d: %s", errors.ErrAICoreAPIRequestFailed, resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
 	}
 
-	var configurationsResp AICoreConfigurationsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&configurationsResp); err != nil {
-		return nil, fmt.Errorf("failed to decode configurations response: %w", err)
+	var configuration AICoreConfiguration
+	if err := json.NewDecoder(resp.Body).Decode(&configuration); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration response: %w", err)
 	}
 
-	return &configurationsResp, nil
+	return &configuration, nil
 }
 
 // CreateConfiguration creates a new configuration in AI Core
@@ -965,14 +1459,14 @@ func (s *AICoreService) CreateConfiguration(c *gin.Context, req *AICoreConfigura
 	}
 
 	// Get access token
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return nil, err
 	}
 
 	// Make request to AI Core
 	url := fmt.Sprintf("%s/v2/lm/configurations", credentials.APIURL)
-	resp, err := s.makeAICoreRequest("POST", url, accessToken, credentials.ResourceGroup, req)
+	resp, err := s.makeAICoreRequest(requestContext(c), "POST", url, accessToken, credentials.ResourceGroup, req)
 	if err != nil {
 		return nil, err
 	}
@@ -980,7 +1474,7 @@ func (s *AICoreService) CreateConfiguration(c *gin.Context, req *AICoreConfigura
 
 	if resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w with status %d: %s", errors.ErrAICoreAPIRequestFailed, resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
 	}
 
 	var configResp AICoreConfigurationResponse
@@ -995,6 +1489,9 @@ func (s *AICoreService) CreateConfiguration(c *gin.Context, req *AICoreConfigura
 // Supports two scenarios:
 // 1. Direct deployment with configurationId
 // 2. Create configuration first, then deploy with the created configurationId
+// With DryRun set, the configuration is created or checked to exist and a preview is returned instead of deploying.
+// With IdempotencyKey set, a key already used by the team returns the cached result without calling AI Core again,
+// and concurrent requests with the same key share a single call.
 func (s *AICoreService) CreateDeployment(c *gin.Context, req *AICoreDeploymentRequest) (*AICoreDeploymentResponse, error) {
 	// Validate that either configurationId or configurationRequest is provided, but not both
 	if req.ConfigurationID == nil && req.ConfigurationRequest == nil {
@@ -1004,6 +1501,84 @@ func (s *AICoreService) CreateDeployment(c *gin.Context, req *AICoreDeploymentRe
 		return nil, fmt.Errorf("configurationId and configurationRequest cannot both be provided")
 	}
 
+	// Reject a malformed TTL before any configuration is created or AI Core is called
+	ttl, err := s.ttlPolicy.resolve(req.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the team up front for keyed requests so a replay skips configuration creation too
+	var teamName, idempotencyKey string
+	if req.IdempotencyKey != "" && !req.DryRun {
+		var err error
+		teamName, err = s.getUserTeam(c)
+		if err != nil {
+			return nil, err
+		}
+		idempotencyKey = cache.BuildKey(cache.KeyPrefixAICoreIdempotency, teamName, req.IdempotencyKey)
+		if cached, ok := s.cachedDeployment(idempotencyKey); ok {
+			return cached, nil
+		}
+
+		// Requests with the same key that arrive while the first is in flight wait for its result
+		// instead of creating a second deployment. The shared call must not fail for every waiter
+		// when the client that started it disconnects, so it runs detached from that request.
+		flightCtx := detachedContext(c)
+		result, err, _ := s.deployFlight.Do(idempotencyKey, func() (interface{}, error) {
+			// A request that finished while this caller was queued has already cached its result
+			if cached, ok := s.cachedDeployment(idempotencyKey); ok {
+				return cached, nil
+			}
+
+			deploymentResp, err := s.createDeployment(flightCtx, req, teamName, ttl)
+			if err != nil {
+				return nil, err
+			}
+			if data, err := json.Marshal(deploymentResp); err == nil {
+				_ = s.idempotency.Set(idempotencyKey, data, s.ttlConfig.AICoreIdempotency)
+			}
+			return deploymentResp, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Each caller gets its own copy of the shared result
+		deploymentResp := *result.(*AICoreDeploymentResponse)
+		return &deploymentResp, nil
+	}
+
+	return s.createDeployment(c, req, teamName, ttl)
+}
+
+// cachedDeployment returns the deployment created earlier for an idempotency key
+func (s *AICoreService) cachedDeployment(idempotencyKey string) (*AICoreDeploymentResponse, bool) {
+	data, err := s.idempotency.Get(idempotencyKey)
+	if err != nil {
+		return nil, false
+	}
+	var cached AICoreDeploymentResponse
+	if json.Unmarshal(data, &cached) != nil {
+		return nil, false
+	}
+	return &cached, true
+}
+
+// createDeployment creates the configuration if requested and the deployment; teamName may be empty
+func (s *AICoreService) createDeployment(c *gin.Context, req *AICoreDeploymentRequest, teamName, ttl string) (*AICoreDeploymentResponse, error) {
+	// Get user's team
+	if teamName == "" {
+		var err error
+		teamName, err = s.getUserTeam(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Throttle deployment calls per team before reaching AI Core, so a throttled request creates no configuration
+	if err := s.checkTeamRateLimit(teamName); err != nil {
+		return nil, err
+	}
+
 	var configurationID string
 
 	// Scenario 1: Direct deployment with existing configurationId
@@ -1018,12 +1593,6 @@ func (s *AICoreService) CreateDeployment(c *gin.Context, req *AICoreDeploymentRe
 		configurationID = configResp.ID
 	}
 
-	// Get user's team
-	teamName, err := s.getUserTeam(c)
-	if err != nil {
-		return nil, err
-	}
-
 	// Get credentials for the team
 	credentials, err := s.getCredentialsForTeam(teamName)
 	if err != nil {
@@ -1031,23 +1600,38 @@ func (s *AICoreService) CreateDeployment(c *gin.Context, req *AICoreDeploymentRe
 	}
 
 	// Get access token
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.DryRun {
+		// A configuration created above is known to exist; a supplied one has to be looked up
+		if req.ConfigurationID != nil {
+			if err := s.checkConfigurationExists(requestContext(c), teamName, credentials, accessToken, configurationID); err != nil {
+				return nil, err
+			}
+		}
+		return &AICoreDeploymentResponse{
+			Message:         "Dry run: configuration is valid, no deployment was created",
+			TTL:             ttl,
+			ConfigurationID: configurationID,
+			DryRun:          true,
+		}, nil
+	}
+
 	// Create the deployment request for AI Core API
 	deploymentReq := struct {
 		ConfigurationID string `json:"configurationId"`
 		TTL             string `json:"ttl,omitempty"`
 	}{
 		ConfigurationID: configurationID,
-		TTL:             req.TTL,
+		TTL:             ttl,
 	}
 
 	// Make request to AI Core
 	url := fmt.Sprintf("%s/v2/lm/deployments", credentials.APIURL)
-	resp, err := s.makeAICoreRequest("POST", url, accessToken, credentials.ResourceGroup, deploymentReq)
+	resp, err := s.makeAICoreRequest(requestContext(c), "POST", url, accessToken, credentials.ResourceGroup, deploymentReq)
 	if err != nil {
 		return nil, err
 	}
@@ -1055,7 +1639,7 @@ func (s *AICoreService) CreateDeployment(c *gin.Context, req *AICoreDeploymentRe
 
 	if resp.StatusCode != http.StatusAccepted {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w with status %d: %s", errors.ErrAICoreAPIRequestFailed, resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
 	}
 
 	var deploymentResp AICoreDeploymentResponse
@@ -1074,6 +1658,11 @@ func (s *AICoreService) UpdateDeployment(c *gin.Context, deploymentID string, re
 		return nil, err
 	}
 
+	// Throttle deployment calls per team before reaching AI Core
+	if err := s.checkTeamRateLimit(teamName); err != nil {
+		return nil, err
+	}
+
 	// Get credentials for the team
 	credentials, err := s.getCredentialsForTeam(teamName)
 	if err != nil {
@@ -1081,14 +1670,14 @@ func (s *AICoreService) UpdateDeployment(c *gin.Context, deploymentID string, re
 	}
 
 	// Get access token
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return nil, err
 	}
 
 	// Make request to AI Core
 	url := fmt.Sprintf("%s/v2/lm/deployments/%s", credentials.APIURL, deploymentID)
-	resp, err := s.makeAICoreRequest("PATCH", url, accessToken, credentials.ResourceGroup, req)
+	resp, err := s.makeAICoreRequest(requestContext(c), "PATCH", url, accessToken, credentials.ResourceGroup, req)
 	if err != nil {
 		return nil, err
 	}
@@ -1100,7 +1689,7 @@ func (s *AICoreService) UpdateDeployment(c *gin.Context, deploymentID string, re
 
 	if resp.StatusCode != http.StatusAccepted {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w with status %d: %s", errors.ErrAICoreAPIRequestFailed, resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
 	}
 
 	var modificationResp AICoreDeploymentModificationResponse
@@ -1111,14 +1700,31 @@ func (s *AICoreService) UpdateDeployment(c *gin.Context, deploymentID string, re
 	return &modificationResp, nil
 }
 
-// DeleteDeployment deletes a deployment in AI Core
-func (s *AICoreService) DeleteDeployment(c *gin.Context, deploymentID string) (*AICoreDeploymentDeletionResponse, error) {
+// DeleteDeployment deletes a deployment in AI Core. When deleteConfiguration is true, the
+// deployment's configuration is deleted as well once AI Core reports the deployment gone; a failure
+// to delete the configuration does not fail the deployment deletion and is reported in ConfigurationError.
+func (s *AICoreService) DeleteDeployment(c *gin.Context, deploymentID string, deleteConfiguration bool) (*AICoreDeploymentDeletionResponse, error) {
+	// Resolve the configuration before the deployment disappears
+	var configurationID string
+	if deleteConfiguration {
+		details, err := s.GetDeploymentDetails(c, deploymentID)
+		if err != nil {
+			return nil, err
+		}
+		configurationID = details.ConfigurationID
+	}
+
 	// Get user's team
 	teamName, err := s.getUserTeam(c)
 	if err != nil {
 		return nil, err
 	}
 
+	// Throttle deployment calls per team before reaching AI Core
+	if err := s.checkTeamRateLimit(teamName); err != nil {
+		return nil, err
+	}
+
 	// Get credentials for the team
 	credentials, err := s.getCredentialsForTeam(teamName)
 	if err != nil {
@@ -1126,14 +1732,14 @@ func (s *AICoreService) DeleteDeployment(c *gin.Context, deploymentID string) (*
 	}
 
 	// Get access token
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return nil, err
 	}
 
 	// Make request to AI Core
 	url := fmt.Sprintf("%s/v2/lm/deployments/%s", credentials.APIURL, deploymentID)
-	resp, err := s.makeAICoreRequest("DELETE", url, accessToken, credentials.ResourceGroup, nil)
+	resp, err := s.makeAICoreRequest(requestContext(c), "DELETE", url, accessToken, credentials.ResourceGroup, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1145,7 +1751,7 @@ func (s *AICoreService) DeleteDeployment(c *gin.Context, deploymentID string) (*
 
 	if resp.StatusCode != http.StatusAccepted {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w with status %d: %s", errors.ErrAICoreAPIRequestFailed, resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
 	}
 
 	var deletionResp AICoreDeploymentDeletionResponse
@@ -1153,9 +1759,63 @@ func (s *AICoreService) DeleteDeployment(c *gin.Context, deploymentID string) (*
 		return nil, fmt.Errorf("failed to decode deployment deletion response: %w", err)
 	}
 
+	if configurationID != "" {
+		// AI Core rejects deleting a configuration while a deployment still uses it, and the deployment
+		// is only gone some time after the deletion was accepted
+		err := s.waitForDeploymentDeleted(c, deploymentID, aicoreDeploymentDeletionTimeout)
+		if err == nil {
+			err = s.deleteConfiguration(requestContext(c), teamName, credentials, accessToken, configurationID)
+		}
+		if err != nil {
+			logger.New().WithFields(map[string]interface{}{
+				"team":             teamName,
+				"deployment_id":    deploymentID,
+				"configuration_id": configurationID,
+			}).Warnf("AI Core: Failed to delete configuration of deleted deployment: %v", err)
+			deletionResp.ConfigurationError = fmt.Sprintf("failed to delete configuration %s: %v", configurationID, err)
+		}
+	}
+
 	return &deletionResp, nil
 }
 
+// checkConfigurationExists verifies that a configuration is visible to the team in AI Core
+func (s *AICoreService) checkConfigurationExists(ctx context.Context, teamName string, credentials *AICoreCredentials, accessToken, configurationID string) error {
+	url := fmt.Sprintf("%s/v2/lm/configurations/%s", credentials.APIURL, configurationID)
+	resp, err := s.makeAICoreRequest(ctx, "GET", url, accessToken, credentials.ResourceGroup, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.ErrAICoreConfigurationNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newAICoreAPIError(teamName, resp.StatusCode, body)
+	}
+
+	return nil
+}
+
+// deleteConfiguration deletes a configuration in AI Core
+func (s *AICoreService) deleteConfiguration(ctx context.Context, teamName string, credentials *AICoreCredentials, accessToken, configurationID string) error {
+	url := fmt.Sprintf("%s/v2/lm/configurations/%s", credentials.APIURL, configurationID)
+	resp, err := s.makeAICoreRequest(ctx, "DELETE", url, accessToken, credentials.ResourceGroup, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(resp.Body)
+		return newAICoreAPIError(teamName, resp.StatusCode, body)
+	}
+
+	return nil
+}
+
 // GetDeploymentDetails retrieves detailed information about a specific deployment from AI Core
 func (s *AICoreService) GetDeploymentDetails(c *gin.Context, deploymentID string) (*AICoreDeploymentDetailsResponse, error) {
 	// Get user's team
@@ -1171,14 +1831,14 @@ func (s *AICoreService) GetDeploymentDetails(c *gin.Context, deploymentID string
 	}
 
 	// Get access token
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return nil, err
 	}
 
 	// Make request to AI Core
 	url := fmt.Sprintf("%s/v2/lm/deployments/%s", credentials.APIURL, deploymentID)
-	resp, err := s.makeAICoreRequest("GET", url, accessToken, credentials.ResourceGroup, nil)
+	resp, err := s.makeAICoreRequest(requestContext(c), "GET", url, accessToken, credentials.ResourceGroup, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1190,7 +1850,7 @@ func (s *AICoreService) GetDeploymentDetails(c *gin.Context, deploymentID string
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%w with status %d: %s", errors.ErrAICoreAPIRequestFailed, resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
 	}
 
 	var deploymentDetails AICoreDeploymentDetailsResponse
@@ -1203,14 +1863,37 @@ func (s *AICoreService) GetDeploymentDetails(c *gin.Context, deploymentID string
 
 // AICoreInferenceRequest represents a chat inference request
 type AICoreInferenceRequest struct {
-	DeploymentID string                   `json:"deploymentId" validate:"required"`
-	Messages     []AICoreInferenceMessage `json:"messages" validate:"required,min=1"`
-	MaxTokens    int                      `json:"max_tokens,omitempty"`
-	Temperature  float64                  `json:"temperature,omitempty"`
-	TopP         float64                  `json:"top_p,omitempty"`
-	Stream       bool                     `json:"stream,omitempty"`
+	DeploymentID     string                   `json:"deploymentId" validate:"required"`
+	Messages         []AICoreInferenceMessage `json:"messages" validate:"required,min=1"`
+	MaxTokens        int                      `json:"max_tokens,omitempty"`
+	Temperature      float64                  `json:"temperature,omitempty"`
+	TopP             float64                  `json:"top_p,omitempty"`
+	Stop             []string                 `json:"stop,omitempty"`
+	PresencePenalty  float64                  `json:"presence_penalty,omitempty"`
+	FrequencyPenalty float64                  `json:"frequency_penalty,omitempty"`
+	Stream           bool                     `json:"stream,omitempty"`
+	// ModelFamily optionally selects the endpoint/payload format instead of guessing it from the model name
+	ModelFamily string `json:"modelFamily,omitempty" validate:"omitempty,oneof=gemini openai anthropic orchestration"`
+	// SafetySettings maps Gemini harm categories to block thresholds, e.g. HARM_CATEGORY_HARASSMENT: BLOCK_ONLY_HIGH.
+	// Only Gemini models use it; other providers ignore it.
+	SafetySettings map[string]string `json:"safetySettings,omitempty"`
+	// OrchestrationConfig holds module configurations keyed by module name (e.g. templating_module_config,
+	// grounding_module_config) merged into orchestration_config.module_configurations. Only orchestration
+	// deployments use it; other providers ignore it.
+	OrchestrationConfig map[string]interface{} `json:"orchestrationConfig,omitempty"`
+	// InputParams fills the {{?name}} placeholders of an orchestration template. Only orchestration
+	// deployments use it; other providers ignore it.
+	InputParams map[string]string `json:"inputParams,omitempty"`
 }
 
+// Model families accepted by AICoreInferenceRequest.ModelFamily
+const (
+	ModelFamilyGemini        = "gemini"
+	ModelFamilyOpenAI        = "openai"
+	ModelFamilyAnthropic     = "anthropic"
+	ModelFamilyOrchestration = "orchestration"
+)
+
 // AICoreInferenceMessage represents a single message in the chat
 // Content can be either a string or an array of content parts (for multimodal messages)
 type AICoreInferenceMessage struct {
@@ -1252,7 +1935,9 @@ type AICoreInferenceResponse struct {
 type AICoreInferenceChoice struct {
 	Index        int                    `json:"index"`
 	Message      AICoreInferenceMessage `json:"message"`
-	FinishReason string                 `json:"finish_reason"`
+	FinishReason string                 `json:"finish_reason"` // normalized across providers
+	// RawFinishReason is the stop reason as reported by the provider
+	RawFinishReason string `json:"raw_finish_reason,omitempty"`
 }
 
 // AICoreInferenceUsage represents token usage information
@@ -1263,7 +1948,15 @@ type AICoreInferenceUsage struct {
 }
 
 // ChatInference performs a chat inference request to a deployed model
-func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceRequest) (*AICoreInferenceResponse, error) {
+func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceRequest) (_ *AICoreInferenceResponse, err error) {
+	_, endCall := s.startCall(c, "ChatInference")
+	defer func() { endCall(err) }()
+
+	// Reject oversized prompts before spending any calls on a request the model cannot accept
+	if err := s.checkPromptSize(req); err != nil {
+		return nil, err
+	}
+
 	// Get all deployments accessible to the user (reuses the same logic as Deployments tab)
 	deploymentsResp, err := s.GetDeployments(c)
 	if err != nil {
@@ -1295,13 +1988,18 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 		return nil, fmt.Errorf("deployment URL not available for deployment %s", req.DeploymentID)
 	}
 
+	// Throttle inference calls per team before reaching AI Core
+	if err := s.checkTeamRateLimit(targetTeamName); err != nil {
+		return nil, err
+	}
+
 	// Get credentials and token for the team that owns this deployment
 	credentials, err := s.getCredentialsForTeam(targetTeamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials for team %s: %w", targetTeamName, err)
 	}
 
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
@@ -1313,33 +2011,15 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 	// 3. Gemini: Uses /models/<model>:generateContent endpoint
 	// 4. Orchestration: Uses orchestration-specific endpoints (not foundation-models scenario)
 
-	isOrchestration := false
-	isGPTModel := false
-	isGeminiModel := false
-	modelName := ""
-
-	// Check if this is orchestration based on scenario ID only
-	// Orchestration deployments have a different scenario ID (not foundation-models)
-	if strings.Contains(strings.ToLower(targetDeployment.ScenarioID), "orchestration") {
-		isOrchestration = true
-	}
-
-	// Extract model name and check model type
-	if extractedName := extractModelNameFromDetails(targetDeployment.Details); extractedName != "" {
-		modelName = extractedName
-		lowerName := strings.ToLower(extractedName)
-		if strings.Contains(lowerName, "gpt") || strings.Contains(lowerName, "o1") ||
-			strings.Contains(lowerName, "o3") || strings.Contains(lowerName, "openai") {
-			isGPTModel = true
-		} else if strings.Contains(lowerName, "gemini") {
-			isGeminiModel = true
-		}
-	}
+	modelName, isOrchestration, isGPTModel, isGeminiModel := detectModelFamily(targetDeployment, req.ModelFamily)
 
 	// Trim messages to fit within model context limits
 	// This prevents "context too large" errors
 	contextLimit := getModelContextLimit(modelName)
 	req.Messages = trimMessagesToContextLimit(req.Messages, contextLimit)
+	if !isGeminiModel {
+		req.Messages = withTeamSystemPrompt(req.Messages, credentials.SystemPrompt)
+	}
 
 	var inferencePayload map[string]interface{}
 	var inferenceURL string
@@ -1418,16 +2098,19 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 		}
 
 		// Add generation config if parameters provided
-		if req.MaxTokens > 0 || req.Temperature > 0 {
-			generationConfig := make(map[string]interface{})
-			if req.MaxTokens > 0 {
-				generationConfig["maxOutputTokens"] = req.MaxTokens
-			}
-			if req.Temperature > 0 {
-				generationConfig["temperature"] = req.Temperature
-			}
+		generationConfig := make(map[string]interface{})
+		if req.MaxTokens > 0 {
+			generationConfig["maxOutputTokens"] = req.MaxTokens
+		}
+		if req.Temperature > 0 {
+			generationConfig["temperature"] = req.Temperature
+		}
+		addGeminiSamplingParams(generationConfig, req)
+		if len(generationConfig) > 0 {
 			inferencePayload["generation_config"] = generationConfig
 		}
+		addGeminiSafetySettings(inferencePayload, req)
+		addGeminiSystemInstruction(inferencePayload, credentials.SystemPrompt)
 
 		// Gemini endpoint format: /models/<model>:generateContent or streamGenerateContent for streaming
 		if req.Stream {
@@ -1453,10 +2136,7 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 
 		// Prepare orchestration request payload
 		// SAP AI Core orchestration uses a specific format with orchestration_config
-		modelParams := map[string]interface{}{
-			"frequency_penalty": 0,
-			"presence_penalty":  0,
-		}
+		modelParams := make(map[string]interface{})
 
 		if req.MaxTokens > 0 {
 			modelParams["max_tokens"] = req.MaxTokens
@@ -1469,21 +2149,27 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 		} else {
 			modelParams["temperature"] = 0.7
 		}
+		addOpenAISamplingParams(modelParams, req)
+
+		moduleConfigs := map[string]interface{}{
+			"templating_module_config": map[string]interface{}{
+				"template": templateMessages,
+			},
+			"llm_module_config": map[string]interface{}{
+				"model_name":    modelName,
+				"model_params":  modelParams,
+				"model_version": "latest",
+			},
+		}
+		if err := mergeOrchestrationModules(moduleConfigs, req.OrchestrationConfig); err != nil {
+			return nil, err
+		}
 
 		inferencePayload = map[string]interface{}{
 			"orchestration_config": map[string]interface{}{
-				"module_configurations": map[string]interface{}{
-					"templating_module_config": map[string]interface{}{
-						"template": templateMessages,
-					},
-					"llm_module_config": map[string]interface{}{
-						"model_name":    modelName,
-						"model_params":  modelParams,
-						"model_version": "latest",
-					},
-				},
+				"module_configurations": moduleConfigs,
 			},
-			"input_params": map[string]interface{}{},
+			"input_params": orchestrationInputParams(req),
 		}
 
 		// Use orchestration /completion endpoint
@@ -1497,14 +2183,9 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 				"role": msg.Role,
 			}
 
-			// Check if content is multimodal (array) or simple text (string)
-			if contentArr, ok := msg.Content.([]interface{}); ok {
-				// Multimodal content (text + images for GPT-4o, GPT-4-Turbo, GPT-4o Mini)
-				message["content"] = contentArr
-			} else {
-				// Simple text content
-				message["content"] = msg.Content
-			}
+			// Multimodal content (text + images for GPT-4o, GPT-4-Turbo, GPT-4o Mini) is sent as
+			// an OpenAI content array; simple text stays a plain string
+			message["content"] = openAIMessageContent(msg.Content)
 
 			messages = append(messages, message)
 		}
@@ -1533,9 +2214,7 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 			} else {
 				inferencePayload["temperature"] = 0.7
 			}
-			if req.TopP > 0 {
-				inferencePayload["top_p"] = req.TopP
-			}
+			addOpenAISamplingParams(inferencePayload, req)
 		}
 
 		// Add stream parameter for GPT models
@@ -1548,18 +2227,19 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 	} else {
 		// Anthropic Claude foundation models use /invoke endpoint with Anthropic format
 		// Convert messages to Anthropic Claude API format
-		var systemPrompt string
-		var userMessages []map[string]string
+		// Anthropic takes a single system field, so every system message is folded into it
+		systemPrompt := anthropicSystemPrompt(req.Messages)
+		var userMessages []map[string]interface{}
 
 		for _, msg := range req.Messages {
 			if msg.Role == "system" {
-				systemPrompt = getMessageText(msg)
-			} else {
-				userMessages = append(userMessages, map[string]string{
-					"role":    msg.Role,
-					"content": getMessageText(msg),
-				})
+				continue
 			}
+			// Multimodal content becomes Anthropic content blocks; simple text stays a plain string
+			userMessages = append(userMessages, map[string]interface{}{
+				"role":    msg.Role,
+				"content": anthropicMessageContent(msg.Content),
+			})
 		}
 
 		// Build Anthropic-compatible payload
@@ -1584,9 +2264,7 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 		} else {
 			inferencePayload["temperature"] = 0.7
 		}
-		if req.TopP > 0 {
-			inferencePayload["top_p"] = req.TopP
-		}
+		addAnthropicSamplingParams(inferencePayload, req)
 
 		// Add stream parameter for Anthropic models
 		if req.Stream {
@@ -1597,7 +2275,7 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 		inferenceURL = fmt.Sprintf("%s/invoke", targetDeployment.DeploymentURL)
 	}
 
-	resp, err := s.makeAICoreRequest("POST", inferenceURL, accessToken, credentials.ResourceGroup, inferencePayload)
+	resp, err := s.makeAICoreRequestWithRetry(c, targetTeamName, "POST", inferenceURL, accessToken, credentials.ResourceGroup, inferencePayload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make inference request: %w", err)
 	}
@@ -1605,7 +2283,7 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("inference request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAICoreAPIError(targetTeamName, resp.StatusCode, body)
 	}
 
 	var inferenceResp *AICoreInferenceResponse
@@ -1660,7 +2338,7 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 					Role:    "assistant",
 					Content: text,
 				},
-				FinishReason: strings.ToLower(candidate.FinishReason),
+				FinishReason: candidate.FinishReason,
 			})
 		}
 	} else if isOrchestration {
@@ -1777,25 +2455,90 @@ func (s *AICoreService) ChatInference(c *gin.Context, req *AICoreInferenceReques
 		}
 	}
 
+	normalizeFinishReasons(inferenceResp)
+	s.recordUsage(c, targetTeamName, inferenceResp.Usage)
+
 	return inferenceResp, nil
 }
 
+// AttachmentResponse describes an uploaded attachment ready for use in multimodal requests
+type AttachmentResponse struct {
+	URL      string `json:"url"`
+	MIMEType string `json:"mimeType"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	// Inline is false when the attachment carries active content (HTML/SVG) and must not be rendered inline
+	Inline bool `json:"inline"`
+}
+
 // UploadAttachment processes uploaded files for AI inference
-// Converts files to base64 data URLs for use in multimodal requests
+// Converts files to base64 data URLs for use in multimodal requests, or streams them to the
+// configured AttachmentStore and returns the stored file's URL instead
 // Supports images, text files (txt, json, html, csv, etc.), and documents
-func (s *AICoreService) UploadAttachment(c *gin.Context, file multipart.File, header *multipart.FileHeader) (map[string]interface{}, error) {
+func (s *AICoreService) UploadAttachment(c *gin.Context, file multipart.File, header *multipart.FileHeader) (_ *AttachmentResponse, err error) {
+	_, endCall := s.startCall(c, "UploadAttachment")
+	defer func() { endCall(err) }()
+
+	// Throttle uploads per user before reading the file
+	if err := s.checkUploadRateLimit(c); err != nil {
+		return nil, err
+	}
+
+	// Read the leading bytes used for content sniffing without consuming the rest of the file
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+	mimeType := attachmentMIMEType(head, header.Filename)
+	inline, err := s.checkAttachmentPolicy(mimeType, head)
+	if err != nil {
+		return nil, err
+	}
+	content := io.MultiReader(bytes.NewReader(head), file)
+
+	if s.attachments != nil {
+		url, err := s.attachments.Put(requestContext(c), header.Filename, mimeType, content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store attachment: %w", err)
+		}
+		return &AttachmentResponse{
+			URL:      url,
+			MIMEType: mimeType,
+			Filename: header.Filename,
+			Size:     header.Size,
+			Inline:   inline,
+		}, nil
+	}
+
 	// Read file content
-	fileBytes, err := io.ReadAll(file)
+	fileBytes, err := io.ReadAll(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	// Convert to base64 data URL
+	base64Data := base64.StdEncoding.EncodeToString(fileBytes)
+	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
+
+	return &AttachmentResponse{
+		URL:      dataURL,
+		MIMEType: mimeType,
+		Filename: header.Filename,
+		Size:     header.Size,
+		Inline:   inline,
+	}, nil
+}
+
+// attachmentMIMEType detects the MIME type of an attachment from its leading bytes and filename
+func attachmentMIMEType(head []byte, name string) string {
 	// Detect MIME type from content
-	mimeType := http.DetectContentType(fileBytes)
+	mimeType := http.DetectContentType(head)
 
 	// For text files, http.DetectContentType may return generic types
 	// Use file extension to get more accurate MIME type
-	filename := strings.ToLower(header.Filename)
+	filename := strings.ToLower(name)
 	switch {
 	case strings.HasSuffix(filename, ".json"):
 		mimeType = "application/json"
@@ -1803,6 +2546,8 @@ func (s *AICoreService) UploadAttachment(c *gin.Context, file multipart.File, he
 		mimeType = "text/plain"
 	case strings.HasSuffix(filename, ".html") || strings.HasSuffix(filename, ".htm"):
 		mimeType = "text/html"
+	case strings.HasSuffix(filename, ".svg"):
+		mimeType = "image/svg+xml"
 	case strings.HasSuffix(filename, ".csv"):
 		mimeType = "text/csv"
 	case strings.HasSuffix(filename, ".xml"):
@@ -1815,16 +2560,7 @@ func (s *AICoreService) UploadAttachment(c *gin.Context, file multipart.File, he
 		mimeType = "application/pdf"
 	}
 
-	// Convert to base64 data URL
-	base64Data := base64.StdEncoding.EncodeToString(fileBytes)
-	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
-
-	return map[string]interface{}{
-		"url":      dataURL,
-		"mimeType": mimeType,
-		"filename": header.Filename,
-		"size":     header.Size,
-	}, nil
+	return mimeType
 }
 
 // extractModelNameFromDetails extracts the model name from deployment details
@@ -1942,3 +2678,287 @@ func getGPTAPIVersion(modelName string) string {
 	// All other GPT models use the standard API version
 	return "2023-05-15"
 }
+
+// addOpenAISamplingParams adds the optional sampling parameters using OpenAI field names
+// (used by GPT models and orchestration). Zero values are omitted.
+func addOpenAISamplingParams(params map[string]interface{}, req *AICoreInferenceRequest) {
+	if req.TopP > 0 {
+		params["top_p"] = req.TopP
+	}
+	if len(req.Stop) > 0 {
+		params["stop"] = req.Stop
+	}
+	if req.PresencePenalty != 0 {
+		params["presence_penalty"] = req.PresencePenalty
+	}
+	if req.FrequencyPenalty != 0 {
+		params["frequency_penalty"] = req.FrequencyPenalty
+	}
+}
+
+// addGeminiSamplingParams adds the optional sampling parameters to a Gemini generation_config.
+// Zero values are omitted.
+func addGeminiSamplingParams(generationConfig map[string]interface{}, req *AICoreInferenceRequest) {
+	if req.TopP > 0 {
+		generationConfig["topP"] = req.TopP
+	}
+	if len(req.Stop) > 0 {
+		generationConfig["stopSequences"] = req.Stop
+	}
+	if req.PresencePenalty != 0 {
+		generationConfig["presencePenalty"] = req.PresencePenalty
+	}
+	if req.FrequencyPenalty != 0 {
+		generationConfig["frequencyPenalty"] = req.FrequencyPenalty
+	}
+}
+
+// addGeminiSafetySettings maps the requested category thresholds into a Gemini safetySettings array,
+// sorted by category. Nothing is added when no settings are requested.
+func addGeminiSafetySettings(payload map[string]interface{}, req *AICoreInferenceRequest) {
+	if len(req.SafetySettings) == 0 {
+		return
+	}
+
+	categories := make([]string, 0, len(req.SafetySettings))
+	for category := range req.SafetySettings {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	safetySettings := make([]map[string]string, 0, len(categories))
+	for _, category := range categories {
+		safetySettings = append(safetySettings, map[string]string{
+			"category":  category,
+			"threshold": req.SafetySettings[category],
+		})
+	}
+	payload["safetySettings"] = safetySettings
+}
+
+// mergeOrchestrationModules merges caller-supplied module configurations into the generated ones.
+// A supplied module replaces the generated module of the same name, except:
+//   - templating_module_config: the supplied template messages are appended to the generated template, so the
+//     conversation and the team's mandatory system prompt are kept; its other fields are merged.
+//   - llm_module_config: fields are merged so that model_params keeps the values mapped from the request's
+//     sampling fields. model_name cannot differ from the deployment's model.
+func mergeOrchestrationModules(moduleConfigs map[string]interface{}, supplied map[string]interface{}) error {
+	for name, config := range supplied {
+		switch name {
+		case "templating_module_config":
+			templatingConfig, ok := config.(map[string]interface{})
+			if !ok {
+				return errors.ErrInvalidOrchestrationTemplate
+			}
+			generated := moduleConfigs[name].(map[string]interface{})
+			for key, value := range templatingConfig {
+				if key != "template" {
+					generated[key] = value
+					continue
+				}
+				messages, ok := value.([]interface{})
+				if !ok {
+					return errors.ErrInvalidOrchestrationTemplate
+				}
+				template := generated["template"].([]map[string]interface{})
+				for _, message := range messages {
+					m, ok := message.(map[string]interface{})
+					if !ok {
+						return errors.ErrInvalidOrchestrationTemplate
+					}
+					template = append(template, m)
+				}
+				generated["template"] = template
+			}
+		case "llm_module_config":
+			llmConfig, ok := config.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			generated := moduleConfigs[name].(map[string]interface{})
+			if modelName, ok := llmConfig["model_name"]; ok && modelName != generated["model_name"] {
+				return errors.ErrOrchestrationModelOverride
+			}
+			for key, value := range llmConfig {
+				if key != "model_params" {
+					generated[key] = value
+				}
+			}
+		default:
+			moduleConfigs[name] = config
+		}
+	}
+	return nil
+}
+
+// orchestrationInputParams returns the values for the template placeholders, never nil
+func orchestrationInputParams(req *AICoreInferenceRequest) map[string]string {
+	if req.InputParams == nil {
+		return map[string]string{}
+	}
+	return req.InputParams
+}
+
+// addAnthropicSamplingParams adds the optional sampling parameters using Anthropic field names.
+// Anthropic models have no presence/frequency penalties, so those are not sent.
+func addAnthropicSamplingParams(payload map[string]interface{}, req *AICoreInferenceRequest) {
+	if req.TopP > 0 {
+		payload["top_p"] = req.TopP
+	}
+	if len(req.Stop) > 0 {
+		payload["stop_sequences"] = req.Stop
+	}
+}
+
+// detectModelFamily determines the deployment's model name and inference format.
+// An explicit family override wins; otherwise orchestration is detected from the scenario ID
+// and GPT/Gemini from the model name, with Anthropic as the fallback.
+func detectModelFamily(deployment *AICoreDeployment, override string) (modelName string, isOrchestration, isGPTModel, isGeminiModel bool) {
+	modelName = extractModelNameFromDetails(deployment.Details)
+
+	switch strings.ToLower(override) {
+	case ModelFamilyGemini:
+		return modelName, false, false, true
+	case ModelFamilyOpenAI:
+		return modelName, false, true, false
+	case ModelFamilyAnthropic:
+		return modelName, false, false, false
+	case ModelFamilyOrchestration:
+		return modelName, true, false, false
+	}
+
+	// Orchestration deployments have a different scenario ID (not foundation-models)
+	if strings.Contains(strings.ToLower(deployment.ScenarioID), "orchestration") {
+		isOrchestration = true
+	}
+
+	lowerName := strings.ToLower(modelName)
+	if strings.Contains(lowerName, "gpt") || strings.Contains(lowerName, "o1") ||
+		strings.Contains(lowerName, "o3") || strings.Contains(lowerName, "openai") {
+		isGPTModel = true
+	} else if strings.Contains(lowerName, "gemini") {
+		isGeminiModel = true
+	}
+
+	return modelName, isOrchestration, isGPTModel, isGeminiModel
+}
+
+// messageContentParts normalizes multimodal message content into generic parts.
+// It returns false for plain (string) content.
+func messageContentParts(content interface{}) ([]map[string]interface{}, bool) {
+	switch v := content.(type) {
+	case []interface{}:
+		parts := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			if part, ok := item.(map[string]interface{}); ok {
+				parts = append(parts, part)
+			}
+		}
+		return parts, true
+	case []map[string]interface{}:
+		return v, true
+	case []AICoreMessageContent:
+		parts := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			part := map[string]interface{}{"type": item.Type, "text": item.Text}
+			if item.ImageURL != nil {
+				part["image_url"] = map[string]interface{}{"url": item.ImageURL.URL}
+			}
+			parts = append(parts, part)
+		}
+		return parts, true
+	}
+	return nil, false
+}
+
+// contentPartImageURL returns the URL of an image_url part, accepting both {"url": ...} and a bare string
+func contentPartImageURL(part map[string]interface{}) string {
+	switch v := part["image_url"].(type) {
+	case map[string]interface{}:
+		url, _ := v["url"].(string)
+		return url
+	case string:
+		return v
+	}
+	return ""
+}
+
+// openAIMessageContent converts message content to the OpenAI /chat/completions format.
+// Plain strings are returned unchanged; text and image_url parts are normalized to OpenAI's shape.
+func openAIMessageContent(content interface{}) interface{} {
+	parts, ok := messageContentParts(content)
+	if !ok {
+		return content
+	}
+
+	converted := make([]map[string]interface{}, 0, len(parts))
+	for _, part := range parts {
+		switch part["type"] {
+		case "text":
+			converted = append(converted, map[string]interface{}{"type": "text", "text": part["text"]})
+		case "image_url":
+			if url := contentPartImageURL(part); url != "" {
+				converted = append(converted, map[string]interface{}{
+					"type":      "image_url",
+					"image_url": map[string]interface{}{"url": url},
+				})
+			}
+		default:
+			// Forward other OpenAI part types untouched
+			converted = append(converted, part)
+		}
+	}
+	return converted
+}
+
+// anthropicMessageContent converts message content to Anthropic content blocks.
+// Plain strings are returned unchanged; images must be base64 data URLs, as Anthropic
+// on AI Core only accepts base64 image sources, so other image URLs are skipped.
+func anthropicMessageContent(content interface{}) interface{} {
+	parts, ok := messageContentParts(content)
+	if !ok {
+		if str, ok := content.(string); ok {
+			return str
+		}
+		return ""
+	}
+
+	blocks := make([]map[string]interface{}, 0, len(parts))
+	for _, part := range parts {
+		switch part["type"] {
+		case "text":
+			blocks = append(blocks, map[string]interface{}{"type": "text", "text": part["text"]})
+		case "image_url":
+			mediaType, data, ok := parseImageDataURL(contentPartImageURL(part))
+			if !ok {
+				logger.New().Warn("AI Core: Skipping non-base64 image for Anthropic model")
+				continue
+			}
+			blocks = append(blocks, map[string]interface{}{
+				"type": "image",
+				"source": map[string]interface{}{
+					"type":       "base64",
+					"media_type": mediaType,
+					"data":       data,
+				},
+			})
+		}
+	}
+	return blocks
+}
+
+// parseImageDataURL splits a "data:<media type>;base64,<data>" URL into its media type and payload
+func parseImageDataURL(url string) (string, string, bool) {
+	if !strings.HasPrefix(url, "data:") {
+		return "", "", false
+	}
+	header, data, found := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+	if !found || data == "" {
+		return "", "", false
+	}
+	mediaType, isBase64 := strings.CutSuffix(header, ";base64")
+	if !isBase64 || mediaType == "" {
+		return "", "", false
+	}
+	return mediaType, data, true
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"developer-portal-backend/internal/errors"
+)
+
+// AttachmentStore persists uploaded attachments, e.g. in object storage, and returns a URL to fetch them
+type AttachmentStore interface {
+	Put(ctx context.Context, filename, mimeType string, content io.Reader) (url string, err error)
+}
+
+// SetAttachmentStore sets the store UploadAttachment streams files to; nil restores base64 data URLs
+func (s *AICoreService) SetAttachmentStore(store AttachmentStore) {
+	s.attachments = store
+}
+
+// AttachmentPolicy controls how UploadAttachment treats active content (HTML and SVG) that a
+// browser would execute if the frontend rendered the attachment inline
+type AttachmentPolicy string
+
+const (
+	// AttachmentPolicyPermissive accepts every upload and reports all of them as inline-safe
+	AttachmentPolicyPermissive AttachmentPolicy = "permissive"
+	// AttachmentPolicyStrict accepts active content but flags it as not inline-safe
+	AttachmentPolicyStrict AttachmentPolicy = "strict"
+	// AttachmentPolicyReject refuses active content uploads
+	AttachmentPolicyReject AttachmentPolicy = "reject"
+)
+
+// getAttachmentPolicy reads AI_CORE_ATTACHMENT_POLICY, falling back to permissive when unset or unknown
+func getAttachmentPolicy() AttachmentPolicy {
+	switch policy := AttachmentPolicy(strings.ToLower(os.Getenv("AI_CORE_ATTACHMENT_POLICY"))); policy {
+	case AttachmentPolicyStrict, AttachmentPolicyReject:
+		return policy
+	default:
+		return AttachmentPolicyPermissive
+	}
+}
+
+// SetAttachmentPolicy sets how UploadAttachment treats HTML and SVG uploads
+func (s *AICoreService) SetAttachmentPolicy(policy AttachmentPolicy) {
+	s.attachPolicy = policy
+}
+
+// checkAttachmentPolicy reports whether an upload of mimeType starting with head may be rendered inline,
+// or ErrAttachmentTypeNotAllowed when the policy rejects it. The content is checked as well as the declared
+// type, so renaming an HTML or SVG file does not bypass the policy.
+func (s *AICoreService) checkAttachmentPolicy(mimeType string, head []byte) (bool, error) {
+	if !isActiveContent(mimeType) && !isActiveContent(http.DetectContentType(head)) && !isSVGDocument(head) {
+		return true, nil
+	}
+	switch s.attachPolicy {
+	case AttachmentPolicyReject:
+		return false, errors.ErrAttachmentTypeNotAllowed
+	case AttachmentPolicyStrict:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// isActiveContent reports whether mimeType is HTML or SVG, which can carry scripts
+func isActiveContent(mimeType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(mimeType), ";")
+	switch strings.TrimSpace(mediaType) {
+	case "text/html", "application/xhtml+xml", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// isSVGDocument reports whether head is XML whose root element is <svg>, which content sniffing
+// only reports as generic XML or text
+func isSVGDocument(head []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(head))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return strings.EqualFold(start.Name.Local, "svg")
+		}
+	}
+}
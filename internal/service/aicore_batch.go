@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// deploymentLookupWorkers bounds the number of concurrent AI Core calls made by GetDeploymentsByIDs
+const deploymentLookupWorkers = 4
+
+// teamAccess holds what is needed to call AI Core on behalf of one team
+type teamAccess struct {
+	team        string
+	credentials *AICoreCredentials
+	accessToken string
+}
+
+// GetDeploymentsByIDs resolves several deployments directly by ID across the user's teams, in parallel.
+// The result is keyed by deployment ID; IDs that every team reports as not found are left out. A lookup
+// that fails for any other reason fails the call, as the deployment may be hidden behind the failure.
+func (s *AICoreService) GetDeploymentsByIDs(c *gin.Context, ids []string) (_ map[string]*AICoreDeploymentDetailsResponse, err error) {
+	log, endCall := s.startCall(c, "GetDeploymentsByIDs")
+	defer func() { endCall(err) }()
+
+	email, exists := auth.GetUserEmail(c)
+	if !exists {
+		return nil, errors.ErrUserEmailNotFound
+	}
+
+	member, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ErrUserNotFoundInDB
+		}
+		return nil, fmt.Errorf("failed to get user from database: %w", err)
+	}
+
+	teamNames, err := s.getAllTeamsForUser(member)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := requestContext(c)
+
+	// Skip teams without usable credentials, as GetDeployments does
+	teams := make([]teamAccess, 0, len(teamNames))
+	for _, teamName := range teamNames {
+		credentials, err := s.getCredentialsForTeam(teamName)
+		if err != nil {
+			continue
+		}
+		accessToken, err := s.getAccessToken(ctx, credentials)
+		if err != nil {
+			continue
+		}
+		teams = append(teams, teamAccess{team: teamName, credentials: credentials, accessToken: accessToken})
+	}
+
+	results := make(map[string]*AICoreDeploymentDetailsResponse, len(ids))
+	var lookupErr error
+	var mu sync.Mutex
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < deploymentLookupWorkers && i < len(ids); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				deployment, err := s.lookupDeployment(ctx, teams, id)
+				if err != nil {
+					log.WithField("deployment_id", id).Warnf("AI Core: Failed to look up deployment: %v", err)
+				}
+				mu.Lock()
+				if deployment != nil {
+					results[id] = deployment
+				} else if err != nil && lookupErr == nil {
+					lookupErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	queued := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || queued[id] {
+			continue
+		}
+		queued[id] = true
+		jobs <- id
+	}
+	close(jobs)
+	wg.Wait()
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", ctxErr)
+	}
+	if lookupErr != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", lookupErr)
+	}
+
+	return results, nil
+}
+
+// lookupDeployment fetches a deployment from the first team that has it. It returns nil without an error
+// when every team reports the deployment as not found, and the last failure when a team could not be asked.
+func (s *AICoreService) lookupDeployment(ctx context.Context, teams []teamAccess, deploymentID string) (*AICoreDeploymentDetailsResponse, error) {
+	var lastErr error
+	for _, t := range teams {
+		url := fmt.Sprintf("%s/v2/lm/deployments/%s", t.credentials.APIURL, deploymentID)
+		resp, err := s.makeAICoreRequest(ctx, "GET", url, t.accessToken, t.credentials.ResourceGroup, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		deployment, err := decodeDeploymentLookup(t.team, resp)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if deployment != nil {
+			return deployment, nil
+		}
+	}
+	return nil, lastErr
+}
+
+// decodeDeploymentLookup reads a deployment details response; a 404 yields neither a deployment nor an error
+func decodeDeploymentLookup(teamName string, resp *http.Response) (*AICoreDeploymentDetailsResponse, error) {
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, newAICoreAPIError(teamName, resp.StatusCode, body)
+	}
+
+	var deployment AICoreDeploymentDetailsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
+		return nil, fmt.Errorf("failed to decode deployment details response: %w", err)
+	}
+	return &deployment, nil
+}
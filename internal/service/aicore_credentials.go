@@ -0,0 +1,107 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"developer-portal-backend/internal/errors"
+)
+
+// AICoreCredentialsProvider supplies AI Core credentials per team, e.g. from the environment or a secrets manager
+type AICoreCredentialsProvider interface {
+	GetForTeam(team string) (*AICoreCredentials, bool)
+}
+
+// reloadableCredentialsProvider is implemented by providers whose source can be re-read and can fail to load
+type reloadableCredentialsProvider interface {
+	AICoreCredentialsProvider
+	Load() error
+	Err() error
+}
+
+// EnvCredentialsProvider reads credentials from the AI_CORE_CREDENTIALS environment variable (JSON array).
+// The variable is parsed lazily on first use and can be re-read with Load.
+type EnvCredentialsProvider struct {
+	mu          sync.RWMutex
+	once        sync.Once
+	credentials map[string]*AICoreCredentials
+	loadErr     error
+}
+
+// NewEnvCredentialsProvider creates the default, environment-backed credentials provider
+func NewEnvCredentialsProvider() *EnvCredentialsProvider {
+	return &EnvCredentialsProvider{credentials: make(map[string]*AICoreCredentials)}
+}
+
+// Load (re)reads AI_CORE_CREDENTIALS and replaces the cached credentials
+func (p *EnvCredentialsProvider) Load() error {
+	credentials, err := parseAICoreCredentials(os.Getenv("AI_CORE_CREDENTIALS"))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.loadErr = err
+	if err == nil {
+		p.credentials = credentials
+	}
+	return err
+}
+
+// Err returns the error of the most recent load, if any
+func (p *EnvCredentialsProvider) Err() error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.loadErr
+}
+
+// GetForTeam returns the credentials configured for team
+func (p *EnvCredentialsProvider) GetForTeam(team string) (*AICoreCredentials, bool) {
+	p.once.Do(func() {
+		_ = p.Load()
+	})
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	cred, exists := p.credentials[team]
+	return cred, exists
+}
+
+// Configured reports whether any credentials are available, loading them on first use
+func (p *EnvCredentialsProvider) Configured() error {
+	p.once.Do(func() {
+		_ = p.Load()
+	})
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.loadErr != nil {
+		return p.loadErr
+	}
+	if len(p.credentials) == 0 {
+		return errors.ErrAICoreCredentialsNotSet
+	}
+	return nil
+}
+
+// parseAICoreCredentials parses the AI_CORE_CREDENTIALS JSON array into a map keyed by team name
+func parseAICoreCredentials(credentialsJSON string) (map[string]*AICoreCredentials, error) {
+	if credentialsJSON == "" {
+		return nil, errors.ErrAICoreCredentialsNotSet
+	}
+
+	var credentialsList []AICoreCredentials
+	if err := json.Unmarshal([]byte(credentialsJSON), &credentialsList); err != nil {
+		return nil, fmt.Errorf("%w: %v", errors.ErrAICoreCredentialsInvalid, err)
+	}
+
+	credentials := make(map[string]*AICoreCredentials, len(credentialsList))
+	for i := range credentialsList {
+		cred := &credentialsList[i]
+		credentials[cred.Team] = cred
+	}
+	return credentials, nil
+}
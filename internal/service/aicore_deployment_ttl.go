@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"developer-portal-backend/internal/errors"
+)
+
+const (
+	defaultAICoreDeploymentTTL = "24h"
+	minAICoreDeploymentTTL     = 10 * time.Minute
+	maxAICoreDeploymentTTL     = 168 * time.Hour
+)
+
+// deploymentTTLPattern matches the TTL format accepted by AI Core: a whole number of minutes, hours or days
+var deploymentTTLPattern = regexp.MustCompile(`^([1-9][0-9]*)([mhd])$`)
+
+// deploymentTTLUnits maps the TTL unit suffixes to their durations
+var deploymentTTLUnits = map[string]time.Duration{"m": time.Minute, "h": time.Hour, "d": 24 * time.Hour}
+
+// deploymentTTLPolicy holds the TTL applied when a deployment request has none and the accepted range
+type deploymentTTLPolicy struct {
+	defaultTTL string
+	min        time.Duration
+	max        time.Duration
+}
+
+// getDeploymentTTLPolicy reads AI_CORE_DEFAULT_DEPLOYMENT_TTL, falling back to 24h when unset or outside 10m–168h
+func getDeploymentTTLPolicy() deploymentTTLPolicy {
+	policy := deploymentTTLPolicy{
+		defaultTTL: defaultAICoreDeploymentTTL,
+		min:        minAICoreDeploymentTTL,
+		max:        maxAICoreDeploymentTTL,
+	}
+	if ttl := strings.TrimSpace(os.Getenv("AI_CORE_DEFAULT_DEPLOYMENT_TTL")); ttl != "" {
+		if _, err := policy.validate(ttl); err == nil {
+			policy.defaultTTL = ttl
+		}
+	}
+	return policy
+}
+
+// SetDeploymentTTLPolicy overrides the default deployment TTL and the accepted range.
+// An empty defaultTTL lets deployments without a TTL run until stopped; non-positive bounds keep the current ones.
+func (s *AICoreService) SetDeploymentTTLPolicy(defaultTTL string, min, max time.Duration) {
+	if min > 0 {
+		s.ttlPolicy.min = min
+	}
+	if max > 0 {
+		s.ttlPolicy.max = max
+	}
+	s.ttlPolicy.defaultTTL = strings.TrimSpace(defaultTTL)
+}
+
+// resolve returns the TTL to send to AI Core: the default when ttl is empty, otherwise ttl once it
+// is known to be well-formed and within range. ErrInvalidTTL is returned for anything else.
+func (p deploymentTTLPolicy) resolve(ttl string) (string, error) {
+	ttl = strings.TrimSpace(ttl)
+	if ttl == "" {
+		return p.defaultTTL, nil
+	}
+	return p.validate(ttl)
+}
+
+// validate checks the format and range of a non-empty TTL
+func (p deploymentTTLPolicy) validate(ttl string) (string, error) {
+	match := deploymentTTLPattern.FindStringSubmatch(ttl)
+	if match == nil {
+		return "", fmt.Errorf("%w: %q is not a number followed by m, h or d", errors.ErrInvalidTTL, ttl)
+	}
+
+	unit := deploymentTTLUnits[match[2]]
+	// Comparing the count against max/unit avoids overflowing time.Duration for huge values
+	value, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil || value > int64(p.max/unit) || time.Duration(value)*unit < p.min {
+		return "", fmt.Errorf("%w: %q must be between %s and %s", errors.ErrInvalidTTL, ttl, p.min, p.max)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import "strings"
+
+// Normalized finish reasons reported in AICoreInferenceChoice.FinishReason regardless of the provider
+const (
+	FinishReasonStop          = "stop"
+	FinishReasonLength        = "length"
+	FinishReasonContentFilter = "content_filter"
+	FinishReasonToolCalls     = "tool_calls"
+)
+
+// normalizeFinishReason maps a provider's native stop reason (e.g. GPT "stop", Anthropic "end_turn",
+// Gemini "STOP") to the common vocabulary. Unknown reasons are passed through lowercased.
+func normalizeFinishReason(raw string) string {
+	reason := strings.ToLower(strings.TrimSpace(raw))
+	switch reason {
+	case "stop", "end_turn", "stop_sequence":
+		return FinishReasonStop
+	case "length", "max_tokens", "model_context_window_exceeded":
+		return FinishReasonLength
+	case "content_filter", "safety", "recitation", "blocklist", "prohibited_content", "spii", "image_safety", "refusal":
+		return FinishReasonContentFilter
+	case "tool_calls", "function_call", "tool_use":
+		return FinishReasonToolCalls
+	default:
+		return reason
+	}
+}
+
+// normalizeFinishReasons normalizes the finish reason of every choice, keeping the provider's value in RawFinishReason
+func normalizeFinishReasons(resp *AICoreInferenceResponse) {
+	for i := range resp.Choices {
+		choice := &resp.Choices[i]
+		choice.RawFinishReason = choice.FinishReason
+		choice.FinishReason = normalizeFinishReason(choice.FinishReason)
+	}
+}
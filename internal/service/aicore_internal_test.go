@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccessToken_ConcurrentRefreshSharesOneRequest(t *testing.T) {
+	var tokenCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenCalls, 1)
+		// Hold the refresh open so every caller arrives while it is in flight
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "fresh-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	svc := NewAICoreService(nil, nil, nil, nil, nil).(*AICoreService)
+	credentials := &AICoreCredentials{Team: "team-alpha", OAuthURL: server.URL + "/oauth/token", APIURL: server.URL}
+
+	// An expired token forces every caller to refresh
+	svc.tokenCache["team-alpha"] = &tokenCache{token: "stale-token", expiresAt: time.Now().Add(-time.Minute)}
+
+	const callers = 20
+	tokens := make([]string, callers)
+	errs := make([]error, callers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			tokens[i], errs[i] = svc.getAccessToken(context.Background(), credentials)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenCalls))
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "fresh-token", tokens[i])
+	}
+}
+
+func TestGetAccessToken_CancelledCallerDoesNotAbortRefresh(t *testing.T) {
+	release := make(chan struct{})
+	var tokenCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenCalls, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "fresh-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	svc := NewAICoreService(nil, nil, nil, nil, nil).(*AICoreService)
+	credentials := &AICoreCredentials{Team: "team-alpha", OAuthURL: server.URL + "/oauth/token", APIURL: server.URL}
+
+	// The caller that started the refresh gives up
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := svc.getAccessToken(ctx, credentials)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A later caller joins the same refresh and receives its token
+	close(release)
+	token, err := svc.getAccessToken(context.Background(), credentials)
+
+	require.NoError(t, err)
+	assert.Equal(t, "fresh-token", token)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tokenCalls))
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"time"
+
+	"developer-portal-backend/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// aicoreCallKey marks the gin context while an AI Core service method is running, so nested calls
+// (e.g. ChatInference resolving deployments through GetDeployments) are logged once by the outermost method
+const aicoreCallKey = "aicore_call"
+
+// startCall logs entry into an AI Core service method and returns a logger carrying the request context
+// and the operation name, plus a function that logs the outcome when deferred. Nested calls reuse the
+// outer call's logging and their deferred function is a no-op.
+func (s *AICoreService) startCall(c *gin.Context, operation string) (*logger.Logger, func(err error)) {
+	log := aicoreLogger(c).WithField("operation", operation)
+	if c == nil {
+		return log, func(error) {}
+	}
+	if outer := c.GetString(aicoreCallKey); outer != "" {
+		return log.WithField("parent_operation", outer), func(error) {}
+	}
+	c.Set(aicoreCallKey, operation)
+
+	started := time.Now()
+	log.Debug("AI Core call started")
+
+	return log, func(err error) {
+		c.Set(aicoreCallKey, "")
+		if err != nil {
+			log.WithField("duration", time.Since(started).String()).Errorf("AI Core call failed: %v", err)
+			return
+		}
+		log.WithField("duration", time.Since(started).String()).Debug("AI Core call finished")
+	}
+}
+
+// aicoreLogger returns the request-scoped logger, falling back to the default logger outside of a request
+func aicoreLogger(c *gin.Context) *logger.Logger {
+	if c == nil || c.Request == nil {
+		return logger.New()
+	}
+	return logger.FromGinContext(c)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultAICoreDeploymentPollInterval = 5 * time.Second
+
+// aicoreDeploymentDeletionTimeout bounds how long DeleteDeployment waits for a deployment to disappear
+// before deleting its configuration
+const aicoreDeploymentDeletionTimeout = time.Minute
+
+// aicoreTerminalFailureStatuses are deployment statuses that will not progress any further
+var aicoreTerminalFailureStatuses = map[string]bool{
+	"DEAD":    true,
+	"UNKNOWN": true,
+}
+
+// SetDeploymentPolling overrides the interval and clock used by WaitForDeploymentStatus.
+// A nil now or sleep keeps the current implementation.
+func (s *AICoreService) SetDeploymentPolling(interval time.Duration, now func() time.Time, sleep func(c *gin.Context, delay time.Duration) error) {
+	if interval > 0 {
+		s.pollInterval = interval
+	}
+	if now != nil {
+		s.now = now
+	}
+	if sleep != nil {
+		s.sleep = sleep
+	}
+}
+
+// WaitForDeploymentStatus polls GetDeploymentDetails until the deployment reaches target,
+// enters a terminal failure status (DEAD/UNKNOWN) or timeout elapses.
+// The final details are returned in the first two cases; ErrDeploymentTimeout otherwise.
+func (s *AICoreService) WaitForDeploymentStatus(c *gin.Context, deploymentID, target string, timeout time.Duration) (*AICoreDeploymentDetailsResponse, error) {
+	if deploymentID == "" {
+		return nil, apperrors.ErrMissingDeploymentID
+	}
+
+	var details *AICoreDeploymentDetailsResponse
+	err := s.pollDeployment(c, timeout, func() (bool, error) {
+		var err error
+		details, err = s.GetDeploymentDetails(c, deploymentID)
+		if err != nil {
+			return false, err
+		}
+		status := strings.ToUpper(details.Status)
+		return strings.EqualFold(status, target) || aicoreTerminalFailureStatuses[status], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// waitForDeploymentDeleted polls GetDeploymentDetails until AI Core no longer knows the deployment
+// or timeout elapses, in which case ErrDeploymentTimeout is returned
+func (s *AICoreService) waitForDeploymentDeleted(c *gin.Context, deploymentID string, timeout time.Duration) error {
+	return s.pollDeployment(c, timeout, func() (bool, error) {
+		_, err := s.GetDeploymentDetails(c, deploymentID)
+		if errors.Is(err, apperrors.ErrAICoreDeploymentNotFound) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+// pollDeployment calls check every poll interval until it reports done, fails or timeout elapses
+func (s *AICoreService) pollDeployment(c *gin.Context, timeout time.Duration, check func() (bool, error)) error {
+	now := s.now
+	if now == nil {
+		now = time.Now
+	}
+	sleep := s.sleep
+	if sleep == nil {
+		sleep = sleepWithContext
+	}
+	interval := s.pollInterval
+	if interval <= 0 {
+		interval = defaultAICoreDeploymentPollInterval
+	}
+
+	deadline := now().Add(timeout)
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		remaining := deadline.Sub(now())
+		if remaining <= 0 {
+			return apperrors.ErrDeploymentTimeout
+		}
+
+		delay := interval
+		if delay > remaining {
+			delay = remaining
+		}
+		if err := sleep(c, delay); err != nil {
+			return err
+		}
+	}
+}
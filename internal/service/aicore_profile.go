@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+
+	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ProfileSummaryResponse represents the response for /ai-core/me/summary: everything the portal needs about
+// the current user on page load
+type ProfileSummaryResponse struct {
+	ID              string     `json:"id"`
+	UUID            string     `json:"uuid"`
+	User            string     `json:"user"`
+	FirstName       string     `json:"first_name"`
+	LastName        string     `json:"last_name"`
+	Email           string     `json:"email"`
+	TeamID          *uuid.UUID `json:"team_id,omitempty"`
+	TeamDomain      string     `json:"team_domain"`
+	TeamRole        string     `json:"team_role"`
+	PortalAdmin     bool       `json:"portal_admin"`
+	AIInstances     []string   `json:"ai_instances"`
+	FavoritesCount  int        `json:"favorites_count"`
+	SubscribedCount int        `json:"subscribed_count"`
+}
+
+// GetProfileSummary resolves the authenticated user's identity, AI instances, favorites and subscription counts
+// and portal admin flag in one call. The user is looked up once; counts and the admin flag come from metadata
+// and AI instances are resolved as in GetMe. Users without a team get an empty list of AI instances.
+func (s *AICoreService) GetProfileSummary(c *gin.Context) (_ *ProfileSummaryResponse, err error) {
+	_, endCall := s.startCall(c, "GetProfileSummary")
+	defer func() { endCall(err) }()
+
+	username, exists := auth.GetUsername(c)
+	if !exists || username == "" {
+		return nil, errors.ErrUserEmailNotFound
+	}
+
+	member, err := s.userRepo.GetByName(username)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.ErrUserNotFoundInDB
+		}
+		return nil, fmt.Errorf("failed to get user by name: %w", err)
+	}
+
+	me, err := s.resolveMe(username, member)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := toUserResponse(member)
+	return &ProfileSummaryResponse{
+		ID:              identity.ID,
+		UUID:            identity.UUID,
+		User:            username,
+		FirstName:       identity.FirstName,
+		LastName:        identity.LastName,
+		Email:           identity.Email,
+		TeamID:          identity.TeamID,
+		TeamDomain:      identity.TeamDomain,
+		TeamRole:        identity.TeamRole,
+		PortalAdmin:     isPortalAdmin(member),
+		AIInstances:     me.AIInstances,
+		FavoritesCount:  len(getFavoriteLinkIDsFromUser(member)),
+		SubscribedCount: len(getSubscribedPluginIDsFromUser(member)),
+	}, nil
+}
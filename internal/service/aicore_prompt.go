@@ -0,0 +1,85 @@
+package service
+
+import (
+	"fmt"
+
+	apperrors "developer-portal-backend/internal/errors"
+)
+
+const (
+	defaultAICoreMaxMessages     = 1000
+	defaultAICoreMaxPromptTokens = 200000
+)
+
+// PromptTokenEstimator approximates the number of prompt tokens of a set of messages
+type PromptTokenEstimator func(messages []AICoreInferenceMessage) int
+
+// promptLimits bounds the size of inference requests accepted before calling AI Core
+type promptLimits struct {
+	maxMessages int
+	maxTokens   int
+	estimate    PromptTokenEstimator
+}
+
+// getPromptLimits reads AI_CORE_MAX_MESSAGES and AI_CORE_MAX_PROMPT_TOKENS, falling back to defaults when unset or invalid
+func getPromptLimits() promptLimits {
+	return promptLimits{
+		maxMessages: getPositiveIntEnv("AI_CORE_MAX_MESSAGES", defaultAICoreMaxMessages),
+		maxTokens:   getPositiveIntEnv("AI_CORE_MAX_PROMPT_TOKENS", defaultAICoreMaxPromptTokens),
+		estimate:    estimatePromptTokens,
+	}
+}
+
+// SetPromptLimits overrides the maximum number of messages and estimated prompt tokens per inference request.
+// A value of 0 disables the corresponding check.
+func (s *AICoreService) SetPromptLimits(maxMessages, maxTokens int) {
+	s.promptLimits.maxMessages = maxMessages
+	s.promptLimits.maxTokens = maxTokens
+}
+
+// SetPromptTokenEstimator replaces the chars/4 token estimate; nil restores the default
+func (s *AICoreService) SetPromptTokenEstimator(estimator PromptTokenEstimator) {
+	if estimator == nil {
+		estimator = estimatePromptTokens
+	}
+	s.promptLimits.estimate = estimator
+}
+
+// checkPromptSize rejects requests with more messages or estimated tokens than the configured limits
+func (s *AICoreService) checkPromptSize(req *AICoreInferenceRequest) error {
+	limits := s.promptLimits
+	if limits.maxMessages > 0 && len(req.Messages) > limits.maxMessages {
+		return fmt.Errorf("%w: %d messages exceed the limit of %d", apperrors.ErrPromptTooLarge, len(req.Messages), limits.maxMessages)
+	}
+	if limits.maxTokens > 0 && limits.estimate != nil {
+		if tokens := limits.estimate(req.Messages); tokens > limits.maxTokens {
+			return fmt.Errorf("%w: about %d tokens exceed the limit of %d", apperrors.ErrPromptTooLarge, tokens, limits.maxTokens)
+		}
+	}
+	return nil
+}
+
+// estimatePromptTokens approximates tokens as one per four characters of text content; images and files are not counted
+func estimatePromptTokens(messages []AICoreInferenceMessage) int {
+	chars := 0
+	for _, msg := range messages {
+		switch content := msg.Content.(type) {
+		case string:
+			chars += len(content)
+		case []AICoreMessageContent:
+			for _, part := range content {
+				chars += len(part.Text)
+			}
+		case []interface{}:
+			// Content decoded from JSON arrives as generic maps
+			for _, part := range content {
+				if m, ok := part.(map[string]interface{}); ok {
+					if text, ok := m["text"].(string); ok {
+						chars += len(text)
+					}
+				}
+			}
+		}
+	}
+	return chars / 4
+}
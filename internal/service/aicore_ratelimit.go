@@ -0,0 +1,294 @@
+package service
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultAICoreTeamRateLimit = 60 // requests per minute per team
+	defaultAICoreTeamRateBurst = 10
+	defaultAICoreMaxRetries    = 2
+	defaultAICoreMaxRetryDelay = 10 * time.Second
+	// Rate-limited responses tolerated per AI Core instance during one GetDeployments fan-out
+	defaultAICoreFanOutThrottleBudget = 4
+
+	defaultAICoreUploadRateLimit = 30 // uploads per minute per user
+	defaultAICoreUploadRateBurst = 10
+)
+
+// teamTokenBucket holds the remaining tokens of a single team's bucket
+type teamTokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// teamRateLimiter is a goroutine-safe token-bucket limiter keyed by team name
+type teamRateLimiter struct {
+	mu         sync.Mutex
+	ratePerSec float64
+	burst      float64
+	buckets    map[string]*teamTokenBucket
+	now        func() time.Time
+}
+
+// newTeamRateLimiter creates a limiter refilling requestsPerMinute tokens per minute up to burst tokens
+func newTeamRateLimiter(requestsPerMinute, burst int) *teamRateLimiter {
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = defaultAICoreTeamRateLimit
+	}
+	if burst <= 0 {
+		burst = defaultAICoreTeamRateBurst
+	}
+	return &teamRateLimiter{
+		ratePerSec: float64(requestsPerMinute) / 60.0,
+		burst:      float64(burst),
+		buckets:    make(map[string]*teamTokenBucket),
+		now:        time.Now,
+	}
+}
+
+// newTeamRateLimiterFromEnv creates a limiter configured by AI_CORE_TEAM_RATE_LIMIT (requests per minute)
+// and AI_CORE_TEAM_RATE_BURST, falling back to defaults when unset or invalid
+func newTeamRateLimiterFromEnv() *teamRateLimiter {
+	return newTeamRateLimiter(
+		getPositiveIntEnv("AI_CORE_TEAM_RATE_LIMIT", defaultAICoreTeamRateLimit),
+		getPositiveIntEnv("AI_CORE_TEAM_RATE_BURST", defaultAICoreTeamRateBurst),
+	)
+}
+
+// Allow consumes a token from the team's bucket and reports whether the call may proceed.
+// When the bucket is empty it also returns how long until the next token is available.
+func (l *teamRateLimiter) Allow(teamName string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket, exists := l.buckets[teamName]
+	if !exists {
+		bucket = &teamTokenBucket{tokens: l.burst, lastRefill: now}
+		l.buckets[teamName] = bucket
+	}
+
+	// Refill based on the time elapsed since the last call
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	if elapsed > 0 {
+		bucket.tokens += elapsed * l.ratePerSec
+		if bucket.tokens > l.burst {
+			bucket.tokens = l.burst
+		}
+		bucket.lastRefill = now
+	}
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.ratePerSec * float64(time.Second))
+		return false, wait.Round(time.Second)
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// getAICoreMaxRetries reads AI_CORE_MAX_RETRIES, allowing 0 to disable retries
+func getAICoreMaxRetries() int {
+	value, err := strconv.Atoi(os.Getenv("AI_CORE_MAX_RETRIES"))
+	if err != nil || value < 0 {
+		return defaultAICoreMaxRetries
+	}
+	return value
+}
+
+// getAICoreMaxRetryDelay reads AI_CORE_MAX_RETRY_DELAY_SECONDS, the cap applied to upstream Retry-After values
+func getAICoreMaxRetryDelay() time.Duration {
+	return time.Duration(getPositiveIntEnv("AI_CORE_MAX_RETRY_DELAY_SECONDS", int(defaultAICoreMaxRetryDelay/time.Second))) * time.Second
+}
+
+// getAICoreFanOutThrottleBudget reads AI_CORE_FANOUT_THROTTLE_BUDGET, allowing 0 to skip an instance's
+// remaining teams after its first rate-limited response
+func getAICoreFanOutThrottleBudget() int {
+	value, err := strconv.Atoi(os.Getenv("AI_CORE_FANOUT_THROTTLE_BUDGET"))
+	if err != nil || value < 0 {
+		return defaultAICoreFanOutThrottleBudget
+	}
+	return value
+}
+
+// getPositiveIntEnv reads a positive integer from the environment, returning fallback when unset or invalid
+func getPositiveIntEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// newUploadRateLimiterFromEnv creates a per-user limiter configured by AI_CORE_UPLOAD_RATE_LIMIT (uploads
+// per minute) and AI_CORE_UPLOAD_RATE_BURST, falling back to defaults when unset or invalid
+func newUploadRateLimiterFromEnv() *teamRateLimiter {
+	return newTeamRateLimiter(
+		getPositiveIntEnv("AI_CORE_UPLOAD_RATE_LIMIT", defaultAICoreUploadRateLimit),
+		getPositiveIntEnv("AI_CORE_UPLOAD_RATE_BURST", defaultAICoreUploadRateBurst),
+	)
+}
+
+// SetUploadRateLimit overrides the per-user rate limit for attachment uploads
+func (s *AICoreService) SetUploadRateLimit(uploadsPerMinute, burst int) {
+	s.uploadLimiter = newTeamRateLimiter(uploadsPerMinute, burst)
+}
+
+// checkUploadRateLimit returns ErrUploadRateLimited when the user's local upload bucket is empty
+func (s *AICoreService) checkUploadRateLimit(c *gin.Context) error {
+	if s.uploadLimiter == nil {
+		return nil
+	}
+	user, ok := auth.GetUserEmail(c)
+	if !ok || user == "" {
+		user, _ = auth.GetUsername(c)
+	}
+	if allowed, _ := s.uploadLimiter.Allow(user); !allowed {
+		return errors.ErrUploadRateLimited
+	}
+	return nil
+}
+
+// SetRateLimit overrides the per-team rate limit for inference and deployment calls
+func (s *AICoreService) SetRateLimit(requestsPerMinute, burst int) {
+	s.rateLimiter = newTeamRateLimiter(requestsPerMinute, burst)
+}
+
+// checkTeamRateLimit returns an AICoreRateLimitError when the team's local bucket is empty
+func (s *AICoreService) checkTeamRateLimit(teamName string) error {
+	if s.rateLimiter == nil {
+		return nil
+	}
+	if allowed, wait := s.rateLimiter.Allow(teamName); !allowed {
+		return &errors.AICoreRateLimitError{Team: teamName, RetryAfter: wait}
+	}
+	return nil
+}
+
+// SetRetryPolicy overrides how often and how long AI Core 429 responses are retried
+func (s *AICoreService) SetRetryPolicy(maxRetries int, maxRetryDelay time.Duration) {
+	s.maxRetries = maxRetries
+	s.maxRetryDelay = maxRetryDelay
+}
+
+// throttleBudget is a token bucket without refill holding the rate-limited responses tolerated from one
+// AI Core instance during a fan-out, so a degraded instance is not called for every remaining team.
+type throttleBudget struct {
+	remaining int
+	exhausted bool
+}
+
+// newThrottleBudget creates a budget tolerating limit rate-limited responses
+func newThrottleBudget(limit int) *throttleBudget {
+	return &throttleBudget{remaining: limit}
+}
+
+// take consumes one token, marking the budget exhausted when none are left
+func (b *throttleBudget) take() {
+	if b.remaining > 0 {
+		b.remaining--
+		return
+	}
+	b.exhausted = true
+}
+
+// isExhausted reports whether the instance rate limited more requests than the budget tolerates
+func (b *throttleBudget) isExhausted() bool {
+	return b.exhausted
+}
+
+// SetFanOutThrottleBudget overrides how many rate-limited responses one AI Core instance may return during
+// a GetDeployments call before its remaining teams are skipped
+func (s *AICoreService) SetFanOutThrottleBudget(limit int) {
+	s.throttleLimit = limit
+}
+
+// makeAICoreRequestWithRetry makes an AI Core request and transparently retries 429 responses,
+// waiting for the upstream Retry-After (capped to maxRetryDelay) between attempts.
+// When all retries are exhausted an AICoreRateLimitError carrying the last Retry-After is returned.
+func (s *AICoreService) makeAICoreRequestWithRetry(c *gin.Context, teamName, method, url, accessToken, resourceGroup string, body interface{}) (*http.Response, error) {
+	log := logger.New().WithFields(map[string]interface{}{
+		"team_name": teamName,
+		"url":       url,
+	})
+
+	for attempt := 0; ; attempt++ {
+		resp, err := s.makeAICoreRequest(requestContext(c), method, url, accessToken, resourceGroup, body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		resp.Body.Close()
+
+		if attempt >= s.maxRetries {
+			log.Warnf("AI Core: Rate limited, giving up after %d retries", attempt)
+			return nil, &errors.AICoreRateLimitError{Team: teamName, RetryAfter: retryAfter}
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = time.Second
+		}
+		if s.maxRetryDelay > 0 && delay > s.maxRetryDelay {
+			delay = s.maxRetryDelay
+		}
+		log.Infof("AI Core: Rate limited, retrying in %s (attempt %d of %d)", delay, attempt+1, s.maxRetries)
+
+		if err := sleepWithContext(c, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait.Round(time.Second)
+		}
+	}
+	return 0
+}
+
+// sleepWithContext waits for the given delay, returning early if the request is cancelled
+func sleepWithContext(c *gin.Context, delay time.Duration) error {
+	if c == nil || c.Request == nil {
+		time.Sleep(delay)
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.Request.Context().Done():
+		return c.Request.Context().Err()
+	}
+}
@@ -17,6 +17,10 @@ import (
 
 // ChatInferenceStream handles streaming chat inference using Server-Sent Events
 func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInferenceRequest, writer gin.ResponseWriter) error {
+	if err := s.checkPromptSize(req); err != nil {
+		return err
+	}
+
 	// Get all deployments accessible to the user
 	deploymentsResp, err := s.GetDeployments(c)
 	if err != nil {
@@ -48,43 +52,31 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 		return fmt.Errorf("deployment URL not available for deployment %s", req.DeploymentID)
 	}
 
+	// Throttle inference calls per team before reaching AI Core
+	if err := s.checkTeamRateLimit(targetTeamName); err != nil {
+		return err
+	}
+
 	// Get credentials and token for the team that owns this deployment
 	credentials, err := s.getCredentialsForTeam(targetTeamName)
 	if err != nil {
 		return fmt.Errorf("failed to get credentials for team %s: %w", targetTeamName, err)
 	}
 
-	accessToken, err := s.getAccessToken(credentials)
+	accessToken, err := s.getAccessToken(requestContext(c), credentials)
 	if err != nil {
 		return fmt.Errorf("failed to get access token: %w", err)
 	}
 
 	// Determine model type (same logic as ChatInference)
-	isOrchestration := false
-	isGPTModel := false
-	isGeminiModel := false
-	modelName := ""
-
-	// Check if this is orchestration based on scenario ID
-	if strings.Contains(strings.ToLower(targetDeployment.ScenarioID), "orchestration") {
-		isOrchestration = true
-	}
-
-	// Extract model name and check model type
-	if extractedName := extractModelNameFromDetails(targetDeployment.Details); extractedName != "" {
-		modelName = extractedName
-		lowerName := strings.ToLower(extractedName)
-		if strings.Contains(lowerName, "gpt") || strings.Contains(lowerName, "o1") ||
-			strings.Contains(lowerName, "o3") || strings.Contains(lowerName, "openai") {
-			isGPTModel = true
-		} else if strings.Contains(lowerName, "gemini") {
-			isGeminiModel = true
-		}
-	}
+	modelName, isOrchestration, isGPTModel, isGeminiModel := detectModelFamily(targetDeployment, req.ModelFamily)
 
 	// Trim messages to fit within model context limits
 	contextLimit := getModelContextLimit(modelName)
 	req.Messages = trimMessagesToContextLimit(req.Messages, contextLimit)
+	if !isGeminiModel {
+		req.Messages = withTeamSystemPrompt(req.Messages, credentials.SystemPrompt)
+	}
 
 	// Build inference payload (reuse logic from ChatInference)
 	var inferencePayload map[string]interface{}
@@ -156,16 +148,19 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 			},
 		}
 
-		if req.MaxTokens > 0 || req.Temperature > 0 {
-			generationConfig := make(map[string]interface{})
-			if req.MaxTokens > 0 {
-				generationConfig["maxOutputTokens"] = req.MaxTokens
-			}
-			if req.Temperature > 0 {
-				generationConfig["temperature"] = req.Temperature
-			}
+		generationConfig := make(map[string]interface{})
+		if req.MaxTokens > 0 {
+			generationConfig["maxOutputTokens"] = req.MaxTokens
+		}
+		if req.Temperature > 0 {
+			generationConfig["temperature"] = req.Temperature
+		}
+		addGeminiSamplingParams(generationConfig, req)
+		if len(generationConfig) > 0 {
 			inferencePayload["generation_config"] = generationConfig
 		}
+		addGeminiSafetySettings(inferencePayload, req)
+		addGeminiSystemInstruction(inferencePayload, credentials.SystemPrompt)
 
 		inferenceURL = fmt.Sprintf("%s/models/%s:streamGenerateContent", targetDeployment.DeploymentURL, modelName)
 	} else if isOrchestration {
@@ -182,10 +177,7 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 			})
 		}
 
-		modelParams := map[string]interface{}{
-			"frequency_penalty": 0,
-			"presence_penalty":  0,
-		}
+		modelParams := make(map[string]interface{})
 
 		if req.MaxTokens > 0 {
 			modelParams["max_tokens"] = req.MaxTokens
@@ -198,21 +190,27 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 		} else {
 			modelParams["temperature"] = 0.7
 		}
+		addOpenAISamplingParams(modelParams, req)
+
+		moduleConfigs := map[string]interface{}{
+			"templating_module_config": map[string]interface{}{
+				"template": templateMessages,
+			},
+			"llm_module_config": map[string]interface{}{
+				"model_name":    modelName,
+				"model_params":  modelParams,
+				"model_version": "latest",
+			},
+		}
+		if err := mergeOrchestrationModules(moduleConfigs, req.OrchestrationConfig); err != nil {
+			return err
+		}
 
 		inferencePayload = map[string]interface{}{
 			"orchestration_config": map[string]interface{}{
-				"module_configurations": map[string]interface{}{
-					"templating_module_config": map[string]interface{}{
-						"template": templateMessages,
-					},
-					"llm_module_config": map[string]interface{}{
-						"model_name":    modelName,
-						"model_params":  modelParams,
-						"model_version": "latest",
-					},
-				},
+				"module_configurations": moduleConfigs,
 			},
-			"input_params": map[string]interface{}{},
+			"input_params": orchestrationInputParams(req),
 			"stream":       true, // Enable streaming
 		}
 
@@ -225,11 +223,7 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 				"role": msg.Role,
 			}
 
-			if contentArr, ok := msg.Content.([]interface{}); ok {
-				message["content"] = contentArr
-			} else {
-				message["content"] = msg.Content
-			}
+			message["content"] = openAIMessageContent(msg.Content)
 
 			messages = append(messages, message)
 		}
@@ -255,26 +249,24 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 			} else {
 				inferencePayload["temperature"] = 0.7
 			}
-			if req.TopP > 0 {
-				inferencePayload["top_p"] = req.TopP
-			}
+			addOpenAISamplingParams(inferencePayload, req)
 		}
 
 		inferenceURL = fmt.Sprintf("%s/chat/completions?api-version=%s", targetDeployment.DeploymentURL, apiVersion)
 	} else {
 		// Anthropic Claude models (default if not GPT, Gemini, or Orchestration)
-		var systemPrompt string
-		var userMessages []map[string]string
+		// Anthropic takes a single system field, so every system message is folded into it
+		systemPrompt := anthropicSystemPrompt(req.Messages)
+		var userMessages []map[string]interface{}
 
 		for _, msg := range req.Messages {
 			if msg.Role == "system" {
-				systemPrompt = getMessageText(msg)
-			} else {
-				userMessages = append(userMessages, map[string]string{
-					"role":    msg.Role,
-					"content": getMessageText(msg),
-				})
+				continue
 			}
+			userMessages = append(userMessages, map[string]interface{}{
+				"role":    msg.Role,
+				"content": anthropicMessageContent(msg.Content),
+			})
 		}
 
 		inferencePayload = map[string]interface{}{
@@ -296,16 +288,14 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 		} else {
 			inferencePayload["temperature"] = 0.7
 		}
-		if req.TopP > 0 {
-			inferencePayload["top_p"] = req.TopP
-		}
+		addAnthropicSamplingParams(inferencePayload, req)
 
 		// SAP AI Core Claude streaming uses invoke-with-response-stream endpoint
 		inferenceURL = fmt.Sprintf("%s/invoke-with-response-stream", targetDeployment.DeploymentURL)
 	}
 
 	// Make the streaming request
-	resp, err := s.makeAICoreRequest("POST", inferenceURL, accessToken, credentials.ResourceGroup, inferencePayload)
+	resp, err := s.makeAICoreRequestWithRetry(c, targetTeamName, "POST", inferenceURL, accessToken, credentials.ResourceGroup, inferencePayload)
 	if err != nil {
 		return fmt.Errorf("failed to make inference request: %w", err)
 	}
@@ -313,7 +303,7 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("inference request failed with status %d: %s", resp.StatusCode, string(body))
+		return newAICoreAPIError(targetTeamName, resp.StatusCode, body)
 	}
 
 	// Stream the response using SSE
@@ -324,7 +314,8 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 
 	log := logger.FromGinContext(c)
 
-	// Read the streaming response line by line
+	// Read the streaming response line by line, collecting any token usage the chunks report
+	var usage AICoreInferenceUsage
 	reader := bufio.NewReader(resp.Body)
 	for {
 		line, err := reader.ReadBytes('\n')
@@ -361,6 +352,7 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 				log.Warnf("Failed to parse chunk: %v", err)
 				continue
 			}
+			accumulateStreamUsage(&usage, chunk)
 
 			// Convert Gemini format to OpenAI format if needed
 			if isGeminiModel {
@@ -391,7 +383,7 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 
 										// Check for finish reason
 										if finishReason, ok := candidate["finishReason"].(string); ok && finishReason != "" {
-											openAIChunk["choices"].([]map[string]interface{})[0]["finish_reason"] = strings.ToLower(finishReason)
+											openAIChunk["choices"].([]map[string]interface{})[0]["finish_reason"] = normalizeFinishReason(finishReason)
 										}
 
 										convertedData, _ := json.Marshal(openAIChunk)
@@ -412,5 +404,7 @@ func (s *AICoreService) ChatInferenceStream(c *gin.Context, req *AICoreInference
 		}
 	}
 
+	s.recordUsage(c, targetTeamName, usage)
+
 	return nil
 }
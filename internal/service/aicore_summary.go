@@ -0,0 +1,37 @@
+package service
+
+import "github.com/gin-gonic/gin"
+
+// DeploymentSummary is the flattened view of a deployment used by the model picker
+type DeploymentSummary struct {
+	ID         string `json:"id"`
+	ModelName  string `json:"modelName"` // Empty when the deployment does not report a model
+	ScenarioID string `json:"scenarioId"`
+	Status     string `json:"status"`
+}
+
+// GetDeploymentSummaries lists the user's deployments across teams with the model name extracted from
+// details.resources.backend_details.model.name, so clients need not parse the nested details
+func (s *AICoreService) GetDeploymentSummaries(c *gin.Context) (_ []DeploymentSummary, err error) {
+	log, endCall := s.startCall(c, "GetDeploymentSummaries")
+	defer func() { endCall(err) }()
+
+	deployments, err := s.listDeployments(c, log, AICoreDeploymentFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]DeploymentSummary, 0, deployments.Count)
+	for _, team := range deployments.Deployments {
+		for _, deployment := range team.Deployments {
+			summaries = append(summaries, DeploymentSummary{
+				ID:         deployment.ID,
+				ModelName:  extractModelNameFromDetails(deployment.Details),
+				ScenarioID: deployment.ScenarioID,
+				Status:     deployment.Status,
+			})
+		}
+	}
+
+	return summaries, nil
+}
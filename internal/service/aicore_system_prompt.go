@@ -0,0 +1,70 @@
+package service
+
+import "strings"
+
+// withTeamSystemPrompt prepends a team's mandatory system prompt to the conversation. An existing
+// text system message is merged with it, the team prompt first, so user instructions are kept.
+func withTeamSystemPrompt(messages []AICoreInferenceMessage, systemPrompt string) []AICoreInferenceMessage {
+	if systemPrompt == "" {
+		return messages
+	}
+
+	result := make([]AICoreInferenceMessage, len(messages))
+	copy(result, messages)
+	for i, msg := range result {
+		if msg.Role != "system" {
+			continue
+		}
+		if text, ok := msg.Content.(string); ok {
+			if text != "" {
+				systemPrompt += "\n\n" + text
+			}
+			result[i].Content = systemPrompt
+			return result
+		}
+		break
+	}
+
+	return append([]AICoreInferenceMessage{{Role: "system", Content: systemPrompt}}, result...)
+}
+
+// addGeminiSystemInstruction sets a team's system prompt as Gemini's systemInstruction, since Gemini has no system role
+func addGeminiSystemInstruction(payload map[string]interface{}, systemPrompt string) {
+	if systemPrompt == "" {
+		return
+	}
+	payload["systemInstruction"] = map[string]interface{}{
+		"parts": []map[string]interface{}{{"text": systemPrompt}},
+	}
+}
+
+// anthropicSystemPrompt joins the text of every system message in order. Anthropic takes a single system
+// field, so keeping only one message would drop the team prompt withTeamSystemPrompt placed in the first.
+func anthropicSystemPrompt(messages []AICoreInferenceMessage) string {
+	var texts []string
+	for _, msg := range messages {
+		if msg.Role != "system" {
+			continue
+		}
+		if text := messageContentText(msg.Content); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// messageContentText returns plain message content, or the text parts of multimodal content joined by blank lines
+func messageContentText(content interface{}) string {
+	parts, ok := messageContentParts(content)
+	if !ok {
+		text, _ := content.(string)
+		return text
+	}
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if text, _ := part["text"].(string); part["type"] == "text" && text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"developer-portal-backend/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UsageRecorder receives the token usage of each successful chat inference, e.g. to account spend per team and user
+type UsageRecorder interface {
+	Record(ctx context.Context, team, user string, promptTokens, completionTokens int)
+}
+
+// noopUsageRecorder discards usage; it is the default until a recorder is configured
+type noopUsageRecorder struct{}
+
+func (noopUsageRecorder) Record(ctx context.Context, team, user string, promptTokens, completionTokens int) {
+}
+
+// SetUsageRecorder sets the recorder notified after each successful inference; nil restores the default no-op recorder
+func (s *AICoreService) SetUsageRecorder(recorder UsageRecorder) {
+	if recorder == nil {
+		recorder = noopUsageRecorder{}
+	}
+	s.usageRecorder = recorder
+}
+
+// recordUsage reports the token usage of an inference made on behalf of the authenticated user
+func (s *AICoreService) recordUsage(c *gin.Context, team string, usage AICoreInferenceUsage) {
+	if s.usageRecorder == nil {
+		return
+	}
+	user, _ := auth.GetUserEmail(c)
+	s.usageRecorder.Record(requestContext(c), team, user, usage.PromptTokens, usage.CompletionTokens)
+}
+
+// accumulateStreamUsage collects token counts reported in a streaming chunk. OpenAI chunks carry a "usage"
+// object, Anthropic reports input tokens on message_start and output tokens on message_delta, and Gemini
+// repeats cumulative "usageMetadata" counts. Orchestration streams carry no counts and leave usage untouched.
+func accumulateStreamUsage(usage *AICoreInferenceUsage, chunk map[string]interface{}) {
+	intField := func(m map[string]interface{}, key string) (int, bool) {
+		v, ok := m[key].(float64)
+		return int(v), ok
+	}
+
+	if u, ok := chunk["usage"].(map[string]interface{}); ok {
+		if v, ok := intField(u, "prompt_tokens"); ok {
+			usage.PromptTokens = v
+		}
+		if v, ok := intField(u, "completion_tokens"); ok {
+			usage.CompletionTokens = v
+		}
+		if v, ok := intField(u, "output_tokens"); ok {
+			usage.CompletionTokens = v
+		}
+	}
+	if message, ok := chunk["message"].(map[string]interface{}); ok {
+		if u, ok := message["usage"].(map[string]interface{}); ok {
+			if v, ok := intField(u, "input_tokens"); ok {
+				usage.PromptTokens = v
+			}
+		}
+	}
+	if u, ok := chunk["usageMetadata"].(map[string]interface{}); ok {
+		if v, ok := intField(u, "promptTokenCount"); ok {
+			usage.PromptTokens = v
+		}
+		if v, ok := intField(u, "candidatesTokenCount"); ok {
+			usage.CompletionTokens = v
+		}
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/repository"
 
 	"github.com/go-playground/validator/v10"
@@ -71,16 +72,16 @@ type UpdateDocumentationRequest struct {
 // CreateDocumentation validates and creates a new documentation
 func (s *DocumentationService) CreateDocumentation(req *CreateDocumentationRequest) (*DocumentationResponse, error) {
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 
 	if strings.TrimSpace(req.CreatedBy) == "" {
-		return nil, fmt.Errorf("created_by is required")
+		return nil, apperrors.NewValidationError("created_by", "created_by is required")
 	}
 
 	teamID, err := uuid.Parse(req.TeamID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid team_id UUID: %w", err)
+		return nil, apperrors.NewValidationError("team_id", "invalid team_id UUID: "+err.Error())
 	}
 
 	// Validate team exists
@@ -92,7 +93,7 @@ func (s *DocumentationService) CreateDocumentation(req *CreateDocumentationReque
 	// Parse and validate GitHub URL
 	owner, repo, branch, docsPath, err := parseGitHubURL(req.URL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid GitHub URL: %w", err)
+		return nil, apperrors.NewValidationError("url", "invalid GitHub URL: "+err.Error())
 	}
 
 	doc := &models.Documentation{
@@ -145,11 +146,11 @@ func (s *DocumentationService) GetDocumentationsByTeamID(teamID uuid.UUID) ([]Do
 // UpdateDocumentation updates an existing documentation
 func (s *DocumentationService) UpdateDocumentation(id uuid.UUID, req *UpdateDocumentationRequest) (*DocumentationResponse, error) {
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 
 	if strings.TrimSpace(req.UpdatedBy) == "" {
-		return nil, fmt.Errorf("updated_by is required")
+		return nil, apperrors.NewValidationError("updated_by", "updated_by is required")
 	}
 
 	// Get existing documentation
@@ -162,7 +163,7 @@ func (s *DocumentationService) UpdateDocumentation(id uuid.UUID, req *UpdateDocu
 	if req.URL != nil && *req.URL != "" {
 		owner, repo, branch, docsPath, err := parseGitHubURL(*req.URL)
 		if err != nil {
-			return nil, fmt.Errorf("invalid GitHub URL: %w", err)
+			return nil, apperrors.NewValidationError("url", "invalid GitHub URL: "+err.Error())
 		}
 		doc.Owner = owner
 		doc.Repo = repo
@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
@@ -10,6 +11,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/mocks"
 	"developer-portal-backend/internal/service"
 )
@@ -289,6 +291,24 @@ func (suite *DocumentationServiceTestSuite) TestCreateDocumentation_Error_Missin
 	suite.Contains(err.Error(), "validation failed")
 }
 
+func (suite *DocumentationServiceTestSuite) TestCreateDocumentation_Error_ValidationMapsTo400() {
+	req := &service.CreateDocumentationRequest{
+		TeamID:      "",
+		URL:         "https://github.tools.sap/org/repo",
+		Title:       "Test Doc",
+		Description: "Test",
+		CreatedBy:   "test-user",
+	}
+
+	_, err := suite.documentationService.CreateDocumentation(req)
+
+	var appErr *apperrors.AppError
+	suite.Require().ErrorAs(err, &appErr)
+	suite.Equal(http.StatusBadRequest, appErr.HTTPStatus)
+	suite.Equal(apperrors.CodeValidation, appErr.Code)
+	suite.Equal("required", appErr.Fields["team_id"])
+}
+
 func (suite *DocumentationServiceTestSuite) TestCreateDocumentation_Error_InvalidTeamIDUUID() {
 	req := &service.CreateDocumentationRequest{
 		TeamID:      "not-a-uuid",
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifies the kind of domain event
+type EventType string
+
+const (
+	EventUserCreated     EventType = "user.created"
+	EventUserUpdated     EventType = "user.updated"
+	EventUserDeleted     EventType = "user.deleted"
+	EventUserTeamChanged EventType = "user.team_changed"
+)
+
+// FieldChange holds the previous and new value of a changed field
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Event is a domain event emitted after a successful repository write
+type Event struct {
+	Type       EventType              `json:"type"`
+	UserID     uuid.UUID              `json:"user_id"`
+	Changes    map[string]FieldChange `json:"changes,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// EventPublisher delivers domain events to downstream consumers such as a search indexer or audit log
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// userEventFields captures the user fields tracked in event diffs; a nil user yields no fields
+func userEventFields(user *models.User) map[string]interface{} {
+	if user == nil {
+		return nil
+	}
+
+	var teamID interface{}
+	if user.TeamID != nil {
+		teamID = user.TeamID.String()
+	}
+
+	return map[string]interface{}{
+		"user_id":       user.UserID,
+		"first_name":    user.FirstName,
+		"last_name":     user.LastName,
+		"email":         user.Email,
+		"pending_email": user.PendingEmail,
+		"mobile":        user.Mobile,
+		"role":          string(user.TeamDomain),
+		"team_role":     string(user.TeamRole),
+		"team_id":       teamID,
+		"metadata":      string(user.Metadata),
+		"deleted":       user.DeletedAt.Valid,
+	}
+}
+
+// diffFields returns the fields whose values differ between before and after
+func diffFields(before, after map[string]interface{}) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	for key, oldValue := range before {
+		if newValue := after[key]; newValue != oldValue {
+			changes[key] = FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	for key, newValue := range after {
+		if _, seen := before[key]; !seen && newValue != nil {
+			changes[key] = FieldChange{Old: nil, New: newValue}
+		}
+	}
+	return changes
+}
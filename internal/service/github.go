@@ -3,18 +3,26 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
+	pathpkg "path"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/cache"
 	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/logger"
+	"developer-portal-backend/internal/repository"
 
 	"github.com/google/go-github/v57/github"
 	"golang.org/x/oauth2"
@@ -22,22 +30,136 @@ import (
 
 // GitHubService provides methods to interact with GitHub API
 type GitHubService struct {
-	authService GitHubAuthService
+	authService    GitHubAuthService
+	cache          cache.CacheService
+	ttlConfig      cache.TTLConfig
+	maxSearchPages int
+	maxFileSize    int
+	vcsProviders   map[string]VCSProvider
+
+	userRepo        repository.UserRepositoryInterface // Source of users' default providers, optional
+	defaultProvider string                             // Provider used when neither the caller nor the user names one
+
+	providerTimeouts map[string]time.Duration // Per-provider request timeout overrides
+
+	statsPollAttempts int           // Requests made while GitHub answers 202 for repository statistics
+	statsPollDelay    time.Duration // Wait between repository statistics requests
+
+	graphQLMaxAttempts    int                                                  // Attempts per GraphQL query on 5xx/network errors
+	graphQLRetryBaseDelay time.Duration                                        // Backoff before the first GraphQL retry
+	sleep                 func(ctx context.Context, delay time.Duration) error // Waits between GraphQL retries
 }
 
+const (
+	// defaultMaxSearchPages caps how many search result pages are aggregated when no explicit page is requested
+	defaultMaxSearchPages = 10
+	// defaultMaxFileSize caps the size in bytes of file content returned by GetRepositoryContent
+	defaultMaxFileSize = 1 << 20
+	// defaultStatsPollAttempts bounds how often repository statistics are requested while GitHub is computing them
+	defaultStatsPollAttempts = 4
+	// defaultStatsPollDelay is the wait between repository statistics requests
+	defaultStatsPollDelay = time.Second
+)
+
 // NewGitHubService creates a new GitHub service
 func NewGitHubService(authService *auth.AuthService) *GitHubService {
-	return &GitHubService{
-		authService: NewAuthServiceAdapter(authService),
-	}
+	return NewGitHubServiceWithAdapter(NewAuthServiceAdapter(authService))
+}
+
+// NewGitHubServiceWithCache creates a new GitHub service with caching support
+func NewGitHubServiceWithCache(authService *auth.AuthService, cacheService cache.CacheService, ttlConfig cache.TTLConfig) *GitHubService {
+	s := NewGitHubService(authService)
+	s.SetCache(cacheService)
+	s.SetTTLConfig(ttlConfig)
+	return s
 }
 
 // NewGitHubServiceWithAdapter creates a new GitHub service with a custom auth service adapter
 // This constructor is primarily for testing with mock auth services
 func NewGitHubServiceWithAdapter(authService GitHubAuthService) *GitHubService {
 	return &GitHubService{
-		authService: authService,
+		authService:    authService,
+		cache:          cache.NewNoOpCache(), // Default to no-op cache
+		ttlConfig:      cache.DefaultTTLConfig(),
+		maxSearchPages: defaultMaxSearchPages,
+		maxFileSize:    defaultMaxFileSize,
+		vcsProviders:   make(map[string]VCSProvider),
+
+		providerTimeouts: make(map[string]time.Duration),
+
+		statsPollAttempts: defaultStatsPollAttempts,
+		statsPollDelay:    defaultStatsPollDelay,
+
+		graphQLMaxAttempts:    defaultGraphQLMaxAttempts,
+		graphQLRetryBaseDelay: defaultGraphQLRetryBaseDelay,
+		sleep:                 sleepContext,
+	}
+}
+
+// SetCache sets the cache service (useful for testing or late initialization)
+func (s *GitHubService) SetCache(cacheService cache.CacheService) {
+	if cacheService == nil {
+		cacheService = cache.NewNoOpCache()
+	}
+	s.cache = cacheService
+}
+
+// SetTTLConfig sets the TTL configuration
+func (s *GitHubService) SetTTLConfig(config cache.TTLConfig) {
+	s.ttlConfig = config
+}
+
+// fileSizeLimiter is implemented by VCS providers that cap the size of file content they return
+type fileSizeLimiter interface {
+	SetMaxFileSize(maxBytes int)
+}
+
+// SetMaxFileSize sets the largest file in bytes GetRepositoryContent will return,
+// for GitHub and for the registered VCS providers that support a limit
+func (s *GitHubService) SetMaxFileSize(maxBytes int) {
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxFileSize
+	}
+	s.maxFileSize = maxBytes
+	for _, vcsProvider := range s.vcsProviders {
+		if limiter, ok := vcsProvider.(fileSizeLimiter); ok {
+			limiter.SetMaxFileSize(maxBytes)
+		}
+	}
+}
+
+// RegisterVCSProvider routes calls for the given provider name to another VCS implementation (e.g. GitLab).
+// The provider inherits the service's file size limit.
+func (s *GitHubService) RegisterVCSProvider(provider string, vcsProvider VCSProvider) {
+	if limiter, ok := vcsProvider.(fileSizeLimiter); ok {
+		limiter.SetMaxFileSize(s.maxFileSize)
+	}
+	s.vcsProviders[provider] = vcsProvider
+}
+
+// GetOpenPullRequests implements VCSProvider
+func (s *GitHubService) GetOpenPullRequests(ctx context.Context, userUUID, provider, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error) {
+	return s.GetUserOpenPullRequests(ctx, userUUID, provider, state, sort, direction, perPage, page)
+}
+
+// SetStatsPolling sets how often and how far apart repository statistics are requested while GitHub answers 202
+func (s *GitHubService) SetStatsPolling(attempts int, delay time.Duration) {
+	if attempts <= 0 {
+		attempts = defaultStatsPollAttempts
+	}
+	if delay < 0 {
+		delay = defaultStatsPollDelay
+	}
+	s.statsPollAttempts = attempts
+	s.statsPollDelay = delay
+}
+
+// SetMaxSearchPages sets how many search result pages are aggregated when no explicit page is requested
+func (s *GitHubService) SetMaxSearchPages(maxPages int) {
+	if maxPages <= 0 {
+		maxPages = defaultMaxSearchPages
 	}
+	s.maxSearchPages = maxPages
 }
 
 // PullRequest represents a GitHub pull request
@@ -52,6 +174,30 @@ type PullRequest struct {
 	User      GitHubUser `json:"user"`
 	Repo      Repository `json:"repository"`
 	Draft     bool       `json:"draft" example:"false"`
+	Provider  string     `json:"provider,omitempty" example:"githubtools"`
+}
+
+// Review statuses reported in PullRequestDetails.ReviewStatus
+const (
+	ReviewStatusApproved         = "approved"
+	ReviewStatusChangesRequested = "changes_requested"
+	ReviewStatusReviewRequired   = "review_required"
+	ReviewStatusNone             = "none"
+)
+
+// PullRequestDetails represents a single pull request with its merge and review state
+type PullRequestDetails struct {
+	PullRequest
+	Merged         bool   `json:"merged" example:"false"`
+	Mergeable      *bool  `json:"mergeable" example:"true"` // nil while GitHub is still computing mergeability
+	MergeableState string `json:"mergeable_state" example:"clean"`
+	ReviewStatus   string `json:"review_status" example:"approved"`
+	ChangedFiles   int    `json:"changed_files" example:"3"`
+	Additions      int    `json:"additions" example:"120"`
+	Deletions      int    `json:"deletions" example:"15"`
+	Commits        int    `json:"commits" example:"2"`
+	BaseBranch     string `json:"base_branch" example:"main"`
+	HeadBranch     string `json:"head_branch" example:"feature/new-thing"`
 }
 
 // GitHubUser represents a GitHub user
@@ -63,16 +209,47 @@ type GitHubUser struct {
 
 // Repository represents a GitHub repository
 type Repository struct {
-	Name     string `json:"name" example:"my-repo"`
-	FullName string `json:"full_name" example:"owner/my-repo"`
-	Owner    string `json:"owner" example:"owner"`
-	Private  bool   `json:"private" example:"false"`
+	Name          string `json:"name" example:"my-repo"`
+	FullName      string `json:"full_name" example:"owner/my-repo"`
+	Owner         string `json:"owner" example:"owner"`
+	Private       bool   `json:"private" example:"false"`
+	Description   string `json:"description,omitempty" example:"Backend for the developer portal"`
+	DefaultBranch string `json:"default_branch,omitempty" example:"main"`
+}
+
+// RepositoriesResponse represents the response for repository searches
+type RepositoriesResponse struct {
+	Repositories []Repository `json:"repositories"`
+	Total        int          `json:"total"`
+	NextPage     int          `json:"next_page,omitempty" example:"2"`
 }
 
 // PullRequestsResponse represents the response for pull requests
 type PullRequestsResponse struct {
-	PullRequests []PullRequest `json:"pull_requests"`
-	Total        int           `json:"total"`
+	PullRequests  []PullRequest     `json:"pull_requests"`
+	Total         int               `json:"total"`
+	NextPage      int               `json:"next_page,omitempty" example:"2"`
+	PartialErrors map[string]string `json:"partial_errors,omitempty"` // provider -> error, set by multi-provider queries
+}
+
+// Issue represents a GitHub issue
+type Issue struct {
+	ID        int64      `json:"id" example:"1234567890"`
+	Number    int        `json:"number" example:"17"`
+	Title     string     `json:"title" example:"Broken link on landing page"`
+	State     string     `json:"state" example:"open"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-01-01T12:00:00Z"`
+	UpdatedAt time.Time  `json:"updated_at" example:"2025-01-02T12:00:00Z"`
+	HTMLURL   string     `json:"html_url" example:"https://github.com/owner/repo/issues/17"`
+	User      GitHubUser `json:"user"`
+	Repo      Repository `json:"repository"`
+}
+
+// IssuesResponse represents the response for issues
+type IssuesResponse struct {
+	Issues   []Issue `json:"issues"`
+	Total    int     `json:"total"`
+	NextPage int     `json:"next_page,omitempty" example:"2"`
 }
 
 // TotalContributions Response represents the response for user contributions
@@ -111,6 +288,8 @@ type ContributionsHeatmapResponse struct {
 	Weeks              []ContributionWeek `json:"weeks"`
 	From               string             `json:"from" example:"2024-10-16T00:00:00Z"`
 	To                 string             `json:"to" example:"2025-10-16T23:59:59Z"`
+	// ETag is a content hash of the contribution counts, sent as a response header
+	ETag string `json:"-"`
 }
 
 // PRMergeTimeDataPoint represents a single data point for PR merge time metrics (weekly)
@@ -124,6 +303,9 @@ type PRMergeTimeDataPoint struct {
 // AveragePRMergeTimeResponse represents the response for average PR merge time
 type AveragePRMergeTimeResponse struct {
 	AveragePRMergeTimeHours float64                `json:"average_pr_merge_time_hours" example:"24.5"`
+	MedianPRMergeTimeHours  float64                `json:"median_pr_merge_time_hours" example:"12.25"`
+	P90PRMergeTimeHours     float64                `json:"p90_pr_merge_time_hours" example:"60.5"`
+	MaxPRMergeTimeHours     float64                `json:"max_pr_merge_time_hours" example:"96"`
 	PRCount                 int                    `json:"pr_count" example:"15"`
 	Period                  string                 `json:"period" example:"30d"`
 	From                    string                 `json:"from" example:"2024-10-03T00:00:00Z"`
@@ -131,6 +313,37 @@ type AveragePRMergeTimeResponse struct {
 	TimeSeries              []PRMergeTimeDataPoint `json:"time_series"`
 }
 
+// WeeklyCommits represents the number of commits to a repository in one week
+type WeeklyCommits struct {
+	WeekStart string `json:"week_start" example:"2024-10-13"`
+	Count     int    `json:"count" example:"42"`
+}
+
+// gitHubRateLimitError classifies a 403 from GitHub as the primary (hourly quota) or the secondary rate limit.
+// err is the go-github error of the call; raw requests pass github.CheckResponse(resp), which keeps the body readable.
+// Secondary limits are recognised by go-github's AbuseRateLimitError, a Retry-After header or their message.
+func gitHubRateLimitError(resp *http.Response, err error) error {
+	var primaryErr *github.RateLimitError
+	if errors.As(err, &primaryErr) {
+		return apperrors.ErrGitHubAPIRateLimitExceeded
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return &apperrors.GitHubSecondaryRateLimitError{RetryAfter: abuseErr.GetRetryAfter()}
+	}
+	if resp != nil {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			seconds, _ := strconv.Atoi(retryAfter)
+			return &apperrors.GitHubSecondaryRateLimitError{RetryAfter: time.Duration(seconds) * time.Second}
+		}
+	}
+	var respErr *github.ErrorResponse
+	if errors.As(err, &respErr) && strings.Contains(strings.ToLower(respErr.Message), "secondary rate limit") {
+		return &apperrors.GitHubSecondaryRateLimitError{}
+	}
+	return apperrors.ErrGitHubAPIRateLimitExceeded
+}
+
 // parseRepositoryFromURL extracts repository information from a GitHub URL
 // Handles URLs like: https://github.com/owner/repo/pull/123
 // or https://github.enterprise.com/owner/repo/pull/123
@@ -159,12 +372,383 @@ func parseRepositoryFromURL(urlStr string) (owner, repoName, fullName string) {
 	return owner, repoName, fullName
 }
 
-// GetUserOpenPullRequests retrieves all open pull requests for the authenticated user
+// QueryFilters narrows a pull request search. Empty fields add no qualifiers.
+type QueryFilters struct {
+	Org     string   // restrict to repositories of this organization (org:)
+	Repo    string   // restrict to a single repository in owner/name form (repo:)
+	Exclude []string // labels whose pull requests are left out (-label:)
+}
+
+// qualifiers renders the filters as GitHub search qualifiers, quoting values so they cannot inject further qualifiers
+func (f QueryFilters) qualifiers() string {
+	var parts []string
+	if org := strings.TrimSpace(f.Org); org != "" {
+		parts = append(parts, searchQualifier("org", org))
+	}
+	if repo := strings.TrimSpace(f.Repo); repo != "" {
+		parts = append(parts, searchQualifier("repo", repo))
+	}
+	for _, label := range f.Exclude {
+		if label = strings.TrimSpace(label); label != "" {
+			parts = append(parts, "-"+searchQualifier("label", label))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// searchQualifier formats key:value, quoting the value when it contains whitespace or quotes
+func searchQualifier(key, value string) string {
+	if strings.ContainsAny(value, " \t\"") {
+		value = `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return key + ":" + value
+}
+
+// GetUserOpenPullRequests retrieves all open pull requests for the authenticated user.
+// When page is positive only that page is returned, with NextPage set if more results exist.
+// When page is zero or negative all pages are aggregated, up to the configured page cap.
 func (s *GitHubService) GetUserOpenPullRequests(ctx context.Context, userUUID, provider, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error) {
+	return s.GetUserOpenPullRequestsWithFilters(ctx, userUUID, provider, state, sort, direction, perPage, page, QueryFilters{})
+}
+
+// GetUserOpenPullRequestsWithFilters behaves like GetUserOpenPullRequests and appends the filters' qualifiers to the search.
+// Filters apply to GitHub providers only; registered VCS providers ignore them.
+func (s *GitHubService) GetUserOpenPullRequestsWithFilters(ctx context.Context, userUUID, provider, state, sort, direction string, perPage, page int, filters QueryFilters) (*PullRequestsResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+
+	if vcsProvider, ok := s.vcsProviders[provider]; ok {
+		return vcsProvider.GetOpenPullRequests(ctx, userUUID, provider, state, sort, direction, perPage, page)
+	}
+
+	client, err := s.newAuthenticatedClient(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default values
+	if state == "" {
+		state = "open"
+	}
+	if sort == "" {
+		sort = "created"
+	}
+	if direction == "" {
+		direction = "desc"
+	}
+	perPage = ClampPageSize(perPage)
+
+	// Search for pull requests created by the authenticated user
+	// Using search API for better filtering capabilities
+	// Note: GitHub Search API doesn't support state:all - omit state qualifier to get all PRs
+	var query string
+	if state == "all" {
+		query = "is:pr author:@me"
+	} else {
+		query = fmt.Sprintf("is:pr author:@me state:%s", state)
+	}
+	if qualifiers := filters.qualifiers(); qualifiers != "" {
+		query += " " + qualifiers
+	}
+
+	return s.searchPullRequests(ctx, client, query, sort, direction, perPage, page)
+}
+
+// GetUserOpenPullRequestsAllProviders queries every given provider concurrently and merges the results.
+// Each PR is annotated with its source provider. A failing provider is recorded in PartialErrors
+// instead of failing the call; an error is only returned when every provider fails.
+func (s *GitHubService) GetUserOpenPullRequestsAllProviders(ctx context.Context, userUUID string, providers []string, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error) {
+	if userUUID == "" || len(providers) == 0 {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+
+	type providerResult struct {
+		response *PullRequestsResponse
+		err      error
+	}
+
+	results := make([]providerResult, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(i int, provider string) {
+			defer wg.Done()
+			response, err := s.GetUserOpenPullRequests(ctx, userUUID, provider, state, sort, direction, perPage, page)
+			results[i] = providerResult{response: response, err: err}
+		}(i, provider)
+	}
+	wg.Wait()
+
+	// Merge in provider order so the output is deterministic
+	merged := &PullRequestsResponse{
+		PullRequests: make([]PullRequest, 0),
+	}
+	for i, result := range results {
+		if result.err != nil {
+			if merged.PartialErrors == nil {
+				merged.PartialErrors = make(map[string]string)
+			}
+			merged.PartialErrors[providers[i]] = result.err.Error()
+			continue
+		}
+		for _, pr := range result.response.PullRequests {
+			pr.Provider = providers[i]
+			merged.PullRequests = append(merged.PullRequests, pr)
+		}
+		merged.Total += result.response.Total
+	}
+
+	if len(merged.PartialErrors) == len(providers) {
+		return nil, fmt.Errorf("failed to fetch pull requests from all providers: %w", results[0].err)
+	}
+
+	return merged, nil
+}
+
+// GetUserReviewRequests retrieves pull requests awaiting review from the authenticated user.
+// Paging follows the same rules as GetUserOpenPullRequests.
+func (s *GitHubService) GetUserReviewRequests(ctx context.Context, userUUID, provider, state, sort, order string, perPage, page int) (*PullRequestsResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
 	if userUUID == "" || provider == "" {
 		return nil, apperrors.ErrMissingUserUUIDAndProvider
 	}
 
+	client, err := s.newAuthenticatedClient(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default values
+	if state == "" {
+		state = "open"
+	}
+	if sort == "" {
+		sort = "created"
+	}
+	if order == "" {
+		order = "desc"
+	}
+	perPage = ClampPageSize(perPage)
+
+	// Note: GitHub Search API doesn't support state:all - omit state qualifier to get all PRs
+	var query string
+	if state == "all" {
+		query = "is:pr review-requested:@me"
+	} else {
+		query = fmt.Sprintf("is:pr review-requested:@me state:%s", state)
+	}
+
+	return s.searchPullRequests(ctx, client, query, sort, order, perPage, page)
+}
+
+// GetUserAssignedIssues retrieves issues assigned to the authenticated user
+func (s *GitHubService) GetUserAssignedIssues(ctx context.Context, userUUID, provider, state, sort, order string, perPage, page int) (*IssuesResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+
+	client, err := s.newAuthenticatedClient(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default values
+	if state == "" {
+		state = "open"
+	}
+	if sort == "" {
+		sort = "created"
+	}
+	if order == "" {
+		order = "desc"
+	}
+	perPage = ClampPageSize(perPage)
+	if page <= 0 {
+		page = 1
+	}
+
+	// Note: GitHub Search API doesn't support state:all - omit state qualifier to get all issues
+	var query string
+	if state == "all" {
+		query = "is:issue assignee:@me"
+	} else {
+		query = fmt.Sprintf("is:issue assignee:@me state:%s", state)
+	}
+
+	searchOpts := &github.SearchOptions{
+		Sort:  sort,
+		Order: order,
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+			Page:    page,
+		},
+	}
+
+	result, resp, err := client.Search.Issues(ctx, query, searchOpts)
+	if err != nil {
+		// Check if it's a rate limit error
+		if resp != nil && resp.StatusCode == 403 {
+			return nil, gitHubRateLimitError(resp.Response, err)
+		}
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	issues := make([]Issue, 0, len(result.Issues))
+	for _, ghIssue := range result.Issues {
+		if ghIssue.PullRequestLinks != nil {
+			continue // Skip pull requests, they are issues in GitHub API too
+		}
+
+		issue := Issue{
+			ID:        ghIssue.GetID(),
+			Number:    ghIssue.GetNumber(),
+			Title:     ghIssue.GetTitle(),
+			State:     ghIssue.GetState(),
+			CreatedAt: ghIssue.GetCreatedAt().Time,
+			UpdatedAt: ghIssue.GetUpdatedAt().Time,
+			HTMLURL:   ghIssue.GetHTMLURL(),
+			User: GitHubUser{
+				Login:     ghIssue.GetUser().GetLogin(),
+				ID:        ghIssue.GetUser().GetID(),
+				AvatarURL: ghIssue.GetUser().GetAvatarURL(),
+			},
+			Repo: repositoryFromIssue(ghIssue),
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return &IssuesResponse{
+		Issues:   issues,
+		Total:    result.GetTotal(),
+		NextPage: resp.NextPage,
+	}, nil
+}
+
+// GetUserRepositories retrieves the repositories the authenticated user can access
+func (s *GitHubService) GetUserRepositories(ctx context.Context, userUUID, provider, sort string, perPage, page int) ([]Repository, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+
+	client, err := s.newAuthenticatedClient(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default values
+	if sort == "" {
+		sort = "updated"
+	}
+	perPage = ClampPageSize(perPage)
+	if page <= 0 {
+		page = 1
+	}
+
+	repos, resp, err := client.Repositories.List(ctx, "", &github.RepositoryListOptions{
+		Sort: sort,
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+			Page:    page,
+		},
+	})
+	if err != nil {
+		// Check if it's a rate limit error
+		if resp != nil && resp.StatusCode == 403 {
+			return nil, gitHubRateLimitError(resp.Response, err)
+		}
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	repositories := make([]Repository, 0, len(repos))
+	for _, repo := range repos {
+		repositories = append(repositories, repositoryFromGitHub(repo))
+	}
+
+	return repositories, nil
+}
+
+// SearchRepositories searches repositories visible to the authenticated user using GitHub search syntax
+func (s *GitHubService) SearchRepositories(ctx context.Context, userUUID, provider, query, sort string, perPage, page int) (*RepositoriesResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, apperrors.ErrSearchQueryMissing
+	}
+
+	client, err := s.newAuthenticatedClient(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default values; an empty sort keeps GitHub's best-match ordering
+	perPage = ClampPageSize(perPage)
+	if page <= 0 {
+		page = 1
+	}
+
+	result, resp, err := client.Search.Repositories(ctx, query, &github.SearchOptions{
+		Sort: sort,
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+			Page:    page,
+		},
+	})
+	if err != nil {
+		// Check if it's a rate limit error
+		if resp != nil && resp.StatusCode == 403 {
+			return nil, gitHubRateLimitError(resp.Response, err)
+		}
+		return nil, fmt.Errorf("failed to search repositories: %w", err)
+	}
+
+	repositories := make([]Repository, 0, len(result.Repositories))
+	for _, repo := range result.Repositories {
+		repositories = append(repositories, repositoryFromGitHub(repo))
+	}
+
+	return &RepositoriesResponse{
+		Repositories: repositories,
+		Total:        result.GetTotal(),
+		NextPage:     resp.NextPage,
+	}, nil
+}
+
+// repositoryFromGitHub converts a go-github repository into the portal's Repository shape
+func repositoryFromGitHub(repo *github.Repository) Repository {
+	return Repository{
+		Name:          repo.GetName(),
+		FullName:      repo.GetFullName(),
+		Owner:         repo.GetOwner().GetLogin(),
+		Private:       repo.GetPrivate(),
+		Description:   repo.GetDescription(),
+		DefaultBranch: repo.GetDefaultBranch(),
+	}
+}
+
+// newAuthenticatedClient resolves the user's access token and provider configuration into an authenticated GitHub client
+func (s *GitHubService) newAuthenticatedClient(ctx context.Context, userUUID, provider string) (*github.Client, error) {
 	// Get GitHub access token using validated JWT claims
 	accessToken, err := s.authService.GetGitHubAccessToken(userUUID, provider)
 	if err != nil {
@@ -181,44 +765,31 @@ func (s *GitHubService) GetUserOpenPullRequests(ctx context.Context, userUUID, p
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := withRequestTimeout(oauth2.NewClient(ctx, ts), s.requestTimeout(provider, githubClientConfig))
 
 	// Create authenticated GitHub client
-	var client *github.Client
 	if githubClientConfig != nil && githubClientConfig.GetEnterpriseBaseURL() != "" {
-		client, err = github.NewEnterpriseClient(githubClientConfig.GetEnterpriseBaseURL(), githubClientConfig.GetEnterpriseBaseURL(), tc)
+		client, err := github.NewEnterpriseClient(githubClientConfig.GetEnterpriseBaseURL(), githubClientConfig.GetEnterpriseBaseURL(), tc)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create GitHub Enterprise client: %w", err)
 		}
-	} else {
-		client = github.NewClient(tc)
+		return client, nil
 	}
+	return github.NewClient(tc), nil
+}
 
-	// Set default values
-	if state == "" {
-		state = "open"
-	}
-	if sort == "" {
-		sort = "created"
-	}
-	if direction == "" {
-		direction = "desc"
-	}
-	if perPage <= 0 || perPage > 100 {
-		perPage = 30
-	}
-	if page <= 0 {
+// searchPullRequests runs an issue search restricted to pull requests and converts the results.
+// A positive page fetches that single page; otherwise pages are followed via the Link header
+// until exhausted or maxSearchPages is reached, in which case NextPage points at the first unfetched page.
+func (s *GitHubService) searchPullRequests(ctx context.Context, client *github.Client, query, sort, direction string, perPage, page int) (*PullRequestsResponse, error) {
+	aggregate := page <= 0
+	if aggregate {
 		page = 1
 	}
 
-	// Search for pull requests created by the authenticated user
-	// Using search API for better filtering capabilities
-	// Note: GitHub Search API doesn't support state:all - omit state qualifier to get all PRs
-	var query string
-	if state == "all" {
-		query = "is:pr author:@me"
-	} else {
-		query = fmt.Sprintf("is:pr author:@me state:%s", state)
+	maxPages := s.maxSearchPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxSearchPages
 	}
 
 	searchOpts := &github.SearchOptions{
@@ -230,18 +801,37 @@ func (s *GitHubService) GetUserOpenPullRequests(ctx context.Context, userUUID, p
 		},
 	}
 
-	result, resp, err := client.Search.Issues(ctx, query, searchOpts)
-	if err != nil {
-		// Check if it's a rate limit error
-		if resp != nil && resp.StatusCode == 403 {
-			return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+	response := &PullRequestsResponse{
+		PullRequests: make([]PullRequest, 0),
+	}
+
+	for fetched := 0; ; fetched++ {
+		result, resp, err := client.Search.Issues(ctx, query, searchOpts)
+		if err != nil {
+			// Check if it's a rate limit error
+			if resp != nil && resp.StatusCode == 403 {
+				return nil, gitHubRateLimitError(resp.Response, err)
+			}
+			return nil, fmt.Errorf("failed to search pull requests: %w", err)
 		}
-		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+
+		response.Total = result.GetTotal()
+		response.PullRequests = append(response.PullRequests, convertIssuesToPullRequests(result.Issues)...)
+		response.NextPage = resp.NextPage
+
+		if !aggregate || resp.NextPage == 0 || fetched+1 >= maxPages {
+			break
+		}
+		searchOpts.Page = resp.NextPage
 	}
 
-	// Convert GitHub issues (PRs are issues in GitHub API) to our PR structure
-	pullRequests := make([]PullRequest, 0, len(result.Issues))
-	for _, issue := range result.Issues {
+	return response, nil
+}
+
+// convertIssuesToPullRequests converts GitHub issues (PRs are issues in GitHub API) to our PR structure
+func convertIssuesToPullRequests(issues []*github.Issue) []PullRequest {
+	pullRequests := make([]PullRequest, 0, len(issues))
+	for _, issue := range issues {
 		if issue.PullRequestLinks == nil {
 			continue // Skip if it's not actually a PR
 		}
@@ -260,47 +850,54 @@ func (s *GitHubService) GetUserOpenPullRequests(ctx context.Context, userUUID, p
 				ID:        issue.GetUser().GetID(),
 				AvatarURL: issue.GetUser().GetAvatarURL(),
 			},
-		}
-
-		// Parse repository info from the issue
-		if issue.Repository != nil {
-			pr.Repo = Repository{
-				Name:     issue.Repository.GetName(),
-				FullName: issue.Repository.GetFullName(),
-				Private:  issue.Repository.GetPrivate(),
-			}
-			if issue.Repository.Owner != nil {
-				pr.Repo.Owner = issue.Repository.Owner.GetLogin()
-			}
-		} else {
-			// Fallback: parse repository info from the HTML URL
-			// GitHub Search API often doesn't include the Repository field
-			owner, repoName, fullName := parseRepositoryFromURL(issue.GetHTMLURL())
-			if owner != "" && repoName != "" {
-				pr.Repo = Repository{
-					Name:     repoName,
-					FullName: fullName,
-					Owner:    owner,
-					// Note: We can't determine if the repo is private from the URL alone
-					// Default to false, but this could be enhanced with an additional API call if needed
-					Private: false,
-				}
-			}
+			Repo: repositoryFromIssue(issue),
 		}
 
 		pullRequests = append(pullRequests, pr)
 	}
 
-	response := &PullRequestsResponse{
-		PullRequests: pullRequests,
-		Total:        result.GetTotal(),
+	return pullRequests
+}
+
+// repositoryFromIssue extracts repository information from a search result issue
+func repositoryFromIssue(issue *github.Issue) Repository {
+	var repo Repository
+
+	// Parse repository info from the issue
+	if issue.Repository != nil {
+		repo = Repository{
+			Name:     issue.Repository.GetName(),
+			FullName: issue.Repository.GetFullName(),
+			Private:  issue.Repository.GetPrivate(),
+		}
+		if issue.Repository.Owner != nil {
+			repo.Owner = issue.Repository.Owner.GetLogin()
+		}
+	} else {
+		// Fallback: parse repository info from the HTML URL
+		// GitHub Search API often doesn't include the Repository field
+		owner, repoName, fullName := parseRepositoryFromURL(issue.GetHTMLURL())
+		if owner != "" && repoName != "" {
+			repo = Repository{
+				Name:     repoName,
+				FullName: fullName,
+				Owner:    owner,
+				// Note: We can't determine if the repo is private from the URL alone
+				// Default to false, but this could be enhanced with an additional API call if needed
+				Private: false,
+			}
+		}
 	}
 
-	return response, nil
+	return repo
 }
 
 // GetUserTotalContributions retrieves the total contributions for the authenticated user over a specified period
 func (s *GitHubService) GetUserTotalContributions(ctx context.Context, userUUID, provider, period string) (*TotalContributionsResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
 	if userUUID == "" || provider == "" {
 		return nil, apperrors.ErrMissingUserUUIDAndProvider
 	}
@@ -407,19 +1004,9 @@ func (s *GitHubService) GetUserTotalContributions(ctx context.Context, userUUID,
 	ghReq.Header.Set("Content-Type", "application/json")
 	ghReq.Header.Set("Accept", "application/json")
 
-	// Execute request - respect context deadline if available
-	httpClient := &http.Client{}
-	if deadline, ok := ctx.Deadline(); ok {
-		timeout := time.Until(deadline)
-		if timeout > 0 {
-			httpClient.Timeout = timeout
-		} else {
-			httpClient.Timeout = time.Second // Minimal timeout for expired contexts
-		}
-	} else {
-		httpClient.Timeout = 30 * time.Second
-	}
-	resp, err := httpClient.Do(ghReq)
+	// Execute request - the caller's context deadline still applies on top of the provider timeout
+	httpClient := withRequestTimeout(&http.Client{}, s.requestTimeout(provider, githubClientConfig))
+	resp, err := s.doGraphQLRequest(ctx, httpClient, ghReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute GraphQL query: %w", err)
 	}
@@ -427,7 +1014,7 @@ func (s *GitHubService) GetUserTotalContributions(ctx context.Context, userUUID,
 
 	// Check for rate limit
 	if resp.StatusCode == 403 {
-		return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+		return nil, gitHubRateLimitError(resp, github.CheckResponse(resp))
 	}
 
 	// Check for other HTTP errors
@@ -485,12 +1072,82 @@ func (s *GitHubService) GetUserTotalContributions(ctx context.Context, userUUID,
 	return response, nil
 }
 
-// GetContributionsHeatmap retrieves the contribution heatmap for the authenticated user
+// GetContributionsHeatmap retrieves the contribution heatmap for the authenticated user.
+// The returned response carries an ETag computed from its contribution data.
 func (s *GitHubService) GetContributionsHeatmap(ctx context.Context, userUUID, provider, period string) (*ContributionsHeatmapResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.loadContributionsHeatmap(ctx, userUUID, provider, period)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy before tagging, cached responses are shared between callers
+	tagged := *response
+	tagged.ETag = heatmapETag(response)
+	return &tagged, nil
+}
+
+// GetContributionsHeatmapIfNoneMatch retrieves the contribution heatmap and compares its
+// ETag against the given If-None-Match header value. When they match, the response is
+// returned together with ErrNotModified so the caller can reply with 304 Not Modified.
+func (s *GitHubService) GetContributionsHeatmapIfNoneMatch(ctx context.Context, userUUID, provider, period, ifNoneMatch string) (*ContributionsHeatmapResponse, error) {
+	response, err := s.GetContributionsHeatmap(ctx, userUUID, provider, period)
+	if err != nil {
+		return nil, err
+	}
+
+	if etagMatches(ifNoneMatch, response.ETag) {
+		return response, apperrors.ErrNotModified
+	}
+
+	return response, nil
+}
+
+// heatmapETag hashes the total and per-day counts of a heatmap. The from/to bounds are
+// left out because they move with the current time even when the data does not.
+func heatmapETag(response *ContributionsHeatmapResponse) string {
+	payload, err := json.Marshal(struct {
+		TotalContributions int                `json:"total_contributions"`
+		Weeks              []ContributionWeek `json:"weeks"`
+	}{response.TotalContributions, response.Weeks})
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(payload)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header value matches the given ETag.
+// It accepts "*", comma-separated lists and weak validators.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadContributionsHeatmap loads the contribution heatmap from the provider or the cache
+func (s *GitHubService) loadContributionsHeatmap(ctx context.Context, userUUID, provider, period string) (*ContributionsHeatmapResponse, error) {
 	if userUUID == "" || provider == "" {
 		return nil, apperrors.ErrMissingUserUUIDAndProvider
 	}
 
+	if vcsProvider, ok := s.vcsProviders[provider]; ok {
+		return vcsProvider.GetContributionsHeatmap(ctx, userUUID, provider, period)
+	}
+
 	log := logger.WithContext(ctx).WithFields(map[string]interface{}{
 		"provider": provider,
 		"period":   period,
@@ -532,16 +1189,16 @@ func (s *GitHubService) GetContributionsHeatmap(ctx context.Context, userUUID, p
 			}
 		}`
 	} else {
-		// Validate period format before parsing
-		if len(period) < 2 || period[len(period)-1] != 'd' {
-			log.Errorf("Invalid period format: %s", period)
-			return nil, fmt.Errorf("%w: period must be in format '<number>d' (e.g., '30d', '90d', '365d')", apperrors.ErrInvalidPeriodFormat)
+		// Only the supported periods are accepted
+		days, err := normalizeSupportedPeriod(period)
+		if err != nil {
+			log.Errorf("Unsupported period: %s", period)
+			return nil, err
 		}
 
 		// Parse custom period and calculate date range
-		var err error
 		var parsedPeriod string
-		from, to, parsedPeriod, err = parsePeriod(period)
+		from, to, parsedPeriod, err = parsePeriod(days)
 		if err != nil {
 			log.Errorf("Failed to parse period '%s': %v", period, err)
 			return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidPeriodFormat, err)
@@ -572,6 +1229,21 @@ func (s *GitHubService) GetContributionsHeatmap(ctx context.Context, userUUID, p
 		}`, from.Format(time.RFC3339), to.Format(time.RFC3339))
 	}
 
+	// Serve repeated profile views from cache to avoid GraphQL round-trips
+	cachePeriod := period
+	if cachePeriod == "" {
+		cachePeriod = "default"
+	}
+	cacheKey := cache.BuildKey(cache.KeyPrefixGitHubContrib, "heatmap", userUUID, provider, cachePeriod)
+	wrapper := cache.NewCacheWrapper[*ContributionsHeatmapResponse](s.cache)
+
+	return wrapper.GetOrFetch(cacheKey, s.ttlConfig.GitHubContributions, func() (*ContributionsHeatmapResponse, error) {
+		return s.fetchContributionsHeatmap(ctx, log, userUUID, provider, query)
+	})
+}
+
+// fetchContributionsHeatmap executes the contribution heatmap GraphQL query against GitHub
+func (s *GitHubService) fetchContributionsHeatmap(ctx context.Context, log *logger.Logger, userUUID, provider, query string) (*ContributionsHeatmapResponse, error) {
 	// Get GitHub access token using validated JWT claims
 	accessToken, err := s.authService.GetGitHubAccessToken(userUUID, provider)
 	if err != nil {
@@ -646,19 +1318,9 @@ func (s *GitHubService) GetContributionsHeatmap(ctx context.Context, userUUID, p
 	ghReq.Header.Set("Content-Type", "application/json")
 	ghReq.Header.Set("Accept", "application/json")
 
-	// Execute request - respect context deadline if available
-	httpClient := &http.Client{}
-	if deadline, ok := ctx.Deadline(); ok {
-		timeout := time.Until(deadline)
-		if timeout > 0 {
-			httpClient.Timeout = timeout
-		} else {
-			httpClient.Timeout = time.Second // Minimal timeout for expired contexts
-		}
-	} else {
-		httpClient.Timeout = 30 * time.Second
-	}
-	resp, err := httpClient.Do(ghReq)
+	// Execute request - the caller's context deadline still applies on top of the provider timeout
+	httpClient := withRequestTimeout(&http.Client{}, s.requestTimeout(provider, githubClientConfig))
+	resp, err := s.doGraphQLRequest(ctx, httpClient, ghReq)
 	if err != nil {
 		log.Errorf("Failed to execute GraphQL query: %v", err)
 		return nil, fmt.Errorf("failed to execute GraphQL query: %w", err)
@@ -670,7 +1332,7 @@ func (s *GitHubService) GetContributionsHeatmap(ctx context.Context, userUUID, p
 	// Check for rate limit
 	if resp.StatusCode == 403 {
 		log.Warn("GitHub API rate limit exceeded")
-		return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+		return nil, gitHubRateLimitError(resp, github.CheckResponse(resp))
 	}
 
 	// Check for other HTTP errors
@@ -745,6 +1407,10 @@ func (s *GitHubService) GetContributionsHeatmap(ctx context.Context, userUUID, p
 
 // GetAveragePRMergeTime retrieves the average time to merge PRs for the authenticated user
 func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, provider, period string) (*AveragePRMergeTimeResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
 	if userUUID == "" || provider == "" {
 		return nil, apperrors.ErrMissingUserUUIDAndProvider
 	}
@@ -759,17 +1425,23 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 	// Parse and validate period
 	var from, to time.Time
 	var parsedPeriod string
-	var err error
 
 	if period == "" {
 		period = "30d"
 	}
 
-	from, to, parsedPeriod, err = parsePeriod(period)
+	days, err := normalizeSupportedPeriod(period)
+	if err != nil {
+		log.Errorf("Unsupported period: %s", period)
+		return nil, err
+	}
+
+	from, to, _, err = parsePeriod(days)
 	if err != nil {
 		log.Errorf("Invalid period format: %s", period)
 		return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidPeriodFormat, err)
 	}
+	parsedPeriod = period
 
 	log.Debugf("Querying merged PRs from %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
 
@@ -871,19 +1543,9 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 		ghReq.Header.Set("Content-Type", "application/json")
 		ghReq.Header.Set("Accept", "application/json")
 
-		httpClient := &http.Client{}
-		if deadline, ok := ctx.Deadline(); ok {
-			timeout := time.Until(deadline)
-			if timeout > 0 {
-				httpClient.Timeout = timeout
-			} else {
-				httpClient.Timeout = time.Second
-			}
-		} else {
-			httpClient.Timeout = 30 * time.Second
-		}
+		httpClient := withRequestTimeout(&http.Client{}, s.requestTimeout(provider, githubClientConfig))
 
-		resp, err := httpClient.Do(ghReq)
+		resp, err := s.doGraphQLRequest(ctx, httpClient, ghReq)
 		if err != nil {
 			log.Errorf("Failed to execute GraphQL query: %v", err)
 			return nil, fmt.Errorf("failed to execute GraphQL query: %w", err)
@@ -894,7 +1556,7 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 
 		if resp.StatusCode == 403 {
 			log.Warn("GitHub API rate limit exceeded")
-			return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+			return nil, gitHubRateLimitError(resp, github.CheckResponse(resp))
 		}
 
 		if resp.StatusCode != 200 {
@@ -962,6 +1624,7 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 
 	var totalHours float64
 	var validPRCount int
+	mergeTimes := make([]float64, 0, len(allPRs))
 
 	for _, pr := range allPRs {
 		if pr.MergedAt == "" || pr.CreatedAt == "" {
@@ -983,6 +1646,7 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 		mergeTimeHours := mergedAt.Sub(createdAt).Hours()
 		totalHours += mergeTimeHours
 		validPRCount++
+		mergeTimes = append(mergeTimes, mergeTimeHours)
 
 		// Assign PR to the appropriate week
 		for _, week := range weeks {
@@ -1001,6 +1665,7 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 	if validPRCount > 0 {
 		averageHours = roundTo2Decimals(totalHours / float64(validPRCount))
 	}
+	medianHours, p90Hours, maxHours := mergeTimePercentiles(mergeTimes)
 
 	// Build time series (always 4 weeks, newest to oldest)
 	timeSeries := make([]PRMergeTimeDataPoint, 4)
@@ -1020,6 +1685,9 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 
 	response := &AveragePRMergeTimeResponse{
 		AveragePRMergeTimeHours: averageHours,
+		MedianPRMergeTimeHours:  medianHours,
+		P90PRMergeTimeHours:     p90Hours,
+		MaxPRMergeTimeHours:     maxHours,
 		PRCount:                 validPRCount,
 		Period:                  parsedPeriod,
 		From:                    from.Format(time.RFC3339),
@@ -1032,11 +1700,49 @@ func (s *GitHubService) GetAveragePRMergeTime(ctx context.Context, userUUID, pro
 	return response, nil
 }
 
+// mergeTimePercentiles returns the median, 90th percentile and maximum of the merge times in hours,
+// rounded to 2 decimal places; all are 0 when there are no merge times
+func mergeTimePercentiles(hours []float64) (median, p90, maximum float64) {
+	if len(hours) == 0 {
+		return 0, 0, 0
+	}
+
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+
+	return roundTo2Decimals(percentile(sorted, 0.5)), roundTo2Decimals(percentile(sorted, 0.9)), roundTo2Decimals(sorted[len(sorted)-1])
+}
+
+// percentile returns the p-th quantile (0..1) of sorted values, interpolating linearly between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
 // roundTo2Decimals rounds a float64 to 2 decimal places
 func roundTo2Decimals(num float64) float64 {
 	return math.Round(num*100) / 100
 }
 
+// supportedPeriods maps the periods accepted by the contribution heatmap and PR merge time to their parsePeriod form
+var supportedPeriods = map[string]string{
+	"7d":  "7d",
+	"30d": "30d",
+	"90d": "90d",
+	"1y":  "365d",
+}
+
+// normalizeSupportedPeriod returns the parsePeriod form of a supported period, or ErrInvalidPeriod otherwise
+func normalizeSupportedPeriod(period string) (string, error) {
+	days, ok := supportedPeriods[period]
+	if !ok {
+		return "", fmt.Errorf("%w %q: must be one of 7d, 30d, 90d or 1y", apperrors.ErrInvalidPeriod, period)
+	}
+	return days, nil
+}
+
 // parsePeriod parses a period string (e.g., "30d", "90d", "365d") and returns the from/to dates
 // Default period is 365 days if not specified or invalid
 func parsePeriod(period string) (from, to time.Time, parsedPeriod string, err error) {
@@ -1075,8 +1781,83 @@ func parsePeriod(period string) (from, to time.Time, parsedPeriod string, err er
 	return from, to, parsedPeriod, nil
 }
 
+// GetCommitActivity retrieves the weekly commit counts of a repository for the weeks overlapping the period.
+// GitHub computes these statistics asynchronously and answers 202 until they are ready, so the request is
+// repeated a few times before ErrGitHubStatsNotReady is returned.
+func (s *GitHubService) GetCommitActivity(ctx context.Context, userUUID, provider, owner, repo, period string) ([]WeeklyCommits, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+	if owner == "" || repo == "" {
+		return nil, apperrors.ErrOwnerAndRepositoryMissing
+	}
+
+	from, to, _, err := parsePeriod(period)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidPeriodFormat, err)
+	}
+
+	client, err := s.newAuthenticatedClient(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	var activity []*github.WeeklyCommitActivity
+	for attempt := 1; ; attempt++ {
+		var resp *github.Response
+		activity, resp, err = client.Repositories.ListCommitActivity(ctx, owner, repo)
+		if err == nil {
+			break
+		}
+
+		var accepted *github.AcceptedError
+		if !errors.As(err, &accepted) {
+			if resp != nil && resp.StatusCode == 403 {
+				return nil, gitHubRateLimitError(resp.Response, err)
+			}
+			if resp != nil && resp.StatusCode == 404 {
+				return nil, apperrors.NewNotFoundError("repository")
+			}
+			return nil, fmt.Errorf("failed to get commit activity: %w", err)
+		}
+
+		if attempt >= s.statsPollAttempts {
+			return nil, apperrors.ErrGitHubStatsNotReady
+		}
+		if err := s.sleep(ctx, s.statsPollDelay); err != nil {
+			return nil, err
+		}
+	}
+
+	weeks := make([]WeeklyCommits, 0, len(activity))
+	for _, week := range activity {
+		weekStart := week.GetWeek().Time.UTC()
+		if weekStart.AddDate(0, 0, 7).Before(from) || weekStart.After(to) {
+			continue
+		}
+		weeks = append(weeks, WeeklyCommits{
+			WeekStart: weekStart.Format("2006-01-02"),
+			Count:     week.GetTotal(),
+		})
+	}
+
+	return weeks, nil
+}
+
 // GetRepositoryContent fetches repository file or directory content from GitHub
 func (s *GitHubService) GetRepositoryContent(ctx context.Context, userUUID, provider, owner, repo, path, ref string) (interface{}, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if vcsProvider, ok := s.vcsProviders[provider]; ok {
+		return vcsProvider.GetRepositoryContent(ctx, userUUID, provider, owner, repo, path, ref)
+	}
+
 	// Get access token from auth service
 	accessToken, err := s.authService.GetGitHubAccessToken(userUUID, provider)
 	if err != nil {
@@ -1093,7 +1874,7 @@ func (s *GitHubService) GetRepositoryContent(ctx context.Context, userUUID, prov
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := withRequestTimeout(oauth2.NewClient(ctx, ts), s.requestTimeout(provider, githubClientConfig))
 
 	// Create authenticated GitHub client
 	var client *github.Client
@@ -1116,6 +1897,13 @@ func (s *GitHubService) GetRepositoryContent(ctx context.Context, userUUID, prov
 		path = path[1:]
 	}
 
+	// Refuse oversized files before their content is downloaded
+	if path != "" {
+		if err := s.checkRepositoryFileSize(ctx, client, owner, repo, path, ref); err != nil {
+			return nil, err
+		}
+	}
+
 	// Fetch repository content
 	fileContent, directoryContent, resp, err := client.Repositories.GetContents(
 		ctx,
@@ -1126,18 +1914,8 @@ func (s *GitHubService) GetRepositoryContent(ctx context.Context, userUUID, prov
 			Ref: ref,
 		},
 	)
-
-	// Handle errors
 	if err != nil {
-		// Check for rate limit
-		if resp != nil && resp.StatusCode == 403 {
-			return nil, apperrors.ErrGitHubAPIRateLimitExceeded
-		}
-		// Check for not found
-		if resp != nil && resp.StatusCode == 404 {
-			return nil, apperrors.NewNotFoundError("repository content")
-		}
-		return nil, fmt.Errorf("failed to fetch repository content: %w", err)
+		return nil, repositoryContentError(resp, err)
 	}
 
 	// Return directory contents (array)
@@ -1166,10 +1944,19 @@ func (s *GitHubService) GetRepositoryContent(ctx context.Context, userUUID, prov
 
 	// Return file content (object)
 	if fileContent != nil {
+		// The file may have changed since its size was checked
+		if fileContent.GetSize() > s.maxFileSize {
+			return nil, fmt.Errorf("%w: %s is %d bytes, limit is %d bytes", apperrors.ErrFileTooLarge, fileContent.GetPath(), fileContent.GetSize(), s.maxFileSize)
+		}
+
+		// GetContent decodes the base64 payload returned by GitHub
 		content, err := fileContent.GetContent()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get file content: %w", err)
 		}
+		if len(content) > s.maxFileSize {
+			return nil, fmt.Errorf("%w: %s is %d bytes, limit is %d bytes", apperrors.ErrFileTooLarge, fileContent.GetPath(), len(content), s.maxFileSize)
+		}
 		return map[string]interface{}{
 			"name":         fileContent.GetName(),
 			"path":         fileContent.GetPath(),
@@ -1193,8 +1980,46 @@ func (s *GitHubService) GetRepositoryContent(ctx context.Context, userUUID, prov
 	return nil, fmt.Errorf("unexpected response from GitHub API")
 }
 
+// checkRepositoryFileSize looks up path in the listing of its parent directory, which carries sizes but
+// no content, and returns ErrFileTooLarge when path is a file above the size limit
+func (s *GitHubService) checkRepositoryFileSize(ctx context.Context, client *github.Client, owner, repo, path, ref string) error {
+	parent := pathpkg.Dir(path)
+	if parent == "." {
+		parent = ""
+	}
+
+	_, entries, resp, err := client.Repositories.GetContents(ctx, owner, repo, parent, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		return repositoryContentError(resp, err)
+	}
+
+	for _, entry := range entries {
+		if entry.GetPath() == path && entry.GetType() == "file" && entry.GetSize() > s.maxFileSize {
+			return fmt.Errorf("%w: %s is %d bytes, limit is %d bytes", apperrors.ErrFileTooLarge, path, entry.GetSize(), s.maxFileSize)
+		}
+	}
+	return nil
+}
+
+// repositoryContentError maps a failed contents request to the rate limit, not found or a generic error
+func repositoryContentError(resp *github.Response, err error) error {
+	// Check for rate limit
+	if resp != nil && resp.StatusCode == 403 {
+		return gitHubRateLimitError(resp.Response, err)
+	}
+	// Check for not found
+	if resp != nil && resp.StatusCode == 404 {
+		return apperrors.NewNotFoundError("repository content")
+	}
+	return fmt.Errorf("failed to fetch repository content: %w", err)
+}
+
 // UpdateRepositoryFile updates a file in a GitHub repository
 func (s *GitHubService) UpdateRepositoryFile(ctx context.Context, userUUID, provider, owner, repo, path, message, content, sha, branch string) (interface{}, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
 	// Get access token from auth service
 	accessToken, err := s.authService.GetGitHubAccessToken(userUUID, provider)
 	if err != nil {
@@ -1211,7 +2036,7 @@ func (s *GitHubService) UpdateRepositoryFile(ctx context.Context, userUUID, prov
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := withRequestTimeout(oauth2.NewClient(ctx, ts), s.requestTimeout(provider, githubClientConfig))
 
 	// Create authenticated GitHub client
 	var client *github.Client
@@ -1247,7 +2072,7 @@ func (s *GitHubService) UpdateRepositoryFile(ctx context.Context, userUUID, prov
 	if err != nil {
 		// Check for rate limit
 		if resp != nil && resp.StatusCode == 403 {
-			return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+			return nil, gitHubRateLimitError(resp.Response, err)
 		}
 		// Check for not found
 		if resp != nil && resp.StatusCode == 404 {
@@ -1290,6 +2115,10 @@ func (s *GitHubService) UpdateRepositoryFile(ctx context.Context, userUUID, prov
 
 // GetGitHubAsset fetches a GitHub asset (image, file, etc.) with authentication
 func (s *GitHubService) GetGitHubAsset(ctx context.Context, userUUID, provider, assetURL string) ([]byte, string, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, "", err
+	}
 	log := logger.WithContext(ctx).WithFields(map[string]interface{}{
 		"asset_url": assetURL,
 		"provider":  provider,
@@ -1348,11 +2177,12 @@ func (s *GitHubService) GetGitHubAsset(ctx context.Context, userUUID, provider,
 
 	// Check response status
 	if resp.StatusCode == 403 {
+		rateErr := gitHubRateLimitError(resp, github.CheckResponse(resp))
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		log.WithFields(map[string]interface{}{
 			"response_body": string(bodyBytes),
 		}).Warn("GitHub API rate limit exceeded for asset")
-		return nil, "", apperrors.ErrGitHubAPIRateLimitExceeded
+		return nil, "", rateErr
 	}
 	if resp.StatusCode == 404 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
@@ -1420,6 +2250,10 @@ func (s *GitHubService) GetGitHubAsset(ctx context.Context, userUUID, provider,
 }
 
 func (s *GitHubService) ClosePullRequest(ctx context.Context, userUUID, provider, owner, repo string, prNumber int, deleteBranch bool) (*PullRequest, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
 	if (userUUID == "") || (provider == "") {
 		return nil, apperrors.ErrMissingUserUUIDAndProvider
 	}
@@ -1443,7 +2277,7 @@ func (s *GitHubService) ClosePullRequest(ctx context.Context, userUUID, provider
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := withRequestTimeout(oauth2.NewClient(ctx, ts), s.requestTimeout(provider, githubClientConfig))
 
 	// Create authenticated GitHub client
 	var client *github.Client
@@ -1460,7 +2294,7 @@ func (s *GitHubService) ClosePullRequest(ctx context.Context, userUUID, provider
 	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, prNumber)
 	if err != nil {
 		if resp != nil && resp.StatusCode == 403 {
-			return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+			return nil, gitHubRateLimitError(resp.Response, err)
 		}
 		if resp != nil && resp.StatusCode == 404 {
 			return nil, apperrors.NewNotFoundError("pull request")
@@ -1479,7 +2313,7 @@ func (s *GitHubService) ClosePullRequest(ctx context.Context, userUUID, provider
 	})
 	if err != nil {
 		if resp != nil && resp.StatusCode == 403 {
-			return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+			return nil, gitHubRateLimitError(resp.Response, err)
 		}
 		if resp != nil && resp.StatusCode == 404 {
 			return nil, apperrors.NewNotFoundError("pull request")
@@ -1507,7 +2341,7 @@ func (s *GitHubService) ClosePullRequest(ctx context.Context, userUUID, provider
 			delResp, delErr := client.Git.DeleteRef(ctx, headOwner, headRepoName, ref)
 			if delErr != nil {
 				if delResp != nil && delResp.StatusCode == 403 {
-					return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+					return nil, gitHubRateLimitError(delResp.Response, delErr)
 				}
 				// Ignore 404 (branch already deleted or not found)
 				if delResp == nil || delResp.StatusCode != 404 {
@@ -1543,8 +2377,117 @@ func (s *GitHubService) ClosePullRequest(ctx context.Context, userUUID, provider
 	return &result, nil
 }
 
+// GetPullRequestDetails fetches a single pull request together with its mergeable state, review status and change counts
+func (s *GitHubService) GetPullRequestDetails(ctx context.Context, userUUID, provider, owner, repo string, number int) (*PullRequestDetails, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+	if owner == "" || repo == "" {
+		return nil, apperrors.ErrOwnerAndRepositoryMissing
+	}
+
+	client, err := s.newAuthenticatedClient(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 403 {
+			return nil, gitHubRateLimitError(resp.Response, err)
+		}
+		if resp != nil && resp.StatusCode == 404 {
+			return nil, apperrors.ErrPullRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, number, &github.ListOptions{PerPage: MaxPageSize})
+	if err != nil {
+		if resp != nil && resp.StatusCode == 403 {
+			return nil, gitHubRateLimitError(resp.Response, err)
+		}
+		return nil, fmt.Errorf("failed to list pull request reviews: %w", err)
+	}
+
+	repository := repositoryFromGitHub(pr.GetBase().GetRepo())
+	if repository.Name == "" {
+		repository = Repository{Name: repo, FullName: owner + "/" + repo, Owner: owner}
+	}
+
+	return &PullRequestDetails{
+		PullRequest: PullRequest{
+			ID:        pr.GetID(),
+			Number:    pr.GetNumber(),
+			Title:     pr.GetTitle(),
+			State:     pr.GetState(),
+			CreatedAt: pr.GetCreatedAt().Time,
+			UpdatedAt: pr.GetUpdatedAt().Time,
+			HTMLURL:   pr.GetHTMLURL(),
+			Draft:     pr.GetDraft(),
+			User: GitHubUser{
+				Login:     pr.GetUser().GetLogin(),
+				ID:        pr.GetUser().GetID(),
+				AvatarURL: pr.GetUser().GetAvatarURL(),
+			},
+			Repo: repository,
+		},
+		Merged:         pr.GetMerged(),
+		Mergeable:      pr.Mergeable,
+		MergeableState: pr.GetMergeableState(),
+		ReviewStatus:   reviewStatus(reviews, len(pr.RequestedReviewers)+len(pr.RequestedTeams) > 0),
+		ChangedFiles:   pr.GetChangedFiles(),
+		Additions:      pr.GetAdditions(),
+		Deletions:      pr.GetDeletions(),
+		Commits:        pr.GetCommits(),
+		BaseBranch:     pr.GetBase().GetRef(),
+		HeadBranch:     pr.GetHead().GetRef(),
+	}, nil
+}
+
+// reviewStatus summarizes the latest review of each reviewer: any outstanding change request wins over approvals.
+// Without decisive reviews the PR needs review if reviewers were requested.
+func reviewStatus(reviews []*github.PullRequestReview, reviewersRequested bool) string {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		state := strings.ToUpper(review.GetState())
+		// Comments do not change a reviewer's decision
+		if state != "APPROVED" && state != "CHANGES_REQUESTED" && state != "DISMISSED" {
+			continue
+		}
+		latest[review.GetUser().GetLogin()] = state
+	}
+
+	approved := false
+	for _, state := range latest {
+		switch state {
+		case "CHANGES_REQUESTED":
+			return ReviewStatusChangesRequested
+		case "APPROVED":
+			approved = true
+		}
+	}
+
+	switch {
+	case approved:
+		return ReviewStatusApproved
+	case reviewersRequested:
+		return ReviewStatusReviewRequired
+	default:
+		return ReviewStatusNone
+	}
+}
+
 // GetUserPRReviewComments gets the total number of PR review comments made by the authenticated user
 func (s *GitHubService) GetUserPRReviewComments(ctx context.Context, userUUID, provider, period string) (*PRReviewCommentsResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
 	if userUUID == "" || provider == "" {
 		return nil, fmt.Errorf("userUUID and provider are required")
 	}
@@ -1552,7 +2495,6 @@ func (s *GitHubService) GetUserPRReviewComments(ctx context.Context, userUUID, p
 	// Parse period (default to 30 days)
 	var from, to time.Time
 	var parsedPeriod string
-	var err error
 
 	if period == "" {
 		period = "30d"
@@ -1585,7 +2527,7 @@ func (s *GitHubService) GetUserPRReviewComments(ctx context.Context, userUUID, p
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: accessToken},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := withRequestTimeout(oauth2.NewClient(ctx, ts), s.requestTimeout(provider, githubClientConfig))
 
 	// Create authenticated GitHub client
 	var client *github.Client
@@ -1602,7 +2544,7 @@ func (s *GitHubService) GetUserPRReviewComments(ctx context.Context, userUUID, p
 	user, resp, err := client.Users.Get(ctx, "")
 	if err != nil {
 		if resp != nil && resp.StatusCode == 403 {
-			return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+			return nil, gitHubRateLimitError(resp.Response, err)
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -1629,7 +2571,7 @@ func (s *GitHubService) GetUserPRReviewComments(ctx context.Context, userUUID, p
 		result, resp, err := client.Search.Issues(ctx, query, searchOpts)
 		if err != nil {
 			if resp != nil && resp.StatusCode == 403 {
-				return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+				return nil, gitHubRateLimitError(resp.Response, err)
 			}
 			return nil, fmt.Errorf("failed to search PR review comments: %w", err)
 		}
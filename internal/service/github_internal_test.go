@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"developer-portal-backend/internal/auth"
 	apperrors "developer-portal-backend/internal/errors"
 
+	"github.com/google/go-github/v57/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -222,6 +224,193 @@ func TestGetContributionsHeatmap_Success(t *testing.T) {
 	require.Len(t, res.Weeks[0].ContributionDays, 2)
 }
 
+func TestGetContributionsHeatmap_RetriesTransientServerErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"Something went wrong"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"contributionsCollection":{"startedAt":"2024-10-30T00:00:00Z","endedAt":"2025-10-30T23:59:59Z","contributionCalendar":{"totalContributions":7,"weeks":[]}}}}}`))
+	}))
+	defer server.Close()
+
+	svc := NewGitHubServiceWithAdapter(&mockAuthService{accessToken: "test-token", baseURL: server.URL})
+	var delays []time.Duration
+	svc.SetGraphQLRetryPolicy(3, 10*time.Millisecond, func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	})
+
+	res, err := svc.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, 7, res.TotalContributions)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	require.Len(t, delays, 2)
+	assert.GreaterOrEqual(t, delays[1], 10*time.Millisecond, "backoff should grow between retries")
+}
+
+func TestGetContributionsHeatmap_DoesNotRetryClientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Bad request"}`))
+	}))
+	defer server.Close()
+
+	svc := NewGitHubServiceWithAdapter(&mockAuthService{accessToken: "test-token", baseURL: server.URL})
+	svc.SetGraphQLRetryPolicy(3, time.Millisecond, func(ctx context.Context, delay time.Duration) error { return nil })
+
+	res, err := svc.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "")
+	require.Error(t, err)
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "GraphQL query failed with status 400")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestGetContributionsHeatmap_ProviderTimeoutOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	svc := NewGitHubServiceWithAdapter(&mockAuthService{accessToken: "test-token", baseURL: server.URL})
+	svc.SetProviderTimeout("githubtools", 100*time.Millisecond)
+	svc.SetGraphQLRetryPolicy(1, 0, nil)
+
+	res, err := svc.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "")
+	require.Error(t, err)
+	assert.Nil(t, res)
+	assert.True(t, errors.Is(err, apperrors.ErrUpstreamTimeout))
+}
+
+func TestGetContributionsHeatmap_ETag(t *testing.T) {
+	var count int32 = 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":{"viewer":{"contributionsCollection":{"startedAt":"2024-10-30T00:00:00Z","endedAt":"2025-10-30T23:59:59Z","contributionCalendar":{"totalContributions":%d,"weeks":[{"firstDay":"2025-10-26","contributionDays":[{"date":"2025-10-26","contributionCount":%d,"contributionLevel":"FIRST_QUARTILE","color":"#9be9a8"}]}]}}}}}`, atomic.LoadInt32(&count), atomic.LoadInt32(&count))
+	}))
+	defer server.Close()
+
+	svc := NewGitHubServiceWithAdapter(&mockAuthService{accessToken: "test-token", baseURL: server.URL})
+
+	first, err := svc.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, first.ETag)
+
+	// Identical data yields the same ETag
+	second, err := svc.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "")
+	require.NoError(t, err)
+	assert.Equal(t, first.ETag, second.ETag)
+
+	// A matching If-None-Match reports not modified
+	res, err := svc.GetContributionsHeatmapIfNoneMatch(context.Background(), "test-uuid", "githubtools", "", first.ETag)
+	assert.ErrorIs(t, err, apperrors.ErrNotModified)
+	require.NotNil(t, res)
+	assert.Equal(t, first.ETag, res.ETag)
+
+	// Changed counts yield a new ETag and a full response
+	atomic.StoreInt32(&count, 4)
+	res, err = svc.GetContributionsHeatmapIfNoneMatch(context.Background(), "test-uuid", "githubtools", "", first.ETag)
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.TotalContributions)
+	assert.NotEqual(t, first.ETag, res.ETag)
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"abc"`
+
+	assert.True(t, etagMatches(`"abc"`, etag))
+	assert.True(t, etagMatches(`W/"abc"`, etag))
+	assert.True(t, etagMatches(`"xyz", "abc"`, etag))
+	assert.True(t, etagMatches("*", etag))
+	assert.False(t, etagMatches("", etag))
+	assert.False(t, etagMatches(`"xyz"`, etag))
+	assert.False(t, etagMatches(`"abc"`, ""))
+}
+
+func TestRequestTimeout_Resolution(t *testing.T) {
+	svc := NewGitHubServiceWithAdapter(&mockAuthService{})
+	configured := auth.NewGitHubClient(&auth.ProviderConfig{RequestTimeout: 5 * time.Second})
+
+	assert.Equal(t, defaultGitHubRequestTimeout, svc.requestTimeout("githubtools", nil))
+	assert.Equal(t, defaultGitHubRequestTimeout, svc.requestTimeout("githubtools", auth.NewGitHubClient(&auth.ProviderConfig{})))
+	assert.Equal(t, 5*time.Second, svc.requestTimeout("githubtools", configured))
+
+	svc.SetProviderTimeout("githubtools", time.Second)
+	assert.Equal(t, time.Second, svc.requestTimeout("githubtools", configured))
+
+	svc.SetProviderTimeout("githubtools", 0)
+	assert.Equal(t, 5*time.Second, svc.requestTimeout("githubtools", configured))
+}
+
+func TestMergeTimePercentiles(t *testing.T) {
+	tests := []struct {
+		name           string
+		hours          []float64
+		expectedMedian float64
+		expectedP90    float64
+		expectedMax    float64
+	}{
+		{name: "no PRs", hours: nil},
+		{name: "single PR", hours: []float64{5.5}, expectedMedian: 5.5, expectedP90: 5.5, expectedMax: 5.5},
+		{name: "odd count", hours: []float64{10, 2, 6}, expectedMedian: 6, expectedP90: 9.2, expectedMax: 10},
+		{name: "even count with outlier", hours: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 100}, expectedMedian: 5.5, expectedP90: 18.1, expectedMax: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			median, p90, maximum := mergeTimePercentiles(tt.hours)
+			assert.Equal(t, tt.expectedMedian, median)
+			assert.Equal(t, tt.expectedP90, p90)
+			assert.Equal(t, tt.expectedMax, maximum)
+		})
+	}
+}
+
+func TestMergeTimePercentiles_DoesNotReorderInput(t *testing.T) {
+	hours := []float64{3, 1, 2}
+	mergeTimePercentiles(hours)
+	assert.Equal(t, []float64{3, 1, 2}, hours)
+}
+
+func TestNormalizeSupportedPeriod(t *testing.T) {
+	valid := map[string]string{"7d": "7d", "30d": "30d", "90d": "90d", "1y": "365d"}
+	for period, expected := range valid {
+		t.Run(period, func(t *testing.T) {
+			days, err := normalizeSupportedPeriod(period)
+			require.NoError(t, err)
+			assert.Equal(t, expected, days)
+		})
+	}
+
+	for _, period := range []string{"30days", "1d", "365d", "2y", "-7d"} {
+		t.Run(period, func(t *testing.T) {
+			_, err := normalizeSupportedPeriod(period)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, apperrors.ErrInvalidPeriod))
+			assert.True(t, errors.Is(err, apperrors.ErrInvalidPeriodFormat))
+		})
+	}
+}
+
+func TestGetAveragePRMergeTime_UnsupportedPeriod(t *testing.T) {
+	svc := NewGitHubServiceWithAdapter(&mockAuthService{accessToken: "test-token"})
+
+	res, err := svc.GetAveragePRMergeTime(context.Background(), "test-uuid", "githubtools", "30days")
+	require.Error(t, err)
+	assert.Nil(t, res)
+	assert.True(t, errors.Is(err, apperrors.ErrInvalidPeriod))
+}
+
 func TestGetContributionsHeatmap_InvalidPeriod(t *testing.T) {
 	// Mock provider configured
 	mock := &mockAuthService{
@@ -273,3 +462,30 @@ func TestGetUserTotalContributions_ContextDeadline(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, res)
 }
+
+func TestReviewStatus(t *testing.T) {
+	review := func(login, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: github.String(login)}, State: github.String(state)}
+	}
+
+	tests := []struct {
+		name               string
+		reviews            []*github.PullRequestReview
+		reviewersRequested bool
+		want               string
+	}{
+		{name: "no reviews and no reviewers", want: ReviewStatusNone},
+		{name: "no reviews with requested reviewers", reviewersRequested: true, want: ReviewStatusReviewRequired},
+		{name: "approved", reviews: []*github.PullRequestReview{review("alice", "APPROVED")}, want: ReviewStatusApproved},
+		{name: "change request outranks approval", reviews: []*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "CHANGES_REQUESTED")}, want: ReviewStatusChangesRequested},
+		{name: "later approval supersedes own change request", reviews: []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED"), review("bob", "APPROVED")}, want: ReviewStatusApproved},
+		{name: "comments alone are not a decision", reviews: []*github.PullRequestReview{review("alice", "COMMENTED")}, reviewersRequested: true, want: ReviewStatusReviewRequired},
+		{name: "dismissed change request", reviews: []*github.PullRequestReview{review("bob", "CHANGES_REQUESTED"), review("bob", "DISMISSED")}, want: ReviewStatusNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reviewStatus(tt.reviews, tt.reviewersRequested))
+		})
+	}
+}
@@ -8,12 +8,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/cache"
+	"developer-portal-backend/internal/database/models"
 	"developer-portal-backend/internal/mocks"
 	"developer-portal-backend/internal/service"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -162,6 +167,707 @@ func TestGetUserOpenPullRequests_FullFlow_WithMocks(t *testing.T) {
 	assert.True(t, pr2.Repo.Private)
 }
 
+// TestGetUserOpenPullRequests_AggregatesAllPages tests that all pages are fetched when no explicit page is given
+func TestGetUserOpenPullRequests_AggregatesAllPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Create mock GitHub API server returning two pages linked via the Link header
+	var requestedPages []string
+	var mockGitHubServer *httptest.Server
+	mockGitHubServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		number := 1
+		if page == "2" {
+			number = 2
+		} else {
+			nextURL := *r.URL
+			query := nextURL.Query()
+			query.Set("page", "2")
+			nextURL.RawQuery = query.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s>; rel="next", <%s%s>; rel="last"`,
+				mockGitHubServer.URL, nextURL.RequestURI(), mockGitHubServer.URL, nextURL.RequestURI()))
+		}
+
+		response := map[string]interface{}{
+			"total_count": 2,
+			"items": []map[string]interface{}{
+				{
+					"id":       int64(number),
+					"number":   number,
+					"title":    fmt.Sprintf("PR %d", number),
+					"state":    "open",
+					"html_url": fmt.Sprintf("%s/owner/repo/pull/%d", mockGitHubServer.URL, number),
+					"pull_request": map[string]interface{}{
+						"url": fmt.Sprintf("%s/repos/owner/repo/pulls/%d", mockGitHubServer.URL, number),
+					},
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	result, err := githubService.GetUserOpenPullRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 1, 0)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, []string{"1", "2"}, requestedPages)
+	assert.Equal(t, 2, result.Total)
+	require.Len(t, result.PullRequests, 2)
+	assert.Equal(t, 1, result.PullRequests[0].Number)
+	assert.Equal(t, 2, result.PullRequests[1].Number)
+	assert.Equal(t, 0, result.NextPage)
+}
+
+// TestGetUserOpenPullRequests_ExplicitPageReturnsNextPage tests that an explicit page is fetched alone with a cursor
+func TestGetUserOpenPullRequests_ExplicitPageReturnsNextPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	requests := 0
+	var mockGitHubServer *httptest.Server
+	mockGitHubServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v3/search/issues?page=2>; rel="next"`, mockGitHubServer.URL))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_count": 2, "items": [{"id": 1, "number": 1, "pull_request": {"url": "x"}}]}`))
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	result, err := githubService.GetUserOpenPullRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 1, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Len(t, result.PullRequests, 1)
+	assert.Equal(t, 2, result.NextPage)
+}
+
+// TestGetUserOpenPullRequestsAllProviders_PartialFailure tests merging PRs while recording a failing provider
+func TestGetUserOpenPullRequestsAllProviders_PartialFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_count": 1, "items": [{"id": 1, "number": 42, "title": "Tools PR", "state": "open", "html_url": "https://github.tools.example/owner/repo/pull/42", "pull_request": {"url": "x"}}]}`))
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubwdf").
+		Return("", fmt.Errorf("no token stored"))
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	result, err := githubService.GetUserOpenPullRequestsAllProviders(context.Background(), "test-uuid", []string{"githubtools", "githubwdf"}, "open", "created", "desc", 30, 1)
+
+	require.NoError(t, err)
+	require.Len(t, result.PullRequests, 1)
+	assert.Equal(t, 42, result.PullRequests[0].Number)
+	assert.Equal(t, "githubtools", result.PullRequests[0].Provider)
+	assert.Equal(t, 1, result.Total)
+	require.Len(t, result.PartialErrors, 1)
+	assert.Contains(t, result.PartialErrors["githubwdf"], "no token stored")
+}
+
+// TestGetUserOpenPullRequestsAllProviders_AllFail tests an error is returned when no provider succeeds
+func TestGetUserOpenPullRequestsAllProviders_AllFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", gomock.Any()).
+		Return("", fmt.Errorf("no token stored")).
+		Times(2)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	result, err := githubService.GetUserOpenPullRequestsAllProviders(context.Background(), "test-uuid", []string{"githubtools", "githubwdf"}, "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+// TestGetUserReviewRequests_FullFlow_WithMocks tests fetching PRs awaiting the user's review
+func TestGetUserReviewRequests_FullFlow_WithMocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var mockGitHubServer *httptest.Server
+	mockGitHubServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Contains(t, r.URL.Path, "/search/issues")
+		assert.Contains(t, r.URL.RawQuery, "is%3Apr")
+		assert.Contains(t, r.URL.RawQuery, "review-requested%3A%40me")
+		assert.NotContains(t, r.URL.RawQuery, "author%3A%40me")
+
+		response := map[string]interface{}{
+			"total_count": 1,
+			"items": []map[string]interface{}{
+				{
+					"id":         int64(555),
+					"number":     7,
+					"title":      "Please review",
+					"state":      "open",
+					"created_at": "2025-02-01T12:00:00Z",
+					"updated_at": "2025-02-02T12:00:00Z",
+					"html_url":   mockGitHubServer.URL + "/team/service/pull/7",
+					"draft":      true,
+					"user": map[string]interface{}{
+						"login": "colleague",
+						"id":    int64(999),
+					},
+					"pull_request": map[string]interface{}{
+						"url": mockGitHubServer.URL + "/repos/team/service/pulls/7",
+					},
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	result, err := githubService.GetUserReviewRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.Total)
+	require.Len(t, result.PullRequests, 1)
+
+	pr := result.PullRequests[0]
+	assert.Equal(t, int64(555), pr.ID)
+	assert.Equal(t, 7, pr.Number)
+	assert.Equal(t, "Please review", pr.Title)
+	assert.True(t, pr.Draft)
+	assert.Equal(t, "colleague", pr.User.Login)
+	assert.Equal(t, "service", pr.Repo.Name)
+	assert.Equal(t, "team", pr.Repo.Owner)
+	assert.Equal(t, "team/service", pr.Repo.FullName)
+}
+
+// TestGetUserReviewRequests_GitHubAPIRateLimit tests rate limit handling for review requests
+func TestGetUserReviewRequests_GitHubAPIRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	result, err := githubService.GetUserReviewRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
+}
+
+// newGitHubTestService returns a GitHubService backed by a mock GitHub server serving the given handler.
+// Tests needing other settings call the service's setters on the result.
+func newGitHubTestService(t *testing.T, ctrl *gomock.Controller, handler http.HandlerFunc) *service.GitHubService {
+	mockGitHubServer := httptest.NewServer(handler)
+	t.Cleanup(mockGitHubServer.Close)
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		MinTimes(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil).
+		MinTimes(1)
+
+	return service.NewGitHubServiceWithAdapter(mockAuthService)
+}
+
+// rateLimitedHandler answers every call with a 403 carrying the given headers and body
+func rateLimitedHandler(headers map[string]string, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for key, value := range headers {
+			w.Header().Set(key, value)
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(body))
+	}
+}
+
+// TestGetUserReviewRequests_PrimaryRateLimit tests that an exhausted hourly quota maps to the primary rate limit error
+func TestGetUserReviewRequests_PrimaryRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, rateLimitedHandler(
+		map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000000"},
+		`{"message": "API rate limit exceeded for user ID 1.", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api"}`))
+
+	result, err := githubService.GetUserReviewRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
+	assert.NotErrorIs(t, err, apperrors.ErrGitHubSecondaryRateLimit)
+}
+
+// TestGetUserReviewRequests_SecondaryRateLimit tests that a secondary limit maps to its own error carrying Retry-After
+func TestGetUserReviewRequests_SecondaryRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, rateLimitedHandler(
+		map[string]string{"Retry-After": "60", "X-RateLimit-Remaining": "4000"},
+		`{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`))
+
+	result, err := githubService.GetUserReviewRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubSecondaryRateLimit)
+	assert.NotErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
+	rateErr, ok := apperrors.AsGitHubSecondaryRateLimitError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 60*time.Second, rateErr.RetryAfter)
+}
+
+// TestGetUserReviewRequests_ProviderTimeout tests that a slow provider fails with ErrUpstreamTimeout
+func TestGetUserReviewRequests_ProviderTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{
+			EnterpriseBaseURL: mockGitHubServer.URL,
+			RequestTimeout:    100 * time.Millisecond,
+		}), nil).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	started := time.Now()
+	result, err := githubService.GetUserReviewRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrUpstreamTimeout)
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+// TestGetUserReviewRequests_GitHubClientRetrievalFailure tests client retrieval failure for review requests
+func TestGetUserReviewRequests_GitHubClientRetrievalFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(nil, fmt.Errorf("provider not configured")).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	result, err := githubService.GetUserReviewRequests(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get GitHub client")
+}
+
+// assignedIssuesHandler serves the given payload from a mock search API, checking the assigned-issues query
+func assignedIssuesHandler(t *testing.T, expectedState string, response map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/search/issues")
+		assert.Contains(t, r.URL.RawQuery, "is%3Aissue")
+		assert.Contains(t, r.URL.RawQuery, "assignee%3A%40me")
+		assert.Contains(t, r.URL.RawQuery, "state%3A"+expectedState)
+		assert.Equal(t, "created", r.URL.Query().Get("sort"))
+		assert.Equal(t, "desc", r.URL.Query().Get("order"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// TestGetUserAssignedIssues_OpenState tests fetching open issues with default parameters
+func TestGetUserAssignedIssues_OpenState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, assignedIssuesHandler(t, "open", map[string]interface{}{
+		"total_count": 1,
+		"items": []map[string]interface{}{
+			{
+				"id":         int64(1001),
+				"number":     17,
+				"title":      "Broken link on landing page",
+				"state":      "open",
+				"created_at": "2025-01-01T12:00:00Z",
+				"updated_at": "2025-01-02T12:00:00Z",
+				"html_url":   "https://github.example.com/owner/portal/issues/17",
+				"user": map[string]interface{}{
+					"login": "reporter",
+					"id":    int64(42),
+				},
+			},
+		},
+	}))
+
+	result, err := githubService.GetUserAssignedIssues(context.Background(), "test-uuid", "githubtools", "", "", "", 0, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	require.Len(t, result.Issues, 1)
+
+	issue := result.Issues[0]
+	assert.Equal(t, int64(1001), issue.ID)
+	assert.Equal(t, 17, issue.Number)
+	assert.Equal(t, "Broken link on landing page", issue.Title)
+	assert.Equal(t, "open", issue.State)
+	assert.Equal(t, "https://github.example.com/owner/portal/issues/17", issue.HTMLURL)
+	assert.Equal(t, "reporter", issue.User.Login)
+	assert.Equal(t, "portal", issue.Repo.Name)
+	assert.Equal(t, "owner/portal", issue.Repo.FullName)
+	assert.Equal(t, time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC), issue.UpdatedAt.UTC())
+}
+
+// TestGetUserAssignedIssues_ClosedState tests fetching closed issues and skipping pull requests
+func TestGetUserAssignedIssues_ClosedState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, assignedIssuesHandler(t, "closed", map[string]interface{}{
+		"total_count": 1,
+		"items": []map[string]interface{}{
+			{
+				"id":       int64(2002),
+				"number":   3,
+				"title":    "Old bug",
+				"state":    "closed",
+				"html_url": "https://github.example.com/owner/api/issues/3",
+				"repository": map[string]interface{}{
+					"name":      "api",
+					"full_name": "owner/api",
+					"private":   true,
+					"owner": map[string]interface{}{
+						"login": "owner",
+					},
+				},
+			},
+			{
+				"id":       int64(2003),
+				"number":   4,
+				"title":    "Not an issue",
+				"state":    "closed",
+				"html_url": "https://github.example.com/owner/api/pull/4",
+				"pull_request": map[string]interface{}{
+					"url": "https://github.example.com/api/v3/repos/owner/api/pulls/4",
+				},
+			},
+		},
+	}))
+
+	result, err := githubService.GetUserAssignedIssues(context.Background(), "test-uuid", "githubtools", "closed", "created", "desc", 30, 1)
+
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "closed", result.Issues[0].State)
+	assert.Equal(t, "api", result.Issues[0].Repo.Name)
+	assert.True(t, result.Issues[0].Repo.Private)
+}
+
+// TestGetUserAssignedIssues_EmptyResults tests when no issues are assigned
+func TestGetUserAssignedIssues_EmptyResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, assignedIssuesHandler(t, "open", map[string]interface{}{
+		"total_count": 0,
+		"items":       []map[string]interface{}{},
+	}))
+
+	result, err := githubService.GetUserAssignedIssues(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+	assert.Empty(t, result.Issues)
+}
+
+// TestGetUserRepositories_MultipleRepos tests listing repositories accessible to the user
+func TestGetUserRepositories_MultipleRepos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v3/user/repos", r.URL.Path)
+		assert.Equal(t, "updated", r.URL.Query().Get("sort"))
+		assert.Equal(t, "50", r.URL.Query().Get("per_page"))
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+
+		response := []map[string]interface{}{
+			{
+				"name":           "portal",
+				"full_name":      "platform/portal",
+				"private":        false,
+				"description":    "Developer portal",
+				"default_branch": "main",
+				"owner":          map[string]interface{}{"login": "platform"},
+			},
+			{
+				"name":           "secrets",
+				"full_name":      "jdoe/secrets",
+				"private":        true,
+				"default_branch": "master",
+				"owner":          map[string]interface{}{"login": "jdoe"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	repos, err := githubService.GetUserRepositories(context.Background(), "test-uuid", "githubtools", "", 50, 2)
+
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Equal(t, service.Repository{
+		Name:          "portal",
+		FullName:      "platform/portal",
+		Owner:         "platform",
+		Private:       false,
+		Description:   "Developer portal",
+		DefaultBranch: "main",
+	}, repos[0])
+	assert.Equal(t, "jdoe", repos[1].Owner)
+	assert.True(t, repos[1].Private)
+	assert.Equal(t, "master", repos[1].DefaultBranch)
+}
+
+// TestGetUserRepositories_RateLimited tests forbidden responses map to the rate limit error
+func TestGetUserRepositories_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}))
+	defer mockGitHubServer.Close()
+
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(1)
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil).
+		Times(1)
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+
+	repos, err := githubService.GetUserRepositories(context.Background(), "test-uuid", "githubtools", "updated", 30, 1)
+
+	assert.Nil(t, repos)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
+}
+
+// TestSearchRepositories_MultipleResults tests searching repositories with several matches
+func TestSearchRepositories_MultipleResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v3/search/repositories", r.URL.Path)
+		assert.Equal(t, "portal org:platform", r.URL.Query().Get("q"))
+		assert.Equal(t, "stars", r.URL.Query().Get("sort"))
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+
+		response := map[string]interface{}{
+			"total_count": 42,
+			"items": []map[string]interface{}{
+				{
+					"name":           "portal",
+					"full_name":      "platform/portal",
+					"private":        false,
+					"description":    "Developer portal",
+					"default_branch": "main",
+					"owner":          map[string]interface{}{"login": "platform"},
+				},
+				{
+					"name":           "portal-ui",
+					"full_name":      "platform/portal-ui",
+					"private":        true,
+					"default_branch": "master",
+					"owner":          map[string]interface{}{"login": "platform"},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	result, err := githubService.SearchRepositories(context.Background(), "test-uuid", "githubtools", "portal org:platform", "stars", 500, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, result.Total)
+	require.Len(t, result.Repositories, 2)
+	assert.Equal(t, service.Repository{
+		Name:          "portal",
+		FullName:      "platform/portal",
+		Owner:         "platform",
+		Private:       false,
+		Description:   "Developer portal",
+		DefaultBranch: "main",
+	}, result.Repositories[0])
+	assert.Equal(t, "platform/portal-ui", result.Repositories[1].FullName)
+	assert.True(t, result.Repositories[1].Private)
+}
+
+// TestSearchRepositories_EmptyResults tests a search without matches
+func TestSearchRepositories_EmptyResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "20", r.URL.Query().Get("per_page"))
+		assert.Empty(t, r.URL.Query().Get("sort"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_count": 0,
+			"items":       []map[string]interface{}{},
+		})
+	})
+
+	result, err := githubService.SearchRepositories(context.Background(), "test-uuid", "githubtools", "does-not-exist", "", 0, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+	assert.NotNil(t, result.Repositories)
+	assert.Empty(t, result.Repositories)
+	assert.Zero(t, result.NextPage)
+}
+
+// TestSearchRepositories_RateLimited tests forbidden responses map to the rate limit error
+func TestSearchRepositories_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	})
+
+	result, err := githubService.SearchRepositories(context.Background(), "test-uuid", "githubtools", "portal", "", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
+}
+
+// TestSearchRepositories_EmptyQuery tests that a blank query is rejected before calling GitHub
+func TestSearchRepositories_EmptyQuery(t *testing.T) {
+	githubService := service.NewGitHubServiceWithAdapter(nil)
+
+	result, err := githubService.SearchRepositories(context.Background(), "test-uuid", "githubtools", "  ", "", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrSearchQueryMissing)
+}
+
 // TestGetUserOpenPullRequests_ClosedState tests fetching closed PRs
 func TestGetUserOpenPullRequests_ClosedState(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -227,6 +933,54 @@ func TestGetUserOpenPullRequests_ClosedState(t *testing.T) {
 	assert.Equal(t, "closed", result.PullRequests[0].State)
 }
 
+// TestGetUserOpenPullRequestsWithFilters_Qualifiers tests that filters are appended to the search query
+func TestGetUserOpenPullRequestsWithFilters_Qualifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `is:pr author:@me state:open org:platform repo:platform/portal -label:wip -label:"do not merge"`, r.URL.Query().Get("q"))
+		assert.Contains(t, r.URL.RawQuery, "org%3Aplatform")
+		assert.Contains(t, r.URL.RawQuery, "repo%3Aplatform%2Fportal")
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_count": 0,
+			"items":       []map[string]interface{}{},
+		})
+	})
+
+	filters := service.QueryFilters{
+		Org:     "platform",
+		Repo:    "platform/portal",
+		Exclude: []string{"wip", " ", `do not "merge"`},
+	}
+	result, err := githubService.GetUserOpenPullRequestsWithFilters(context.Background(), "test-uuid", "githubtools", "open", "created", "desc", 30, 1, filters)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+}
+
+// TestGetUserOpenPullRequestsWithFilters_EmptyFilters tests that empty filters keep the default query
+func TestGetUserOpenPullRequestsWithFilters_EmptyFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "is:pr author:@me", r.URL.Query().Get("q"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_count": 0,
+			"items":       []map[string]interface{}{},
+		})
+	})
+
+	_, err := githubService.GetUserOpenPullRequestsWithFilters(context.Background(), "test-uuid", "githubtools", "all", "created", "desc", 30, 1, service.QueryFilters{})
+
+	require.NoError(t, err)
+}
+
 // TestGetUserOpenPullRequests_EmptyResults tests when no PRs are found
 func TestGetUserOpenPullRequests_EmptyResults(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -792,6 +1546,75 @@ func TestGetContributionsHeatmap_DefaultPeriod(t *testing.T) {
 	assert.Equal(t, "2024-12-31T23:59:59Z", result.To)
 }
 
+// TestGetContributionsHeatmap_CachedWithinTTL verifies repeated calls are served from cache
+func TestGetContributionsHeatmap_CachedWithinTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Create mock GraphQL server counting requests
+	var graphQLRequests int32
+	mockGraphQLServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&graphQLRequests, 1)
+		response := `{
+			"data": {
+				"viewer": {
+					"contributionsCollection": {
+						"startedAt": "2024-01-01T00:00:00Z",
+						"endedAt": "2024-12-31T23:59:59Z",
+						"contributionCalendar": {
+							"totalContributions": 42,
+							"weeks": []
+						}
+					}
+				}
+			}
+		}`
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(response))
+	}))
+	defer mockGraphQLServer.Close()
+
+	// Create mock auth service
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+
+	envConfig := &auth.ProviderConfig{
+		EnterpriseBaseURL: mockGraphQLServer.URL,
+	}
+	githubClient := auth.NewGitHubClient(envConfig)
+
+	// Provider validation runs on every call, the token is only needed on cache misses
+	mockAuthService.EXPECT().
+		GetGitHubClient("githubtools").
+		Return(githubClient, nil).
+		AnyTimes()
+
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "githubtools").
+		Return("test-token", nil).
+		Times(2)
+
+	// Create GitHub service with an in-memory cache
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+	githubService.SetCache(cache.NewInMemoryCache(cache.DefaultCacheConfig()))
+
+	// First call populates the cache
+	first, err := githubService.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "30d")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&graphQLRequests))
+
+	// Second call within TTL issues no GraphQL request
+	second, err := githubService.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "30d")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&graphQLRequests))
+	assert.Equal(t, first, second)
+
+	// A different period is cached separately
+	_, err = githubService.GetContributionsHeatmap(context.Background(), "test-uuid", "githubtools", "90d")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&graphQLRequests))
+}
+
 // TestGetAveragePRMergeTime_Success tests successful scenarios with different PR data
 func TestGetAveragePRMergeTime_Success(t *testing.T) {
 	testCases := []struct {
@@ -1266,6 +2089,79 @@ func TestGetRepositoryContent_Success(t *testing.T) {
 	}
 }
 
+// TestGetRepositoryContent_SizeLimit tests oversized files are rejected from their listed size before their
+// content is fetched, while directory listings are unaffected
+func TestGetRepositoryContent_SizeLimit(t *testing.T) {
+	// The root listing reports sizes; files are only served from their own path
+	listings := map[string]string{
+		"/api/v3/repos/owner/repo/contents/": `[{"type": "file", "name": "big.bin", "path": "big.bin", "size": 2048},
+			{"type": "file", "name": "small.txt", "path": "small.txt", "size": 5},
+			{"type": "dir", "name": "docs", "path": "docs", "size": 0}]`,
+		"/api/v3/repos/owner/repo/contents/small.txt": fmt.Sprintf(`{"type": "file", "name": "small.txt", "path": "small.txt", "size": 5, "encoding": "base64", "content": %q}`,
+			base64.StdEncoding.EncodeToString([]byte("hello"))),
+		"/api/v3/repos/owner/repo/contents/docs": `[{"type": "file", "name": "huge.pdf", "path": "docs/huge.pdf", "size": 999999}]`,
+	}
+
+	testCases := []struct {
+		name        string
+		path        string
+		expectError bool
+	}{
+		{name: "FileOverLimit", path: "big.bin", expectError: true},
+		{name: "FileWithinLimit", path: "small.txt"},
+		{name: "DirectoryListing", path: "docs"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var requested []string
+			mockGitHubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = append(requested, r.URL.Path)
+				response, ok := listings[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(response))
+			}))
+			defer mockGitHubServer.Close()
+
+			mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+			mockAuthService.EXPECT().
+				GetGitHubAccessToken("test-uuid", "githubtools").
+				Return("test-token", nil)
+			mockAuthService.EXPECT().
+				GetGitHubClient("githubtools").
+				Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: mockGitHubServer.URL}), nil)
+
+			githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+			githubService.SetMaxFileSize(1024)
+
+			result, err := githubService.GetRepositoryContent(context.Background(), "test-uuid", "githubtools", "owner", "repo", tc.path, "main")
+
+			if tc.expectError {
+				assert.Nil(t, result)
+				assert.ErrorIs(t, err, apperrors.ErrFileTooLarge)
+				assert.NotContains(t, requested, "/api/v3/repos/owner/repo/contents/big.bin", "oversized content must not be fetched")
+				return
+			}
+			require.NoError(t, err)
+			if fileResult, ok := result.(map[string]interface{}); ok {
+				assert.Equal(t, "hello", fileResult["content"])
+				assert.NotContains(t, fileResult, "decoded_content")
+			} else {
+				dirResult, ok := result.([]map[string]interface{})
+				require.True(t, ok, "Expected directory result to be an array")
+				assert.Len(t, dirResult, 1)
+			}
+		})
+	}
+}
+
 // TestUpdateRepositoryFile_Success tests successful file update scenarios
 func TestUpdateRepositoryFile_Success(t *testing.T) {
 	testCases := []struct {
@@ -2315,6 +3211,96 @@ func TestClosePullRequest_DeleteBranch_BranchAlreadyDeleted(t *testing.T) {
 	assert.Equal(t, "closed", result.State)
 }
 
+// TestGetPullRequestDetails_Found tests fetching a single pull request with merge and review state
+func TestGetPullRequestDetails_Found(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v3/repos/platform/portal/pulls/42":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":              int64(4242),
+				"number":          42,
+				"title":           "Add repository search",
+				"state":           "open",
+				"html_url":        "https://github.com/platform/portal/pull/42",
+				"draft":           false,
+				"merged":          false,
+				"mergeable":       true,
+				"mergeable_state": "clean",
+				"changed_files":   3,
+				"additions":       120,
+				"deletions":       15,
+				"commits":         2,
+				"user":            map[string]interface{}{"login": "jdoe", "id": 7},
+				"head":            map[string]interface{}{"ref": "feature/search"},
+				"base": map[string]interface{}{
+					"ref": "main",
+					"repo": map[string]interface{}{
+						"name":           "portal",
+						"full_name":      "platform/portal",
+						"private":        true,
+						"default_branch": "main",
+						"owner":          map[string]interface{}{"login": "platform"},
+					},
+				},
+			})
+		case "/api/v3/repos/platform/portal/pulls/42/reviews":
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"state": "CHANGES_REQUESTED", "user": map[string]interface{}{"login": "alice"}},
+				{"state": "COMMENTED", "user": map[string]interface{}{"login": "alice"}},
+				{"state": "APPROVED", "user": map[string]interface{}{"login": "alice"}},
+				{"state": "APPROVED", "user": map[string]interface{}{"login": "bob"}},
+			})
+		default:
+			t.Errorf("unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	details, err := githubService.GetPullRequestDetails(context.Background(), "test-uuid", "githubtools", "platform", "portal", 42)
+
+	require.NoError(t, err)
+	require.NotNil(t, details)
+	assert.Equal(t, int64(4242), details.ID)
+	assert.Equal(t, 42, details.Number)
+	assert.Equal(t, "jdoe", details.User.Login)
+	assert.Equal(t, "platform/portal", details.Repo.FullName)
+	assert.True(t, details.Repo.Private)
+	assert.False(t, details.Merged)
+	require.NotNil(t, details.Mergeable)
+	assert.True(t, *details.Mergeable)
+	assert.Equal(t, "clean", details.MergeableState)
+	assert.Equal(t, service.ReviewStatusApproved, details.ReviewStatus)
+	assert.Equal(t, 3, details.ChangedFiles)
+	assert.Equal(t, 120, details.Additions)
+	assert.Equal(t, 15, details.Deletions)
+	assert.Equal(t, 2, details.Commits)
+	assert.Equal(t, "main", details.BaseBranch)
+	assert.Equal(t, "feature/search", details.HeadBranch)
+}
+
+// TestGetPullRequestDetails_NotFound tests that a missing pull request maps to ErrPullRequestNotFound
+func TestGetPullRequestDetails_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/repos/platform/portal/pulls/404", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	details, err := githubService.GetPullRequestDetails(context.Background(), "test-uuid", "githubtools", "platform", "portal", 404)
+
+	assert.Nil(t, details)
+	assert.ErrorIs(t, err, apperrors.ErrPullRequestNotFound)
+}
+
 // TestGetUserPRReviewComments_Success tests successful scenarios with different comment counts
 func TestGetUserPRReviewComments_Success(t *testing.T) {
 	testCases := []struct {
@@ -2858,3 +3844,263 @@ func TestGetUserPRReviewComments_SearchRateLimitExceeded(t *testing.T) {
 	assert.Nil(t, result)
 	assert.ErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
 }
+
+// commitActivityWeeks builds a stats/commit_activity payload with one entry per week start
+func commitActivityWeeks(weekStarts []time.Time, totals []int) []map[string]interface{} {
+	weeks := make([]map[string]interface{}, len(weekStarts))
+	for i, start := range weekStarts {
+		weeks[i] = map[string]interface{}{
+			"days":  []int{0, totals[i], 0, 0, 0, 0, 0},
+			"total": totals[i],
+			"week":  start.Unix(),
+		}
+	}
+	return weeks
+}
+
+// TestGetCommitActivity_Ready tests parsing of ready statistics and filtering by period
+func TestGetCommitActivity_Ready(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	recent := today.AddDate(0, 0, -7)
+	old := today.AddDate(0, 0, -200)
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/repos/owner/repo/stats/commit_activity", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(commitActivityWeeks([]time.Time{old, recent}, []int{5, 12}))
+	})
+	githubService.SetStatsPolling(3, 0)
+
+	result, err := githubService.GetCommitActivity(context.Background(), "test-uuid", "githubtools", "owner", "repo", "30d")
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, recent.Format("2006-01-02"), result[0].WeekStart)
+	assert.Equal(t, 12, result[0].Count)
+}
+
+// TestGetCommitActivity_RetriesWhileComputing tests that a 202 response is polled until the statistics are ready
+func TestGetCommitActivity_RetriesWhileComputing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	week := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -7)
+	var requests int32
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(commitActivityWeeks([]time.Time{week}, []int{3}))
+	})
+	githubService.SetStatsPolling(3, 0)
+
+	result, err := githubService.GetCommitActivity(context.Background(), "test-uuid", "githubtools", "owner", "repo", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	require.Len(t, result, 1)
+	assert.Equal(t, 3, result[0].Count)
+}
+
+// TestGetCommitActivity_StillComputing tests that ErrGitHubStatsNotReady is returned once polling is exhausted
+func TestGetCommitActivity_StillComputing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var requests int32
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	githubService.SetStatsPolling(3, 0)
+
+	result, err := githubService.GetCommitActivity(context.Background(), "test-uuid", "githubtools", "owner", "repo", "")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubStatsNotReady)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+// TestGetCommitActivity_RateLimited tests rate limit handling
+func TestGetCommitActivity_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	githubService := newGitHubTestService(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	})
+	githubService.SetStatsPolling(3, 0)
+
+	result, err := githubService.GetCommitActivity(context.Background(), "test-uuid", "githubtools", "owner", "repo", "")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
+}
+
+// timelineHandler serves the PR search and, unless heatmapStatus is an error, a heatmap with the given days
+func timelineHandler(prs []map[string]interface{}, heatmapStatus int, days []map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/search/issues":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"total_count": len(prs), "items": prs})
+		case "/api/graphql":
+			w.WriteHeader(heatmapStatus)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"viewer": map[string]interface{}{"contributionsCollection": map[string]interface{}{
+					"contributionCalendar": map[string]interface{}{
+						"totalContributions": 10,
+						"weeks":              []interface{}{map[string]interface{}{"firstDay": days[0]["date"], "contributionDays": days}},
+					},
+				}}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func timelineTestPRs(now time.Time) []map[string]interface{} {
+	return []map[string]interface{}{
+		{"id": 1, "number": 11, "title": "Recent PR", "state": "open", "created_at": now.Add(-2 * time.Hour).Format(time.RFC3339), "html_url": "https://github.com/owner/repo/pull/11", "pull_request": map[string]interface{}{"url": "https://github.com/owner/repo/pull/11"}},
+		{"id": 2, "number": 12, "title": "Older PR", "state": "closed", "created_at": now.AddDate(0, 0, -3).Format(time.RFC3339), "html_url": "https://github.com/owner/repo/pull/12", "pull_request": map[string]interface{}{"url": "https://github.com/owner/repo/pull/12"}},
+		{"id": 3, "number": 13, "title": "Outside period", "state": "closed", "created_at": now.AddDate(0, 0, -60).Format(time.RFC3339), "html_url": "https://github.com/owner/repo/pull/13", "pull_request": map[string]interface{}{"url": "https://github.com/owner/repo/pull/13"}},
+	}
+}
+
+// TestGetUserActivityTimeline_MergesSources tests that PRs and contribution days are merged newest first
+func TestGetUserActivityTimeline_MergesSources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().UTC()
+	days := []map[string]interface{}{
+		{"date": now.AddDate(0, 0, -5).Format("2006-01-02"), "contributionCount": 4},
+		{"date": now.AddDate(0, 0, -4).Format("2006-01-02"), "contributionCount": 0},
+		{"date": now.AddDate(0, 0, -1).Format("2006-01-02"), "contributionCount": 6},
+	}
+	githubService := newGitHubTestService(t, ctrl, timelineHandler(timelineTestPRs(now), http.StatusOK, days))
+
+	timeline, err := githubService.GetUserActivityTimeline(context.Background(), "test-uuid", "githubtools", "30d")
+
+	require.NoError(t, err)
+	assert.Empty(t, timeline.PartialErrors)
+	assert.Equal(t, "30d", timeline.Period)
+
+	// The PR outside the period and the day without contributions are dropped
+	require.Len(t, timeline.Events, 4)
+	assert.Equal(t, service.TimelineEventPullRequest, timeline.Events[0].Type)
+	assert.Equal(t, 11, timeline.Events[0].PullRequest.Number)
+	assert.Equal(t, service.TimelineEventContribution, timeline.Events[1].Type)
+	assert.Equal(t, 6, timeline.Events[1].ContributionCount)
+	assert.Equal(t, service.TimelineEventPullRequest, timeline.Events[2].Type)
+	assert.Equal(t, 12, timeline.Events[2].PullRequest.Number)
+	assert.Equal(t, service.TimelineEventContribution, timeline.Events[3].Type)
+	assert.Equal(t, 4, timeline.Events[3].ContributionCount)
+}
+
+// TestGetUserActivityTimeline_HeatmapFailureKeepsPRs tests that a failing heatmap still returns the PRs
+func TestGetUserActivityTimeline_HeatmapFailureKeepsPRs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().UTC()
+	days := []map[string]interface{}{{"date": now.Format("2006-01-02"), "contributionCount": 1}}
+	githubService := newGitHubTestService(t, ctrl, timelineHandler(timelineTestPRs(now), http.StatusInternalServerError, days))
+
+	timeline, err := githubService.GetUserActivityTimeline(context.Background(), "test-uuid", "githubtools", "30d")
+
+	require.NoError(t, err)
+	require.Len(t, timeline.Events, 2)
+	for _, event := range timeline.Events {
+		assert.Equal(t, service.TimelineEventPullRequest, event.Type)
+	}
+	assert.Contains(t, timeline.PartialErrors, "contributions")
+	assert.NotContains(t, timeline.PartialErrors, "pull_requests")
+}
+
+// TestGetUserActivityTimeline_InvalidPeriod tests that unsupported periods are rejected before any call
+func TestGetUserActivityTimeline_InvalidPeriod(t *testing.T) {
+	githubService := service.NewGitHubServiceWithAdapter(mocks.NewMockGitHubAuthService(gomock.NewController(t)))
+
+	timeline, err := githubService.GetUserActivityTimeline(context.Background(), "test-uuid", "githubtools", "45d")
+
+	assert.Nil(t, timeline)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidPeriod)
+}
+
+// TestResolveProvider_ExplicitProvider tests that a given provider is used without consulting any default
+func TestResolveProvider_ExplicitProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken(userID.String(), "githubwdf").
+		Return("", fmt.Errorf("no token stored"))
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+	githubService.SetUserRepository(mocks.NewMockUserRepositoryInterface(ctrl))
+	githubService.SetDefaultProvider("githubtools")
+
+	_, err := githubService.GetUserReviewRequests(context.Background(), userID.String(), "githubwdf", "open", "created", "desc", 30, 1)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, apperrors.ErrNoDefaultProvider)
+}
+
+// TestResolveProvider_EmptyProviderUsesUserDefault tests that an empty provider resolves to the user's metadata default
+func TestResolveProvider_EmptyProviderUsesUserDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockUserRepo.EXPECT().
+		GetByID(userID).
+		Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Metadata: json.RawMessage(`{"default_provider": "githubwdf"}`)}, nil)
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken(userID.String(), "githubwdf").
+		Return("", fmt.Errorf("no token stored"))
+
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+	githubService.SetUserRepository(mockUserRepo)
+	githubService.SetDefaultProvider("githubtools")
+
+	_, err := githubService.GetUserReviewRequests(context.Background(), userID.String(), "", "open", "created", "desc", 30, 1)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, apperrors.ErrNoDefaultProvider)
+}
+
+// TestResolveProvider_EmptyProviderWithoutDefault tests that an empty provider fails clearly when no default is configured
+func TestResolveProvider_EmptyProviderWithoutDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	mockUserRepo := mocks.NewMockUserRepositoryInterface(ctrl)
+	mockUserRepo.EXPECT().
+		GetByID(userID).
+		Return(&models.User{BaseModel: models.BaseModel{ID: userID}, Metadata: json.RawMessage(`{}`)}, nil)
+
+	githubService := service.NewGitHubServiceWithAdapter(mocks.NewMockGitHubAuthService(ctrl))
+	githubService.SetUserRepository(mockUserRepo)
+
+	result, err := githubService.GetUserReviewRequests(context.Background(), userID.String(), "", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrNoDefaultProvider)
+	assert.ErrorIs(t, err, apperrors.ErrMissingUserUUIDAndProvider)
+	assert.Contains(t, err.Error(), "no default provider configured")
+}
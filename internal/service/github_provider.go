@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// defaultProviderMetadataKey is the user metadata field holding the user's preferred VCS provider
+const defaultProviderMetadataKey = "default_provider"
+
+// SetUserRepository lets calls without a provider fall back to the default provider stored in the user's metadata
+func (s *GitHubService) SetUserRepository(userRepo repository.UserRepositoryInterface) {
+	s.userRepo = userRepo
+}
+
+// SetDefaultProvider sets the provider used when neither the caller nor the user's metadata names one.
+// An empty provider removes the service default.
+func (s *GitHubService) SetDefaultProvider(provider string) {
+	s.defaultProvider = strings.TrimSpace(provider)
+}
+
+// resolveProvider returns provider when given, otherwise the user's default provider, then the service default.
+// It fails with ErrNoDefaultProvider when none is configured. An empty userUUID is left to the callers' validation.
+func (s *GitHubService) resolveProvider(ctx context.Context, userUUID, provider string) (string, error) {
+	if provider != "" || userUUID == "" {
+		return provider, nil
+	}
+	if userProvider := s.userDefaultProvider(ctx, userUUID); userProvider != "" {
+		return userProvider, nil
+	}
+	if s.defaultProvider != "" {
+		return s.defaultProvider, nil
+	}
+	return "", apperrors.ErrNoDefaultProvider
+}
+
+// userDefaultProvider reads metadata.default_provider of the user, returning "" when it is unavailable
+func (s *GitHubService) userDefaultProvider(ctx context.Context, userUUID string) string {
+	if s.userRepo == nil {
+		return ""
+	}
+	userID, err := uuid.Parse(userUUID)
+	if err != nil {
+		return ""
+	}
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil || user == nil || user.Metadata == nil {
+		if err != nil {
+			logger.WithContext(ctx).Warnf("Failed to load user %s for default provider: %v", userUUID, err)
+		}
+		return ""
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(user.Metadata, &metadata); err != nil {
+		return ""
+	}
+	provider, _ := metadata[defaultProviderMetadataKey].(string)
+	return strings.TrimSpace(provider)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"developer-portal-backend/internal/logger"
+)
+
+const (
+	// defaultGraphQLMaxAttempts bounds how often a GraphQL query is sent when GitHub keeps failing with 5xx or network errors
+	defaultGraphQLMaxAttempts = 3
+	// defaultGraphQLRetryBaseDelay is the backoff before the first retry; it doubles on every further attempt
+	defaultGraphQLRetryBaseDelay = 250 * time.Millisecond
+)
+
+// SetGraphQLRetryPolicy overrides the attempt count, base backoff delay and sleep function used for GraphQL retries.
+// Non-positive values keep the defaults and a nil sleep keeps the current implementation.
+func (s *GitHubService) SetGraphQLRetryPolicy(maxAttempts int, baseDelay time.Duration, sleep func(ctx context.Context, delay time.Duration) error) {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultGraphQLMaxAttempts
+	}
+	if baseDelay <= 0 {
+		baseDelay = defaultGraphQLRetryBaseDelay
+	}
+	s.graphQLMaxAttempts = maxAttempts
+	s.graphQLRetryBaseDelay = baseDelay
+	if sleep != nil {
+		s.sleep = sleep
+	}
+}
+
+// doGraphQLRequest executes a GraphQL request, retrying 5xx responses and network errors with jittered
+// exponential backoff. 4xx responses are returned as-is. The last response or error is returned once
+// the attempts are exhausted, so callers keep their existing status handling.
+func (s *GitHubService) doGraphQLRequest(ctx context.Context, httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	maxAttempts := s.graphQLMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultGraphQLMaxAttempts
+	}
+	sleep := s.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := httpClient.Do(req)
+		if attempt >= maxAttempts || !isRetryableGraphQLResult(ctx, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		delay := s.graphQLRetryDelay(attempt)
+		logger.WithContext(ctx).Warnf("GraphQL attempt %d/%d failed, retrying in %s", attempt, maxAttempts, delay)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+
+		// The request body was consumed by the previous attempt
+		next := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			next.Body = body
+		}
+		req = next
+	}
+}
+
+// isRetryableGraphQLResult reports whether a GraphQL call failed transiently
+func isRetryableGraphQLResult(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// A cancelled or expired caller context will not recover by retrying
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// graphQLRetryDelay returns the jittered backoff before the given retry, between half and the full exponential delay
+func (s *GitHubService) graphQLRetryDelay(attempt int) time.Duration {
+	base := s.graphQLRetryBaseDelay
+	if base <= 0 {
+		base = defaultGraphQLRetryBaseDelay
+	}
+	delay := base << (attempt - 1)
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// sleepContext waits for delay or until ctx is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
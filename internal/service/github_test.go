@@ -227,7 +227,7 @@ func (suite *GitHubServiceTestSuite) TestGetAveragePRMergeTime_ClientRetrievalFa
 func (suite *GitHubServiceTestSuite) TestGetAveragePRMergeTime_ValidPeriods() {
 	ctx := context.Background()
 
-	validPeriods := []string{"1d", "7d", "30d", "90d", "180d", "365d"}
+	validPeriods := []string{"7d", "30d", "90d", "1y"}
 
 	for _, period := range validPeriods {
 		suite.Run(period, func() {
@@ -942,17 +942,23 @@ func (suite *GitHubServiceTestSuite) TestGetContributionsHeatmap_PeriodValidatio
 			},
 		},
 		{
-			name:          "Valid_1Day",
+			name:          "Unsupported_1Day",
 			period:        "1d",
-			shouldBeValid: true,
+			shouldBeValid: false,
 			setupMocks: func() {
 				suite.mockAuthSvc.EXPECT().
 					GetGitHubClient("githubtools").
-					Return(nil, nil).
-					Times(2)
+					Return(nil, nil)
+			},
+		},
+		{
+			name:          "Unsupported_30Days_LongForm",
+			period:        "30days",
+			shouldBeValid: false,
+			setupMocks: func() {
 				suite.mockAuthSvc.EXPECT().
-					GetGitHubAccessToken("test-uuid", "githubtools").
-					Return("test-token", nil)
+					GetGitHubClient("githubtools").
+					Return(nil, nil)
 			},
 		},
 		{
@@ -998,8 +1004,8 @@ func (suite *GitHubServiceTestSuite) TestGetContributionsHeatmap_PeriodValidatio
 			},
 		},
 		{
-			name:          "Valid_365Days",
-			period:        "365d",
+			name:          "Valid_1Year",
+			period:        "1y",
 			shouldBeValid: true,
 			setupMocks: func() {
 				suite.mockAuthSvc.EXPECT().
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timeline event types reported in TimelineEvent.Type
+const (
+	TimelineEventPullRequest  = "pull_request"
+	TimelineEventContribution = "contributions"
+)
+
+// Timeline sources used as PartialErrors keys
+const (
+	timelineSourcePullRequests  = "pull_requests"
+	timelineSourceContributions = "contributions"
+)
+
+// defaultTimelinePeriod is used when GetUserActivityTimeline is called without a period
+const defaultTimelinePeriod = "30d"
+
+// TimelineEvent is a single entry of a user's activity timeline: an opened pull request or a day with contributions
+type TimelineEvent struct {
+	Type              string       `json:"type" example:"pull_request"`
+	Date              time.Time    `json:"date" example:"2025-01-15T12:00:00Z"`
+	PullRequest       *PullRequest `json:"pull_request,omitempty"`
+	ContributionCount int          `json:"contribution_count,omitempty" example:"5"`
+}
+
+// ActivityTimelineResponse represents a user's merged activity for a period, newest event first
+type ActivityTimelineResponse struct {
+	Events        []TimelineEvent   `json:"events"`
+	Period        string            `json:"period" example:"30d"`
+	From          string            `json:"from" example:"2024-10-16T00:00:00Z"`
+	To            string            `json:"to" example:"2024-11-16T23:59:59Z"`
+	PartialErrors map[string]string `json:"partial_errors,omitempty"` // source -> error, set when one source failed
+}
+
+// GetUserActivityTimeline merges the user's pull requests opened in the period with their contribution days.
+// Both sources are fetched concurrently; a failing source is recorded in PartialErrors while the other
+// still populates the timeline. An error is only returned when both sources fail.
+func (s *GitHubService) GetUserActivityTimeline(ctx context.Context, userUUID, provider, period string) (*ActivityTimelineResponse, error) {
+	provider, err := s.resolveProvider(ctx, userUUID, provider)
+	if err != nil {
+		return nil, err
+	}
+	if period == "" {
+		period = defaultTimelinePeriod
+	}
+	days, err := normalizeSupportedPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	from, to, _, err := parsePeriod(days)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg         sync.WaitGroup
+		prs        *PullRequestsResponse
+		prErr      error
+		heatmap    *ContributionsHeatmapResponse
+		heatmapErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		prs, prErr = s.GetUserOpenPullRequests(ctx, userUUID, provider, "all", "created", "desc", MaxPageSize, 1)
+	}()
+	go func() {
+		defer wg.Done()
+		heatmap, heatmapErr = s.GetContributionsHeatmap(ctx, userUUID, provider, period)
+	}()
+	wg.Wait()
+
+	if prErr != nil && heatmapErr != nil {
+		return nil, fmt.Errorf("failed to fetch activity timeline: %w", prErr)
+	}
+
+	response := &ActivityTimelineResponse{
+		Events: make([]TimelineEvent, 0),
+		Period: period,
+		From:   from.Format(time.RFC3339),
+		To:     to.Format(time.RFC3339),
+	}
+
+	if prErr != nil {
+		response.PartialErrors = map[string]string{timelineSourcePullRequests: prErr.Error()}
+	} else {
+		for i := range prs.PullRequests {
+			pr := prs.PullRequests[i]
+			if pr.CreatedAt.Before(from) || pr.CreatedAt.After(to) {
+				continue
+			}
+			response.Events = append(response.Events, TimelineEvent{
+				Type:        TimelineEventPullRequest,
+				Date:        pr.CreatedAt,
+				PullRequest: &pr,
+			})
+		}
+	}
+
+	if heatmapErr != nil {
+		response.PartialErrors = map[string]string{timelineSourceContributions: heatmapErr.Error()}
+	} else {
+		for _, week := range heatmap.Weeks {
+			for _, day := range week.ContributionDays {
+				date, err := time.Parse("2006-01-02", day.Date)
+				if err != nil || day.ContributionCount == 0 {
+					continue
+				}
+				response.Events = append(response.Events, TimelineEvent{
+					Type:              TimelineEventContribution,
+					Date:              date,
+					ContributionCount: day.ContributionCount,
+				})
+			}
+		}
+	}
+
+	// Newest first; a pull request opened during a day sorts ahead of that day's contributions
+	sort.SliceStable(response.Events, func(i, j int) bool {
+		return response.Events[i].Date.After(response.Events[j].Date)
+	})
+
+	return response, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+)
+
+// defaultGitHubRequestTimeout bounds a single GitHub REST or GraphQL request when the provider sets no timeout
+const defaultGitHubRequestTimeout = 30 * time.Second
+
+// SetProviderTimeout overrides the request timeout for one provider, taking precedence over its ProviderConfig.
+// A non-positive timeout removes the override.
+func (s *GitHubService) SetProviderTimeout(provider string, timeout time.Duration) {
+	if timeout <= 0 {
+		delete(s.providerTimeouts, provider)
+		return
+	}
+	s.providerTimeouts[provider] = timeout
+}
+
+// requestTimeout resolves the timeout for a provider: service override, then provider config, then the default
+func (s *GitHubService) requestTimeout(provider string, clientConfig *auth.GitHubClient) time.Duration {
+	if timeout, ok := s.providerTimeouts[provider]; ok {
+		return timeout
+	}
+	if clientConfig != nil && clientConfig.GetRequestTimeout() > 0 {
+		return clientConfig.GetRequestTimeout()
+	}
+	return defaultGitHubRequestTimeout
+}
+
+// withRequestTimeout makes every request sent by client time out after timeout,
+// reporting the expiry as apperrors.ErrUpstreamTimeout
+func withRequestTimeout(client *http.Client, timeout time.Duration) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &upstreamTimeoutTransport{base: base, timeout: timeout}
+	return client
+}
+
+// upstreamTimeoutTransport applies a deadline to each round trip, including reading the response body
+type upstreamTimeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *upstreamTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		// Only our own deadline is an upstream timeout; the caller's cancellation or deadline is passed through
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && req.Context().Err() == nil {
+			return nil, fmt.Errorf("%w after %s: %w", apperrors.ErrUpstreamTimeout, t.timeout, err)
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the round trip's timeout context once the response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+)
+
+// gitLabMaxEventPages caps how many pages of user events are read when building a heatmap
+const gitLabMaxEventPages = 50
+
+// GitLabService implements VCSProvider on top of the GitLab REST API (v4)
+type GitLabService struct {
+	authService GitHubAuthService
+	httpClient  *http.Client
+	maxFileSize int
+}
+
+// NewGitLabService creates a new GitLab service.
+// Tokens and base URLs are resolved per provider through the same auth adapter used by GitHubService.
+func NewGitLabService(authService GitHubAuthService) *GitLabService {
+	return &GitLabService{
+		authService: authService,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		maxFileSize: defaultMaxFileSize,
+	}
+}
+
+// SetMaxFileSize sets the largest file in bytes GetRepositoryContent will return
+func (s *GitLabService) SetMaxFileSize(maxBytes int) {
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxFileSize
+	}
+	s.maxFileSize = maxBytes
+}
+
+// gitLabMergeRequest is the subset of a GitLab merge request used by the portal
+type gitLabMergeRequest struct {
+	ID             int64     `json:"id"`
+	IID            int       `json:"iid"`
+	Title          string    `json:"title"`
+	State          string    `json:"state"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	WebURL         string    `json:"web_url"`
+	Draft          bool      `json:"draft"`
+	WorkInProgress bool      `json:"work_in_progress"`
+	Author         struct {
+		ID        int64  `json:"id"`
+		Username  string `json:"username"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"author"`
+}
+
+// gitLabEvent is the subset of a GitLab user event used to build the contributions heatmap
+type gitLabEvent struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetOpenPullRequests retrieves merge requests created by the authenticated user, mapped to PullRequest
+func (s *GitLabService) GetOpenPullRequests(ctx context.Context, userUUID, provider, state, sortBy, direction string, perPage, page int) (*PullRequestsResponse, error) {
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+
+	// Set default values
+	perPage = ClampPageSize(perPage)
+	if page <= 0 {
+		page = 1
+	}
+	if direction != "asc" {
+		direction = "desc"
+	}
+
+	// GitLab uses "opened" instead of "open"
+	gitLabState := "opened"
+	switch state {
+	case "closed":
+		gitLabState = "closed"
+	case "all":
+		gitLabState = "all"
+	}
+
+	orderBy := "created_at"
+	if sortBy == "updated" {
+		orderBy = "updated_at"
+	}
+
+	query := url.Values{}
+	query.Set("scope", "created_by_me")
+	query.Set("state", gitLabState)
+	query.Set("order_by", orderBy)
+	query.Set("sort", direction)
+	query.Set("per_page", strconv.Itoa(perPage))
+	query.Set("page", strconv.Itoa(page))
+
+	var mergeRequests []gitLabMergeRequest
+	header, err := s.getJSON(ctx, userUUID, provider, "/merge_requests", "merge requests", query, &mergeRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequests := make([]PullRequest, 0, len(mergeRequests))
+	for _, mr := range mergeRequests {
+		prState := mr.State
+		if prState == "opened" {
+			prState = "open"
+		} else if prState == "merged" || prState == "locked" {
+			prState = "closed"
+		}
+
+		pullRequests = append(pullRequests, PullRequest{
+			ID:        mr.ID,
+			Number:    mr.IID,
+			Title:     mr.Title,
+			State:     prState,
+			CreatedAt: mr.CreatedAt,
+			UpdatedAt: mr.UpdatedAt,
+			HTMLURL:   mr.WebURL,
+			Draft:     mr.Draft || mr.WorkInProgress,
+			User: GitHubUser{
+				Login:     mr.Author.Username,
+				ID:        mr.Author.ID,
+				AvatarURL: mr.Author.AvatarURL,
+			},
+			Repo: parseGitLabProjectFromURL(mr.WebURL),
+		})
+	}
+
+	total := len(pullRequests)
+	if headerTotal, err := strconv.Atoi(header.Get("X-Total")); err == nil {
+		total = headerTotal
+	}
+	nextPage, _ := strconv.Atoi(header.Get("X-Next-Page"))
+
+	return &PullRequestsResponse{
+		PullRequests: pullRequests,
+		Total:        total,
+		NextPage:     nextPage,
+	}, nil
+}
+
+// GetContributionsHeatmap builds a GitHub-style contribution calendar from the user's GitLab events
+func (s *GitLabService) GetContributionsHeatmap(ctx context.Context, userUUID, provider, period string) (*ContributionsHeatmapResponse, error) {
+	if userUUID == "" || provider == "" {
+		return nil, apperrors.ErrMissingUserUUIDAndProvider
+	}
+
+	from, to, _, err := parsePeriod(period)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidPeriodFormat, err)
+	}
+
+	// GitLab's after/before filters are exclusive dates
+	query := url.Values{}
+	query.Set("after", from.AddDate(0, 0, -1).Format("2006-01-02"))
+	query.Set("before", to.AddDate(0, 0, 1).Format("2006-01-02"))
+	query.Set("per_page", "100")
+
+	counts := make(map[string]int)
+	for page := 1; page <= gitLabMaxEventPages; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var events []gitLabEvent
+		header, err := s.getJSON(ctx, userUUID, provider, "/events", "user events", query, &events)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			counts[event.CreatedAt.UTC().Format("2006-01-02")]++
+		}
+
+		if header.Get("X-Next-Page") == "" {
+			break
+		}
+	}
+
+	return buildContributionsHeatmap(counts, from, to), nil
+}
+
+// GetRepositoryContent retrieves a file or directory listing from a GitLab project.
+// owner and repo are joined into the project path, so owner may contain nested groups.
+func (s *GitLabService) GetRepositoryContent(ctx context.Context, userUUID, provider, owner, repo, path, ref string) (interface{}, error) {
+	// Set default ref if not provided
+	if ref == "" {
+		ref = "main"
+	}
+
+	// Remove leading slash from path if present
+	path = strings.TrimPrefix(path, "/")
+
+	projectPath := "/projects/" + url.PathEscape(owner+"/"+repo)
+
+	// Try the path as a file first, then fall back to a directory listing
+	if path != "" {
+		var file struct {
+			FileName string `json:"file_name"`
+			FilePath string `json:"file_path"`
+			Size     int    `json:"size"`
+			Encoding string `json:"encoding"`
+			Content  string `json:"content"`
+			BlobID   string `json:"blob_id"`
+		}
+		query := url.Values{}
+		query.Set("ref", ref)
+		fileEndpoint := projectPath + "/repository/files/" + url.PathEscape(path)
+
+		// HEAD returns the file metadata without its content, so oversized files are refused before download
+		header, err := s.head(ctx, userUUID, provider, fileEndpoint, "repository file", query)
+		if err == nil {
+			if size, sizeErr := strconv.Atoi(header.Get("X-Gitlab-Size")); sizeErr == nil && size > s.maxFileSize {
+				return nil, fmt.Errorf("%w: %s is %d bytes, limit is %d bytes", apperrors.ErrFileTooLarge, path, size, s.maxFileSize)
+			}
+			_, err = s.getJSON(ctx, userUUID, provider, fileEndpoint, "repository file", query, &file)
+		}
+		if err == nil {
+			if file.Size > s.maxFileSize {
+				return nil, fmt.Errorf("%w: %s is %d bytes, limit is %d bytes", apperrors.ErrFileTooLarge, file.FilePath, file.Size, s.maxFileSize)
+			}
+			content := file.Content
+			if file.Encoding == "base64" {
+				decoded, decodeErr := base64.StdEncoding.DecodeString(file.Content)
+				if decodeErr != nil {
+					return nil, fmt.Errorf("failed to get file content: %w", decodeErr)
+				}
+				content = string(decoded)
+			}
+			return map[string]interface{}{
+				"name":     file.FileName,
+				"path":     file.FilePath,
+				"sha":      file.BlobID,
+				"size":     file.Size,
+				"type":     "file",
+				"content":  content,
+				"encoding": file.Encoding,
+			}, nil
+		}
+		if !apperrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	var tree []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+		Path string `json:"path"`
+	}
+	query := url.Values{}
+	query.Set("ref", ref)
+	query.Set("per_page", "100")
+	if path != "" {
+		query.Set("path", path)
+	}
+
+	if _, err := s.getJSON(ctx, userUUID, provider, projectPath+"/repository/tree", "repository content", query, &tree); err != nil {
+		return nil, err
+	}
+
+	// An unknown path yields an empty tree rather than a 404
+	if len(tree) == 0 && path != "" {
+		return nil, apperrors.NewNotFoundError("repository content")
+	}
+
+	result := make([]map[string]interface{}, len(tree))
+	for i, item := range tree {
+		itemType := "file"
+		if item.Type == "tree" {
+			itemType = "dir"
+		}
+		result[i] = map[string]interface{}{
+			"name": item.Name,
+			"path": item.Path,
+			"sha":  item.ID,
+			"type": itemType,
+		}
+	}
+	return result, nil
+}
+
+// getJSON performs an authenticated GET against the GitLab API and decodes the JSON body into out.
+// entity names the requested resource in the not-found error.
+func (s *GitLabService) getJSON(ctx context.Context, userUUID, provider, endpoint, entity string, query url.Values, out interface{}) (http.Header, error) {
+	resp, err := s.do(ctx, http.MethodGet, userUUID, provider, endpoint, entity, query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("failed to decode GitLab response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// head performs an authenticated HEAD against the GitLab API and returns the response headers
+func (s *GitLabService) head(ctx context.Context, userUUID, provider, endpoint, entity string, query url.Values) (http.Header, error) {
+	resp, err := s.do(ctx, http.MethodHead, userUUID, provider, endpoint, entity, query)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp.Header, nil
+}
+
+// do performs an authenticated request against the GitLab API. Non-200 responses are mapped to errors;
+// on success the caller closes the response body.
+func (s *GitLabService) do(ctx context.Context, method, userUUID, provider, endpoint, entity string, query url.Values) (*http.Response, error) {
+	log := logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"provider": provider,
+		"endpoint": endpoint,
+	})
+
+	// Get access token using validated JWT claims
+	accessToken, err := s.authService.GetGitHubAccessToken(userUUID, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab access token: %w", err)
+	}
+
+	// Get client configuration for the user's provider
+	clientConfig, err := s.authService.GetGitHubClient(provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+	}
+	if clientConfig == nil || clientConfig.GetEnterpriseBaseURL() == "" {
+		return nil, fmt.Errorf("%w: provider '%s' has no enterprise_base_url", apperrors.ErrProviderNotConfigured, provider)
+	}
+
+	requestURL := strings.TrimSuffix(clientConfig.GetEnterpriseBaseURL(), "/") + "/api/v4" + endpoint
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitLab request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GitLab API: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		log.Warn("GitLab API rate limit exceeded")
+		return nil, apperrors.ErrGitHubAPIRateLimitExceeded
+	case http.StatusNotFound:
+		return nil, apperrors.NewNotFoundError(entity)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		log.Errorf("GitLab API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("GitLab API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+}
+
+// parseGitLabProjectFromURL extracts project information from a GitLab web URL
+// Handles URLs like: https://gitlab.example.com/group/subgroup/project/-/merge_requests/12
+func parseGitLabProjectFromURL(webURL string) Repository {
+	parsed, err := url.Parse(webURL)
+	if err != nil {
+		return Repository{}
+	}
+
+	projectPath := strings.Trim(parsed.Path, "/")
+	if idx := strings.Index(projectPath, "/-/"); idx >= 0 {
+		projectPath = projectPath[:idx]
+	}
+
+	lastSlash := strings.LastIndex(projectPath, "/")
+	if lastSlash <= 0 {
+		return Repository{}
+	}
+
+	return Repository{
+		Name:     projectPath[lastSlash+1:],
+		FullName: projectPath,
+		Owner:    projectPath[:lastSlash],
+	}
+}
+
+// buildContributionsHeatmap arranges daily counts into Sunday-based weeks with GitHub-style quartile levels
+func buildContributionsHeatmap(counts map[string]int, from, to time.Time) *ContributionsHeatmapResponse {
+	maxCount := 0
+	total := 0
+	for _, count := range counts {
+		total += count
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	weeks := make([]ContributionWeek, 0)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		if len(weeks) == 0 || day.Weekday() == time.Sunday {
+			weeks = append(weeks, ContributionWeek{FirstDay: date})
+		}
+
+		count := counts[date]
+		level, color := contributionLevel(count, maxCount)
+		current := &weeks[len(weeks)-1]
+		current.ContributionDays = append(current.ContributionDays, ContributionDay{
+			Date:              date,
+			ContributionCount: count,
+			ContributionLevel: level,
+			Color:             color,
+		})
+	}
+
+	return &ContributionsHeatmapResponse{
+		TotalContributions: total,
+		Weeks:              weeks,
+		From:               from.Format(time.RFC3339),
+		To:                 to.Format(time.RFC3339),
+	}
+}
+
+// contributionLevel maps a daily count to GitHub's contribution level and default palette color
+func contributionLevel(count, maxCount int) (string, string) {
+	if count == 0 || maxCount == 0 {
+		return "NONE", "#ebedf0"
+	}
+
+	ratio := float64(count) / float64(maxCount)
+	switch {
+	case ratio <= 0.25:
+		return "FIRST_QUARTILE", "#9be9a8"
+	case ratio <= 0.5:
+		return "SECOND_QUARTILE", "#40c463"
+	case ratio <= 0.75:
+		return "THIRD_QUARTILE", "#30a14e"
+	default:
+		return "FOURTH_QUARTILE", "#216e39"
+	}
+}
@@ -0,0 +1,278 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newGitLabTestAuth creates a mock auth service resolving the "gitlabtools" provider to the given server
+func newGitLabTestAuth(ctrl *gomock.Controller, serverURL string) *mocks.MockGitHubAuthService {
+	mockAuthService := mocks.NewMockGitHubAuthService(ctrl)
+	mockAuthService.EXPECT().
+		GetGitHubAccessToken("test-uuid", "gitlabtools").
+		Return("gitlab-token", nil).
+		AnyTimes()
+	mockAuthService.EXPECT().
+		GetGitHubClient("gitlabtools").
+		Return(auth.NewGitHubClient(&auth.ProviderConfig{EnterpriseBaseURL: serverURL, Type: auth.ProviderTypeGitLab}), nil).
+		AnyTimes()
+	return mockAuthService
+}
+
+// TestGitLabService_GetOpenPullRequests tests merge requests are mapped into the shared PullRequest shape
+func TestGitLabService_GetOpenPullRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var mockGitLabServer *httptest.Server
+	mockGitLabServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/merge_requests", r.URL.Path)
+		assert.Equal(t, "Bearer gitlab-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "created_by_me", r.URL.Query().Get("scope"))
+		assert.Equal(t, "opened", r.URL.Query().Get("state"))
+		assert.Equal(t, "updated_at", r.URL.Query().Get("order_by"))
+		assert.Equal(t, "desc", r.URL.Query().Get("sort"))
+
+		response := []map[string]interface{}{
+			{
+				"id":         int64(9001),
+				"iid":        12,
+				"title":      "Add GitLab support",
+				"state":      "opened",
+				"created_at": "2025-03-01T10:00:00Z",
+				"updated_at": "2025-03-02T10:00:00Z",
+				"web_url":    mockGitLabServer.URL + "/platform/backend/portal/-/merge_requests/12",
+				"draft":      true,
+				"author": map[string]interface{}{
+					"id":         int64(77),
+					"username":   "jdoe",
+					"avatar_url": "https://gitlab.example.com/avatar.png",
+				},
+			},
+			{
+				"id":         int64(9002),
+				"iid":        13,
+				"title":      "Merged change",
+				"state":      "merged",
+				"created_at": "2025-02-01T10:00:00Z",
+				"updated_at": "2025-02-02T10:00:00Z",
+				"web_url":    mockGitLabServer.URL + "/team/tools/-/merge_requests/13",
+				"author": map[string]interface{}{
+					"id":       int64(77),
+					"username": "jdoe",
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total", "5")
+		w.Header().Set("X-Next-Page", "2")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer mockGitLabServer.Close()
+
+	gitlabService := service.NewGitLabService(newGitLabTestAuth(ctrl, mockGitLabServer.URL))
+
+	result, err := gitlabService.GetOpenPullRequests(context.Background(), "test-uuid", "gitlabtools", "open", "updated", "desc", 2, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 2, result.NextPage)
+	require.Len(t, result.PullRequests, 2)
+
+	pr := result.PullRequests[0]
+	assert.Equal(t, int64(9001), pr.ID)
+	assert.Equal(t, 12, pr.Number)
+	assert.Equal(t, "Add GitLab support", pr.Title)
+	assert.Equal(t, "open", pr.State)
+	assert.True(t, pr.Draft)
+	assert.Equal(t, "jdoe", pr.User.Login)
+	assert.Equal(t, int64(77), pr.User.ID)
+	assert.Equal(t, "portal", pr.Repo.Name)
+	assert.Equal(t, "platform/backend", pr.Repo.Owner)
+	assert.Equal(t, "platform/backend/portal", pr.Repo.FullName)
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), pr.CreatedAt.UTC())
+
+	assert.Equal(t, "closed", result.PullRequests[1].State)
+	assert.Equal(t, "team/tools", result.PullRequests[1].Repo.FullName)
+}
+
+// TestGitLabService_GetOpenPullRequests_RateLimited tests GitLab 429 responses map to the shared rate limit error
+func TestGitLabService_GetOpenPullRequests_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitLabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer mockGitLabServer.Close()
+
+	gitlabService := service.NewGitLabService(newGitLabTestAuth(ctrl, mockGitLabServer.URL))
+
+	result, err := gitlabService.GetOpenPullRequests(context.Background(), "test-uuid", "gitlabtools", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrGitHubAPIRateLimitExceeded)
+}
+
+// TestGitLabService_GetOpenPullRequests_NotFound tests a 404 names the requested resource
+func TestGitLabService_GetOpenPullRequests_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitLabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockGitLabServer.Close()
+
+	gitlabService := service.NewGitLabService(newGitLabTestAuth(ctrl, mockGitLabServer.URL))
+
+	result, err := gitlabService.GetOpenPullRequests(context.Background(), "test-uuid", "gitlabtools", "open", "created", "desc", 30, 1)
+
+	assert.Nil(t, result)
+	assert.True(t, apperrors.IsNotFound(err))
+	assert.EqualError(t, err, "merge requests not found")
+}
+
+// TestGitLabService_GetContributionsHeatmap tests events are aggregated into daily contribution counts
+func TestGitLabService_GetContributionsHeatmap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	today := time.Now().UTC()
+	yesterday := today.AddDate(0, 0, -1)
+
+	mockGitLabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/events", r.URL.Path)
+		assert.NotEmpty(t, r.URL.Query().Get("after"))
+		assert.NotEmpty(t, r.URL.Query().Get("before"))
+
+		response := []map[string]interface{}{
+			{"created_at": today.Format(time.RFC3339)},
+			{"created_at": today.Format(time.RFC3339)},
+			{"created_at": yesterday.Format(time.RFC3339)},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer mockGitLabServer.Close()
+
+	gitlabService := service.NewGitLabService(newGitLabTestAuth(ctrl, mockGitLabServer.URL))
+
+	result, err := gitlabService.GetContributionsHeatmap(context.Background(), "test-uuid", "gitlabtools", "7d")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.TotalContributions)
+	require.NotEmpty(t, result.Weeks)
+
+	days := make(map[string]service.ContributionDay)
+	for _, week := range result.Weeks {
+		for _, day := range week.ContributionDays {
+			days[day.Date] = day
+		}
+	}
+	assert.Len(t, days, 8)
+	assert.Equal(t, 2, days[today.Format("2006-01-02")].ContributionCount)
+	assert.Equal(t, "FOURTH_QUARTILE", days[today.Format("2006-01-02")].ContributionLevel)
+	assert.Equal(t, 1, days[yesterday.Format("2006-01-02")].ContributionCount)
+	assert.Equal(t, "SECOND_QUARTILE", days[yesterday.Format("2006-01-02")].ContributionLevel)
+}
+
+// TestGitLabService_GetRepositoryContent tests file content is fetched and decoded
+func TestGitLabService_GetRepositoryContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitLabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/platform%2Fportal/repository/files/docs%2FREADME.md", r.URL.EscapedPath())
+		assert.Equal(t, "develop", r.URL.Query().Get("ref"))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"file_name": "README.md",
+			"file_path": "docs/README.md",
+			"size":      5,
+			"encoding":  "base64",
+			"content":   base64.StdEncoding.EncodeToString([]byte("hello")),
+			"blob_id":   "abc123",
+		})
+	}))
+	defer mockGitLabServer.Close()
+
+	gitlabService := service.NewGitLabService(newGitLabTestAuth(ctrl, mockGitLabServer.URL))
+
+	result, err := gitlabService.GetRepositoryContent(context.Background(), "test-uuid", "gitlabtools", "platform", "portal", "/docs/README.md", "develop")
+
+	require.NoError(t, err)
+	file, ok := result.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "README.md", file["name"])
+	assert.Equal(t, "hello", file["content"])
+	assert.Equal(t, "abc123", file["sha"])
+	assert.Equal(t, "file", file["type"])
+}
+
+// TestGitHubService_RoutesRegisteredProviderToGitLab tests GitHubService delegates calls for GitLab providers
+func TestGitHubService_RoutesRegisteredProviderToGitLab(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGitLabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/merge_requests", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer mockGitLabServer.Close()
+
+	mockAuthService := newGitLabTestAuth(ctrl, mockGitLabServer.URL)
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+	githubService.RegisterVCSProvider("gitlabtools", service.NewGitLabService(mockAuthService))
+
+	result, err := githubService.GetUserOpenPullRequests(context.Background(), "test-uuid", "gitlabtools", "open", "created", "desc", 30, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Total)
+	assert.Empty(t, result.PullRequests)
+}
+
+// TestGitLabService_GetRepositoryContent_SizeLimit tests the limit set on GitHubService applies to GitLab files
+// and is checked from the file metadata before the content is fetched
+func TestGitLabService_GetRepositoryContent_SizeLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var methods []string
+	mockGitLabServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v4/projects/platform%2Fportal/repository/files/big.bin", r.URL.EscapedPath())
+		methods = append(methods, r.Method)
+		w.Header().Set("X-Gitlab-Size", "2048")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"file_name": "big.bin", "file_path": "big.bin", "size": 2048, "encoding": "base64", "content": ""}`))
+	}))
+	defer mockGitLabServer.Close()
+
+	mockAuthService := newGitLabTestAuth(ctrl, mockGitLabServer.URL)
+	githubService := service.NewGitHubServiceWithAdapter(mockAuthService)
+	githubService.RegisterVCSProvider("gitlabtools", service.NewGitLabService(mockAuthService))
+	githubService.SetMaxFileSize(1024)
+
+	result, err := githubService.GetRepositoryContent(context.Background(), "test-uuid", "gitlabtools", "platform", "portal", "big.bin", "main")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, apperrors.ErrFileTooLarge)
+	assert.Equal(t, []string{http.MethodHead}, methods)
+}
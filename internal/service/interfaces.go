@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"mime/multipart"
+	"time"
 
 	"developer-portal-backend/internal/database/models"
 
@@ -17,26 +18,48 @@ import (
 type UserServiceInterface interface {
 	CreateUser(req *CreateUserRequest) (*UserResponse, error)
 	GetUserByID(id uuid.UUID) (*UserResponse, error)
+	GetUserByUUID(id uuid.UUID) (*UserResponse, error)
 	GetUserByUserID(userID string) (*UserResponse, error)
+	GetUsersByEmails(emails []string) (map[string]*UserResponse, error)
 	GetUserByName(name string) (*UserResponse, error)
 	GetUserByNameWithLinks(name string) (*UserWithLinksAndPluginsResponse, error)
 	GetUserByNameWithLinksAndPlugins(name string) (*UserWithLinksAndPluginsResponse, error)
+	GetUserByUserIDWithLinksAndPlugins(userID string) (*UserWithLinksAndPluginsResponse, error)
 	GetUserByUserIDWithLinks(userID string) (*UserWithLinksAndPluginsResponse, error)
 	GetUsersByOrganization(organizationID uuid.UUID, limit, offset int) ([]UserResponse, int64, error)
+	GetUsersByTeam(teamID uuid.UUID, limit, offset int) ([]UserResponse, int64, error)
+	CountUsers() (int64, error)
+	CountActiveUsers(organizationID uuid.UUID) (int64, error)
+	CountUsersByDomain(organizationID uuid.UUID) (map[string]int64, error)
 	GetAllUsers(limit, offset int) ([]UserResponse, int64, error)
-	SearchUsers(organizationID uuid.UUID, query string, limit, offset int) ([]UserResponse, int64, error)
-	SearchUsersGlobal(query string, limit, offset int) ([]UserResponse, int64, error)
+	GetAllUsersAfter(cursor string, limit int) (*UsersCursorPage, error)
+	SearchUsers(organizationID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]UserResponse, int64, error)
+	SearchUsersGlobal(query string, emailOnly bool, limit, offset int) ([]UserResponse, int64, error)
+	SearchUsersByOwnedOrg(organizationID uuid.UUID, query string, limit, offset int) ([]UserResponse, int64, error)
 	GetActiveUsers(organizationID uuid.UUID, limit, offset int) ([]UserResponse, int64, error)
+	GetActiveUsersSince(organizationID uuid.UUID, since time.Time, limit, offset int) ([]UserResponse, int64, error)
 	UpdateUser(id uuid.UUID, req *UpdateUserRequest) (*UserResponse, error)
+	ConfirmEmailChange(id uuid.UUID) (*UserResponse, error)
 	UpdateUserTeam(userID uuid.UUID, teamID uuid.UUID, updatedBy string) (*UserResponse, error)
+	RemoveUserFromTeam(userID uuid.UUID, updatedBy string) (*UserResponse, error)
+	ChangeUserRole(userID uuid.UUID, newDomain, newRole string, changedBy string) (*UserResponse, error)
 	DeleteUser(id uuid.UUID) error
+	RestoreUser(id uuid.UUID) (*UserResponse, error)
 	GetQuickLinks(id uuid.UUID) (*QuickLinksResponse, error)
 	AddQuickLink(id uuid.UUID, req *AddQuickLinkRequest) (*UserResponse, error)
 	RemoveQuickLink(id uuid.UUID, linkURL string) (*UserResponse, error)
-	AddFavoriteLinkByUserID(userID string, linkID uuid.UUID) (*UserResponse, error)
-	RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID) (*UserResponse, error)
-	AddSubscribedPluginByUserID(userID string, pluginID uuid.UUID) (*UserResponse, error)
-	RemoveSubscribedPluginByUserID(userID string, pluginID uuid.UUID) (*UserResponse, error)
+	AddFavoriteLinkByUserID(userID string, linkID uuid.UUID, actor string) (*UserResponse, error)
+	RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID, actor string) (*UserResponse, error)
+	IsFavoriteLink(userID string, linkID uuid.UUID) (bool, error)
+	AddSubscribedPluginByUserID(userID string, pluginID uuid.UUID, actor string) (*UserResponse, error)
+	RemoveSubscribedPluginByUserID(userID string, pluginID uuid.UUID, actor string) (*UserResponse, error)
+	IsSubscribedToPlugin(userID string, pluginID uuid.UUID) (bool, error)
+	GetSubscribedPlugins(userID string) (*SubscribedPluginsResponse, error)
+	ClearFavoriteLinksByUserID(userID, actor string) (*UserResponse, error)
+	ClearSubscribedPluginsByUserID(userID, actor string) (*UserResponse, error)
+	GetMetadataAudit(userID string, limit, offset int) ([]models.MetadataAudit, int64, error)
+	RepairUserMetadata(userID string) (*MetadataRepairReport, error)
+	PruneUserMetadata(userID string) (*MetadataPruneReport, error)
 }
 
 // TeamServiceInterface defines the interface for team service
@@ -64,9 +87,19 @@ type LandscapeServiceInterface interface {
 // GitHubServiceInterface defines the interface for GitHub service
 type GitHubServiceInterface interface {
 	GetUserOpenPullRequests(ctx context.Context, uuid, provider, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error)
+	GetUserOpenPullRequestsWithFilters(ctx context.Context, uuid, provider, state, sort, direction string, perPage, page int, filters QueryFilters) (*PullRequestsResponse, error)
+	GetUserOpenPullRequestsAllProviders(ctx context.Context, uuid string, providers []string, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error)
+	GetUserReviewRequests(ctx context.Context, uuid, provider, state, sort, order string, perPage, page int) (*PullRequestsResponse, error)
+	GetUserAssignedIssues(ctx context.Context, uuid, provider, state, sort, order string, perPage, page int) (*IssuesResponse, error)
+	GetUserRepositories(ctx context.Context, uuid, provider, sort string, perPage, page int) ([]Repository, error)
+	SearchRepositories(ctx context.Context, uuid, provider, query, sort string, perPage, page int) (*RepositoriesResponse, error)
+	GetPullRequestDetails(ctx context.Context, uuid, provider, owner, repo string, number int) (*PullRequestDetails, error)
 	GetUserTotalContributions(ctx context.Context, uuid, provider, period string) (*TotalContributionsResponse, error)
 	GetContributionsHeatmap(ctx context.Context, uuid, provider, period string) (*ContributionsHeatmapResponse, error)
+	GetContributionsHeatmapIfNoneMatch(ctx context.Context, uuid, provider, period, ifNoneMatch string) (*ContributionsHeatmapResponse, error)
+	GetUserActivityTimeline(ctx context.Context, uuid, provider, period string) (*ActivityTimelineResponse, error)
 	GetAveragePRMergeTime(ctx context.Context, uuid, provider, period string) (*AveragePRMergeTimeResponse, error)
+	GetCommitActivity(ctx context.Context, uuid, provider, owner, repo, period string) ([]WeeklyCommits, error)
 	GetUserPRReviewComments(ctx context.Context, uuid, provider, period string) (*PRReviewCommentsResponse, error)
 	GetRepositoryContent(ctx context.Context, uuid, provider, owner, repo, path, ref string) (interface{}, error)
 	UpdateRepositoryFile(ctx context.Context, uuid, provider, owner, repo, path, message, content, sha, branch string) (interface{}, error)
@@ -74,6 +107,13 @@ type GitHubServiceInterface interface {
 	GetGitHubAsset(ctx context.Context, uuid, provider, assetURL string) ([]byte, string, error)
 }
 
+// VCSProvider defines the operations a version control host must support to back a provider
+type VCSProvider interface {
+	GetOpenPullRequests(ctx context.Context, uuid, provider, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error)
+	GetContributionsHeatmap(ctx context.Context, uuid, provider, period string) (*ContributionsHeatmapResponse, error)
+	GetRepositoryContent(ctx context.Context, uuid, provider, owner, repo, path, ref string) (interface{}, error)
+}
+
 // JenkinsServiceInterface defines the interface for Jenkins service
 type JenkinsServiceInterface interface {
 	GetJobParameters(ctx context.Context, jaasName, jobName string) (interface{}, error)
@@ -96,17 +136,28 @@ type JiraServiceInterface interface {
 // AICoreServiceInterface defines the interface for AI Core service
 type AICoreServiceInterface interface {
 	GetDeployments(c *gin.Context) (*AICoreDeploymentsResponse, error)
+	GetDeploymentsByStatus(c *gin.Context, filter AICoreDeploymentFilter) (*AICoreDeploymentsResponse, error)
+	GetDeploymentSummaries(c *gin.Context) ([]DeploymentSummary, error)
 	GetDeploymentDetails(c *gin.Context, deploymentID string) (*AICoreDeploymentDetailsResponse, error)
+	GetDeploymentsByIDs(c *gin.Context, ids []string) (map[string]*AICoreDeploymentDetailsResponse, error)
+	WaitForDeploymentStatus(c *gin.Context, deploymentID, target string, timeout time.Duration) (*AICoreDeploymentDetailsResponse, error)
 	GetModels(c *gin.Context, scenarioID string) (*AICoreModelsResponse, error)
+	GetScenarios(c *gin.Context) (*AICoreScenariosResponse, error)
+	GetExecutables(c *gin.Context, scenarioID string) (*AICoreExecutablesResponse, error)
 	GetConfigurations(c *gin.Context) (*AICoreConfigurationsResponse, error)
+	GetConfigurationDetails(c *gin.Context, configurationID string) (*AICoreConfiguration, error)
 	CreateConfiguration(c *gin.Context, req *AICoreConfigurationRequest) (*AICoreConfigurationResponse, error)
 	CreateDeployment(c *gin.Context, req *AICoreDeploymentRequest) (*AICoreDeploymentResponse, error)
 	UpdateDeployment(c *gin.Context, deploymentID string, req *AICoreDeploymentModificationRequest) (*AICoreDeploymentModificationResponse, error)
-	DeleteDeployment(c *gin.Context, deploymentID string) (*AICoreDeploymentDeletionResponse, error)
+	DeleteDeployment(c *gin.Context, deploymentID string, deleteConfiguration bool) (*AICoreDeploymentDeletionResponse, error)
 	ChatInference(c *gin.Context, req *AICoreInferenceRequest) (*AICoreInferenceResponse, error)
 	ChatInferenceStream(c *gin.Context, req *AICoreInferenceRequest, writer gin.ResponseWriter) error
-	UploadAttachment(c *gin.Context, file multipart.File, header *multipart.FileHeader) (map[string]interface{}, error)
+	UploadAttachment(c *gin.Context, file multipart.File, header *multipart.FileHeader) (*AttachmentResponse, error)
 	GetMe(c *gin.Context) (*AICoreMeResponse, error)
+	GetProfileSummary(c *gin.Context) (*ProfileSummaryResponse, error)
+	GetMeByEmail(email string) (*AICoreMeResponse, error)
+	CheckAICoreCredentials(team string) error
+	InvalidateTeamExpansion(username string) error
 }
 
 // ComponentServiceInterface defines the interface for component service
@@ -139,6 +190,10 @@ type LinkServiceInterface interface {
 	DeleteLink(id uuid.UUID) error
 	// UpdateLink updates an existing link
 	UpdateLink(id uuid.UUID, req *UpdateLinkRequest) (*LinkResponse, error)
+	// GetLinkByName returns the link with the given name owned by a user or team
+	GetLinkByName(owner uuid.UUID, name string) (*LinkResponse, error)
+	// TransferLinkOwnership reassigns a link to a new owner
+	TransferLinkOwnership(linkID uuid.UUID, newOwner uuid.UUID, transferredBy string) (*LinkResponse, error)
 }
 
 // DocumentationServiceInterface defines the interface for documentation service
@@ -155,7 +210,10 @@ type PluginServiceInterface interface {
 	CreatePlugin(req *CreatePluginRequest) (*PluginResponse, error)
 	GetAllPlugins(limit, offset int) (*PluginListResponse, error)
 	GetAllPluginsWithViewer(limit, offset int, viewerName string) (*PluginListResponse, error)
+	ListAvailablePlugins(userOrgID *uuid.UUID) ([]PluginResponse, error)
 	GetPluginByID(id uuid.UUID) (*PluginResponse, error)
+	GetPluginByName(name string) (*PluginResponse, error)
+	SearchPlugins(query string, limit, offset int) (*PluginListResponse, error)
 	UpdatePlugin(id uuid.UUID, req *UpdatePluginRequest) (*PluginResponse, error)
 	DeletePlugin(id uuid.UUID) error
 	GetPluginUIContent(ctx context.Context, pluginID uuid.UUID, githubService GitHubServiceInterface, userUUID, provider string) (*PluginUIResponse, error)
@@ -171,3 +229,13 @@ type AlertsServiceInterface interface {
 	GetProjectAlerts(ctx context.Context, projectIDStr string, userUUID, provider string) (*AlertsResponse, error)
 	CreateAlertPR(ctx context.Context, projectIDStr string, userUUID, provider string, fileName, content, message, description string) (string, error)
 }
+
+// ReadinessServiceInterface defines the interface for readiness checks
+type ReadinessServiceInterface interface {
+	Readiness(ctx context.Context) *ReadinessStatus
+}
+
+// OrganizationServiceInterface defines the interface for organization service
+type OrganizationServiceInterface interface {
+	GetHierarchy(orgID uuid.UUID) (*OrgTree, error)
+}
@@ -142,7 +142,7 @@ type LandscapeListResponse struct {
 func (s *LandscapeService) CreateLandscape(req *CreateLandscapeRequest) (*LandscapeResponse, error) {
 	// Validate request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 
 	// Check if landscape with same name exists (global scope in new model)
@@ -376,7 +376,7 @@ func (s *LandscapeService) Search(_ uuid.UUID, query string, page, pageSize int)
 func (s *LandscapeService) UpdateLandscape(id uuid.UUID, req *UpdateLandscapeRequest) (*LandscapeResponse, error) {
 	// Validate request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 
 	// Get existing landscape
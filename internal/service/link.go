@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // LinkService provides link-related business logic
@@ -73,25 +75,25 @@ type UpdateLinkRequest struct {
 // CreateLink validates and creates a new link
 func (s *LinkService) CreateLink(req *CreateLinkRequest) (*LinkResponse, error) {
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 	if strings.TrimSpace(req.CreatedBy) == "" {
-		return nil, fmt.Errorf("created_by is required")
+		return nil, apperrors.NewValidationError("created_by", "created_by is required")
 	}
 	// Validate created_by is an existing users.user_id OR a team's name
 	if _, err := s.userRepo.GetByUserID(req.CreatedBy); err != nil {
 		if _, errTeam := s.teamRepo.GetByNameGlobal(req.CreatedBy); errTeam != nil {
-			return nil, fmt.Errorf("created_by user or team not found")
+			return nil, apperrors.NewValidationError("created_by", "created_by user or team not found")
 		}
 	}
 
 	ownerUUID, err := uuid.Parse(req.Owner)
 	if err != nil {
-		return nil, fmt.Errorf("invalid owner UUID: %w", err)
+		return nil, apperrors.NewValidationError("owner", "invalid owner UUID: "+err.Error())
 	}
 	categoryUUID, err := uuid.Parse(req.CategoryID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid category_id UUID: %w", err)
+		return nil, apperrors.NewValidationError("category_id", "invalid category_id UUID: "+err.Error())
 	}
 
 	// Validate owner exists (either a user or a team)
@@ -102,12 +104,17 @@ func (s *LinkService) CreateLink(req *CreateLinkRequest) (*LinkResponse, error)
 		ownerValid = true
 	}
 	if !ownerValid {
-		return nil, fmt.Errorf("owner not found as user or team")
+		return nil, apperrors.NewValidationError("owner", "owner not found as user or team")
 	}
 
 	// Validate category exists
 	if _, err := s.categoryRepo.GetByID(categoryUUID); err != nil {
-		return nil, fmt.Errorf("category not found")
+		return nil, apperrors.ErrCategoryNotFound
+	}
+
+	// Link names are unique per owner
+	if err := s.checkLinkNameAvailable(ownerUUID, req.Name, uuid.Nil); err != nil {
+		return nil, err
 	}
 
 	link := &models.Link{
@@ -143,7 +150,7 @@ func (s *LinkService) UpdateLink(id uuid.UUID, req *UpdateLinkRequest) (*LinkRes
 	// Validate request structure
 	if err := s.validator.Struct(req); err != nil {
 		log.WithField("error", err.Error()).Warn("Link validation failed")
-		return nil, apperrors.NewValidationError("", err.Error())
+		return nil, requestValidationError(req, err)
 	}
 
 	if strings.TrimSpace(req.UpdatedBy) == "" {
@@ -186,6 +193,11 @@ func (s *LinkService) UpdateLink(id uuid.UUID, req *UpdateLinkRequest) (*LinkRes
 		return nil, apperrors.ErrCategoryNotFound
 	}
 
+	if err := s.checkLinkNameAvailable(link.Owner, req.Name, link.ID); err != nil {
+		log.Warn("Link name already used by the owner")
+		return nil, err
+	}
+
 	// Update ONLY the allowed fields
 	// SECURITY: Owner is intentionally NOT updated here to prevent ownership changes
 	link.Name = req.Name
@@ -214,6 +226,95 @@ func (s *LinkService) UpdateLink(id uuid.UUID, req *UpdateLinkRequest) (*LinkRes
 	return &res, nil
 }
 
+// checkLinkNameAvailable returns ErrLinkNameTaken when the owner has a link other than excludeID with the given name
+func (s *LinkService) checkLinkNameAvailable(owner uuid.UUID, name string, excludeID uuid.UUID) error {
+	existing, err := s.linkRepo.GetByName(owner, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to check link name: %w", err)
+	}
+	if existing.ID != excludeID {
+		return apperrors.ErrLinkNameTaken
+	}
+	return nil
+}
+
+// GetLinkByName returns the link with the given name owned by the given user or team
+func (s *LinkService) GetLinkByName(owner uuid.UUID, name string) (*LinkResponse, error) {
+	link, err := s.linkRepo.GetByName(owner, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get link: %w", err)
+	}
+
+	res := toLinkResponse(link)
+	return &res, nil
+}
+
+// TransferLinkOwnership reassigns a link to a new owner (user or team), e.g. when the current owner leaves
+func (s *LinkService) TransferLinkOwnership(linkID uuid.UUID, newOwner uuid.UUID, transferredBy string) (*LinkResponse, error) {
+	log := logger.New().WithFields(map[string]interface{}{
+		"operation":      "TransferLinkOwnership",
+		"link_id":        linkID.String(),
+		"new_owner":      newOwner.String(),
+		"transferred_by": transferredBy,
+	})
+
+	if newOwner == uuid.Nil {
+		log.Warn("new_owner is missing")
+		return nil, apperrors.NewValidationError("new_owner", "new_owner is required")
+	}
+	if strings.TrimSpace(transferredBy) == "" {
+		log.Warn("transferred_by is missing")
+		return nil, apperrors.NewValidationError("transferred_by", "transferred_by is required")
+	}
+
+	// Validate transferred_by is an existing users.user_id OR a team's name
+	if _, err := s.userRepo.GetByUserID(transferredBy); err != nil {
+		if _, errTeam := s.teamRepo.GetByNameGlobal(transferredBy); errTeam != nil {
+			log.Warn("transferred_by user or team not found")
+			return nil, apperrors.ErrUserOrTeamNotFound
+		}
+	}
+
+	link, err := s.linkRepo.GetByID(linkID)
+	if err != nil {
+		log.WithField("error", err.Error()).Warn("Link not found in database")
+		return nil, apperrors.ErrLinkNotFound
+	}
+
+	// Validate new owner exists (either a user or a team)
+	if _, err := s.userRepo.GetByID(newOwner); err != nil {
+		if _, errTeam := s.teamRepo.GetByID(newOwner); errTeam != nil {
+			log.Warn("new owner not found as user or team")
+			return nil, apperrors.ErrUserOrTeamNotFound
+		}
+	}
+
+	if err := s.checkLinkNameAvailable(newOwner, link.Name, link.ID); err != nil {
+		log.Warn("Link name already used by the new owner")
+		return nil, err
+	}
+
+	previousOwner := link.Owner
+	link.Owner = newOwner
+	link.UpdatedBy = transferredBy
+
+	if err := s.linkRepo.Update(link); err != nil {
+		log.WithField("error", err.Error()).Error("Failed to transfer link ownership in database")
+		return nil, fmt.Errorf("failed to transfer link ownership: %w", err)
+	}
+
+	log.WithField("previous_owner", previousOwner.String()).Info("Link ownership transferred")
+
+	res := toLinkResponse(link)
+	return &res, nil
+}
+
 // GetByOwnerUserID returns all links owned by the user with the given user_id
 func (s *LinkService) GetByOwnerUserID(ownerUserID string) ([]LinkResponse, error) {
 	if strings.TrimSpace(ownerUserID) == "" {
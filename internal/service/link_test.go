@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 // teamRepoStub is a lightweight stub that satisfies TeamRepositoryInterface.
@@ -107,6 +108,7 @@ func (suite *LinkServiceTestSuite) TestCreateLink_Success_UserOwner() {
 	// category validation: found
 	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
 	// create: set ID on the entity
+	suite.mockLinkRepo.EXPECT().GetByName(ownerID, "my-link").Return(nil, gorm.ErrRecordNotFound)
 	suite.mockLinkRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(l *models.Link) error {
 		l.ID = uuid.New()
 		return nil
@@ -168,6 +170,7 @@ func (suite *LinkServiceTestSuite) TestCreateLink_URLMaxLength() {
 	suite.mockUserRepo.EXPECT().GetByUserID(createdBy).Return(&models.User{UserID: createdBy}, nil)
 	suite.mockUserRepo.EXPECT().GetByID(ownerID).Return(&models.User{BaseModel: models.BaseModel{ID: ownerID}}, nil)
 	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	suite.mockLinkRepo.EXPECT().GetByName(ownerID, "test-link").Return(nil, gorm.ErrRecordNotFound)
 	suite.mockLinkRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(l *models.Link) error {
 		l.ID = uuid.New()
 		return nil
@@ -206,6 +209,7 @@ func (suite *LinkServiceTestSuite) TestCreateLink_URLTooLong() {
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), resp)
 	assert.Contains(suite.T(), err.Error(), "validation failed")
+	assert.True(suite.T(), apperrors.IsValidation(err))
 }
 
 func (suite *LinkServiceTestSuite) TestCreateLink_CreatedByMissing() {
@@ -316,6 +320,7 @@ func (suite *LinkServiceTestSuite) TestCreateLink_RepoError() {
 	suite.mockUserRepo.EXPECT().GetByUserID("creator").Return(&models.User{UserID: "creator"}, nil)
 	suite.mockUserRepo.EXPECT().GetByID(ownerID).Return(&models.User{BaseModel: models.BaseModel{ID: ownerID}}, nil)
 	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	suite.mockLinkRepo.EXPECT().GetByName(ownerID, "ok").Return(nil, gorm.ErrRecordNotFound)
 	suite.mockLinkRepo.EXPECT().Create(gomock.Any()).Return(errors.New("db error"))
 
 	resp, err := suite.linkService.CreateLink(req)
@@ -324,6 +329,50 @@ func (suite *LinkServiceTestSuite) TestCreateLink_RepoError() {
 	assert.Contains(suite.T(), err.Error(), "failed to create link")
 }
 
+func (suite *LinkServiceTestSuite) TestCreateLink_DuplicateNameForOwner() {
+	ownerID := uuid.New()
+	categoryID := uuid.New()
+	req := &service.CreateLinkRequest{
+		Name:       "dashboard",
+		Owner:      ownerID.String(),
+		URL:        "https://example.com/other",
+		CategoryID: categoryID.String(),
+		CreatedBy:  "creator",
+	}
+	suite.mockUserRepo.EXPECT().GetByUserID("creator").Return(&models.User{UserID: "creator"}, nil)
+	suite.mockUserRepo.EXPECT().GetByID(ownerID).Return(&models.User{BaseModel: models.BaseModel{ID: ownerID}}, nil)
+	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	suite.mockLinkRepo.EXPECT().GetByName(ownerID, "dashboard").Return(&models.Link{BaseModel: models.BaseModel{ID: uuid.New(), Name: "dashboard"}, Owner: ownerID}, nil)
+
+	resp, err := suite.linkService.CreateLink(req)
+	assert.Nil(suite.T(), resp)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrLinkNameTaken)
+}
+
+func (suite *LinkServiceTestSuite) TestGetLinkByName_Found() {
+	ownerID := uuid.New()
+	linkID := uuid.New()
+	suite.mockLinkRepo.EXPECT().GetByName(ownerID, "dashboard").Return(&models.Link{
+		BaseModel: models.BaseModel{ID: linkID, Name: "dashboard", Title: "dashboard"},
+		Owner:     ownerID,
+		URL:       "https://example.com",
+	}, nil)
+
+	resp, err := suite.linkService.GetLinkByName(ownerID, "dashboard")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), linkID.String(), resp.ID)
+	assert.Equal(suite.T(), "https://example.com", resp.URL)
+}
+
+func (suite *LinkServiceTestSuite) TestGetLinkByName_NotFound() {
+	ownerID := uuid.New()
+	suite.mockLinkRepo.EXPECT().GetByName(ownerID, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+	resp, err := suite.linkService.GetLinkByName(ownerID, "missing")
+	assert.Nil(suite.T(), resp)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrLinkNotFound)
+}
+
 func (suite *LinkServiceTestSuite) TestGetByOwnerUserID_Success() {
 	ownerUserID := "u123"
 	ownerID := uuid.New()
@@ -555,6 +604,8 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_Success() {
 	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(existingLink, nil)
 	// Validate category exists
 	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	// Name not used by another link of the owner
+	suite.mockLinkRepo.EXPECT().GetByName(existingLink.Owner, req.Name).Return(nil, gorm.ErrRecordNotFound)
 	// Update link
 	suite.mockLinkRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(l *models.Link) error {
 		// Verify the link was updated correctly
@@ -623,6 +674,7 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_Success_UpdatedByTeam() {
 	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(existingLink, nil)
 	// Validate category exists
 	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	suite.mockLinkRepo.EXPECT().GetByName(existingLink.Owner, req.Name).Return(nil, gorm.ErrRecordNotFound)
 	// Update link
 	suite.mockLinkRepo.EXPECT().Update(gomock.Any()).Return(nil)
 
@@ -694,7 +746,7 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_ValidationError_InvalidFields(
 
 			assert.Error(suite.T(), err, tc.description)
 			assert.Nil(suite.T(), resp)
-			assert.Contains(suite.T(), err.Error(), "validation error")
+			assert.Contains(suite.T(), err.Error(), "validation failed")
 		})
 	}
 }
@@ -782,7 +834,7 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_ValidationError_FieldsTooLong(
 
 			assert.Error(suite.T(), err, tc.description)
 			assert.Nil(suite.T(), resp)
-			assert.Contains(suite.T(), err.Error(), "validation error")
+			assert.Contains(suite.T(), err.Error(), "validation failed")
 		})
 	}
 }
@@ -938,6 +990,7 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_Error_RepositoryUpdateFails()
 	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(existingLink, nil)
 	// Category found
 	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	suite.mockLinkRepo.EXPECT().GetByName(existingLink.Owner, req.Name).Return(nil, gorm.ErrRecordNotFound)
 	// Update fails
 	suite.mockLinkRepo.EXPECT().Update(gomock.Any()).Return(errors.New("database error"))
 
@@ -980,6 +1033,8 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_EmptyTags() {
 	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(existingLink, nil)
 	// Category found
 	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	// Keeping its own name is not a conflict
+	suite.mockLinkRepo.EXPECT().GetByName(existingLink.Owner, req.Name).Return(existingLink, nil)
 	// Update
 	suite.mockLinkRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(l *models.Link) error {
 		assert.Equal(suite.T(), "", l.Tags)
@@ -993,6 +1048,30 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_EmptyTags() {
 	assert.Empty(suite.T(), resp.Tags)
 }
 
+func (suite *LinkServiceTestSuite) TestUpdateLink_NameTakenByOwner() {
+	linkID := uuid.New()
+	ownerID := uuid.New()
+	categoryID := uuid.New()
+	req := &service.UpdateLinkRequest{
+		Name:       "dashboard",
+		URL:        "https://example.com",
+		CategoryID: categoryID.String(),
+		UpdatedBy:  "user",
+	}
+	existingLink := &models.Link{BaseModel: models.BaseModel{ID: linkID, Name: "old-name"}, Owner: ownerID}
+
+	suite.mockUserRepo.EXPECT().GetByUserID("user").Return(&models.User{UserID: "user"}, nil)
+	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(existingLink, nil)
+	suite.mockCategoryRepo.EXPECT().GetByID(categoryID).Return(&models.Category{BaseModel: models.BaseModel{ID: categoryID}}, nil)
+	// Another link of the same owner already has the name
+	suite.mockLinkRepo.EXPECT().GetByName(ownerID, "dashboard").Return(&models.Link{BaseModel: models.BaseModel{ID: uuid.New(), Name: "dashboard"}, Owner: ownerID}, nil)
+
+	resp, err := suite.linkService.UpdateLink(linkID, req)
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrLinkNameTaken)
+	assert.Nil(suite.T(), resp)
+}
+
 func (suite *LinkServiceTestSuite) TestUpdateLink_ParseCategoryUUIDError() {
 	linkID := uuid.New()
 	req := &service.UpdateLinkRequest{
@@ -1008,7 +1087,83 @@ func (suite *LinkServiceTestSuite) TestUpdateLink_ParseCategoryUUIDError() {
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), resp)
-	assert.Contains(suite.T(), err.Error(), "validation error")
+	assert.Contains(suite.T(), err.Error(), "validation failed")
+}
+
+// TransferLinkOwnership Tests
+
+func (suite *LinkServiceTestSuite) TestTransferLinkOwnership_Success() {
+	linkID := uuid.New()
+	previousOwner := uuid.New()
+	newOwner := uuid.New()
+	transferredBy := "user.admin"
+
+	existingLink := &models.Link{
+		BaseModel: models.BaseModel{
+			ID:    linkID,
+			Name:  "handover-link",
+			Title: "handover-link",
+		},
+		Owner:      previousOwner,
+		URL:        "https://example.com",
+		CategoryID: uuid.New(),
+	}
+
+	suite.mockUserRepo.EXPECT().GetByUserID(transferredBy).Return(&models.User{UserID: transferredBy}, nil)
+	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(existingLink, nil)
+	suite.mockUserRepo.EXPECT().GetByID(newOwner).Return(&models.User{BaseModel: models.BaseModel{ID: newOwner}}, nil)
+	suite.mockLinkRepo.EXPECT().GetByName(newOwner, existingLink.Name).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockLinkRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(l *models.Link) error {
+		assert.Equal(suite.T(), newOwner, l.Owner)
+		assert.Equal(suite.T(), transferredBy, l.UpdatedBy)
+		assert.Equal(suite.T(), "handover-link", l.Name)
+		return nil
+	})
+
+	resp, err := suite.linkService.TransferLinkOwnership(linkID, newOwner, transferredBy)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), resp)
+	assert.Equal(suite.T(), linkID.String(), resp.ID)
+}
+
+func (suite *LinkServiceTestSuite) TestTransferLinkOwnership_NameTakenByNewOwner() {
+	linkID := uuid.New()
+	newOwner := uuid.New()
+	transferredBy := "user.admin"
+	existingLink := &models.Link{BaseModel: models.BaseModel{ID: linkID, Name: "handover-link"}, Owner: uuid.New()}
+
+	suite.mockUserRepo.EXPECT().GetByUserID(transferredBy).Return(&models.User{UserID: transferredBy}, nil)
+	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(existingLink, nil)
+	suite.mockUserRepo.EXPECT().GetByID(newOwner).Return(&models.User{BaseModel: models.BaseModel{ID: newOwner}}, nil)
+	// The new owner already has a link with the same name
+	suite.mockLinkRepo.EXPECT().GetByName(newOwner, "handover-link").Return(&models.Link{BaseModel: models.BaseModel{ID: uuid.New(), Name: "handover-link"}, Owner: newOwner}, nil)
+
+	resp, err := suite.linkService.TransferLinkOwnership(linkID, newOwner, transferredBy)
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrLinkNameTaken)
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *LinkServiceTestSuite) TestTransferLinkOwnership_LinkNotFound() {
+	linkID := uuid.New()
+	transferredBy := "user.admin"
+
+	suite.mockUserRepo.EXPECT().GetByUserID(transferredBy).Return(&models.User{UserID: transferredBy}, nil)
+	suite.mockLinkRepo.EXPECT().GetByID(linkID).Return(nil, errors.New("record not found"))
+
+	resp, err := suite.linkService.TransferLinkOwnership(linkID, uuid.New(), transferredBy)
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrLinkNotFound)
+	assert.Nil(suite.T(), resp)
+}
+
+func (suite *LinkServiceTestSuite) TestTransferLinkOwnership_NilOwner() {
+	resp, err := suite.linkService.TransferLinkOwnership(uuid.New(), uuid.Nil, "user.admin")
+
+	assert.Error(suite.T(), err)
+	assert.True(suite.T(), apperrors.IsValidation(err))
+	assert.Nil(suite.T(), resp)
 }
 
 func TestLinkServiceTestSuite(t *testing.T) {
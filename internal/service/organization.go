@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// hierarchyPageSize is the page size used when walking an organization's groups and teams
+const hierarchyPageSize = 100
+
+// OrganizationService provides organization-related business logic
+type OrganizationService struct {
+	orgRepo   repository.OrganizationRepositoryInterface
+	groupRepo repository.GroupRepositoryInterface
+	teamRepo  repository.TeamRepositoryInterface
+}
+
+// Ensure OrganizationService implements OrganizationServiceInterface
+var _ OrganizationServiceInterface = (*OrganizationService)(nil)
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(orgRepo repository.OrganizationRepositoryInterface, groupRepo repository.GroupRepositoryInterface, teamRepo repository.TeamRepositoryInterface) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:   orgRepo,
+		groupRepo: groupRepo,
+		teamRepo:  teamRepo,
+	}
+}
+
+// OrgTree represents an organization with its groups and their teams
+type OrgTree struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Owner       string      `json:"owner"`
+	Email       string      `json:"email"`
+	Groups      []GroupNode `json:"groups"`
+}
+
+// GroupNode represents a group within an organization tree
+type GroupNode struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Owner       string     `json:"owner"`
+	Email       string     `json:"email"`
+	PictureURL  string     `json:"picture_url"`
+	Teams       []TeamNode `json:"teams"`
+}
+
+// TeamNode represents a team within an organization tree
+type TeamNode struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Owner       string    `json:"owner"`
+	Email       string    `json:"email"`
+	PictureURL  string    `json:"picture_url"`
+}
+
+// GetHierarchy returns the organization with its groups, each with its teams.
+// Organizations without groups and groups without teams yield empty (non-nil) slices.
+func (s *OrganizationService) GetHierarchy(orgID uuid.UUID) (*OrgTree, error) {
+	org, err := s.orgRepo.GetByID(orgID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	groups, err := s.allGroups(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &OrgTree{
+		ID:          org.ID,
+		Name:        org.Name,
+		Title:       org.Title,
+		Description: org.Description,
+		Owner:       org.Owner,
+		Email:       org.Email,
+		Groups:      make([]GroupNode, 0, len(groups)),
+	}
+
+	for _, group := range groups {
+		teams, err := s.allTeams(group.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		node := GroupNode{
+			ID:          group.ID,
+			Name:        group.Name,
+			Title:       group.Title,
+			Description: group.Description,
+			Owner:       group.Owner,
+			Email:       group.Email,
+			PictureURL:  group.PictureURL,
+			Teams:       make([]TeamNode, 0, len(teams)),
+		}
+		for _, team := range teams {
+			node.Teams = append(node.Teams, TeamNode{
+				ID:          team.ID,
+				Name:        team.Name,
+				Title:       team.Title,
+				Description: team.Description,
+				Owner:       team.Owner,
+				Email:       team.Email,
+				PictureURL:  team.PictureURL,
+			})
+		}
+		tree.Groups = append(tree.Groups, node)
+	}
+
+	return tree, nil
+}
+
+// allGroups pages through every group of an organization
+func (s *OrganizationService) allGroups(orgID uuid.UUID) ([]models.Group, error) {
+	var all []models.Group
+	for offset := 0; ; offset += hierarchyPageSize {
+		groups, total, err := s.groupRepo.GetByOrganizationID(orgID, hierarchyPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get groups: %w", err)
+		}
+		all = append(all, groups...)
+		if len(groups) < hierarchyPageSize || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
+
+// allTeams pages through every team of a group
+func (s *OrganizationService) allTeams(groupID uuid.UUID) ([]models.Team, error) {
+	var all []models.Team
+	for offset := 0; ; offset += hierarchyPageSize {
+		teams, total, err := s.teamRepo.GetByGroupID(groupID, hierarchyPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get teams: %w", err)
+		}
+		all = append(all, teams...)
+		if len(teams) < hierarchyPageSize || int64(len(all)) >= total {
+			return all, nil
+		}
+	}
+}
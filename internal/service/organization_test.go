@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+)
+
+// OrganizationServiceTestSuite defines the test suite for OrganizationService
+type OrganizationServiceTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	mockOrgRepo   *mocks.MockOrganizationRepositoryInterface
+	mockGroupRepo *mocks.MockGroupRepositoryInterface
+	mockTeamRepo  *mocks.MockTeamRepositoryInterface
+	orgService    *service.OrganizationService
+}
+
+// SetupTest sets up the test suite
+func (suite *OrganizationServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOrgRepo = mocks.NewMockOrganizationRepositoryInterface(suite.ctrl)
+	suite.mockGroupRepo = mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.orgService = service.NewOrganizationService(suite.mockOrgRepo, suite.mockGroupRepo, suite.mockTeamRepo)
+}
+
+// TearDownTest cleans up after each test
+func (suite *OrganizationServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// TestGetHierarchy_MultiLevel tests building a tree with several groups, one of them without teams
+func (suite *OrganizationServiceTestSuite) TestGetHierarchy_MultiLevel() {
+	orgID := uuid.New()
+	platformID := uuid.New()
+	emptyGroupID := uuid.New()
+
+	suite.mockOrgRepo.EXPECT().GetByID(orgID).Return(&models.Organization{
+		BaseModel: models.BaseModel{ID: orgID, Name: "acme", Title: "Acme"},
+		Owner:     "I123456",
+	}, nil)
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(orgID, gomock.Any(), 0).Return([]models.Group{
+		{BaseModel: models.BaseModel{ID: platformID, Name: "platform"}, OrgID: orgID},
+		{BaseModel: models.BaseModel{ID: emptyGroupID, Name: "empty"}, OrgID: orgID},
+	}, int64(2), nil)
+	suite.mockTeamRepo.EXPECT().GetByGroupID(platformID, gomock.Any(), 0).Return([]models.Team{
+		{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-a"}, GroupID: platformID},
+		{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-b"}, GroupID: platformID},
+	}, int64(2), nil)
+	suite.mockTeamRepo.EXPECT().GetByGroupID(emptyGroupID, gomock.Any(), 0).Return([]models.Team{}, int64(0), nil)
+
+	tree, err := suite.orgService.GetHierarchy(orgID)
+
+	suite.Require().NoError(err)
+	suite.Equal(orgID, tree.ID)
+	suite.Equal("acme", tree.Name)
+	suite.Require().Len(tree.Groups, 2)
+	suite.Equal("platform", tree.Groups[0].Name)
+	suite.Require().Len(tree.Groups[0].Teams, 2)
+	suite.Equal("team-a", tree.Groups[0].Teams[0].Name)
+	suite.Equal("team-b", tree.Groups[0].Teams[1].Name)
+	suite.NotNil(tree.Groups[1].Teams)
+	suite.Empty(tree.Groups[1].Teams)
+}
+
+// TestGetHierarchy_Paginates tests that groups beyond the first page are fetched
+func (suite *OrganizationServiceTestSuite) TestGetHierarchy_Paginates() {
+	orgID := uuid.New()
+
+	firstPage := make([]models.Group, 100)
+	for i := range firstPage {
+		firstPage[i] = models.Group{BaseModel: models.BaseModel{ID: uuid.New()}, OrgID: orgID}
+	}
+	lastGroup := models.Group{BaseModel: models.BaseModel{ID: uuid.New()}, OrgID: orgID}
+
+	suite.mockOrgRepo.EXPECT().GetByID(orgID).Return(&models.Organization{BaseModel: models.BaseModel{ID: orgID}}, nil)
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(orgID, 100, 0).Return(firstPage, int64(101), nil)
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(orgID, 100, 100).Return([]models.Group{lastGroup}, int64(101), nil)
+	suite.mockTeamRepo.EXPECT().GetByGroupID(gomock.Any(), 100, 0).Return([]models.Team{}, int64(0), nil).Times(101)
+
+	tree, err := suite.orgService.GetHierarchy(orgID)
+
+	suite.Require().NoError(err)
+	suite.Len(tree.Groups, 101)
+	suite.Equal(lastGroup.ID, tree.Groups[100].ID)
+}
+
+// TestGetHierarchy_EmptyOrganization tests an organization without groups
+func (suite *OrganizationServiceTestSuite) TestGetHierarchy_EmptyOrganization() {
+	orgID := uuid.New()
+
+	suite.mockOrgRepo.EXPECT().GetByID(orgID).Return(&models.Organization{BaseModel: models.BaseModel{ID: orgID, Name: "empty-org"}}, nil)
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(orgID, gomock.Any(), 0).Return([]models.Group{}, int64(0), nil)
+
+	tree, err := suite.orgService.GetHierarchy(orgID)
+
+	suite.Require().NoError(err)
+	suite.Equal("empty-org", tree.Name)
+	suite.NotNil(tree.Groups)
+	suite.Empty(tree.Groups)
+}
+
+// TestGetHierarchy_OrganizationNotFound tests a missing organization
+func (suite *OrganizationServiceTestSuite) TestGetHierarchy_OrganizationNotFound() {
+	orgID := uuid.New()
+
+	suite.mockOrgRepo.EXPECT().GetByID(orgID).Return(nil, gorm.ErrRecordNotFound)
+
+	tree, err := suite.orgService.GetHierarchy(orgID)
+
+	suite.ErrorIs(err, apperrors.ErrOrganizationNotFound)
+	suite.Nil(tree)
+}
+
+// TestGetHierarchy_TeamRepositoryError tests that team lookup failures are returned
+func (suite *OrganizationServiceTestSuite) TestGetHierarchy_TeamRepositoryError() {
+	orgID := uuid.New()
+	groupID := uuid.New()
+
+	suite.mockOrgRepo.EXPECT().GetByID(orgID).Return(&models.Organization{BaseModel: models.BaseModel{ID: orgID}}, nil)
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(orgID, gomock.Any(), 0).Return([]models.Group{
+		{BaseModel: models.BaseModel{ID: groupID}, OrgID: orgID},
+	}, int64(1), nil)
+	suite.mockTeamRepo.EXPECT().GetByGroupID(groupID, gomock.Any(), 0).Return(nil, int64(0), errors.New("database error"))
+
+	tree, err := suite.orgService.GetHierarchy(orgID)
+
+	suite.Error(err)
+	suite.Contains(err.Error(), "failed to get teams")
+	suite.Nil(tree)
+}
+
+// TestOrganizationServiceTestSuite runs the test suite
+func TestOrganizationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationServiceTestSuite))
+}
@@ -0,0 +1,48 @@
+package service
+
+import "sync/atomic"
+
+const (
+	// DefaultPageSize is the page size used when a caller does not request one
+	DefaultPageSize = 20
+	// MaxPageSize is the largest page size a caller may request
+	MaxPageSize = 100
+)
+
+var (
+	defaultPageSize atomic.Int64
+	maxPageSize     atomic.Int64
+)
+
+func init() {
+	defaultPageSize.Store(DefaultPageSize)
+	maxPageSize.Store(MaxPageSize)
+}
+
+// SetPageSizeLimits overrides the default and maximum page sizes applied by ClampPageSize.
+// Non-positive values keep the built-in limits, and the default never exceeds the maximum.
+func SetPageSizeLimits(defaultSize, maxSize int) {
+	if maxSize <= 0 {
+		maxSize = MaxPageSize
+	}
+	if defaultSize <= 0 {
+		defaultSize = DefaultPageSize
+	}
+	if defaultSize > maxSize {
+		defaultSize = maxSize
+	}
+	maxPageSize.Store(int64(maxSize))
+	defaultPageSize.Store(int64(defaultSize))
+}
+
+// ClampPageSize returns the page size to use for a requested size: zero or negative
+// selects the default, and anything above the maximum is capped to it.
+func ClampPageSize(requested int) int {
+	if requested <= 0 {
+		return int(defaultPageSize.Load())
+	}
+	if limit := int(maxPageSize.Load()); requested > limit {
+		return limit
+	}
+	return requested
+}
@@ -0,0 +1,29 @@
+package service_test
+
+import (
+	"testing"
+
+	"developer-portal-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClampPageSize(t *testing.T) {
+	assert.Equal(t, service.DefaultPageSize, service.ClampPageSize(0))
+	assert.Equal(t, service.DefaultPageSize, service.ClampPageSize(-5))
+	assert.Equal(t, 50, service.ClampPageSize(50))
+	assert.Equal(t, service.MaxPageSize, service.ClampPageSize(100000))
+}
+
+func TestSetPageSizeLimits(t *testing.T) {
+	defer service.SetPageSizeLimits(service.DefaultPageSize, service.MaxPageSize)
+
+	service.SetPageSizeLimits(10, 40)
+	assert.Equal(t, 10, service.ClampPageSize(0))
+	assert.Equal(t, 25, service.ClampPageSize(25))
+	assert.Equal(t, 40, service.ClampPageSize(500))
+
+	// A default above the maximum is capped to the maximum
+	service.SetPageSizeLimits(80, 40)
+	assert.Equal(t, 40, service.ClampPageSize(0))
+}
@@ -3,16 +3,19 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
 
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/repository"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // PluginService handles business logic for plugins
@@ -33,15 +36,16 @@ func NewPluginService(pluginRepo repository.PluginRepositoryInterface, userRepo
 
 // PluginResponse represents the response structure for a plugin
 type PluginResponse struct {
-	ID                 uuid.UUID `json:"id"`
-	Name               string    `json:"name"`
-	Title              string    `json:"title"`
-	Description        string    `json:"description"`
-	Icon               string    `json:"icon"`
-	ReactComponentPath string    `json:"react_component_path"`
-	BackendServerURL   string    `json:"backend_server_url"`
-	Owner              string    `json:"owner"`
-	Subscribed         bool      `json:"subscribed,omitempty"`
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	Title              string     `json:"title"`
+	Description        string     `json:"description"`
+	Icon               string     `json:"icon"`
+	ReactComponentPath string     `json:"react_component_path"`
+	BackendServerURL   string     `json:"backend_server_url"`
+	Owner              string     `json:"owner"`
+	OrgID              *uuid.UUID `json:"org_id,omitempty"`
+	Subscribed         bool       `json:"subscribed,omitempty"`
 }
 
 // PluginListResponse represents the response structure for plugin list
@@ -174,6 +178,21 @@ func (s *PluginService) GetAllPluginsWithViewer(limit, offset int, viewerName st
 	}, nil
 }
 
+// ListAvailablePlugins returns the global plugins together with those scoped to the user's organization;
+// a nil userOrgID yields only the global plugins
+func (s *PluginService) ListAvailablePlugins(userOrgID *uuid.UUID) ([]PluginResponse, error) {
+	plugins, err := s.pluginRepo.GetAvailableForOrganization(userOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available plugins: %w", err)
+	}
+
+	pluginResponses := make([]PluginResponse, len(plugins))
+	for i, plugin := range plugins {
+		pluginResponses[i] = s.toPluginResponse(&plugin)
+	}
+	return pluginResponses, nil
+}
+
 // GetPluginByID retrieves a plugin by ID
 func (s *PluginService) GetPluginByID(id uuid.UUID) (*PluginResponse, error) {
 	plugin, err := s.pluginRepo.GetByID(id)
@@ -185,6 +204,48 @@ func (s *PluginService) GetPluginByID(id uuid.UUID) (*PluginResponse, error) {
 	return &response, nil
 }
 
+// GetPluginByName retrieves a plugin by its unique name
+func (s *PluginService) GetPluginByName(name string) (*PluginResponse, error) {
+	plugin, err := s.pluginRepo.GetByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrPluginNotFound
+		}
+		return nil, err
+	}
+
+	response := s.toPluginResponse(plugin)
+	return &response, nil
+}
+
+// SearchPlugins searches plugins by name, title or description with pagination
+func (s *PluginService) SearchPlugins(query string, limit, offset int) (*PluginListResponse, error) {
+	// Set default pagination values
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	plugins, total, err := s.pluginRepo.Search(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	pluginResponses := make([]PluginResponse, len(plugins))
+	for i, plugin := range plugins {
+		pluginResponses[i] = s.toPluginResponse(&plugin)
+	}
+
+	return &PluginListResponse{
+		Plugins: pluginResponses,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
 // CreatePlugin creates a new plugin
 func (s *PluginService) CreatePlugin(req *CreatePluginRequest) (*PluginResponse, error) {
 	// Validate the request
@@ -297,6 +358,7 @@ func (s *PluginService) toPluginResponse(plugin *models.Plugin) PluginResponse {
 		ReactComponentPath: plugin.ReactComponentPath,
 		BackendServerURL:   plugin.BackendServerURL,
 		Owner:              plugin.Owner,
+		OrgID:              plugin.OrgID,
 	}
 }
 
@@ -335,12 +397,7 @@ func (s *PluginService) GetPluginUIContent(ctx context.Context, pluginID uuid.UU
 		return nil, fmt.Errorf("invalid GitHub URL in react_component_path: %w", err)
 	}
 
-	// Use default provider if not provided
-	if provider == "" {
-		provider = "github"
-	}
-
-	// Fetch the file content from GitHub
+	// Fetch the file content from GitHub; an empty provider is resolved by the GitHub service
 	content, err := githubService.GetRepositoryContent(ctx, userUUID, provider, owner, repo, filePath, ref)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch content from GitHub: %w", err)
@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 // MockPluginRepository is a mock implementation of PluginRepositoryInterface
@@ -44,6 +47,32 @@ func (m *MockPluginRepository) GetAll(limit, offset int) ([]models.Plugin, int64
 	return args.Get(0).([]models.Plugin), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockPluginRepository) GetByIDs(ids []uuid.UUID) ([]models.Plugin, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Plugin), args.Error(1)
+}
+
+func (m *MockPluginRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Plugin, int64, error) {
+	args := m.Called(orgID, limit, offset)
+	return args.Get(0).([]models.Plugin), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPluginRepository) GetAvailableForOrganization(orgID *uuid.UUID) ([]models.Plugin, error) {
+	args := m.Called(orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Plugin), args.Error(1)
+}
+
+func (m *MockPluginRepository) Search(query string, limit, offset int) ([]models.Plugin, int64, error) {
+	args := m.Called(query, limit, offset)
+	return args.Get(0).([]models.Plugin), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockPluginRepository) Update(plugin *models.Plugin) error {
 	args := m.Called(plugin)
 	return args.Error(0)
@@ -85,6 +114,11 @@ func (m *MockUserRepository) GetAll(limit, offset int) ([]models.User, int64, er
 	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockUserRepository) GetAllAfter(createdAt time.Time, id uuid.UUID, limit int) ([]models.User, error) {
+	args := m.Called(createdAt, id, limit)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) Update(user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
@@ -95,6 +129,19 @@ func (m *MockUserRepository) Delete(id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) GetByIDIncludingDeleted(id uuid.UUID) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Restore(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
 	args := m.Called(email)
 	if args.Get(0) == nil {
@@ -103,6 +150,14 @@ func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByEmails(emails []string) ([]models.User, error) {
+	args := m.Called(emails)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByUserID(userID string) (*models.User, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
@@ -129,13 +184,18 @@ func (m *MockUserRepository) GetWithOrganization(id uuid.UUID) (*models.User, er
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) SearchByOrganization(orgID uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
-	args := m.Called(orgID, query, limit, offset)
+func (m *MockUserRepository) SearchByOrganization(orgID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]models.User, int64, error) {
+	args := m.Called(orgID, query, emailOnly, limit, offset)
 	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockUserRepository) SearchByNameOrTitleGlobal(query string, limit, offset int) ([]models.User, int64, error) {
-	args := m.Called(query, limit, offset)
+func (m *MockUserRepository) SearchByNameOrTitleGlobal(query string, emailOnly bool, limit, offset int) ([]models.User, int64, error) {
+	args := m.Called(query, emailOnly, limit, offset)
+	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) SearchByTeamIDs(teamIDs []uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
+	args := m.Called(teamIDs, query, limit, offset)
 	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
 }
 
@@ -144,6 +204,29 @@ func (m *MockUserRepository) GetActiveByOrganization(orgID uuid.UUID, limit, off
 	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockUserRepository) GetActiveByOrganizationSince(orgID uuid.UUID, since time.Time, limit, offset int) ([]models.User, int64, error) {
+	args := m.Called(orgID, since, limit, offset)
+	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) Count() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) CountByOrganization(orgID uuid.UUID) (int64, error) {
+	args := m.Called(orgID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) CountByDomain(orgID uuid.UUID) (map[string]int64, error) {
+	args := m.Called(orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
 func (m *MockUserRepository) GetUserIDsByPrefix(prefix string) ([]string, error) {
 	args := m.Called(prefix)
 	return args.Get(0).([]string), args.Error(1)
@@ -167,6 +250,62 @@ func (m *MockGitHubService) GetUserOpenPullRequests(ctx context.Context, uuid, p
 	return args.Get(0).(*PullRequestsResponse), args.Error(1)
 }
 
+func (m *MockGitHubService) GetUserOpenPullRequestsWithFilters(ctx context.Context, uuid, provider, state, sort, direction string, perPage, page int, filters QueryFilters) (*PullRequestsResponse, error) {
+	args := m.Called(ctx, uuid, provider, state, sort, direction, perPage, page, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PullRequestsResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserOpenPullRequestsAllProviders(ctx context.Context, uuid string, providers []string, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error) {
+	args := m.Called(ctx, uuid, providers, state, sort, direction, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PullRequestsResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserReviewRequests(ctx context.Context, uuid, provider, state, sort, order string, perPage, page int) (*PullRequestsResponse, error) {
+	args := m.Called(ctx, uuid, provider, state, sort, order, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PullRequestsResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserAssignedIssues(ctx context.Context, uuid, provider, state, sort, order string, perPage, page int) (*IssuesResponse, error) {
+	args := m.Called(ctx, uuid, provider, state, sort, order, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*IssuesResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetUserRepositories(ctx context.Context, uuid, provider, sort string, perPage, page int) ([]Repository, error) {
+	args := m.Called(ctx, uuid, provider, sort, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Repository), args.Error(1)
+}
+
+func (m *MockGitHubService) SearchRepositories(ctx context.Context, uuid, provider, query, sort string, perPage, page int) (*RepositoriesResponse, error) {
+	args := m.Called(ctx, uuid, provider, query, sort, perPage, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RepositoriesResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetPullRequestDetails(ctx context.Context, uuid, provider, owner, repo string, number int) (*PullRequestDetails, error) {
+	args := m.Called(ctx, uuid, provider, owner, repo, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*PullRequestDetails), args.Error(1)
+}
+
 func (m *MockGitHubService) GetUserTotalContributions(ctx context.Context, uuid, provider, period string) (*TotalContributionsResponse, error) {
 	args := m.Called(ctx, uuid, provider, period)
 	if args.Get(0) == nil {
@@ -183,6 +322,22 @@ func (m *MockGitHubService) GetContributionsHeatmap(ctx context.Context, uuid, p
 	return args.Get(0).(*ContributionsHeatmapResponse), args.Error(1)
 }
 
+func (m *MockGitHubService) GetUserActivityTimeline(ctx context.Context, uuid, provider, period string) (*ActivityTimelineResponse, error) {
+	args := m.Called(ctx, uuid, provider, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ActivityTimelineResponse), args.Error(1)
+}
+
+func (m *MockGitHubService) GetContributionsHeatmapIfNoneMatch(ctx context.Context, uuid, provider, period, ifNoneMatch string) (*ContributionsHeatmapResponse, error) {
+	args := m.Called(ctx, uuid, provider, period, ifNoneMatch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ContributionsHeatmapResponse), args.Error(1)
+}
+
 func (m *MockGitHubService) GetAveragePRMergeTime(ctx context.Context, uuid, provider, period string) (*AveragePRMergeTimeResponse, error) {
 	args := m.Called(ctx, uuid, provider, period)
 	if args.Get(0) == nil {
@@ -191,6 +346,14 @@ func (m *MockGitHubService) GetAveragePRMergeTime(ctx context.Context, uuid, pro
 	return args.Get(0).(*AveragePRMergeTimeResponse), args.Error(1)
 }
 
+func (m *MockGitHubService) GetCommitActivity(ctx context.Context, uuid, provider, owner, repo, period string) ([]WeeklyCommits, error) {
+	args := m.Called(ctx, uuid, provider, owner, repo, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]WeeklyCommits), args.Error(1)
+}
+
 func (m *MockGitHubService) GetUserPRReviewComments(ctx context.Context, uuid, provider, period string) (*PRReviewCommentsResponse, error) {
 	args := m.Called(ctx, uuid, provider, period)
 	if args.Get(0) == nil {
@@ -600,6 +763,190 @@ func TestPluginService_GetPluginByID(t *testing.T) {
 	}
 }
 
+func TestPluginService_GetPluginByName(t *testing.T) {
+	plugin := &models.Plugin{
+		BaseModel: models.BaseModel{
+			ID:    uuid.New(),
+			Name:  "test-plugin",
+			Title: "Test Plugin",
+		},
+		Icon:               "TestIcon",
+		ReactComponentPath: "/plugins/test/Test.jsx",
+		BackendServerURL:   "http://localhost:3001",
+	}
+
+	tests := []struct {
+		name          string
+		pluginName    string
+		mockPlugin    *models.Plugin
+		mockError     error
+		expectedError error
+	}{
+		{
+			name:       "exact name match",
+			pluginName: "test-plugin",
+			mockPlugin: plugin,
+		},
+		{
+			name:          "plugin not found",
+			pluginName:    "missing-plugin",
+			mockError:     gorm.ErrRecordNotFound,
+			expectedError: apperrors.ErrPluginNotFound,
+		},
+		{
+			name:          "repository error",
+			pluginName:    "test-plugin",
+			mockError:     errors.New("database error"),
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPluginRepo := new(MockPluginRepository)
+			mockUserRepo := new(MockUserRepository)
+			service := NewPluginService(mockPluginRepo, mockUserRepo, validator.New())
+
+			mockPluginRepo.On("GetByName", tt.pluginName).Return(tt.mockPlugin, tt.mockError)
+
+			result, err := service.GetPluginByName(tt.pluginName)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, plugin.ID, result.ID)
+				assert.Equal(t, plugin.Name, result.Name)
+			}
+
+			mockPluginRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPluginService_ListAvailablePlugins(t *testing.T) {
+	orgID := uuid.New()
+	globalPlugin := models.Plugin{
+		BaseModel:          models.BaseModel{ID: uuid.New(), Name: "global-plugin"},
+		Icon:               "GlobalIcon",
+		ReactComponentPath: "/plugins/global/Global.jsx",
+		BackendServerURL:   "http://localhost:3001",
+	}
+	orgPlugin := models.Plugin{
+		BaseModel:          models.BaseModel{ID: uuid.New(), Name: "org-plugin"},
+		Icon:               "OrgIcon",
+		ReactComponentPath: "/plugins/org/Org.jsx",
+		BackendServerURL:   "http://localhost:3002",
+		OrgID:              &orgID,
+	}
+
+	tests := []struct {
+		name          string
+		userOrgID     *uuid.UUID
+		mockPlugins   []models.Plugin
+		mockError     error
+		expectedNames []string
+		expectError   bool
+	}{
+		{
+			name:          "global only for user without organization",
+			userOrgID:     nil,
+			mockPlugins:   []models.Plugin{globalPlugin},
+			expectedNames: []string{"global-plugin"},
+		},
+		{
+			name:          "global and organization scoped",
+			userOrgID:     &orgID,
+			mockPlugins:   []models.Plugin{globalPlugin, orgPlugin},
+			expectedNames: []string{"global-plugin", "org-plugin"},
+		},
+		{
+			name:        "repository error",
+			userOrgID:   &orgID,
+			mockError:   errors.New("database error"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPluginRepo := new(MockPluginRepository)
+			mockUserRepo := new(MockUserRepository)
+			service := NewPluginService(mockPluginRepo, mockUserRepo, validator.New())
+
+			mockPluginRepo.On("GetAvailableForOrganization", tt.userOrgID).Return(tt.mockPlugins, tt.mockError)
+
+			result, err := service.ListAvailablePlugins(tt.userOrgID)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				names := make([]string, len(result))
+				for i, plugin := range result {
+					names[i] = plugin.Name
+				}
+				assert.Equal(t, tt.expectedNames, names)
+			}
+
+			mockPluginRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPluginService_SearchPlugins(t *testing.T) {
+	plugins := []models.Plugin{
+		{BaseModel: models.BaseModel{ID: uuid.New(), Name: "jenkins-plugin", Title: "Jenkins"}},
+		{BaseModel: models.BaseModel{ID: uuid.New(), Name: "jira-plugin", Title: "Jira"}},
+	}
+
+	t.Run("paginated search", func(t *testing.T) {
+		mockPluginRepo := new(MockPluginRepository)
+		service := NewPluginService(mockPluginRepo, new(MockUserRepository), validator.New())
+
+		mockPluginRepo.On("Search", "j", 2, 2).Return(plugins, int64(5), nil)
+
+		result, err := service.SearchPlugins("j", 2, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Plugins, 2)
+		assert.Equal(t, int64(5), result.Total)
+		assert.Equal(t, 2, result.Limit)
+		assert.Equal(t, 2, result.Offset)
+		assert.Equal(t, "jenkins-plugin", result.Plugins[0].Name)
+		mockPluginRepo.AssertExpectations(t)
+	})
+
+	t.Run("default pagination", func(t *testing.T) {
+		mockPluginRepo := new(MockPluginRepository)
+		service := NewPluginService(mockPluginRepo, new(MockUserRepository), validator.New())
+
+		mockPluginRepo.On("Search", "j", 20, 0).Return([]models.Plugin{}, int64(0), nil)
+
+		result, err := service.SearchPlugins("j", 0, -1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result.Plugins)
+		assert.Equal(t, 20, result.Limit)
+		mockPluginRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockPluginRepo := new(MockPluginRepository)
+		service := NewPluginService(mockPluginRepo, new(MockUserRepository), validator.New())
+
+		mockPluginRepo.On("Search", "j", 10, 0).Return([]models.Plugin{}, int64(0), errors.New("database error"))
+
+		result, err := service.SearchPlugins("j", 10, 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestPluginService_CreatePlugin(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -951,6 +1298,25 @@ func TestPluginService_GetPluginUIContent(t *testing.T) {
 			expectError:     false,
 			expectedContent: "import React from 'react';",
 		},
+		{
+			name:     "empty provider is passed to the GitHub service",
+			pluginID: pluginID,
+			userUUID: userUUID,
+			provider: "",
+			mockPlugin: &models.Plugin{
+				BaseModel: models.BaseModel{
+					ID: pluginID,
+				},
+				ReactComponentPath: "https://github.com/owner/repo/blob/main/src/Component.tsx",
+			},
+			mockPluginError: nil,
+			mockGitHubResponse: map[string]interface{}{
+				"content": "import React from 'react';",
+			},
+			mockGitHubError: nil,
+			expectError:     false,
+			expectedContent: "import React from 'react';",
+		},
 		{
 			name:            "plugin not found",
 			pluginID:        pluginID,
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"developer-portal-backend/internal/repository"
+)
+
+// Readiness check statuses
+const (
+	ReadinessStatusReady         = "ready"
+	ReadinessStatusNotReady      = "not ready"
+	ReadinessStatusNotConfigured = "not configured"
+)
+
+// ReadinessCheck is the outcome of a single dependency check
+type ReadinessCheck struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReadinessStatus aggregates dependency checks for a readiness probe
+type ReadinessStatus struct {
+	Ready     bool                      `json:"ready"`
+	Checks    map[string]ReadinessCheck `json:"checks"`
+	Timestamp time.Time                 `json:"timestamp"`
+}
+
+// credentialsPresenceChecker is implemented by credential providers that can report whether any credentials are configured
+type credentialsPresenceChecker interface {
+	Configured() error
+}
+
+// ReadinessService reports whether the application's dependencies are available
+type ReadinessService struct {
+	db          repository.HealthCheckerInterface
+	credentials AICoreCredentialsProvider
+}
+
+// Ensure ReadinessService implements ReadinessServiceInterface
+var _ ReadinessServiceInterface = (*ReadinessService)(nil)
+
+// NewReadinessService creates a new readiness service; a nil credentialsProvider reads AI_CORE_CREDENTIALS
+func NewReadinessService(db repository.HealthCheckerInterface, credentialsProvider AICoreCredentialsProvider) *ReadinessService {
+	if credentialsProvider == nil {
+		credentialsProvider = NewEnvCredentialsProvider()
+	}
+	return &ReadinessService{db: db, credentials: credentialsProvider}
+}
+
+// Readiness checks the database and AI Core credential presence. Only the database gates readiness:
+// AI Core is an optional feature, so missing credentials are reported without failing the probe.
+func (s *ReadinessService) Readiness(ctx context.Context) *ReadinessStatus {
+	status := &ReadinessStatus{
+		Ready:     true,
+		Checks:    make(map[string]ReadinessCheck),
+		Timestamp: time.Now(),
+	}
+
+	if err := s.db.HealthCheck(ctx); err != nil {
+		status.Ready = false
+		status.Checks["database"] = ReadinessCheck{Status: ReadinessStatusNotReady, Error: err.Error()}
+	} else {
+		status.Checks["database"] = ReadinessCheck{Status: ReadinessStatusReady}
+	}
+
+	aiCore := ReadinessCheck{Status: ReadinessStatusReady}
+	if checker, ok := s.credentials.(credentialsPresenceChecker); ok {
+		if err := checker.Configured(); err != nil {
+			aiCore = ReadinessCheck{Status: ReadinessStatusNotConfigured, Error: err.Error()}
+		}
+	}
+	status.Checks["aicore_credentials"] = aiCore
+
+	return status
+}
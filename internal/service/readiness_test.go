@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadiness_Healthy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := mocks.NewMockHealthCheckerInterface(ctrl)
+	db.EXPECT().HealthCheck(gomock.Any()).Return(nil)
+	t.Setenv("AI_CORE_CREDENTIALS", `[{"team":"team-a","clientId":"id","clientSecret":"secret","oauthUrl":"https://auth.example.com","apiUrl":"https://api.example.com","resourceGroup":"default"}]`)
+
+	status := service.NewReadinessService(db, nil).Readiness(context.Background())
+
+	assert.True(t, status.Ready)
+	assert.Equal(t, service.ReadinessStatusReady, status.Checks["database"].Status)
+	assert.Equal(t, service.ReadinessStatusReady, status.Checks["aicore_credentials"].Status)
+}
+
+func TestReadiness_DatabaseUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := mocks.NewMockHealthCheckerInterface(ctrl)
+	db.EXPECT().HealthCheck(gomock.Any()).Return(errors.New("sql: database is closed"))
+	t.Setenv("AI_CORE_CREDENTIALS", "")
+
+	status := service.NewReadinessService(db, nil).Readiness(context.Background())
+
+	assert.False(t, status.Ready)
+	assert.Equal(t, service.ReadinessStatusNotReady, status.Checks["database"].Status)
+	assert.Equal(t, "sql: database is closed", status.Checks["database"].Error)
+	// Missing AI Core credentials are reported but do not gate readiness on their own
+	assert.Equal(t, service.ReadinessStatusNotConfigured, status.Checks["aicore_credentials"].Status)
+}
+
+func TestReadiness_AICoreCredentialsMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := mocks.NewMockHealthCheckerInterface(ctrl)
+	db.EXPECT().HealthCheck(gomock.Any()).Return(nil)
+	t.Setenv("AI_CORE_CREDENTIALS", "")
+
+	status := service.NewReadinessService(db, nil).Readiness(context.Background())
+
+	assert.True(t, status.Ready)
+	assert.Equal(t, service.ReadinessStatusNotConfigured, status.Checks["aicore_credentials"].Status)
+	assert.NotEmpty(t, status.Checks["aicore_credentials"].Error)
+}
@@ -247,17 +247,7 @@ func (s *TeamService) GetBySimpleName(teamName string) (*TeamWithMembersResponse
 	// Convert members to UserResponse including metadata fallback
 	memberResponses := make([]UserResponse, len(members))
 	for i, m := range members {
-		memberResponses[i] = UserResponse{
-			ID:         m.UserID,
-			UUID:       m.BaseModel.ID.String(),
-			TeamID:     m.TeamID,
-			FirstName:  m.FirstName,
-			LastName:   m.LastName,
-			Email:      m.Email,
-			Mobile:     m.Mobile,
-			TeamDomain: string(m.TeamDomain),
-			TeamRole:   string(m.TeamRole),
-		}
+		memberResponses[i] = *toUserResponse(&m)
 	}
 
 	// Fetch links owned by team
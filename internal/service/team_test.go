@@ -1942,7 +1942,7 @@ func (suite *TeamServiceTestSuite) TestGetBySimpleNameWithViewer_ViewerNotFound(
 	suite.mockUserRepo.EXPECT().GetByTeamID(teamID, 1000, 0).Return([]models.User{}, int64(0), nil)
 	suite.mockGroupRepo.EXPECT().GetByID(groupID).Return(group, nil)
 	suite.mockLinkRepo.EXPECT().GetByOwner(teamID).Return(links, nil)
-	suite.mockUserRepo.EXPECT().GetByName(viewerName).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockUserRepo.EXPECT().GetByName(viewerName).Return(nil, apperrors.ErrUserNotFound)
 
 	// Execute
 	result, err := suite.teamService.GetBySimpleNameWithViewer(teamName, viewerName)
@@ -1,24 +1,45 @@
 package service
 
 import (
+	"context"
 	"developer-portal-backend/internal/database/models"
 	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/logger"
 	"developer-portal-backend/internal/repository"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// orgExpansionPageSize is the page size used when loading an organization's groups and teams
+const orgExpansionPageSize = 500
+
 // UserService handles business logic for members
 type UserService struct {
 	repo       repository.UserRepositoryInterface
 	linkRepo   repository.LinkRepositoryInterface
 	pluginRepo repository.PluginRepositoryInterface
 	validator  *validator.Validate
+	events     EventPublisher
+	auditRepo  repository.MetadataAuditRepositoryInterface
+	teamRepo   repository.TeamRepositoryInterface
+	groupRepo  repository.GroupRepositoryInterface
+
+	cacheInvalidators []UserCacheInvalidator
+}
+
+// UserCacheInvalidator drops cached data derived from a user's team or role, such as AI Core's GetMe team expansion
+type UserCacheInvalidator interface {
+	InvalidateTeamExpansion(username string) error
 }
 
 // NewUserService creates a new member service
@@ -31,6 +52,158 @@ func NewUserService(repo repository.UserRepositoryInterface, linkRepo repository
 	}
 }
 
+// SetEventPublisher sets the publisher notified of user lifecycle changes; nil disables events
+func (s *UserService) SetEventPublisher(publisher EventPublisher) {
+	s.events = publisher
+}
+
+// RegisterCacheInvalidator adds a cache invalidated whenever a user's team or role changes
+func (s *UserService) RegisterCacheInvalidator(invalidator UserCacheInvalidator) {
+	if invalidator != nil {
+		s.cacheInvalidators = append(s.cacheInvalidators, invalidator)
+	}
+}
+
+// SetMetadataAuditRepository sets the repository recording metadata changes; nil disables auditing
+func (s *UserService) SetMetadataAuditRepository(auditRepo repository.MetadataAuditRepositoryInterface) {
+	s.auditRepo = auditRepo
+}
+
+// SetOrganizationRepositories sets the repositories used to resolve a user's organization through
+// their team and group; without them users only see global plugins
+func (s *UserService) SetOrganizationRepositories(teamRepo repository.TeamRepositoryInterface, groupRepo repository.GroupRepositoryInterface) {
+	s.teamRepo = teamRepo
+	s.groupRepo = groupRepo
+}
+
+// userOrganizationID resolves the organization a user belongs to via team -> group.
+// It returns nil when the user has no team or the organization repositories are not configured.
+func (s *UserService) userOrganizationID(user *models.User) (*uuid.UUID, error) {
+	if user == nil || user.TeamID == nil || s.teamRepo == nil || s.groupRepo == nil {
+		return nil, nil
+	}
+
+	team, err := s.teamRepo.GetByID(*user.TeamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	group, err := s.groupRepo.GetByID(team.GroupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	orgID := group.OrgID
+	return &orgID, nil
+}
+
+// ensureTeamExists returns apperrors.ErrTeamNotFound when teamID does not refer to an existing team.
+// The check is skipped when the organization repositories are not configured.
+func (s *UserService) ensureTeamExists(teamID uuid.UUID) error {
+	if s.teamRepo == nil {
+		return nil
+	}
+
+	if _, err := s.teamRepo.GetByID(teamID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrTeamNotFound
+		}
+		return fmt.Errorf("failed to get team: %w", err)
+	}
+	return nil
+}
+
+// recordMetadataAudit appends an audit entry for a metadata change if auditing is configured.
+// The metadata write has already succeeded, so audit failures are logged rather than returned.
+func (s *UserService) recordMetadataAudit(userID string, field models.MetadataAuditField, operation models.MetadataAuditOperation, targetID uuid.UUID, actor string) {
+	if s.auditRepo == nil {
+		return
+	}
+
+	entry := &models.MetadataAudit{
+		UserID:    userID,
+		Field:     field,
+		Operation: operation,
+		TargetID:  targetID,
+		Actor:     actor,
+		CreatedAt: time.Now(),
+	}
+	if err := s.auditRepo.Create(entry); err != nil {
+		logger.New().WithFields(map[string]interface{}{
+			"user_id":   userID,
+			"field":     field,
+			"operation": operation,
+			"error":     err.Error(),
+		}).Warn("Failed to record metadata audit entry")
+	}
+}
+
+// GetMetadataAudit retrieves the metadata change history of a user identified by user_id, newest first
+func (s *UserService) GetMetadataAudit(userID string, limit, offset int) ([]models.MetadataAudit, int64, error) {
+	if userID == "" {
+		return nil, 0, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+	if s.auditRepo == nil {
+		return []models.MetadataAudit{}, 0, nil
+	}
+
+	entries, total, err := s.auditRepo.GetByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get metadata audit: %w", err)
+	}
+	return entries, total, nil
+}
+
+// publishUserEvent emits an event for user if a publisher is configured and there is something to report.
+// The write has already succeeded, so publish failures are logged rather than returned.
+func (s *UserService) publishUserEvent(eventType EventType, userID uuid.UUID, changes map[string]FieldChange) {
+	if s.events == nil || len(changes) == 0 {
+		return
+	}
+
+	event := Event{
+		Type:       eventType,
+		UserID:     userID,
+		Changes:    changes,
+		OccurredAt: time.Now(),
+	}
+	if err := s.events.Publish(context.Background(), event); err != nil {
+		logger.New().WithFields(map[string]interface{}{
+			"event":   eventType,
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Warn("Failed to publish user event")
+	}
+}
+
+// invalidateUserCaches drops cached data derived from user's team or role.
+// The write has already succeeded, so invalidation failures are logged rather than returned.
+func (s *UserService) invalidateUserCaches(user *models.User) {
+	for _, invalidator := range s.cacheInvalidators {
+		if err := invalidator.InvalidateTeamExpansion(user.Name); err != nil {
+			logger.New().WithFields(map[string]interface{}{
+				"user_id": user.ID,
+				"error":   err.Error(),
+			}).Warn("Failed to invalidate user cache")
+		}
+	}
+}
+
+// publishUserChanges emits UserUpdated for the fields that changed and UserTeamChanged if the team moved
+func (s *UserService) publishUserChanges(userID uuid.UUID, before map[string]interface{}, after *models.User) {
+	changes := diffFields(before, userEventFields(after))
+	s.publishUserEvent(EventUserUpdated, userID, changes)
+	if teamChange, ok := changes["team_id"]; ok {
+		s.publishUserEvent(EventUserTeamChanged, userID, map[string]FieldChange{"team_id": teamChange})
+	}
+}
+
 // CreateUserRequest represents the data needed to create a member
 // Note: Aligned with models.Member (BaseModel + string ID for IUser)
 type CreateUserRequest struct {
@@ -58,15 +231,16 @@ type UpdateUserRequest struct {
 
 // UserResponse represents the response data for a member
 type UserResponse struct {
-	ID         string     `json:"id"`
-	UUID       string     `json:"uuid"`
-	TeamID     *uuid.UUID `json:"team_id,omitempty"`
-	FirstName  string     `json:"first_name"`
-	LastName   string     `json:"last_name"`
-	Email      string     `json:"email"`
-	Mobile     string     `json:"mobile"`
-	TeamDomain string     `json:"team_domain"` // models.TeamDomain value
-	TeamRole   string     `json:"team_role"`   // models.TeamRole value
+	ID           string     `json:"id"`
+	UUID         string     `json:"uuid"`
+	TeamID       *uuid.UUID `json:"team_id,omitempty"`
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	Email        string     `json:"email"`
+	PendingEmail string     `json:"pending_email,omitempty"` // new email awaiting confirmation
+	Mobile       string     `json:"mobile"`
+	TeamDomain   string     `json:"team_domain"` // models.TeamDomain value
+	TeamRole     string     `json:"team_role"`   // models.TeamRole value
 }
 
 type UserWithLinksAndPluginsResponse struct {
@@ -84,6 +258,12 @@ type UserWithLinksAndPluginsResponse struct {
 	Plugins     []PluginResponse `json:"plugins"` // subscribed plugins
 }
 
+// SubscribedPluginsResponse lists a user's subscribed plugins together with subscribed IDs that no longer exist
+type SubscribedPluginsResponse struct {
+	Plugins       []PluginResponse `json:"plugins"`
+	UnresolvedIDs []uuid.UUID      `json:"unresolved_ids"` // subscribed plugin IDs without a matching plugin
+}
+
 // UsersListResponse is the swagger schema for GET /users
 type UsersListResponse struct {
 	Users  []UserResponse `json:"users"`
@@ -92,6 +272,12 @@ type UsersListResponse struct {
 	Offset int            `json:"offset"`
 }
 
+// UsersCursorPage is a page of users fetched with cursor pagination
+type UsersCursorPage struct {
+	Users      []UserResponse `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"` // empty on the last page
+}
+
 // LDAPUserSearchItem represents a single LDAP user search result item for Swagger
 type LDAPUserSearchItem struct {
 	ID        string `json:"id"`
@@ -111,11 +297,11 @@ type LDAPUserSearchResponse struct {
 func (s *UserService) CreateUser(req *CreateUserRequest) (*UserResponse, error) {
 	// Validate request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 	// Require created_by from token
 	if strings.TrimSpace(req.CreatedBy) == "" {
-		return nil, fmt.Errorf("created_by is required")
+		return nil, apperrors.NewValidationError("created_by", "created_by is required")
 	}
 
 	// Check if email already exists (unique within system)
@@ -124,16 +310,22 @@ func (s *UserService) CreateUser(req *CreateUserRequest) (*UserResponse, error)
 		return nil, apperrors.ErrUserExists
 	}
 
-	// Determine team domain (role) default
-	teamDomain := models.TeamDomainDeveloper
+	// Determine team domain (role), falling back to the default
+	teamDomain := models.DefaultTeamDomain
 	if req.Role != nil {
 		teamDomain = models.TeamDomain(*req.Role)
+		if !teamDomain.IsValid() {
+			return nil, apperrors.NewValidationError("role", fmt.Sprintf("unknown role %q", *req.Role))
+		}
 	}
 
-	// Determine team role default
-	teamRole := models.TeamRoleMember
+	// Determine team role, falling back to the default
+	teamRole := models.DefaultTeamRole
 	if req.TeamRole != nil {
 		teamRole = models.TeamRole(*req.TeamRole)
+		if !teamRole.IsValid() {
+			return nil, apperrors.NewValidationError("team_role", fmt.Sprintf("unknown team role %q", *req.TeamRole))
+		}
 	}
 
 	user := &models.User{
@@ -155,12 +347,13 @@ func (s *UserService) CreateUser(req *CreateUserRequest) (*UserResponse, error)
 	if err := s.repo.Create(user); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	s.publishUserEvent(EventUserCreated, user.ID, diffFields(nil, userEventFields(user)))
 
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
 }
 
 // AddFavoriteLinkByUserID adds link_id to user's metadata.favorites identified by user_id
-func (s *UserService) AddFavoriteLinkByUserID(userID string, linkID uuid.UUID) (*UserResponse, error) {
+func (s *UserService) AddFavoriteLinkByUserID(userID string, linkID uuid.UUID, actor string) (*UserResponse, error) {
 	if userID == "" {
 		return nil, apperrors.NewValidationError("user_id", "user_id is required")
 	}
@@ -172,8 +365,9 @@ func (s *UserService) AddFavoriteLinkByUserID(userID string, linkID uuid.UUID) (
 	user, err := s.repo.GetByUserID(userID)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by userID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
+	before := userEventFields(user)
 
 	// Parse or initialize metadata as a JSON object
 	var meta map[string]interface{}
@@ -220,18 +414,26 @@ func (s *UserService) AddFavoriteLinkByUserID(userID string, linkID uuid.UUID) (
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 	}
+	if err := ValidateMetadataKey(bytes, "favorites"); err != nil {
+		return nil, err
+	}
 	user.Metadata = json.RawMessage(bytes)
 
 	// Persist update
 	if err := s.repo.Update(user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	s.publishUserChanges(user.ID, before, user)
+	// Re-adding an existing ID changes nothing and is not audited
+	if !exists {
+		s.recordMetadataAudit(userID, models.MetadataAuditFieldFavorites, models.MetadataAuditOperationAdd, linkID, actor)
+	}
 
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
 }
 
 // RemoveFavoriteLinkByUserID removes link_id from user's metadata.favorites identified by user_id
-func (s *UserService) RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID) (*UserResponse, error) {
+func (s *UserService) RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID, actor string) (*UserResponse, error) {
 	if userID == "" {
 		return nil, apperrors.NewValidationError("user_id", "user_id is required")
 	}
@@ -243,8 +445,9 @@ func (s *UserService) RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID
 	user, err := s.repo.GetByUserID(userID)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by userID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
+	before := userEventFields(user)
 
 	// Parse or initialize metadata as a JSON object
 	var meta map[string]interface{}
@@ -287,18 +490,87 @@ func (s *UserService) RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 	}
+	if err := ValidateMetadataKey(bytes, "favorites"); err != nil {
+		return nil, err
+	}
 	user.Metadata = json.RawMessage(bytes)
 
 	// Persist update
 	if err := s.repo.Update(user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	s.publishUserChanges(user.ID, before, user)
+	// Removing an absent ID changes nothing and is not audited
+	if len(filtered) < len(favorites) {
+		s.recordMetadataAudit(userID, models.MetadataAuditFieldFavorites, models.MetadataAuditOperationRemove, linkID, actor)
+	}
+
+	return toUserResponse(user), nil
+}
+
+// IsFavoriteLink reports whether link_id is present in the user's metadata.favorites identified by user_id
+func (s *UserService) IsFavoriteLink(userID string, linkID uuid.UUID) (bool, error) {
+	if userID == "" {
+		return false, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+	if linkID == uuid.Nil {
+		return false, apperrors.NewValidationError("link_id", "link_id is required")
+	}
+
+	// Load user by string user_id
+	user, err := s.repo.GetByUserID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return false, userLookupError(err)
+	}
+
+	// Missing or invalid metadata means there are no favorites
+	if len(user.Metadata) == 0 {
+		return false, nil
+	}
+	var meta map[string]interface{}
+	if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+		return false, nil
+	}
+
+	linkStr := linkID.String()
+	if arr, ok := meta["favorites"].([]interface{}); ok {
+		for _, it := range arr {
+			if str, ok := it.(string); ok && strings.TrimSpace(str) == linkStr {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// IsSubscribedToPlugin reports whether plugin_id is present in the user's metadata.subscribed identified by user_id
+func (s *UserService) IsSubscribedToPlugin(userID string, pluginID uuid.UUID) (bool, error) {
+	if userID == "" {
+		return false, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+	if pluginID == uuid.Nil {
+		return false, apperrors.NewValidationError("plugin_id", "plugin_id is required")
+	}
+
+	// Load user by string user_id
+	user, err := s.repo.GetByUserID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return false, userLookupError(err)
+	}
 
-	return s.convertToResponse(user), nil
+	for _, id := range s.GetSubscribedPluginIDsFromUser(user) {
+		if id == pluginID {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // AddSubscribedPluginByUserID adds plugin_id to user's metadata.subscribed identified by user_id
-func (s *UserService) AddSubscribedPluginByUserID(userID string, pluginID uuid.UUID) (*UserResponse, error) {
+func (s *UserService) AddSubscribedPluginByUserID(userID string, pluginID uuid.UUID, actor string) (*UserResponse, error) {
 	if userID == "" {
 		return nil, apperrors.NewValidationError("user_id", "user_id is required")
 	}
@@ -310,7 +582,28 @@ func (s *UserService) AddSubscribedPluginByUserID(userID string, pluginID uuid.U
 	user, err := s.repo.GetByUserID(userID)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by userID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
+	}
+	before := userEventFields(user)
+
+	// Only allow subscribing to plugins that exist
+	plugin, err := s.pluginRepo.GetByID(pluginID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrPluginNotFound
+		}
+		return nil, fmt.Errorf("failed to get plugin: %w", err)
+	}
+
+	// Organization-scoped plugins are only visible to members of that organization
+	if plugin.OrgID != nil {
+		orgID, err := s.userOrganizationID(user)
+		if err != nil {
+			return nil, err
+		}
+		if orgID == nil || *orgID != *plugin.OrgID {
+			return nil, apperrors.ErrPluginNotAvailable
+		}
 	}
 
 	// Parse or initialize metadata as a JSON object
@@ -358,18 +651,26 @@ func (s *UserService) AddSubscribedPluginByUserID(userID string, pluginID uuid.U
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 	}
+	if err := ValidateMetadataKey(bytes, "subscribed"); err != nil {
+		return nil, err
+	}
 	user.Metadata = json.RawMessage(bytes)
 
 	// Persist update
 	if err := s.repo.Update(user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	s.publishUserChanges(user.ID, before, user)
+	// Re-adding an existing ID changes nothing and is not audited
+	if !exists {
+		s.recordMetadataAudit(userID, models.MetadataAuditFieldSubscribed, models.MetadataAuditOperationAdd, pluginID, actor)
+	}
 
-	return s.convertToResponseWithPlugins(user), nil
+	return toUserResponse(user), nil
 }
 
 // RemoveSubscribedPluginByUserID removes plugin_id from user's metadata.subscribed identified by user_id
-func (s *UserService) RemoveSubscribedPluginByUserID(userID string, pluginID uuid.UUID) (*UserResponse, error) {
+func (s *UserService) RemoveSubscribedPluginByUserID(userID string, pluginID uuid.UUID, actor string) (*UserResponse, error) {
 	if userID == "" {
 		return nil, apperrors.NewValidationError("user_id", "user_id is required")
 	}
@@ -381,8 +682,9 @@ func (s *UserService) RemoveSubscribedPluginByUserID(userID string, pluginID uui
 	user, err := s.repo.GetByUserID(userID)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by userID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
+	before := userEventFields(user)
 
 	// Parse or initialize metadata as a JSON object
 	var meta map[string]interface{}
@@ -425,14 +727,58 @@ func (s *UserService) RemoveSubscribedPluginByUserID(userID string, pluginID uui
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 	}
+	if err := ValidateMetadataKey(bytes, "subscribed"); err != nil {
+		return nil, err
+	}
 	user.Metadata = json.RawMessage(bytes)
 
 	// Persist update
 	if err := s.repo.Update(user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	s.publishUserChanges(user.ID, before, user)
+	// Removing an absent ID changes nothing and is not audited
+	if len(filtered) < len(subscribed) {
+		s.recordMetadataAudit(userID, models.MetadataAuditFieldSubscribed, models.MetadataAuditOperationRemove, pluginID, actor)
+	}
+
+	return toUserResponse(user), nil
+}
+
+// userLookupError maps a failed user repository lookup to the service error: the repository reports a missing
+// user as apperrors.ErrUserNotFound, anything else is an unexpected failure
+func userLookupError(err error) error {
+	if err == nil || errors.Is(err, apperrors.ErrUserNotFound) {
+		return apperrors.ErrUserNotFound
+	}
+	return fmt.Errorf("failed to get user: %w", err)
+}
 
-	return s.convertToResponse(user), nil
+// requestValidationError maps a validator failure on req to a ValidationError whose Fields name each failed
+// field by its JSON name together with the rule it broke, so clients can point at the offending input
+func requestValidationError(req interface{}, err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return apperrors.NewFieldValidationError(err.Error(), nil)
+	}
+
+	reqType := reflect.TypeOf(req)
+	for reqType != nil && reqType.Kind() == reflect.Ptr {
+		reqType = reqType.Elem()
+	}
+	fields := make(map[string]string, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		name := fieldErr.Field()
+		if reqType != nil && reqType.Kind() == reflect.Struct {
+			if structField, ok := reqType.FieldByName(fieldErr.StructField()); ok {
+				if jsonName := strings.Split(structField.Tag.Get("json"), ",")[0]; jsonName != "" && jsonName != "-" {
+					name = jsonName
+				}
+			}
+		}
+		fields[name] = fieldErr.Tag()
+	}
+	return apperrors.NewFieldValidationError(err.Error(), fields)
 }
 
 // GetMemberByID retrieves a member by ID (UUID)
@@ -440,10 +786,54 @@ func (s *UserService) GetUserByID(id uuid.UUID) (*UserResponse, error) {
 	user, err := s.repo.GetByID(id)
 	if err != nil {
 		logger.New().WithField("error", err).Error("Error getting user by ID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
+	}
+
+	return toUserResponse(user), nil
+}
+
+// GetUserByUUID retrieves a user by the database primary key, the value exposed as UserResponse.UUID.
+// This is distinct from the string UserID (e.g. I123456), exposed as UserResponse.ID; use GetUserByUserID for that.
+func (s *UserService) GetUserByUUID(id uuid.UUID) (*UserResponse, error) {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, userLookupError(err)
 	}
 
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
+}
+
+// GetUsersByEmails resolves many emails with one repository query, keyed by the
+// trimmed, lower-cased email. Blank emails and emails without a user are omitted.
+func (s *UserService) GetUsersByEmails(emails []string) (map[string]*UserResponse, error) {
+	result := make(map[string]*UserResponse)
+
+	normalized := make([]string, 0, len(emails))
+	seen := make(map[string]struct{}, len(emails))
+	for _, email := range emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" {
+			continue
+		}
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		normalized = append(normalized, email)
+	}
+	if len(normalized) == 0 {
+		return result, nil
+	}
+
+	users, err := s.repo.GetByEmails(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users by email: %w", err)
+	}
+
+	for i := range users {
+		result[strings.ToLower(users[i].Email)] = toUserResponse(&users[i])
+	}
+	return result, nil
 }
 
 // GetUserByUserID retrieves a member by their string UserID (e.g., I123456)
@@ -455,10 +845,10 @@ func (s *UserService) GetUserByUserID(userID string) (*UserResponse, error) {
 	user, err := s.repo.GetByUserID(userID)
 	if err != nil {
 		logger.New().WithField("error", err).Error("Error getting user by UserID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
 
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
 }
 
 // GetUserByName retrieves a user by BaseModel.Name (used to store username)
@@ -471,10 +861,10 @@ func (s *UserService) GetUserByName(name string) (*UserResponse, error) {
 	user, err := s.repo.GetByName(name)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by name")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
 
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
 }
 
 // GetUserByNameWithLinks retrieves a user by BaseModel.Name and returns links-enriched response
@@ -486,7 +876,7 @@ func (s *UserService) GetUserByNameWithLinks(name string) (*UserWithLinksAndPlug
 
 	user, err := s.repo.GetByName(name)
 	if err != nil || user == nil {
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
 
 	// Reuse existing logic by delegating to the user_id-based implementation
@@ -502,53 +892,56 @@ func (s *UserService) GetUserByUserIDWithPlugins(userID string) ([]PluginRespons
 	user, err := s.repo.GetByUserID(userID)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by UserID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
 
 	return s.GetSubscribedPluginsFromUser(user), nil
 }
 
+// GetSubscribedPluginIDsFromUser parses the plugin IDs in user metadata.subscribed without resolving them
+func (s *UserService) GetSubscribedPluginIDsFromUser(user *models.User) []uuid.UUID {
+	return getSubscribedPluginIDsFromUser(user)
+}
+
+// getSubscribedPluginIDsFromUser parses the plugin IDs in user metadata.subscribed
+func getSubscribedPluginIDsFromUser(user *models.User) []uuid.UUID {
+	pluginIDs := make([]uuid.UUID, 0)
+	if user == nil || len(user.Metadata) == 0 {
+		return pluginIDs
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+		return pluginIDs
+	}
+
+	switch arr := meta["subscribed"].(type) {
+	case []interface{}:
+		for _, it := range arr {
+			if str, ok := it.(string); ok && str != "" {
+				if id, err := uuid.Parse(strings.TrimSpace(str)); err == nil {
+					pluginIDs = append(pluginIDs, id)
+				}
+			}
+		}
+	case []string:
+		for _, str := range arr {
+			if id, err := uuid.Parse(strings.TrimSpace(str)); err == nil {
+				pluginIDs = append(pluginIDs, id)
+			}
+		}
+	}
+	return pluginIDs
+}
+
 // GetSubscribedPluginsFromUser extracts and fetches subscribed plugins from user metadata
 func (s *UserService) GetSubscribedPluginsFromUser(user *models.User) []PluginResponse {
 	subscribedPlugins := make([]PluginResponse, 0)
-	if len(user.Metadata) > 0 {
-		var meta map[string]interface{}
-		if err := json.Unmarshal(user.Metadata, &meta); err == nil && meta != nil {
-			if v, ok := meta["subscribed"]; ok && v != nil {
-				var pluginIDs []uuid.UUID
-				switch arr := v.(type) {
-				case []interface{}:
-					for _, it := range arr {
-						if s, ok := it.(string); ok && s != "" {
-							if id, err := uuid.Parse(strings.TrimSpace(s)); err == nil {
-								pluginIDs = append(pluginIDs, id)
-							}
-						}
-					}
-				case []string:
-					for _, s2 := range arr {
-						if id, err := uuid.Parse(strings.TrimSpace(s2)); err == nil {
-							pluginIDs = append(pluginIDs, id)
-						}
-					}
-				}
 
-				// Fetch plugin details for each subscribed plugin
-				for _, pluginID := range pluginIDs {
-					if plugin, err := s.pluginRepo.GetByID(pluginID); err == nil {
-						subscribedPlugins = append(subscribedPlugins, PluginResponse{
-							ID:                 plugin.ID,
-							Name:               plugin.Name,
-							Title:              plugin.Title,
-							Description:        plugin.Description,
-							Icon:               plugin.Icon,
-							ReactComponentPath: plugin.ReactComponentPath,
-							BackendServerURL:   plugin.BackendServerURL,
-							Owner:              plugin.Owner,
-						})
-					}
-				}
-			}
+	// Fetch plugin details for each subscribed plugin
+	for _, pluginID := range s.GetSubscribedPluginIDsFromUser(user) {
+		if plugin, err := s.pluginRepo.GetByID(pluginID); err == nil {
+			subscribedPlugins = append(subscribedPlugins, toUserPluginResponse(plugin))
 		}
 	}
 	return subscribedPlugins
@@ -564,7 +957,7 @@ func (s *UserService) GetUserByNameWithLinksAndPlugins(name string) (*UserWithLi
 	user, err := s.repo.GetByName(name)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by name")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
 
 	// Get the user with links first
@@ -588,49 +981,78 @@ func (s *UserService) GetUserByUserIDWithLinks(userID string) (*UserWithLinksAnd
 	user, err := s.repo.GetByUserID(userID)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by userID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
 
-	// Portal admin flag computed from metadata
-	portalAdmin := false
+	// Plugins are empty here, they should be fetched separately if needed
+	return s.newUserWithLinksAndPluginsResponse(user, s.resolveUserLinks(user), []PluginResponse{}), nil
+}
+
+// GetUserByUserIDWithLinksAndPlugins returns a user with links and subscribed plugins by their UserID.
+// The user is looked up once; links and plugins are then each resolved with a single batched query, concurrently.
+func (s *UserService) GetUserByUserIDWithLinksAndPlugins(userID string) (*UserWithLinksAndPluginsResponse, error) {
+	if userID == "" {
+		return nil, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+
+	user, err := s.repo.GetByUserID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return nil, userLookupError(err)
+	}
+
+	var links []LinkResponse
+	var plugins []PluginResponse
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		links = s.resolveUserLinks(user)
+	}()
+	go func() {
+		defer wg.Done()
+		plugins = s.resolveSubscribedPlugins(user)
+	}()
+	wg.Wait()
 
-	// Parse favorites from metadata
+	return s.newUserWithLinksAndPluginsResponse(user, links, plugins), nil
+}
+
+// getFavoriteLinkIDsFromUser parses the link IDs in user metadata.favorites
+func getFavoriteLinkIDsFromUser(user *models.User) map[uuid.UUID]struct{} {
 	favSet := make(map[uuid.UUID]struct{})
-	if len(user.Metadata) > 0 {
-		var meta map[string]interface{}
-		if err := json.Unmarshal(user.Metadata, &meta); err == nil && meta != nil {
-			if v, ok := meta["favorites"]; ok && v != nil {
-				switch arr := v.(type) {
-				case []interface{}:
-					for _, it := range arr {
-						if s, ok := it.(string); ok && s != "" {
-							if id, err := uuid.Parse(strings.TrimSpace(s)); err == nil {
-								favSet[id] = struct{}{}
-							}
-						}
-					}
-				case []string:
-					for _, s2 := range arr {
-						if id, err := uuid.Parse(strings.TrimSpace(s2)); err == nil {
-							favSet[id] = struct{}{}
-						}
-					}
+	if len(user.Metadata) == 0 {
+		return favSet
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+		return favSet
+	}
+
+	switch arr := meta["favorites"].(type) {
+	case []interface{}:
+		for _, it := range arr {
+			if str, ok := it.(string); ok && str != "" {
+				if id, err := uuid.Parse(strings.TrimSpace(str)); err == nil {
+					favSet[id] = struct{}{}
 				}
 			}
-			// Compute portal_admin flag (supports bool, string, numeric)
-			if v, ok := meta["portal_admin"]; ok && v != nil {
-				switch val := v.(type) {
-				case bool:
-					portalAdmin = val
-				case string:
-					trim := strings.TrimSpace(val)
-					portalAdmin = strings.EqualFold(trim, "true") || trim == "1" || strings.EqualFold(trim, "yes")
-				case float64:
-					portalAdmin = val != 0
-				}
+		}
+	case []string:
+		for _, str := range arr {
+			if id, err := uuid.Parse(strings.TrimSpace(str)); err == nil {
+				favSet[id] = struct{}{}
 			}
 		}
 	}
+	return favSet
+}
+
+// resolveUserLinks merges the user's favorite and owned links, flagging the favorites
+func (s *UserService) resolveUserLinks(user *models.User) []LinkResponse {
+	favSet := getFavoriteLinkIDsFromUser(user)
 
 	// Collect favorite IDs
 	favIDs := make([]uuid.UUID, 0, len(favSet))
@@ -660,25 +1082,133 @@ func (s *UserService) GetUserByUserIDWithLinks(userID string) (*UserWithLinksAnd
 		}
 		links = append(links, lr)
 	}
+	return links
+}
+
+// resolveSubscribedPlugins fetches the user's subscribed plugins in one query, keeping the subscription order.
+// Plugins that no longer exist are skipped.
+func (s *UserService) resolveSubscribedPlugins(user *models.User) []PluginResponse {
+	subscribedPlugins, _, err := s.splitSubscribedPlugins(s.GetSubscribedPluginIDsFromUser(user))
+	if err != nil {
+		logger.New().WithField("error", err).Warn("Failed to get subscribed plugins")
+		return make([]PluginResponse, 0)
+	}
+	return subscribedPlugins
+}
+
+// GetSubscribedPlugins returns the subscribed plugins of a user identified by user_id along with the subscribed
+// IDs that no longer resolve to a plugin, so the caller can offer to unsubscribe from them
+func (s *UserService) GetSubscribedPlugins(userID string) (*SubscribedPluginsResponse, error) {
+	if userID == "" {
+		return nil, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+
+	user, err := s.repo.GetByUserID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return nil, userLookupError(err)
+	}
+
+	plugins, unresolved, err := s.splitSubscribedPlugins(s.GetSubscribedPluginIDsFromUser(user))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscribed plugins: %w", err)
+	}
+	return &SubscribedPluginsResponse{Plugins: plugins, UnresolvedIDs: unresolved}, nil
+}
+
+// splitSubscribedPlugins resolves plugin IDs with a single batched query, keeping the subscription order,
+// and returns the IDs that have no matching plugin separately
+func (s *UserService) splitSubscribedPlugins(pluginIDs []uuid.UUID) ([]PluginResponse, []uuid.UUID, error) {
+	resolved := make([]PluginResponse, 0, len(pluginIDs))
+	unresolved := make([]uuid.UUID, 0)
+	if len(pluginIDs) == 0 {
+		return resolved, unresolved, nil
+	}
+
+	plugins, err := s.pluginRepo.GetByIDs(pluginIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := make(map[uuid.UUID]*models.Plugin, len(plugins))
+	for i := range plugins {
+		byID[plugins[i].ID] = &plugins[i]
+	}
+	for _, pluginID := range pluginIDs {
+		if plugin, ok := byID[pluginID]; ok {
+			resolved = append(resolved, toUserPluginResponse(plugin))
+		} else {
+			unresolved = append(unresolved, pluginID)
+		}
+	}
+	return resolved, unresolved, nil
+}
+
+// toUserPluginResponse converts a plugin model to the response embedded in user details
+func toUserPluginResponse(plugin *models.Plugin) PluginResponse {
+	return PluginResponse{
+		ID:                 plugin.ID,
+		Name:               plugin.Name,
+		Title:              plugin.Title,
+		Description:        plugin.Description,
+		Icon:               plugin.Icon,
+		ReactComponentPath: plugin.ReactComponentPath,
+		BackendServerURL:   plugin.BackendServerURL,
+		Owner:              plugin.Owner,
+	}
+}
 
-	resp := &UserWithLinksAndPluginsResponse{
-		ID:          user.UserID,
-		UUID:        user.ID.String(),
-		TeamID:      user.TeamID,
-		FirstName:   user.FirstName,
-		LastName:    user.LastName,
-		Email:       user.Email,
-		Mobile:      user.Mobile,
-		TeamDomain:  string(user.TeamDomain),
-		TeamRole:    string(user.TeamRole),
-		PortalAdmin: portalAdmin,
+// newUserWithLinksAndPluginsResponse builds the user details response from resolved links and plugins
+func (s *UserService) newUserWithLinksAndPluginsResponse(user *models.User, links []LinkResponse, plugins []PluginResponse) *UserWithLinksAndPluginsResponse {
+	base := toUserResponse(user)
+	return &UserWithLinksAndPluginsResponse{
+		ID:          base.ID,
+		UUID:        base.UUID,
+		TeamID:      base.TeamID,
+		FirstName:   base.FirstName,
+		LastName:    base.LastName,
+		Email:       base.Email,
+		Mobile:      base.Mobile,
+		TeamDomain:  base.TeamDomain,
+		TeamRole:    base.TeamRole,
+		PortalAdmin: s.IsPortalAdmin(user), // Portal admin flag computed from metadata
 		Links:       links,
-		Plugins:     []PluginResponse{}, // Empty array, plugins should be fetched separately if needed
+		Plugins:     plugins,
+	}
+}
+
+// IsPortalAdmin reports whether the user's portal_admin metadata grants admin rights.
+// Accepts a true bool, a non-zero number, or a non-empty string other than "false"/"0".
+func (s *UserService) IsPortalAdmin(user *models.User) bool {
+	return isPortalAdmin(user)
+}
+
+// isPortalAdmin reads the portal_admin flag from user metadata, as described on IsPortalAdmin
+func isPortalAdmin(user *models.User) bool {
+	if user == nil || len(user.Metadata) == 0 {
+		return false
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+		return false
+	}
+
+	switch val := meta["portal_admin"].(type) {
+	case bool:
+		return val
+	case float64:
+		return val != 0
+	case string:
+		trim := strings.TrimSpace(val)
+		return trim != "" && !strings.EqualFold(trim, "false") && trim != "0"
+	default:
+		return false
 	}
-	return resp, nil
 }
 
 func (s *UserService) GetAllUsers(limit, offset int) ([]UserResponse, int64, error) {
+	limit = ClampPageSize(limit)
 	users, total, err := s.repo.GetAll(limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get users: %w", err)
@@ -686,22 +1216,86 @@ func (s *UserService) GetAllUsers(limit, offset int) ([]UserResponse, int64, err
 
 	responses := make([]UserResponse, len(users))
 	for i, user := range users {
-		responses[i] = *s.convertToResponse(&user)
+		responses[i] = *toUserResponse(&user)
 	}
 
 	return responses, total, nil
 }
 
-// SearchUsersGlobal performs case-insensitive search across BaseModel.Name and BaseModel.Title
-func (s *UserService) SearchUsersGlobal(query string, limit, offset int) ([]UserResponse, int64, error) {
-	users, total, err := s.repo.SearchByNameOrTitleGlobal(query, limit, offset)
+// GetAllUsersAfter retrieves users following an opaque cursor returned by a previous call; an empty cursor
+// starts from the beginning. Unlike GetAllUsers, concurrent inserts and deletes do not duplicate or skip rows.
+func (s *UserService) GetAllUsersAfter(cursor string, limit int) (*UsersCursorPage, error) {
+	limit = ClampPageSize(limit)
+
+	var createdAt time.Time
+	var id uuid.UUID
+	if cursor != "" {
+		var err error
+		if createdAt, id, err = decodeUserCursor(cursor); err != nil {
+			return nil, apperrors.NewValidationError("cursor", "invalid cursor")
+		}
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	users, err := s.repo.GetAllAfter(createdAt, id, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	page := &UsersCursorPage{}
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[len(users)-1]
+		page.NextCursor = encodeUserCursor(last.CreatedAt, last.ID)
+	}
+
+	page.Users = make([]UserResponse, len(users))
+	for i, user := range users {
+		page.Users[i] = *toUserResponse(&user)
+	}
+
+	return page, nil
+}
+
+// encodeUserCursor encodes a (created_at, id) keyset as an opaque URL-safe cursor
+func encodeUserCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeUserCursor reverses encodeUserCursor
+func decodeUserCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	createdAtStr, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, fmt.Errorf("malformed cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	return createdAt, id, nil
+}
+
+// SearchUsersGlobal performs case-insensitive search across BaseModel.Name, BaseModel.Title and email.
+// When emailOnly is true only the email is matched.
+func (s *UserService) SearchUsersGlobal(query string, emailOnly bool, limit, offset int) ([]UserResponse, int64, error) {
+	users, total, err := s.repo.SearchByNameOrTitleGlobal(query, emailOnly, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to search users: %w", err)
 	}
 
 	responses := make([]UserResponse, len(users))
 	for i, user := range users {
-		responses[i] = *s.convertToResponse(&user)
+		responses[i] = *toUserResponse(&user)
 	}
 
 	return responses, total, nil
@@ -709,6 +1303,7 @@ func (s *UserService) SearchUsersGlobal(query string, limit, offset int) ([]User
 
 // GetMembersByOrganization retrieves members for an organization
 func (s *UserService) GetUsersByOrganization(organizationID uuid.UUID, limit, offset int) ([]UserResponse, int64, error) {
+	limit = ClampPageSize(limit)
 	users, total, err := s.repo.GetByOrganizationID(organizationID, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get users: %w", err)
@@ -716,7 +1311,22 @@ func (s *UserService) GetUsersByOrganization(organizationID uuid.UUID, limit, of
 
 	responses := make([]UserResponse, len(users))
 	for i, user := range users {
-		responses[i] = *s.convertToResponse(&user)
+		responses[i] = *toUserResponse(&user)
+	}
+
+	return responses, total, nil
+}
+
+// GetUsersByTeam retrieves members of a single team
+func (s *UserService) GetUsersByTeam(teamID uuid.UUID, limit, offset int) ([]UserResponse, int64, error) {
+	users, total, err := s.repo.GetByTeamID(teamID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get team users: %w", err)
+	}
+
+	responses := make([]UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = *toUserResponse(&user)
 	}
 
 	return responses, total, nil
@@ -726,14 +1336,15 @@ func (s *UserService) GetUsersByOrganization(organizationID uuid.UUID, limit, of
 func (s *UserService) UpdateUser(id uuid.UUID, req *UpdateUserRequest) (*UserResponse, error) {
 	// Validate request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 
 	user, err := s.repo.GetByID(id)
 	if err != nil {
 		logger.New().WithField("error", err).Error("Error getting user by id")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
+	before := userEventFields(user)
 
 	// Check email uniqueness if email is being updated
 	if req.Email != nil && *req.Email != user.Email {
@@ -754,7 +1365,12 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *UpdateUserRequest) (*UserRes
 		user.LastName = *req.LastName
 	}
 	if req.Email != nil {
-		user.Email = *req.Email
+		// A changed email stays pending until confirmed via ConfirmEmailChange
+		if *req.Email != user.Email {
+			user.PendingEmail = *req.Email
+		} else {
+			user.PendingEmail = ""
+		}
 	}
 	if req.Mobile != nil {
 		user.Mobile = *req.Mobile
@@ -769,58 +1385,234 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *UpdateUserRequest) (*UserRes
 	if err := s.repo.Update(user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
+	s.publishUserChanges(user.ID, before, user)
+	if req.TeamID != nil || req.TeamDomain != nil || req.TeamRole != nil {
+		s.invalidateUserCaches(user)
+	}
 
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
 }
 
-// UpdateUserTeam sets a user's team and audit fields
+// UpdateUserTeam sets a user's team and audit fields; the target team must exist
 func (s *UserService) UpdateUserTeam(userID uuid.UUID, teamID uuid.UUID, updatedBy string) (*UserResponse, error) {
 	if strings.TrimSpace(updatedBy) == "" {
-		return nil, fmt.Errorf("updated_by is required")
+		return nil, apperrors.NewValidationError("updated_by", "updated_by is required")
 	}
 	user, err := s.repo.GetByID(userID)
 	if err != nil || user == nil {
 		logger.New().WithField("error", err).Error("Error getting user by userID")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
+	}
+	if err := s.ensureTeamExists(teamID); err != nil {
+		return nil, err
 	}
+	before := userEventFields(user)
 	user.TeamID = &teamID
 	user.UpdatedBy = updatedBy
 	if err := s.repo.Update(user); err != nil {
 		return nil, fmt.Errorf("failed to update user team: %w", err)
 	}
-	return s.convertToResponse(user), nil
+	s.publishUserChanges(user.ID, before, user)
+	s.invalidateUserCaches(user)
+	return toUserResponse(user), nil
+}
+
+// ChangeUserRole sets a user's team domain and team role and records who made the change
+func (s *UserService) ChangeUserRole(userID uuid.UUID, newDomain, newRole string, changedBy string) (*UserResponse, error) {
+	if strings.TrimSpace(changedBy) == "" {
+		return nil, apperrors.NewValidationError("changed_by", "changed_by is required")
+	}
+	domain := models.TeamDomain(newDomain)
+	if !domain.IsValid() {
+		return nil, apperrors.NewValidationError("role", fmt.Sprintf("unknown role %q", newDomain))
+	}
+	role := models.TeamRole(newRole)
+	if !role.IsValid() {
+		return nil, apperrors.NewValidationError("team_role", fmt.Sprintf("unknown team role %q", newRole))
+	}
+
+	user, err := s.repo.GetByID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by id")
+		return nil, userLookupError(err)
+	}
+	before := userEventFields(user)
+	user.TeamDomain = domain
+	user.TeamRole = role
+	user.UpdatedBy = changedBy
+	if err := s.repo.Update(user); err != nil {
+		return nil, fmt.Errorf("failed to change user role: %w", err)
+	}
+	s.publishUserChanges(user.ID, before, user)
+	s.invalidateUserCaches(user)
+	return toUserResponse(user), nil
+}
+
+// RemoveUserFromTeam clears a user's team assignment and records who made the change.
+// Unassigning a user without a team succeeds without writing to the repository.
+func (s *UserService) RemoveUserFromTeam(userID uuid.UUID, updatedBy string) (*UserResponse, error) {
+	if strings.TrimSpace(updatedBy) == "" {
+		return nil, apperrors.NewValidationError("updated_by", "updated_by is required")
+	}
+	user, err := s.repo.GetByID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return nil, userLookupError(err)
+	}
+	if user.TeamID == nil {
+		return toUserResponse(user), nil
+	}
+	before := userEventFields(user)
+	user.TeamID = nil
+	user.UpdatedBy = updatedBy
+	if err := s.repo.Update(user); err != nil {
+		return nil, fmt.Errorf("failed to remove user from team: %w", err)
+	}
+	s.publishUserChanges(user.ID, before, user)
+	s.invalidateUserCaches(user)
+	return toUserResponse(user), nil
 }
 
 // DeleteMember deletes a
 func (s *UserService) DeleteUser(id uuid.UUID) error {
-	_, err := s.repo.GetByID(id)
+	user, err := s.repo.GetByID(id)
 	if err != nil {
 		logger.New().WithField("error", err).Error("Error getting user by id")
-		return apperrors.ErrUserNotFound
+		return userLookupError(err)
 	}
 
 	if err := s.repo.Delete(id); err != nil {
 		return fmt.Errorf("failed to delete member: %w", err)
 	}
+	s.publishUserEvent(EventUserDeleted, id, diffFields(userEventFields(user), nil))
 
 	return nil
 }
 
+// ConfirmEmailChange promotes a user's pending email to their active email
+func (s *UserService) ConfirmEmailChange(id uuid.UUID) (*UserResponse, error) {
+	user, err := s.repo.GetByID(id)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by id")
+		return nil, userLookupError(err)
+	}
+
+	if user.PendingEmail == "" {
+		return nil, apperrors.ErrNoPendingEmailChange
+	}
+
+	// The address may have been taken since the change was requested
+	if existingUser, err := s.repo.GetByEmail(user.PendingEmail); err == nil && existingUser != nil && existingUser.ID != user.ID {
+		return nil, apperrors.ErrUserExists
+	}
+
+	before := userEventFields(user)
+	user.Email = user.PendingEmail
+	user.PendingEmail = ""
+	if err := s.repo.Update(user); err != nil {
+		return nil, fmt.Errorf("failed to confirm email change: %w", err)
+	}
+	s.publishUserChanges(user.ID, before, user)
+
+	return toUserResponse(user), nil
+}
+
+// RestoreUser restores a soft-deleted user
+func (s *UserService) RestoreUser(id uuid.UUID) (*UserResponse, error) {
+	user, err := s.repo.GetByIDIncludingDeleted(id)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by id")
+		return nil, userLookupError(err)
+	}
+
+	if user.DeletedAt.Valid {
+		// The email may have been reused by a new user since this one was deleted
+		if existingUser, err := s.repo.GetByEmail(user.Email); err == nil && existingUser != nil && existingUser.ID != user.ID {
+			return nil, apperrors.ErrUserExists
+		}
+		before := userEventFields(user)
+		if err := s.repo.Restore(id); err != nil {
+			return nil, fmt.Errorf("failed to restore member: %w", err)
+		}
+		user.DeletedAt.Valid = false
+		s.publishUserChanges(user.ID, before, user)
+	}
+
+	return toUserResponse(user), nil
+}
+
 // SearchMembers searches for members by first/last name or email
-func (s *UserService) SearchUsers(organizationID uuid.UUID, query string, limit, offset int) ([]UserResponse, int64, error) {
-	users, total, err := s.repo.SearchByOrganization(organizationID, query, limit, offset)
+func (s *UserService) SearchUsers(organizationID uuid.UUID, query string, emailOnly bool, limit, offset int) ([]UserResponse, int64, error) {
+	limit = ClampPageSize(limit)
+	users, total, err := s.repo.SearchByOrganization(organizationID, query, emailOnly, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	responses := make([]UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = *toUserResponse(&user)
+	}
+
+	return responses, total, nil
+}
+
+// SearchUsersByOwnedOrg searches the members of an organization's teams, resolving the organization's
+// groups and their teams first. It requires the organization repositories.
+func (s *UserService) SearchUsersByOwnedOrg(organizationID uuid.UUID, query string, limit, offset int) ([]UserResponse, int64, error) {
+	if s.teamRepo == nil || s.groupRepo == nil {
+		return nil, 0, apperrors.ErrOrgReposNotConfigured
+	}
+
+	teamIDs, err := s.organizationTeamIDs(organizationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit = ClampPageSize(limit)
+	users, total, err := s.repo.SearchByTeamIDs(teamIDs, query, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to search users: %w", err)
 	}
 
 	responses := make([]UserResponse, len(users))
 	for i, user := range users {
-		responses[i] = *s.convertToResponse(&user)
+		responses[i] = *toUserResponse(&user)
 	}
 
 	return responses, total, nil
 }
 
+// organizationTeamIDs returns the IDs of all teams in an organization's groups, paging through both
+func (s *UserService) organizationTeamIDs(organizationID uuid.UUID) ([]uuid.UUID, error) {
+	teamIDs := make([]uuid.UUID, 0)
+	for groupOffset := 0; ; groupOffset += orgExpansionPageSize {
+		groups, totalGroups, err := s.groupRepo.GetByOrganizationID(organizationID, orgExpansionPageSize, groupOffset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get organization groups: %w", err)
+		}
+
+		for _, group := range groups {
+			for teamOffset := 0; ; teamOffset += orgExpansionPageSize {
+				teams, totalTeams, err := s.teamRepo.GetByGroupID(group.ID, orgExpansionPageSize, teamOffset)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get group teams: %w", err)
+				}
+				for _, team := range teams {
+					teamIDs = append(teamIDs, team.ID)
+				}
+				if len(teams) < orgExpansionPageSize || int64(teamOffset+len(teams)) >= totalTeams {
+					break
+				}
+			}
+		}
+
+		if len(groups) < orgExpansionPageSize || int64(groupOffset+len(groups)) >= totalGroups {
+			return teamIDs, nil
+		}
+	}
+}
+
 // GetActiveMembers returns all members for an organization (is_active removed from model)
 func (s *UserService) GetActiveUsers(organizationID uuid.UUID, limit, offset int) ([]UserResponse, int64, error) {
 	users, total, err := s.repo.GetActiveByOrganization(organizationID, limit, offset)
@@ -830,39 +1622,95 @@ func (s *UserService) GetActiveUsers(organizationID uuid.UUID, limit, offset int
 
 	responses := make([]UserResponse, len(users))
 	for i, user := range users {
-		responses[i] = *s.convertToResponse(&user)
+		responses[i] = *toUserResponse(&user)
 	}
 
 	return responses, total, nil
 }
 
-// convertToResponse converts a member model to response
-func (s *UserService) convertToResponse(user *models.User) *UserResponse {
-	return &UserResponse{
-		ID:         user.UserID,
-		UUID:       user.ID.String(),
-		TeamID:     user.TeamID,
-		FirstName:  user.FirstName,
-		LastName:   user.LastName,
-		Email:      user.Email,
-		Mobile:     user.Mobile,
-		TeamDomain: string(user.TeamDomain),
-		TeamRole:   string(user.TeamRole),
+// GetActiveUsersSince returns the members of an organization active after since, i.e. whose record
+// was updated after the cutoff, most recently active first
+func (s *UserService) GetActiveUsersSince(organizationID uuid.UUID, since time.Time, limit, offset int) ([]UserResponse, int64, error) {
+	users, total, err := s.repo.GetActiveByOrganizationSince(organizationID, since, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get active users: %w", err)
 	}
+
+	responses := make([]UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = *toUserResponse(&user)
+	}
+
+	return responses, total, nil
+}
+
+// CountUsers returns the total number of users
+func (s *UserService) CountUsers() (int64, error) {
+	count, err := s.repo.Count()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
 }
 
-// convertToResponseWithPlugins converts a member model to response with subscribed plugins fetched
-func (s *UserService) convertToResponseWithPlugins(user *models.User) *UserResponse {
+// CountActiveUsers returns the number of active users in an organization (is_active removed from model)
+func (s *UserService) CountActiveUsers(organizationID uuid.UUID) (int64, error) {
+	count, err := s.repo.CountByOrganization(organizationID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active users: %w", err)
+	}
+	return count, nil
+}
+
+// CountUsersByDomain returns the number of users in an organization keyed by team domain
+func (s *UserService) CountUsersByDomain(organizationID uuid.UUID) (map[string]int64, error) {
+	counts, err := s.repo.CountByDomain(organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users by domain: %w", err)
+	}
+	return counts, nil
+}
+
+// toUserResponse converts a member model to response. This is the single mapping from models.User to
+// UserResponse. Stored first and last names are used when both are populated; otherwise the names are
+// derived from the display name (BaseModel.Title) via splitDisplayName, keeping the stored values when
+// there is no display name either.
+func toUserResponse(user *models.User) *UserResponse {
+	firstName := strings.TrimSpace(user.FirstName)
+	lastName := strings.TrimSpace(user.LastName)
+	if firstName == "" || lastName == "" {
+		if first, last := splitDisplayName(user.Title); first != "" {
+			firstName, lastName = first, last
+		}
+	}
+
 	return &UserResponse{
-		ID:         user.UserID,
-		UUID:       user.ID.String(),
-		TeamID:     user.TeamID,
-		FirstName:  user.FirstName,
-		LastName:   user.LastName,
-		Email:      user.Email,
-		Mobile:     user.Mobile,
-		TeamDomain: string(user.TeamDomain),
-		TeamRole:   string(user.TeamRole),
+		ID:           user.UserID,
+		UUID:         user.ID.String(),
+		TeamID:       user.TeamID,
+		FirstName:    firstName,
+		LastName:     lastName,
+		Email:        user.Email,
+		PendingEmail: user.PendingEmail,
+		Mobile:       user.Mobile,
+		TeamDomain:   string(user.TeamDomain),
+		TeamRole:     string(user.TeamRole),
+	}
+}
+
+// splitDisplayName splits a display name into first and last name: the first whitespace-separated token
+// is the first name and the remainder is the last name, so "Mary Jane Watson" yields "Mary" and
+// "Jane Watson" and "Jean-Luc de la Fontaine" yields "Jean-Luc" and "de la Fontaine". Repeated whitespace
+// is collapsed, and a single token is returned as the first name only.
+func splitDisplayName(name string) (firstName, lastName string) {
+	fields := strings.Fields(name)
+	switch len(fields) {
+	case 0:
+		return "", ""
+	case 1:
+		return fields[0], ""
+	default:
+		return fields[0], strings.Join(fields[1:], " ")
 	}
 }
 
@@ -894,7 +1742,7 @@ func (s *UserService) GetQuickLinks(id uuid.UUID) (*QuickLinksResponse, error) {
 	// Validate member exists
 	if _, err := s.repo.GetByID(id); err != nil {
 		logger.New().WithField("error", err).Error("Error getting user by name")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
 	return &QuickLinksResponse{QuickLinks: []QuickLink{}}, nil
 }
@@ -903,14 +1751,14 @@ func (s *UserService) GetQuickLinks(id uuid.UUID) (*QuickLinksResponse, error) {
 func (s *UserService) AddQuickLink(id uuid.UUID, req *AddQuickLinkRequest) (*UserResponse, error) {
 	// Validate request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, requestValidationError(req, err)
 	}
 	user, err := s.repo.GetByID(id)
 	if err != nil {
 		logger.New().WithField("error", err).Error("Error getting user by id")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
 }
 
 // RemoveQuickLink removes a quick link (no-op; returns member unchanged)
@@ -921,7 +1769,7 @@ func (s *UserService) RemoveQuickLink(id uuid.UUID, linkURL string) (*UserRespon
 	user, err := s.repo.GetByID(id)
 	if err != nil {
 		logger.New().WithField("error", err).Error("Error getting user by id")
-		return nil, apperrors.ErrUserNotFound
+		return nil, userLookupError(err)
 	}
-	return s.convertToResponse(user), nil
+	return toUserResponse(user), nil
 }
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToUserResponse_Names(t *testing.T) {
+	tests := []struct {
+		name          string
+		firstName     string
+		lastName      string
+		displayName   string
+		wantFirstName string
+		wantLastName  string
+	}{
+		{name: "explicit first and last name preferred over display name", firstName: "Mary Jane", lastName: "Watson", displayName: "Mary Jane Watson", wantFirstName: "Mary Jane", wantLastName: "Watson"},
+		{name: "stored names are trimmed", firstName: "  John ", lastName: " Doe  ", wantFirstName: "John", wantLastName: "Doe"},
+		{name: "only first name stored falls back to display name", firstName: "Cher", displayName: "Cher Sarkisian", wantFirstName: "Cher", wantLastName: "Sarkisian"},
+		{name: "only first name stored without display name", firstName: "Cher", wantFirstName: "Cher"},
+		{name: "two tokens", displayName: "John Doe", wantFirstName: "John", wantLastName: "Doe"},
+		{name: "three tokens", displayName: "Mary Jane Watson", wantFirstName: "Mary", wantLastName: "Jane Watson"},
+		{name: "hyphenated first name and particles", displayName: "Jean-Luc de la Fontaine", wantFirstName: "Jean-Luc", wantLastName: "de la Fontaine"},
+		{name: "extra spaces", displayName: "  Jane    van   Doe ", wantFirstName: "Jane", wantLastName: "van Doe"},
+		{name: "single token", displayName: "Madonna", wantFirstName: "Madonna"},
+		{name: "whitespace-only names", firstName: " ", lastName: "\t", displayName: "   "},
+		{name: "everything empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &models.User{
+				BaseModel: models.BaseModel{ID: uuid.New(), Title: tt.displayName},
+				FirstName: tt.firstName,
+				LastName:  tt.lastName,
+			}
+
+			resp := toUserResponse(user)
+
+			assert.Equal(t, tt.wantFirstName, resp.FirstName)
+			assert.Equal(t, tt.wantLastName, resp.LastName)
+		})
+	}
+}
+
+func TestToUserResponse_Fields(t *testing.T) {
+	teamID := uuid.New()
+	user := &models.User{
+		BaseModel:    models.BaseModel{ID: uuid.New()},
+		TeamID:       &teamID,
+		UserID:       "I123456",
+		FirstName:    "John",
+		LastName:     "Doe",
+		Email:        "john.doe@example.com",
+		PendingEmail: "john.new@example.com",
+		Mobile:       "+1-555-0123",
+		TeamDomain:   models.TeamDomainDevOps,
+		TeamRole:     models.TeamRoleManager,
+	}
+
+	assert.Equal(t, &UserResponse{
+		ID:           "I123456",
+		UUID:         user.ID.String(),
+		TeamID:       &teamID,
+		FirstName:    "John",
+		LastName:     "Doe",
+		Email:        "john.doe@example.com",
+		PendingEmail: "john.new@example.com",
+		Mobile:       "+1-555-0123",
+		TeamDomain:   "devops",
+		TeamRole:     "manager",
+	}, toUserResponse(user))
+}
@@ -0,0 +1,487 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// ValidateMetadata checks the types of the known user metadata keys: favorites and subscribed must be
+// arrays of UUID strings, ai_instances an array of strings and portal_admin a bool, number or string.
+// Unknown keys are allowed with any value. Empty metadata is valid.
+func ValidateMetadata(raw json.RawMessage) error {
+	meta, err := parseMetadataObject(raw)
+	if err != nil || meta == nil {
+		return err
+	}
+
+	for _, key := range []string{"favorites", "subscribed", "ai_instances", "portal_admin"} {
+		if err := validateMetadataKey(meta, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateMetadataKey checks only the given key of the metadata, with the same rules as ValidateMetadata.
+// Writes to one key use it so that an unrelated legacy or invalid key does not reject them.
+func ValidateMetadataKey(raw json.RawMessage, key string) error {
+	meta, err := parseMetadataObject(raw)
+	if err != nil || meta == nil {
+		return err
+	}
+	return validateMetadataKey(meta, key)
+}
+
+// parseMetadataObject decodes metadata as a JSON object; empty metadata yields nil
+func parseMetadataObject(raw json.RawMessage) (map[string]interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, fmt.Errorf("%w: metadata must be a JSON object", apperrors.ErrInvalidMetadata)
+	}
+	return meta, nil
+}
+
+// validateMetadataKey checks the type of one known metadata key; unknown keys are always valid
+func validateMetadataKey(meta map[string]interface{}, key string) error {
+	switch key {
+	case "favorites", "subscribed":
+		return validateMetadataStrings(meta, key, true)
+	case "ai_instances":
+		return validateMetadataStrings(meta, key, false)
+	case "portal_admin":
+		switch meta[key].(type) {
+		case nil, bool, float64, string:
+		default:
+			return fmt.Errorf("%w: portal_admin must be a boolean, number or string", apperrors.ErrInvalidMetadata)
+		}
+	}
+	return nil
+}
+
+// validateMetadataStrings checks that meta[key], when present and non-null, is an array of strings,
+// each of which must parse as a UUID if requireUUID is set
+func validateMetadataStrings(meta map[string]interface{}, key string, requireUUID bool) error {
+	value, ok := meta[key]
+	if !ok || value == nil {
+		return nil
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %s must be an array", apperrors.ErrInvalidMetadata, key)
+	}
+	for i, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s[%d] must be a string", apperrors.ErrInvalidMetadata, key, i)
+		}
+		if requireUUID {
+			if _, err := uuid.Parse(str); err != nil {
+				return fmt.Errorf("%w: %s[%d] must be a UUID", apperrors.ErrInvalidMetadata, key, i)
+			}
+		}
+	}
+	return nil
+}
+
+// uuidListMetadataKeys are the metadata keys holding arrays of UUID strings
+var uuidListMetadataKeys = []string{"favorites", "subscribed"}
+
+// MetadataRepairReport describes the outcome of RepairUserMetadata
+type MetadataRepairReport struct {
+	UserID    string              `json:"user_id"`
+	Repaired  bool                `json:"repaired"`  // metadata was invalid and has been rewritten
+	Reset     bool                `json:"reset"`     // metadata was not parseable JSON; only salvaged arrays were kept
+	Recovered map[string][]string `json:"recovered"` // entries kept, per key
+	Dropped   map[string][]string `json:"dropped"`   // entries discarded, per key
+}
+
+// RepairUserMetadata rewrites a user's metadata so it passes ValidateMetadata, salvaging as much as possible:
+// valid UUIDs are kept from favorites/subscribed (even from unparseable JSON), invalid entries are dropped
+// and unknown keys are preserved. Metadata that is already valid is left untouched.
+func (s *UserService) RepairUserMetadata(userID string) (*MetadataRepairReport, error) {
+	if userID == "" {
+		return nil, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+
+	user, err := s.repo.GetByUserID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return nil, userLookupError(err)
+	}
+
+	report := &MetadataRepairReport{
+		UserID:    userID,
+		Recovered: map[string][]string{},
+		Dropped:   map[string][]string{},
+	}
+	if ValidateMetadata(user.Metadata) == nil {
+		return report, nil
+	}
+
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+		meta = salvageMetadata(user.Metadata, report)
+		report.Reset = true
+	} else {
+		repairMetadataFields(meta, report)
+	}
+
+	repaired, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if err := ValidateMetadata(repaired); err != nil {
+		return nil, err
+	}
+
+	before := userEventFields(user)
+	user.Metadata = json.RawMessage(repaired)
+	if err := s.repo.Update(user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	s.publishUserChanges(user.ID, before, user)
+	report.Repaired = true
+
+	logger.New().WithFields(map[string]interface{}{
+		"user_id":   userID,
+		"reset":     report.Reset,
+		"recovered": report.Recovered,
+		"dropped":   report.Dropped,
+	}).Warn("Repaired invalid user metadata")
+
+	return report, nil
+}
+
+// repairMetadataFields fixes the known keys of a parsed metadata object in place
+func repairMetadataFields(meta map[string]json.RawMessage, report *MetadataRepairReport) {
+	for _, key := range uuidListMetadataKeys {
+		if raw, ok := meta[key]; ok {
+			kept := repairStringList(key, raw, true, report)
+			meta[key] = mustMarshalStrings(kept)
+		}
+	}
+
+	if raw, ok := meta["ai_instances"]; ok {
+		kept := repairStringList("ai_instances", raw, false, report)
+		meta["ai_instances"] = mustMarshalStrings(kept)
+	}
+
+	if raw, ok := meta["portal_admin"]; ok {
+		var value interface{}
+		_ = json.Unmarshal(raw, &value)
+		switch value.(type) {
+		case nil, bool, float64, string:
+		default:
+			report.Dropped["portal_admin"] = append(report.Dropped["portal_admin"], string(raw))
+			delete(meta, "portal_admin")
+		}
+	}
+}
+
+// repairStringList keeps the string entries of a metadata value (a single string is treated as a one-element
+// array), requiring them to be UUIDs if requireUUID is set, and records every entry as recovered or dropped
+func repairStringList(key string, raw json.RawMessage, requireUUID bool, report *MetadataRepairReport) []string {
+	var value interface{}
+	_ = json.Unmarshal(raw, &value)
+
+	var items []interface{}
+	switch v := value.(type) {
+	case nil:
+		return []string{}
+	case []interface{}:
+		items = v
+	default:
+		items = []interface{}{v}
+	}
+
+	kept := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		str, ok := item.(string)
+		if ok && requireUUID {
+			_, err := uuid.Parse(str)
+			ok = err == nil
+		}
+		if !ok {
+			encoded, _ := json.Marshal(item)
+			report.Dropped[key] = append(report.Dropped[key], string(encoded))
+			continue
+		}
+		if !seen[str] {
+			seen[str] = true
+			kept = append(kept, str)
+			report.Recovered[key] = append(report.Recovered[key], str)
+		}
+	}
+	return kept
+}
+
+// salvageMetadata recovers UUID entries of the favorites/subscribed arrays from metadata that is not valid JSON.
+// Each array is read from its opening bracket up to the closing bracket, or the end of input if truncated.
+func salvageMetadata(raw []byte, report *MetadataRepairReport) map[string]json.RawMessage {
+	meta := map[string]json.RawMessage{}
+	for _, key := range uuidListMetadataKeys {
+		start := bytes.Index(raw, []byte(`"`+key+`"`))
+		if start < 0 {
+			continue
+		}
+		rest := raw[start+len(key)+2:]
+		open := bytes.IndexByte(rest, '[')
+		if open < 0 {
+			continue
+		}
+		segment := rest[open+1:]
+		if end := bytes.IndexByte(segment, ']'); end >= 0 {
+			segment = segment[:end]
+		}
+
+		kept := make([]string, 0)
+		seen := make(map[string]bool)
+		for _, token := range strings.Split(string(segment), ",") {
+			token = strings.Trim(strings.TrimSpace(token), `"`)
+			if token == "" {
+				continue
+			}
+			if _, err := uuid.Parse(token); err != nil {
+				report.Dropped[key] = append(report.Dropped[key], token)
+				continue
+			}
+			if !seen[token] {
+				seen[token] = true
+				kept = append(kept, token)
+				report.Recovered[key] = append(report.Recovered[key], token)
+			}
+		}
+		meta[key] = mustMarshalStrings(kept)
+	}
+	return meta
+}
+
+// mustMarshalStrings encodes a string slice, which cannot fail
+func mustMarshalStrings(values []string) json.RawMessage {
+	encoded, _ := json.Marshal(values)
+	return encoded
+}
+
+// ClearFavoriteLinksByUserID empties the user's metadata.favorites, keeping all other metadata keys
+func (s *UserService) ClearFavoriteLinksByUserID(userID, actor string) (*UserResponse, error) {
+	return s.clearMetadataList(userID, "favorites", models.MetadataAuditFieldFavorites, actor)
+}
+
+// ClearSubscribedPluginsByUserID empties the user's metadata.subscribed, keeping all other metadata keys
+func (s *UserService) ClearSubscribedPluginsByUserID(userID, actor string) (*UserResponse, error) {
+	return s.clearMetadataList(userID, "subscribed", models.MetadataAuditFieldSubscribed, actor)
+}
+
+// clearMetadataList sets metadata[key] to an empty array and audits the removal of every UUID it held.
+// Missing or unparseable metadata is replaced by an object holding only the empty array.
+func (s *UserService) clearMetadataList(userID, key string, field models.MetadataAuditField, actor string) (*UserResponse, error) {
+	if userID == "" {
+		return nil, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+
+	user, err := s.repo.GetByUserID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return nil, userLookupError(err)
+	}
+	before := userEventFields(user)
+
+	var meta map[string]json.RawMessage
+	if len(user.Metadata) == 0 || json.Unmarshal(user.Metadata, &meta) != nil || meta == nil {
+		meta = map[string]json.RawMessage{}
+	}
+	removed := metadataListUUIDs(meta[key])
+	meta[key] = mustMarshalStrings([]string{})
+
+	cleared, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	if err := ValidateMetadataKey(cleared, key); err != nil {
+		return nil, err
+	}
+	user.Metadata = json.RawMessage(cleared)
+
+	if err := s.repo.Update(user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	s.publishUserChanges(user.ID, before, user)
+	for _, id := range removed {
+		s.recordMetadataAudit(userID, field, models.MetadataAuditOperationRemove, id, actor)
+	}
+
+	return toUserResponse(user), nil
+}
+
+// metadataListUUIDs returns the distinct UUID entries of a metadata list, skipping anything else
+func metadataListUUIDs(raw json.RawMessage) []uuid.UUID {
+	var entries []interface{}
+	if len(raw) == 0 || json.Unmarshal(raw, &entries) != nil {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(entries))
+	seen := make(map[uuid.UUID]bool, len(entries))
+	for _, entry := range entries {
+		str, ok := entry.(string)
+		if !ok {
+			continue
+		}
+		if id, err := uuid.Parse(str); err == nil && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// MetadataPruneReport describes the outcome of PruneUserMetadata
+type MetadataPruneReport struct {
+	UserID           string `json:"user_id"`
+	PrunedFavorites  int    `json:"pruned_favorites"`  // favorites pointing at deleted links
+	PrunedSubscribed int    `json:"pruned_subscribed"` // subscriptions pointing at deleted plugins
+}
+
+// metadataAuditSystemActor is recorded as the actor of metadata changes made by maintenance jobs
+const metadataAuditSystemActor = "system"
+
+// PruneUserMetadata removes favorites whose link and subscriptions whose plugin no longer exist, keeping the
+// order of the remaining IDs and all other metadata keys, and audits each removal with the system actor.
+// Invalid favorites or subscribed lists must be repaired first with RepairUserMetadata; other keys are not
+// checked. Nothing is written when no ID is dangling.
+func (s *UserService) PruneUserMetadata(userID string) (*MetadataPruneReport, error) {
+	if userID == "" {
+		return nil, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+
+	user, err := s.repo.GetByUserID(userID)
+	if err != nil || user == nil {
+		logger.New().WithField("error", err).Error("Error getting user by userID")
+		return nil, userLookupError(err)
+	}
+
+	report := &MetadataPruneReport{UserID: userID}
+	if len(user.Metadata) == 0 {
+		return report, nil
+	}
+	for _, key := range uuidListMetadataKeys {
+		if err := ValidateMetadataKey(user.Metadata, key); err != nil {
+			return nil, err
+		}
+	}
+	var meta map[string]json.RawMessage
+	if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+		return nil, fmt.Errorf("%w: metadata must be a JSON object", apperrors.ErrInvalidMetadata)
+	}
+
+	prunedFavorites, err := pruneMetadataList(meta, "favorites", func(ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+		links, err := s.linkRepo.GetByIDs(ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get favorite links: %w", err)
+		}
+		existing := make(map[uuid.UUID]bool, len(links))
+		for _, link := range links {
+			existing[link.ID] = true
+		}
+		return existing, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prunedSubscribed, err := pruneMetadataList(meta, "subscribed", func(ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+		plugins, err := s.pluginRepo.GetByIDs(ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subscribed plugins: %w", err)
+		}
+		existing := make(map[uuid.UUID]bool, len(plugins))
+		for _, plugin := range plugins {
+			existing[plugin.ID] = true
+		}
+		return existing, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.PrunedFavorites, report.PrunedSubscribed = len(prunedFavorites), len(prunedSubscribed)
+	if report.PrunedFavorites == 0 && report.PrunedSubscribed == 0 {
+		return report, nil
+	}
+
+	pruned, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	before := userEventFields(user)
+	user.Metadata = json.RawMessage(pruned)
+	if err := s.repo.Update(user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	s.publishUserChanges(user.ID, before, user)
+	for _, id := range prunedFavorites {
+		s.recordMetadataAudit(userID, models.MetadataAuditFieldFavorites, models.MetadataAuditOperationRemove, id, metadataAuditSystemActor)
+	}
+	for _, id := range prunedSubscribed {
+		s.recordMetadataAudit(userID, models.MetadataAuditFieldSubscribed, models.MetadataAuditOperationRemove, id, metadataAuditSystemActor)
+	}
+
+	logger.New().WithFields(map[string]interface{}{
+		"user_id":           userID,
+		"pruned_favorites":  report.PrunedFavorites,
+		"pruned_subscribed": report.PrunedSubscribed,
+	}).Info("Pruned dangling user metadata IDs")
+
+	return report, nil
+}
+
+// pruneMetadataList drops the IDs of the validated list meta[key] that resolve reports as missing, updating meta
+// in place, and returns the dropped IDs
+func pruneMetadataList(meta map[string]json.RawMessage, key string, resolve func(ids []uuid.UUID) (map[uuid.UUID]bool, error)) ([]uuid.UUID, error) {
+	raw, ok := meta[key]
+	if !ok {
+		return nil, nil
+	}
+	var entries []string
+	if err := json.Unmarshal(raw, &entries); err != nil || len(entries) == 0 {
+		return nil, nil
+	}
+
+	// ValidateMetadataKey has already ensured every entry is a UUID
+	ids := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		ids[i], _ = uuid.Parse(entry)
+	}
+	existing, err := resolve(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]string, 0, len(entries))
+	var dropped []uuid.UUID
+	for i, entry := range entries {
+		if existing[ids[i]] {
+			kept = append(kept, entry)
+		} else {
+			dropped = append(dropped, ids[i])
+		}
+	}
+	if len(dropped) > 0 {
+		meta[key] = mustMarshalStrings(kept)
+	}
+	return dropped, nil
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMetadata_Valid(t *testing.T) {
+	valid := []string{
+		``,
+		`{}`,
+		`{"favorites":[],"subscribed":null}`,
+		`{"favorites":["3f6c1a52-8f57-4c3e-9d0e-2a1b4c5d6e7f"],"subscribed":["0b1c2d3e-4f50-4617-8293-a4b5c6d7e8f9"],"ai_instances":["team-a","team-b"],"portal_admin":true}`,
+		`{"portal_admin":"yes","custom_field":{"anything":[1,2,3]}}`,
+		`{"portal_admin":1}`,
+	}
+
+	for _, raw := range valid {
+		assert.NoError(t, service.ValidateMetadata(json.RawMessage(raw)), raw)
+	}
+}
+
+func TestValidateMetadata_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "not an object", raw: `["favorites"]`},
+		{name: "invalid JSON", raw: `{"favorites":`},
+		{name: "favorites not an array", raw: `{"favorites":"3f6c1a52-8f57-4c3e-9d0e-2a1b4c5d6e7f"}`},
+		{name: "favorites entry not a string", raw: `{"favorites":[42]}`},
+		{name: "favorites entry not a UUID", raw: `{"favorites":["not-a-uuid"]}`},
+		{name: "subscribed not an array", raw: `{"subscribed":{"id":"x"}}`},
+		{name: "ai_instances entry not a string", raw: `{"ai_instances":["team-a",true]}`},
+		{name: "portal_admin object", raw: `{"portal_admin":{"enabled":true}}`},
+		{name: "portal_admin array", raw: `{"portal_admin":[true]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateMetadata(json.RawMessage(tt.raw))
+			assert.ErrorIs(t, err, apperrors.ErrInvalidMetadata)
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package service_test
 
 import (
+	"context"
 	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/testutils"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"developer-portal-backend/internal/database/models"
 	"developer-portal-backend/internal/mocks"
@@ -73,7 +78,7 @@ func (suite *UserServiceTestSuite) TestCreateUser() {
 	// Mock GetByEmail to return not found (no existing member with same email)
 	suite.mockUserRepo.EXPECT().
 		GetByEmail(req.Email).
-		Return(nil, gorm.ErrRecordNotFound).
+		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
 	// Mock Create to succeed
@@ -94,6 +99,53 @@ func (suite *UserServiceTestSuite) TestCreateUser() {
 	assert.Equal(suite.T(), teamRole, response.TeamRole)
 }
 
+// TestCreateUser_UnknownRole tests that an unknown role is rejected before anything is written
+func (suite *UserServiceTestSuite) TestCreateUser_UnknownRole() {
+	role := "wizard"
+	req := &service.CreateUserRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		IUser:     "I123456",
+		Role:      &role,
+		CreatedBy: "I123456",
+	}
+
+	suite.mockUserRepo.EXPECT().GetByEmail(req.Email).Return(nil, apperrors.ErrUserNotFound).Times(1)
+	suite.mockUserRepo.EXPECT().Create(gomock.Any()).Times(0)
+
+	response, err := suite.userService.CreateUser(req)
+
+	assert.Nil(suite.T(), response)
+	var validationErr *apperrors.ValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), "role", validationErr.Field)
+	assert.Contains(suite.T(), err.Error(), "wizard")
+}
+
+// TestCreateUser_UnknownTeamRole tests that an unknown team role is rejected before anything is written
+func (suite *UserServiceTestSuite) TestCreateUser_UnknownTeamRole() {
+	teamRole := "overlord"
+	req := &service.CreateUserRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		IUser:     "I123456",
+		TeamRole:  &teamRole,
+		CreatedBy: "I123456",
+	}
+
+	suite.mockUserRepo.EXPECT().GetByEmail(req.Email).Return(nil, apperrors.ErrUserNotFound).Times(1)
+	suite.mockUserRepo.EXPECT().Create(gomock.Any()).Times(0)
+
+	response, err := suite.userService.CreateUser(req)
+
+	assert.Nil(suite.T(), response)
+	var validationErr *apperrors.ValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), "team_role", validationErr.Field)
+}
+
 // TestCreateUserWithDefaultRoleAndTeamRole tests creating a member with default role and team role
 func (suite *UserServiceTestSuite) TestCreateUserWithDefaultRoleAndTeamRole() {
 	teamID := uuid.New()
@@ -111,7 +163,7 @@ func (suite *UserServiceTestSuite) TestCreateUserWithDefaultRoleAndTeamRole() {
 	// Mock GetByEmail to return not found (no existing member with same email)
 	suite.mockUserRepo.EXPECT().
 		GetByEmail(req.Email).
-		Return(nil, gorm.ErrRecordNotFound).
+		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
 	// Mock Create to succeed
@@ -128,8 +180,8 @@ func (suite *UserServiceTestSuite) TestCreateUserWithDefaultRoleAndTeamRole() {
 	assert.Equal(suite.T(), req.FirstName, response.FirstName)
 	assert.Equal(suite.T(), req.LastName, response.LastName)
 	assert.Equal(suite.T(), req.Email, response.Email)
-	assert.Equal(suite.T(), "developer", response.TeamDomain) // Default role
-	assert.Equal(suite.T(), "member", response.TeamRole)      // Default team role
+	assert.Equal(suite.T(), string(models.DefaultTeamDomain), response.TeamDomain)
+	assert.Equal(suite.T(), string(models.DefaultTeamRole), response.TeamRole)
 }
 
 // TestCreateUserValidationError tests creating a member with validation error
@@ -148,9 +200,35 @@ func (suite *UserServiceTestSuite) TestCreateUserValidationError() {
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
+	assert.True(suite.T(), apperrors.IsValidation(err))
 	assert.Contains(suite.T(), err.Error(), "validation failed")
 }
 
+// TestCreateUserValidationError_FieldErrors tests that each invalid field is reported with the rule it broke
+func (suite *UserServiceTestSuite) TestCreateUserValidationError_FieldErrors() {
+	req := &service.CreateUserRequest{
+		FirstName: "",
+		LastName:  "Doe",
+		Email:     "not-an-email",
+		IUser:     "I123456",
+		CreatedBy: "admin",
+	}
+
+	response, err := suite.userService.CreateUser(req)
+
+	assert.Nil(suite.T(), response)
+	assert.True(suite.T(), strings.HasPrefix(err.Error(), "validation failed: "), err.Error())
+	assert.Equal(suite.T(), 1, strings.Count(err.Error(), "validation failed"), err.Error())
+	assert.NotContains(suite.T(), err.Error(), "validation error")
+
+	var appErr *apperrors.AppError
+	suite.Require().ErrorAs(err, &appErr)
+	assert.Equal(suite.T(), http.StatusBadRequest, appErr.HTTPStatus)
+	assert.Equal(suite.T(), apperrors.CodeValidation, appErr.Code)
+	assert.Equal(suite.T(), map[string]string{"first_name": "required", "email": "email"}, appErr.Fields)
+	assert.Equal(suite.T(), err.Error(), appErr.Message)
+}
+
 // TestCreateUserDuplicateEmail tests creating a member with duplicate email
 func (suite *UserServiceTestSuite) TestCreateUserDuplicateEmail() {
 	role := "developer"
@@ -176,6 +254,11 @@ func (suite *UserServiceTestSuite) TestCreateUserDuplicateEmail() {
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
 	assert.Contains(suite.T(), err.Error(), "user already exists")
+
+	var appErr *apperrors.AppError
+	suite.Require().ErrorAs(err, &appErr)
+	assert.Equal(suite.T(), http.StatusConflict, appErr.HTTPStatus)
+	assert.Equal(suite.T(), apperrors.CodeConflict, appErr.Code)
 }
 
 // TestGetUserByID tests getting a user by ID
@@ -205,7 +288,7 @@ func (suite *UserServiceTestSuite) TestGetUserByIDNotFound() {
 
 	suite.mockUserRepo.EXPECT().
 		GetByID(userID).
-		Return(nil, gorm.ErrRecordNotFound).
+		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
 	response, err := suite.userService.GetUserByID(userID)
@@ -213,114 +296,116 @@ func (suite *UserServiceTestSuite) TestGetUserByIDNotFound() {
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
 	assert.Contains(suite.T(), err.Error(), "user not found")
+
+	var appErr *apperrors.AppError
+	suite.Require().ErrorAs(err, &appErr)
+	assert.Equal(suite.T(), http.StatusNotFound, appErr.HTTPStatus)
+	assert.Equal(suite.T(), apperrors.CodeNotFound, appErr.Code)
+	assert.Equal(suite.T(), "user not found", appErr.Message)
 }
 
-// TestGetMembersByOrganization tests getting members by organization
-func (suite *UserServiceTestSuite) TestGetMembersByOrganization() {
-	orgID := uuid.New()
-	limit, offset := 20, 0
-	existingUsers := []models.User{
-		{
-			TeamID:     uuidPtr(),
-			UserID:     "I123456",
-			FirstName:  "John",
-			LastName:   "Doe",
-			Email:      "john@example.com",
-			TeamDomain: models.TeamDomainDeveloper,
-			TeamRole:   models.TeamRoleMember,
-		},
-		{
-			TeamID:     uuidPtr(),
-			UserID:     "I789012",
-			FirstName:  "Jane",
-			LastName:   "Smith",
-			Email:      "jane@example.com",
-			TeamDomain: models.TeamDomainPO,
-			TeamRole:   models.TeamRoleManager,
-		},
-	}
-	expectedTotal := int64(2)
+// TestGetUserByUUID tests looking a user up by the database UUID
+func (suite *UserServiceTestSuite) TestGetUserByUUID() {
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = uuid.New()
 
 	suite.mockUserRepo.EXPECT().
-		GetByOrganizationID(orgID, limit, offset).
-		Return(existingUsers, expectedTotal, nil).
+		GetByID(existingUser.ID).
+		Return(existingUser, nil).
 		Times(1)
 
-	responses, total, err := suite.userService.GetUsersByOrganization(orgID, limit, offset)
+	response, err := suite.userService.GetUserByUUID(existingUser.ID)
 
-	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), expectedTotal, total)
-	assert.Len(suite.T(), responses, 2)
-	assert.Equal(suite.T(), existingUsers[0].FirstName, responses[0].FirstName)
-	assert.Equal(suite.T(), existingUsers[0].LastName, responses[0].LastName)
-	assert.Equal(suite.T(), existingUsers[1].FirstName, responses[1].FirstName)
-	assert.Equal(suite.T(), existingUsers[1].LastName, responses[1].LastName)
-}
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), existingUser.ID.String(), response.UUID)
+	assert.Equal(suite.T(), existingUser.UserID, response.ID)
 
-// TestUpdateMember tests updating a member
-func (suite *UserServiceTestSuite) TestUpdateMember() {
-	userID := uuid.New()
-	existingUser := suite.factories.User.Create()
-	existingUser.TeamID = &userID
+	// The exposed UUID round-trips to the same lookup
+	roundTrip, err := uuid.Parse(response.UUID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), existingUser.ID, roundTrip)
+}
 
-	newFirstName := "John"
-	newLastName := "Updated"
-	newEmail := "john.updated@example.com"
-	req := &service.UpdateUserRequest{
-		FirstName: &newFirstName,
-		LastName:  &newLastName,
-		Email:     &newEmail,
-	}
+// TestGetUserByUUIDNotFound tests looking up an unknown database UUID
+func (suite *UserServiceTestSuite) TestGetUserByUUIDNotFound() {
+	id := uuid.New()
 
 	suite.mockUserRepo.EXPECT().
-		GetByID(userID).
-		Return(existingUser, nil).
+		GetByID(id).
+		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
+	response, err := suite.userService.GetUserByUUID(id)
+
+	assert.Nil(suite.T(), response)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+}
+
+// TestGetUsersByEmails tests resolving a mix of known and unknown emails in one lookup
+func (suite *UserServiceTestSuite) TestGetUsersByEmails() {
+	alice := suite.factories.User.WithEmail("alice@example.com")
+	bob := suite.factories.User.WithEmail("Bob@Example.com")
+
+	// Inputs are normalized and de-duplicated before the single repository call
 	suite.mockUserRepo.EXPECT().
-		GetByEmail(newEmail).
-		Return(nil, gorm.ErrRecordNotFound).
+		GetByEmails([]string{"alice@example.com", "bob@example.com", "missing@example.com"}).
+		Return([]models.User{*alice, *bob}, nil).
 		Times(1)
 
+	result, err := suite.userService.GetUsersByEmails([]string{" ALICE@example.com", "bob@example.com", "missing@example.com", "alice@EXAMPLE.com", ""})
+
+	suite.Require().NoError(err)
+	suite.Len(result, 2)
+	suite.Require().Contains(result, "alice@example.com")
+	suite.Require().Contains(result, "bob@example.com")
+	suite.NotContains(result, "missing@example.com")
+	suite.Equal(alice.UserID, result["alice@example.com"].ID)
+	suite.Equal(bob.UserID, result["bob@example.com"].ID)
+}
+
+// TestGetUsersByEmails_NoEmails tests that blank input skips the repository
+func (suite *UserServiceTestSuite) TestGetUsersByEmails_NoEmails() {
+	suite.mockUserRepo.EXPECT().GetByEmails(gomock.Any()).Times(0)
+
+	result, err := suite.userService.GetUsersByEmails([]string{"", "  "})
+
+	suite.Require().NoError(err)
+	suite.Empty(result)
+}
+
+// TestGetUsersByEmails_RepositoryError tests that a failed lookup is reported
+func (suite *UserServiceTestSuite) TestGetUsersByEmails_RepositoryError() {
 	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		Return(nil).
+		GetByEmails([]string{"alice@example.com"}).
+		Return(nil, errors.New("connection refused")).
 		Times(1)
 
-	response, err := suite.userService.UpdateUser(userID, req)
+	result, err := suite.userService.GetUsersByEmails([]string{"alice@example.com"})
 
-	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), newFirstName, response.FirstName)
-	assert.Equal(suite.T(), newLastName, response.LastName)
-	assert.Equal(suite.T(), newEmail, response.Email)
+	suite.Nil(result)
+	suite.ErrorContains(err, "connection refused")
 }
 
-// TestDeleteMember tests deleting a member
-func (suite *UserServiceTestSuite) TestDeleteMember() {
+// TestGetUserByIDRepositoryFailure tests that unexpected repository errors are not reported as not found
+func (suite *UserServiceTestSuite) TestGetUserByIDRepositoryFailure() {
 	userID := uuid.New()
-	existingUser := suite.factories.User.Create()
-	existingUser.TeamID = &userID
 
 	suite.mockUserRepo.EXPECT().
 		GetByID(userID).
-		Return(existingUser, nil).
-		Times(1)
-
-	suite.mockUserRepo.EXPECT().
-		Delete(userID).
-		Return(nil).
+		Return(nil, errors.New("connection refused")).
 		Times(1)
 
-	err := suite.userService.DeleteUser(userID)
+	response, err := suite.userService.GetUserByID(userID)
 
-	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.NotErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+	assert.Contains(suite.T(), err.Error(), "failed to get user: connection refused")
+	assert.Equal(suite.T(), http.StatusInternalServerError, apperrors.HTTPStatus(err))
 }
 
-// TestSearchMembers tests searching for members
-func (suite *UserServiceTestSuite) TestSearchMembers() {
+// TestGetMembersByOrganization tests getting members by organization
+func (suite *UserServiceTestSuite) TestGetMembersByOrganization() {
 	orgID := uuid.New()
-	query := "john"
 	limit, offset := 20, 0
 	existingUsers := []models.User{
 		{
@@ -328,16 +413,16 @@ func (suite *UserServiceTestSuite) TestSearchMembers() {
 			UserID:     "I123456",
 			FirstName:  "John",
 			LastName:   "Doe",
-			Email:      "john.doe@example.com",
+			Email:      "john@example.com",
 			TeamDomain: models.TeamDomainDeveloper,
 			TeamRole:   models.TeamRoleMember,
 		},
 		{
 			TeamID:     uuidPtr(),
 			UserID:     "I789012",
-			FirstName:  "Mary",
-			LastName:   "Johnson",
-			Email:      "mary.johnson@example.com",
+			FirstName:  "Jane",
+			LastName:   "Smith",
+			Email:      "jane@example.com",
 			TeamDomain: models.TeamDomainPO,
 			TeamRole:   models.TeamRoleManager,
 		},
@@ -345,898 +430,1061 @@ func (suite *UserServiceTestSuite) TestSearchMembers() {
 	expectedTotal := int64(2)
 
 	suite.mockUserRepo.EXPECT().
-		SearchByOrganization(orgID, query, limit, offset).
+		GetByOrganizationID(orgID, limit, offset).
 		Return(existingUsers, expectedTotal, nil).
 		Times(1)
 
-	responses, total, err := suite.userService.SearchUsers(orgID, query, limit, offset)
+	responses, total, err := suite.userService.GetUsersByOrganization(orgID, limit, offset)
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedTotal, total)
 	assert.Len(suite.T(), responses, 2)
-	assert.Equal(suite.T(), existingUsers[0].Email, responses[0].Email)
-	assert.Equal(suite.T(), existingUsers[1].Email, responses[1].Email)
-}
-
-// TestSearchMembersError tests searching for members with error
-func (suite *UserServiceTestSuite) TestSearchMembersError() {
-	orgID := uuid.New()
-	query := "test"
-	limit, offset := 20, 0
-
-	suite.mockUserRepo.EXPECT().
-		SearchByOrganization(orgID, query, limit, offset).
-		Return(nil, int64(0), gorm.ErrInvalidDB).
-		Times(1)
-
-	responses, total, err := suite.userService.SearchUsers(orgID, query, limit, offset)
-
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), responses)
-	assert.Equal(suite.T(), int64(0), total)
-	assert.Contains(suite.T(), err.Error(), "failed to search users")
+	assert.Equal(suite.T(), existingUsers[0].FirstName, responses[0].FirstName)
+	assert.Equal(suite.T(), existingUsers[0].LastName, responses[0].LastName)
+	assert.Equal(suite.T(), existingUsers[1].FirstName, responses[1].FirstName)
+	assert.Equal(suite.T(), existingUsers[1].LastName, responses[1].LastName)
 }
 
-// TestGetActiveMembers tests getting active members
-func (suite *UserServiceTestSuite) TestGetActiveMembers() {
-	orgID := uuid.New()
+// TestGetUsersByTeam tests getting the members of a team
+func (suite *UserServiceTestSuite) TestGetUsersByTeam() {
+	teamID := uuid.New()
 	limit, offset := 20, 0
 	existingUsers := []models.User{
 		{
-			TeamID:     uuidPtr(),
+			TeamID:     &teamID,
 			UserID:     "I123456",
-			FirstName:  "Active",
-			LastName:   "Smith",
-			Email:      "active.smith@example.com",
+			FirstName:  "John",
+			LastName:   "Doe",
+			Email:      "john@example.com",
 			TeamDomain: models.TeamDomainDeveloper,
 			TeamRole:   models.TeamRoleMember,
 		},
 		{
-			TeamID:     uuidPtr(),
+			TeamID:     &teamID,
 			UserID:     "I789012",
-			FirstName:  "Active",
-			LastName:   "Jones",
-			Email:      "active.jones@example.com",
+			FirstName:  "Jane",
+			LastName:   "Smith",
+			Email:      "jane@example.com",
 			TeamDomain: models.TeamDomainPO,
 			TeamRole:   models.TeamRoleManager,
 		},
 	}
-	expectedTotal := int64(2)
 
 	suite.mockUserRepo.EXPECT().
-		GetActiveByOrganization(orgID, limit, offset).
-		Return(existingUsers, expectedTotal, nil).
+		GetByTeamID(teamID, limit, offset).
+		Return(existingUsers, int64(2), nil).
 		Times(1)
 
-	responses, total, err := suite.userService.GetActiveUsers(orgID, limit, offset)
+	responses, total, err := suite.userService.GetUsersByTeam(teamID, limit, offset)
 
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), expectedTotal, total)
+	assert.Equal(suite.T(), int64(2), total)
 	assert.Len(suite.T(), responses, 2)
-	assert.Equal(suite.T(), existingUsers[0].Email, responses[0].Email)
-	assert.Equal(suite.T(), existingUsers[1].Email, responses[1].Email)
+	assert.Equal(suite.T(), "I123456", responses[0].ID)
+	assert.Equal(suite.T(), "I789012", responses[1].ID)
 }
 
-// TestGetActiveMembersError tests getting active members with error
-func (suite *UserServiceTestSuite) TestGetActiveMembersError() {
-	orgID := uuid.New()
+// TestGetUsersByTeam_EmptyTeam tests a team without members returns an empty slice
+func (suite *UserServiceTestSuite) TestGetUsersByTeam_EmptyTeam() {
+	teamID := uuid.New()
 	limit, offset := 20, 0
 
 	suite.mockUserRepo.EXPECT().
-		GetActiveByOrganization(orgID, limit, offset).
-		Return(nil, int64(0), gorm.ErrInvalidDB).
+		GetByTeamID(teamID, limit, offset).
+		Return([]models.User{}, int64(0), nil).
 		Times(1)
 
-	responses, total, err := suite.userService.GetActiveUsers(orgID, limit, offset)
+	responses, total, err := suite.userService.GetUsersByTeam(teamID, limit, offset)
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), responses)
+	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), int64(0), total)
-	assert.Contains(suite.T(), err.Error(), "failed to get active users")
+	assert.NotNil(suite.T(), responses)
+	assert.Len(suite.T(), responses, 0)
 }
 
-// TestUpdateMemberNotFound tests updating a member that doesn't exist
-func (suite *UserServiceTestSuite) TestUpdateMemberNotFound() {
-	userID := uuid.New()
-	newFirstName := "John"
-	req := &service.UpdateUserRequest{
-		FirstName: &newFirstName,
-	}
+// TestGetUsersByTeam_RepositoryError tests repository errors are wrapped
+func (suite *UserServiceTestSuite) TestGetUsersByTeam_RepositoryError() {
+	teamID := uuid.New()
+	limit, offset := 20, 0
 
 	suite.mockUserRepo.EXPECT().
-		GetByID(userID).
-		Return(nil, gorm.ErrRecordNotFound).
+		GetByTeamID(teamID, limit, offset).
+		Return(nil, int64(0), gorm.ErrInvalidDB).
 		Times(1)
 
-	response, err := suite.userService.UpdateUser(userID, req)
+	responses, total, err := suite.userService.GetUsersByTeam(teamID, limit, offset)
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	assert.Nil(suite.T(), responses)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Contains(suite.T(), err.Error(), "failed to get team users")
 }
 
-func (suite *UserServiceTestSuite) TestUpdateMemberEmailConflict() {
-	userID := uuid.New()
-	existingUser := suite.factories.User.Create()
-	existingUser.TeamID = &userID
-
-	conflictingEmail := "taken@example.com"
-	conflictingUser := suite.factories.User.WithEmail(conflictingEmail)
-	conflictingUser.TeamID = uuidPtr()
-
-	req := &service.UpdateUserRequest{
-		Email: &conflictingEmail,
-	}
-
+// TestCountUsers tests counting all users
+func (suite *UserServiceTestSuite) TestCountUsers() {
 	suite.mockUserRepo.EXPECT().
-		GetByID(userID).
-		Return(existingUser, nil).
+		Count().
+		Return(int64(42), nil).
 		Times(1)
 
+	count, err := suite.userService.CountUsers()
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(42), count)
+}
+
+// TestCountUsers_RepositoryError tests repository errors are wrapped
+func (suite *UserServiceTestSuite) TestCountUsers_RepositoryError() {
 	suite.mockUserRepo.EXPECT().
-		GetByEmail(conflictingEmail).
-		Return(conflictingUser, nil).
+		Count().
+		Return(int64(0), gorm.ErrInvalidDB).
 		Times(1)
 
-	response, err := suite.userService.UpdateUser(userID, req)
+	count, err := suite.userService.CountUsers()
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user already exists")
+	assert.Equal(suite.T(), int64(0), count)
+	assert.Contains(suite.T(), err.Error(), "failed to count users")
 }
 
-// ===== Tests for UpdateUserTeam =====
-
-// TestUpdateUserTeam_Success tests successfully updating a user's team
-func (suite *UserServiceTestSuite) TestUpdateUserTeam_Success() {
-	userID := uuid.New()
-	teamID := uuid.New()
-	updatedBy := "I999999"
-
-	existingUser := suite.factories.User.Create()
-	existingUser.TeamID = nil // No team initially
-
-	suite.mockUserRepo.EXPECT().
-		GetByID(userID).
-		Return(existingUser, nil).
-		Times(1)
+// TestCountActiveUsers tests counting users of an organization
+func (suite *UserServiceTestSuite) TestCountActiveUsers() {
+	orgID := uuid.New()
 
 	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			// Verify the team was updated
-			assert.NotNil(suite.T(), user.TeamID)
-			assert.Equal(suite.T(), teamID, *user.TeamID)
-			assert.Equal(suite.T(), updatedBy, user.UpdatedBy)
-			return nil
-		}).
+		CountByOrganization(orgID).
+		Return(int64(7), nil).
 		Times(1)
 
-	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
+	count, err := suite.userService.CountActiveUsers(orgID)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), existingUser.UserID, response.ID)
+	assert.Equal(suite.T(), int64(7), count)
 }
 
-// TestUpdateUserTeam_EmptyUpdatedBy tests error when updatedBy is empty
-func (suite *UserServiceTestSuite) TestUpdateUserTeam_EmptyUpdatedBy() {
-	userID := uuid.New()
-	teamID := uuid.New()
-	updatedBy := ""
+// TestCountUsersByDomain tests counts are grouped by team domain
+func (suite *UserServiceTestSuite) TestCountUsersByDomain() {
+	orgID := uuid.New()
+	expected := map[string]int64{
+		string(models.TeamDomainDeveloper): 5,
+		string(models.TeamDomainDevOps):    2,
+		string(models.TeamDomainPO):        1,
+	}
 
-	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
+	suite.mockUserRepo.EXPECT().
+		CountByDomain(orgID).
+		Return(expected, nil).
+		Times(1)
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "updated_by is required")
+	counts, err := suite.userService.CountUsersByDomain(orgID)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), counts, 3)
+	assert.Equal(suite.T(), int64(5), counts["developer"])
+	assert.Equal(suite.T(), int64(2), counts["devops"])
+	assert.Equal(suite.T(), int64(1), counts["po"])
 }
 
-// TestUpdateUserTeam_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestUpdateUserTeam_UserNotFound() {
-	userID := uuid.New()
-	teamID := uuid.New()
-	updatedBy := "I999999"
+// TestCountUsersByDomain_RepositoryError tests repository errors are wrapped
+func (suite *UserServiceTestSuite) TestCountUsersByDomain_RepositoryError() {
+	orgID := uuid.New()
 
 	suite.mockUserRepo.EXPECT().
-		GetByID(userID).
-		Return(nil, apperrors.ErrUserNotFound).
+		CountByDomain(orgID).
+		Return(nil, gorm.ErrInvalidDB).
 		Times(1)
 
-	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
+	counts, err := suite.userService.CountUsersByDomain(orgID)
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	assert.Nil(suite.T(), counts)
+	assert.Contains(suite.T(), err.Error(), "failed to count users by domain")
 }
 
-// TestUpdateUserTeam_UpdateFails tests error when repository update fails
-func (suite *UserServiceTestSuite) TestUpdateUserTeam_UpdateFails() {
+// TestUpdateMember tests updating a member
+func (suite *UserServiceTestSuite) TestUpdateMember() {
 	userID := uuid.New()
-	teamID := uuid.New()
-	updatedBy := "I999999"
-
 	existingUser := suite.factories.User.Create()
-	existingUser.TeamID = nil
+	existingUser.TeamID = &userID
+
+	newFirstName := "John"
+	newLastName := "Updated"
+	newEmail := "john.updated@example.com"
+	req := &service.UpdateUserRequest{
+		FirstName: &newFirstName,
+		LastName:  &newLastName,
+		Email:     &newEmail,
+	}
 
 	suite.mockUserRepo.EXPECT().
 		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
+	suite.mockUserRepo.EXPECT().
+		GetByEmail(newEmail).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
-		Return(gorm.ErrInvalidDB).
+		Return(nil).
 		Times(1)
 
-	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
+	response, err := suite.userService.UpdateUser(userID, req)
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "failed to update user team")
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), newFirstName, response.FirstName)
+	assert.Equal(suite.T(), newLastName, response.LastName)
+	// Email changes stay pending until confirmed
+	assert.Equal(suite.T(), existingUser.Email, response.Email)
+	assert.Equal(suite.T(), newEmail, response.PendingEmail)
 }
 
-// TestUpdateUserTeam_ChangeExistingTeam tests updating a user who already has a team
-func (suite *UserServiceTestSuite) TestUpdateUserTeam_ChangeExistingTeam() {
+// TestUpdateUser_EmailChangeIsPending tests a new email is stored as pending and the active email is kept
+func (suite *UserServiceTestSuite) TestUpdateUser_EmailChangeIsPending() {
 	userID := uuid.New()
-	oldTeamID := uuid.New()
-	newTeamID := uuid.New()
-	updatedBy := "I999999"
-
 	existingUser := suite.factories.User.Create()
-	existingUser.TeamID = &oldTeamID // Already has a team
+	activeEmail := existingUser.Email
+	newEmail := "new.address@example.com"
 
 	suite.mockUserRepo.EXPECT().
 		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
-
+	suite.mockUserRepo.EXPECT().
+		GetByEmail(newEmail).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			// Verify the team was changed to the new team
-			assert.NotNil(suite.T(), user.TeamID)
-			assert.Equal(suite.T(), newTeamID, *user.TeamID)
-			assert.NotEqual(suite.T(), oldTeamID, *user.TeamID)
-			assert.Equal(suite.T(), updatedBy, user.UpdatedBy)
+			assert.Equal(suite.T(), activeEmail, user.Email)
+			assert.Equal(suite.T(), newEmail, user.PendingEmail)
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.UpdateUserTeam(userID, newTeamID, updatedBy)
+	response, err := suite.userService.UpdateUser(userID, &service.UpdateUserRequest{Email: &newEmail})
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), existingUser.UserID, response.ID)
+	assert.Equal(suite.T(), activeEmail, response.Email)
+	assert.Equal(suite.T(), newEmail, response.PendingEmail)
 }
 
-// TestDeleteMemberNotFound tests deleting a member that doesn't exist
-func (suite *UserServiceTestSuite) TestDeleteMemberNotFound() {
+// TestUpdateUser_PendingEmailDuplicate tests a pending email may not duplicate an active email
+func (suite *UserServiceTestSuite) TestUpdateUser_PendingEmailDuplicate() {
 	userID := uuid.New()
+	existingUser := suite.factories.User.Create()
+	otherUser := suite.factories.User.Create()
+	takenEmail := "taken@example.com"
 
 	suite.mockUserRepo.EXPECT().
 		GetByID(userID).
-		Return(nil, gorm.ErrRecordNotFound).
+		Return(existingUser, nil).
+		Times(1)
+	suite.mockUserRepo.EXPECT().
+		GetByEmail(takenEmail).
+		Return(otherUser, nil).
 		Times(1)
 
-	err := suite.userService.DeleteUser(userID)
+	response, err := suite.userService.UpdateUser(userID, &service.UpdateUserRequest{Email: &takenEmail})
 
-	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	assert.Nil(suite.T(), response)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserExists)
 }
 
-// TestAddFavoriteLinkByUserID_Success tests successfully adding a favorite link to a user with no existing metadata
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_Success() {
-	userID := "I123456"
-	linkID := uuid.New()
-
+// TestConfirmEmailChange_Success tests the pending email is promoted to the active email
+func (suite *UserServiceTestSuite) TestConfirmEmailChange_Success() {
+	userID := uuid.New()
 	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = nil // No existing metadata
+	existingUser.ID = userID
+	existingUser.PendingEmail = "confirmed@example.com"
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
-
+	suite.mockUserRepo.EXPECT().
+		GetByEmail("confirmed@example.com").
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			// Verify metadata was updated correctly
-			assert.NotNil(suite.T(), user.Metadata)
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
-
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
-
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 1)
-			assert.Equal(suite.T(), linkID.String(), favArray[0])
-
+			assert.Equal(suite.T(), "confirmed@example.com", user.Email)
+			assert.Empty(suite.T(), user.PendingEmail)
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID)
+	response, err := suite.userService.ConfirmEmailChange(userID)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
+	assert.Equal(suite.T(), "confirmed@example.com", response.Email)
+	assert.Empty(suite.T(), response.PendingEmail)
 }
 
-// TestAddFavoriteLinkByUserID_WithExistingMetadata tests adding a favorite link to a user with existing metadata but no favorites
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_WithExistingMetadata() {
-	userID := "I123456"
-	linkID := uuid.New()
+// TestConfirmEmailChange_NoPendingEmail tests confirming without a pending change
+func (suite *UserServiceTestSuite) TestConfirmEmailChange_NoPendingEmail() {
+	userID := uuid.New()
+	existingUser := suite.factories.User.Create()
+	existingUser.PendingEmail = ""
 
-	existingMetadata := map[string]interface{}{
-		"portal_admin": true,
-		"other_field":  "value",
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+	suite.mockUserRepo.EXPECT().
+		GetByID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	response, err := suite.userService.ConfirmEmailChange(userID)
+
+	assert.Nil(suite.T(), response)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrNoPendingEmailChange)
+}
 
+// TestDeleteMember tests deleting a member
+func (suite *UserServiceTestSuite) TestDeleteMember() {
+	userID := uuid.New()
 	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.TeamID = &userID
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
 	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
+		Delete(userID).
+		Return(nil).
+		Times(1)
 
-			// Verify existing fields are preserved
-			assert.Equal(suite.T(), true, meta["portal_admin"])
-			assert.Equal(suite.T(), "value", meta["other_field"])
+	err := suite.userService.DeleteUser(userID)
 
-			// Verify favorites was added
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
+	assert.NoError(suite.T(), err)
+}
 
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 1)
-			assert.Equal(suite.T(), linkID.String(), favArray[0])
+// TestDeleteAndRestoreUser tests a soft-deleted user is hidden until restored
+func (suite *UserServiceTestSuite) TestDeleteAndRestoreUser() {
+	userID := uuid.New()
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userID
 
+	// Simulate GORM soft delete: default queries skip rows with DeletedAt set
+	suite.mockUserRepo.EXPECT().
+		GetByID(userID).
+		DoAndReturn(func(id uuid.UUID) (*models.User, error) {
+			if existingUser.DeletedAt.Valid {
+				return nil, apperrors.ErrUserNotFound
+			}
+			return existingUser, nil
+		}).
+		AnyTimes()
+	suite.mockUserRepo.EXPECT().
+		GetByIDIncludingDeleted(userID).
+		Return(existingUser, nil).
+		Times(1)
+	suite.mockUserRepo.EXPECT().
+		Delete(userID).
+		DoAndReturn(func(id uuid.UUID) error {
+			existingUser.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			return nil
+		}).
+		Times(1)
+	suite.mockUserRepo.EXPECT().
+		GetByEmail(existingUser.Email).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+	suite.mockUserRepo.EXPECT().
+		Restore(userID).
+		DoAndReturn(func(id uuid.UUID) error {
+			existingUser.DeletedAt = gorm.DeletedAt{}
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID)
-
+	err := suite.userService.DeleteUser(userID)
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-}
 
-// TestAddFavoriteLinkByUserID_WithExistingFavorites tests adding a favorite link to a user with existing favorites
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_WithExistingFavorites() {
-	userID := "I123456"
-	existingLinkID := uuid.New()
-	newLinkID := uuid.New()
+	_, err = suite.userService.GetUserByID(userID)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
 
-	existingMetadata := map[string]interface{}{
-		"favorites": []string{existingLinkID.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+	restored, err := suite.userService.RestoreUser(userID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), existingUser.UserID, restored.ID)
+
+	response, err := suite.userService.GetUserByID(userID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), existingUser.UserID, response.ID)
+}
 
+// TestRestoreUser_NotDeleted tests restoring an active user succeeds without a repository write
+func (suite *UserServiceTestSuite) TestRestoreUser_NotDeleted() {
+	userID := uuid.New()
 	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByIDIncludingDeleted(userID).
 		Return(existingUser, nil).
 		Times(1)
 
-	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
+	response, err := suite.userService.RestoreUser(userID)
 
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
 
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 2)
+// TestRestoreUser_EmailTaken tests restoring a user whose email now belongs to another active user
+func (suite *UserServiceTestSuite) TestRestoreUser_EmailTaken() {
+	userID := uuid.New()
+	deletedUser := suite.factories.User.Create()
+	deletedUser.ID = userID
+	deletedUser.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	otherUser := suite.factories.User.WithEmail(deletedUser.Email)
+	otherUser.ID = uuid.New()
 
-			// Verify both links are present
-			favStrings := make([]string, len(favArray))
-			for i, v := range favArray {
-				favStrings[i] = v.(string)
-			}
-			assert.Contains(suite.T(), favStrings, existingLinkID.String())
-			assert.Contains(suite.T(), favStrings, newLinkID.String())
-
-			return nil
-		}).
+	suite.mockUserRepo.EXPECT().
+		GetByIDIncludingDeleted(userID).
+		Return(deletedUser, nil).
+		Times(1)
+	suite.mockUserRepo.EXPECT().
+		GetByEmail(deletedUser.Email).
+		Return(otherUser, nil).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, newLinkID)
+	response, err := suite.userService.RestoreUser(userID)
 
-	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
+	assert.Nil(suite.T(), response)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserExists)
 }
 
-// TestAddFavoriteLinkByUserID_Deduplication tests that adding a duplicate link doesn't create duplicates
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_Deduplication() {
-	userID := "I123456"
-	linkID := uuid.New()
-
-	existingMetadata := map[string]interface{}{
-		"favorites": []string{linkID.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
-
-	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+// TestRestoreUser_NotFound tests restoring an unknown user
+func (suite *UserServiceTestSuite) TestRestoreUser_NotFound() {
+	userID := uuid.New()
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
+		GetByIDIncludingDeleted(userID).
+		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
-	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
+	response, err := suite.userService.RestoreUser(userID)
 
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
+	assert.Nil(suite.T(), response)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+}
 
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			// Should still be 1, not duplicated
-			assert.Len(suite.T(), favArray, 1)
-			assert.Equal(suite.T(), linkID.String(), favArray[0])
+// TestSearchMembers tests searching for members
+func (suite *UserServiceTestSuite) TestSearchMembers() {
+	orgID := uuid.New()
+	query := "john"
+	limit, offset := 20, 0
+	existingUsers := []models.User{
+		{
+			TeamID:     uuidPtr(),
+			UserID:     "I123456",
+			FirstName:  "John",
+			LastName:   "Doe",
+			Email:      "john.doe@example.com",
+			TeamDomain: models.TeamDomainDeveloper,
+			TeamRole:   models.TeamRoleMember,
+		},
+		{
+			TeamID:     uuidPtr(),
+			UserID:     "I789012",
+			FirstName:  "Mary",
+			LastName:   "Johnson",
+			Email:      "mary.johnson@example.com",
+			TeamDomain: models.TeamDomainPO,
+			TeamRole:   models.TeamRoleManager,
+		},
+	}
+	expectedTotal := int64(2)
 
-			return nil
-		}).
+	suite.mockUserRepo.EXPECT().
+		SearchByOrganization(orgID, query, false, limit, offset).
+		Return(existingUsers, expectedTotal, nil).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID)
+	responses, total, err := suite.userService.SearchUsers(orgID, query, false, limit, offset)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), expectedTotal, total)
+	assert.Len(suite.T(), responses, 2)
+	assert.Equal(suite.T(), existingUsers[0].Email, responses[0].Email)
+	assert.Equal(suite.T(), existingUsers[1].Email, responses[1].Email)
 }
 
-// TestAddFavoriteLinkByUserID_EmptyUserID tests error when userID is empty
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_EmptyUserID() {
-	linkID := uuid.New()
-
-	response, err := suite.userService.AddFavoriteLinkByUserID("", linkID)
-
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user_id is required")
-}
+// TestSearchMembersError tests searching for members with error
+func (suite *UserServiceTestSuite) TestSearchMembersError() {
+	orgID := uuid.New()
+	query := "test"
+	limit, offset := 20, 0
 
-// TestAddFavoriteLinkByUserID_NilLinkID tests error when linkID is nil
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_NilLinkID() {
-	userID := "I123456"
+	suite.mockUserRepo.EXPECT().
+		SearchByOrganization(orgID, query, false, limit, offset).
+		Return(nil, int64(0), gorm.ErrInvalidDB).
+		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, uuid.Nil)
+	responses, total, err := suite.userService.SearchUsers(orgID, query, false, limit, offset)
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "link_id is required")
+	assert.Nil(suite.T(), responses)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Contains(suite.T(), err.Error(), "failed to search users")
 }
 
-// TestAddFavoriteLinkByUserID_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_UserNotFound() {
-	userID := "I123456"
-	linkID := uuid.New()
+// TestGetActiveMembers tests getting active members
+func (suite *UserServiceTestSuite) TestGetActiveMembers() {
+	orgID := uuid.New()
+	limit, offset := 20, 0
+	existingUsers := []models.User{
+		{
+			TeamID:     uuidPtr(),
+			UserID:     "I123456",
+			FirstName:  "Active",
+			LastName:   "Smith",
+			Email:      "active.smith@example.com",
+			TeamDomain: models.TeamDomainDeveloper,
+			TeamRole:   models.TeamRoleMember,
+		},
+		{
+			TeamID:     uuidPtr(),
+			UserID:     "I789012",
+			FirstName:  "Active",
+			LastName:   "Jones",
+			Email:      "active.jones@example.com",
+			TeamDomain: models.TeamDomainPO,
+			TeamRole:   models.TeamRoleManager,
+		},
+	}
+	expectedTotal := int64(2)
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(nil, gorm.ErrRecordNotFound).
+		GetActiveByOrganization(orgID, limit, offset).
+		Return(existingUsers, expectedTotal, nil).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID)
+	responses, total, err := suite.userService.GetActiveUsers(orgID, limit, offset)
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedTotal, total)
+	assert.Len(suite.T(), responses, 2)
+	assert.Equal(suite.T(), existingUsers[0].Email, responses[0].Email)
+	assert.Equal(suite.T(), existingUsers[1].Email, responses[1].Email)
 }
 
-// TestAddFavoriteLinkByUserID_InvalidMetadata tests handling of invalid metadata JSON
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_InvalidMetadata() {
-	userID := "I123456"
-	linkID := uuid.New()
-
-	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(`invalid json`) // Invalid JSON
+// TestGetActiveMembersError tests getting active members with error
+func (suite *UserServiceTestSuite) TestGetActiveMembersError() {
+	orgID := uuid.New()
+	limit, offset := 20, 0
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
+		GetActiveByOrganization(orgID, limit, offset).
+		Return(nil, int64(0), gorm.ErrInvalidDB).
 		Times(1)
 
-	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			// Should reset to empty object and add favorites
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
+	responses, total, err := suite.userService.GetActiveUsers(orgID, limit, offset)
 
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), responses)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Contains(suite.T(), err.Error(), "failed to get active users")
+}
 
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 1)
-			assert.Equal(suite.T(), linkID.String(), favArray[0])
+// TestGetActiveUsersSince tests that only members active after the cutoff are returned
+func (suite *UserServiceTestSuite) TestGetActiveUsersSince() {
+	orgID := uuid.New()
+	limit, offset := 20, 0
+	since := time.Now().Add(-7 * 24 * time.Hour)
+
+	recent := models.User{
+		BaseModel:  models.BaseModel{ID: uuid.New(), UpdatedAt: since.Add(24 * time.Hour)},
+		UserID:     "I111111",
+		FirstName:  "Recent",
+		LastName:   "Smith",
+		Email:      "recent.smith@example.com",
+		TeamDomain: models.TeamDomainDeveloper,
+		TeamRole:   models.TeamRoleMember,
+	}
+	stale := models.User{
+		BaseModel:  models.BaseModel{ID: uuid.New(), UpdatedAt: since.Add(-24 * time.Hour)},
+		UserID:     "I222222",
+		FirstName:  "Stale",
+		LastName:   "Jones",
+		Email:      "stale.jones@example.com",
+		TeamDomain: models.TeamDomainDeveloper,
+		TeamRole:   models.TeamRoleMember,
+	}
 
-			return nil
+	// The repository applies the cutoff to the stored members
+	suite.mockUserRepo.EXPECT().
+		GetActiveByOrganizationSince(orgID, since, limit, offset).
+		DoAndReturn(func(_ uuid.UUID, cutoff time.Time, _, _ int) ([]models.User, int64, error) {
+			var active []models.User
+			for _, u := range []models.User{recent, stale} {
+				if u.UpdatedAt.After(cutoff) {
+					active = append(active, u)
+				}
+			}
+			return active, int64(len(active)), nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID)
+	responses, total, err := suite.userService.GetActiveUsersSince(orgID, since, limit, offset)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Len(suite.T(), responses, 1)
+	assert.Equal(suite.T(), recent.Email, responses[0].Email)
 }
 
-// TestAddFavoriteLinkByUserID_UpdateFails tests error when repository update fails
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_UpdateFails() {
-	userID := "I123456"
-	linkID := uuid.New()
-
-	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = nil
+// TestGetActiveUsersSinceError tests the error path of GetActiveUsersSince
+func (suite *UserServiceTestSuite) TestGetActiveUsersSinceError() {
+	orgID := uuid.New()
+	since := time.Now()
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
+		GetActiveByOrganizationSince(orgID, since, 20, 0).
+		Return(nil, int64(0), gorm.ErrInvalidDB).
 		Times(1)
 
+	responses, total, err := suite.userService.GetActiveUsersSince(orgID, since, 20, 0)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), responses)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Contains(suite.T(), err.Error(), "failed to get active users")
+}
+
+// TestUpdateMemberNotFound tests updating a member that doesn't exist
+func (suite *UserServiceTestSuite) TestUpdateMemberNotFound() {
+	userID := uuid.New()
+	newFirstName := "John"
+	req := &service.UpdateUserRequest{
+		FirstName: &newFirstName,
+	}
+
 	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		Return(gorm.ErrInvalidDB).
+		GetByID(userID).
+		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID)
+	response, err := suite.userService.UpdateUser(userID, req)
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "failed to update user")
+	assert.Contains(suite.T(), err.Error(), "user not found")
 }
 
-// TestAddFavoriteLinkByUserID_FavoritesAsInterfaceArray tests handling favorites as []interface{} type
-func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_FavoritesAsInterfaceArray() {
-	userID := "I123456"
-	existingLinkID := uuid.New()
-	newLinkID := uuid.New()
+func (suite *UserServiceTestSuite) TestUpdateMemberEmailConflict() {
+	userID := uuid.New()
+	existingUser := suite.factories.User.Create()
+	existingUser.TeamID = &userID
 
-	// Create metadata with favorites as []interface{}
-	existingMetadata := map[string]interface{}{
-		"favorites": []interface{}{existingLinkID.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+	conflictingEmail := "taken@example.com"
+	conflictingUser := suite.factories.User.WithEmail(conflictingEmail)
+	conflictingUser.TeamID = uuidPtr()
 
-	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	req := &service.UpdateUserRequest{
+		Email: &conflictingEmail,
+	}
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
 	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
-
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
-
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 2)
-
-			return nil
-		}).
+		GetByEmail(conflictingEmail).
+		Return(conflictingUser, nil).
 		Times(1)
 
-	response, err := suite.userService.AddFavoriteLinkByUserID(userID, newLinkID)
+	response, err := suite.userService.UpdateUser(userID, req)
 
-	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user already exists")
 }
 
-// TestRemoveFavoriteLinkByUserID_Success tests successfully removing a favorite link from a user
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_Success() {
-	userID := "I123456"
-	linkToRemove := uuid.New()
-	linkToKeep := uuid.New()
+// ===== Tests for UpdateUserTeam =====
 
-	existingMetadata := map[string]interface{}{
-		"favorites": []string{linkToRemove.String(), linkToKeep.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+// TestUpdateUserTeam_Success tests successfully updating a user's team
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_Success() {
+	userID := uuid.New()
+	teamID := uuid.New()
+	updatedBy := "I999999"
 
 	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.TeamID = nil // No team initially
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			// Verify metadata was updated correctly
-			assert.NotNil(suite.T(), user.Metadata)
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
-
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
-
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 1)
-			assert.Equal(suite.T(), linkToKeep.String(), favArray[0])
-			// Verify removed link is not present
-			assert.NotContains(suite.T(), favArray, linkToRemove.String())
-
+			// Verify the team was updated
+			assert.NotNil(suite.T(), user.TeamID)
+			assert.Equal(suite.T(), teamID, *user.TeamID)
+			assert.Equal(suite.T(), updatedBy, user.UpdatedBy)
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkToRemove)
+	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
+	assert.Equal(suite.T(), existingUser.UserID, response.ID)
 }
 
-// TestRemoveFavoriteLinkByUserID_RemoveLastLink tests removing the last favorite link
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_RemoveLastLink() {
-	userID := "I123456"
-	linkID := uuid.New()
+// TestUpdateUserTeam_EmptyUpdatedBy tests error when updatedBy is empty
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_EmptyUpdatedBy() {
+	userID := uuid.New()
+	teamID := uuid.New()
+	updatedBy := ""
 
-	existingMetadata := map[string]interface{}{
-		"favorites": []string{linkID.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "updated_by is required")
+}
+
+// TestUpdateUserTeam_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_UserNotFound() {
+	userID := uuid.New()
+	teamID := uuid.New()
+	updatedBy := "I999999"
+
+	suite.mockUserRepo.EXPECT().
+		GetByID(userID).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestUpdateUserTeam_UpdateFails tests error when repository update fails
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_UpdateFails() {
+	userID := uuid.New()
+	teamID := uuid.New()
+	updatedBy := "I999999"
 
 	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.TeamID = nil
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
+		Return(gorm.ErrInvalidDB).
+		Times(1)
 
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
+	response, err := suite.userService.UpdateUserTeam(userID, teamID, updatedBy)
 
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			// Should be empty array after removing last link
-			assert.Len(suite.T(), favArray, 0)
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "failed to update user team")
+}
 
-			return nil
-		}).
+// withOrganizationRepositories configures the service with mock team and group repositories
+func (suite *UserServiceTestSuite) withOrganizationRepositories() (*mocks.MockTeamRepositoryInterface, *mocks.MockGroupRepositoryInterface) {
+	teamRepo := mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	groupRepo := mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.userService.SetOrganizationRepositories(teamRepo, groupRepo)
+	return teamRepo, groupRepo
+}
+
+// TestSearchUsersByOwnedOrg tests that only members of the organization's teams are matched
+func (suite *UserServiceTestSuite) TestSearchUsersByOwnedOrg() {
+	teamRepo, groupRepo := suite.withOrganizationRepositories()
+	orgID := uuid.New()
+	groupID := uuid.New()
+	teamA := uuid.New()
+	teamB := uuid.New()
+
+	inOrg := models.User{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "I111111", FirstName: "Alice", LastName: "Smith", Email: "alice.smith@example.com", TeamID: &teamB}
+
+	groupRepo.EXPECT().GetByOrganizationID(orgID, gomock.Any(), 0).
+		Return([]models.Group{{BaseModel: models.BaseModel{ID: groupID}, OrgID: orgID}}, int64(1), nil).Times(1)
+	teamRepo.EXPECT().GetByGroupID(groupID, gomock.Any(), 0).
+		Return([]models.Team{{BaseModel: models.BaseModel{ID: teamA}}, {BaseModel: models.BaseModel{ID: teamB}}}, int64(2), nil).Times(1)
+
+	// Only the organization's teams are searched; the repository scoping is covered by its DB test
+	suite.mockUserRepo.EXPECT().
+		SearchByTeamIDs([]uuid.UUID{teamA, teamB}, "alice", 20, 0).
+		Return([]models.User{inOrg}, int64(1), nil).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID)
+	responses, total, err := suite.userService.SearchUsersByOwnedOrg(orgID, "alice", 20, 0)
 
-	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), total)
+	suite.Require().Len(responses, 1)
+	assert.Equal(suite.T(), inOrg.Email, responses[0].Email)
 }
 
-// TestRemoveFavoriteLinkByUserID_NoExistingFavorites tests removing a link when no favorites exist
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_NoExistingFavorites() {
-	userID := "I123456"
-	linkID := uuid.New()
+// TestSearchUsersByOwnedOrg_PagesThroughGroupsAndTeams tests that organizations with more groups and teams
+// than fit in one page are fully resolved
+func (suite *UserServiceTestSuite) TestSearchUsersByOwnedOrg_PagesThroughGroupsAndTeams() {
+	teamRepo, groupRepo := suite.withOrganizationRepositories()
+	orgID := uuid.New()
 
-	existingMetadata := map[string]interface{}{
-		"portal_admin": true,
+	groups := make([]models.Group, 1200)
+	for i := range groups {
+		groups[i] = models.Group{BaseModel: models.BaseModel{ID: uuid.New()}, OrgID: orgID}
+	}
+	// The last group holds more teams than fit in one page
+	lastGroup := groups[len(groups)-1].ID
+	teams := make([]models.Team, 1200)
+	for i := range teams {
+		teams[i] = models.Team{BaseModel: models.BaseModel{ID: uuid.New()}, GroupID: lastGroup}
 	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
 
-	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	groupRepo.EXPECT().GetByOrganizationID(orgID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ uuid.UUID, limit, offset int) ([]models.Group, int64, error) {
+			end := min(offset+limit, len(groups))
+			return groups[offset:end], int64(len(groups)), nil
+		}).AnyTimes()
+	teamRepo.EXPECT().GetByGroupID(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(groupID uuid.UUID, limit, offset int) ([]models.Team, int64, error) {
+			if groupID != lastGroup {
+				return []models.Team{}, 0, nil
+			}
+			end := min(offset+limit, len(teams))
+			return teams[offset:end], int64(len(teams)), nil
+		}).AnyTimes()
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
+		SearchByTeamIDs(gomock.Len(len(teams)), "alice", 20, 0).
+		Return([]models.User{}, int64(0), nil).
 		Times(1)
 
-	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
+	_, _, err := suite.userService.SearchUsersByOwnedOrg(orgID, "alice", 20, 0)
 
-			// Verify other metadata is preserved
-			assert.Equal(suite.T(), true, meta["portal_admin"])
+	suite.Require().NoError(err)
+}
 
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
+// TestSearchUsersByOwnedOrg_NotConfigured tests that the search requires the organization repositories
+func (suite *UserServiceTestSuite) TestSearchUsersByOwnedOrg_NotConfigured() {
+	responses, total, err := suite.userService.SearchUsersByOwnedOrg(uuid.New(), "alice", 20, 0)
 
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			// Should be empty array
-			assert.Len(suite.T(), favArray, 0)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrOrgReposNotConfigured)
+	assert.Nil(suite.T(), responses)
+	assert.Equal(suite.T(), int64(0), total)
+}
 
-			return nil
-		}).
-		Times(1)
+// withTeamRepository configures the service with a mock team repository so team existence is checked
+func (suite *UserServiceTestSuite) withTeamRepository() *mocks.MockTeamRepositoryInterface {
+	teamRepo := mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.userService.SetOrganizationRepositories(teamRepo, mocks.NewMockGroupRepositoryInterface(suite.ctrl))
+	return teamRepo
+}
+
+// TestUpdateUserTeam_ExistingTeam tests moving a user to a team that exists
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_ExistingTeam() {
+	teamRepo := suite.withTeamRepository()
+	userID := uuid.New()
+	teamID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.TeamID = nil
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID)
+	suite.mockUserRepo.EXPECT().GetByID(userID).Return(existingUser, nil).Times(1)
+	teamRepo.EXPECT().GetByID(teamID).Return(&models.Team{BaseModel: models.BaseModel{ID: teamID}}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+
+	response, err := suite.userService.UpdateUserTeam(userID, teamID, "I999999")
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), &teamID, response.TeamID)
 }
 
-// TestRemoveFavoriteLinkByUserID_NoMetadata tests removing a link when user has no metadata
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_NoMetadata() {
-	userID := "I123456"
-	linkID := uuid.New()
+// TestUpdateUserTeam_NonexistentTeam tests that a user cannot be moved to a team that does not exist
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_NonexistentTeam() {
+	teamRepo := suite.withTeamRepository()
+	userID := uuid.New()
+	teamID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = nil // No metadata
+	existingUser.TeamID = nil
+
+	suite.mockUserRepo.EXPECT().GetByID(userID).Return(existingUser, nil).Times(1)
+	teamRepo.EXPECT().GetByID(teamID).Return(nil, gorm.ErrRecordNotFound).Times(1)
+	// Update must not be called
+
+	response, err := suite.userService.UpdateUserTeam(userID, teamID, "I999999")
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrTeamNotFound)
+	assert.Nil(suite.T(), response)
+	assert.Nil(suite.T(), existingUser.TeamID)
+}
+
+// TestUpdateUserTeam_EmptyUpdatedBy_WithTeamRepository tests that updatedBy is validated before any lookup
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_EmptyUpdatedBy_WithTeamRepository() {
+	suite.withTeamRepository()
+
+	response, err := suite.userService.UpdateUserTeam(uuid.New(), uuid.New(), "")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "updated_by is required")
+}
+
+// TestUpdateUserTeam_ChangeExistingTeam tests updating a user who already has a team
+func (suite *UserServiceTestSuite) TestUpdateUserTeam_ChangeExistingTeam() {
+	userID := uuid.New()
+	oldTeamID := uuid.New()
+	newTeamID := uuid.New()
+	updatedBy := "I999999"
+
+	existingUser := suite.factories.User.Create()
+	existingUser.TeamID = &oldTeamID // Already has a team
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
-
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
-
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			// Should be empty array
-			assert.Len(suite.T(), favArray, 0)
-
+			// Verify the team was changed to the new team
+			assert.NotNil(suite.T(), user.TeamID)
+			assert.Equal(suite.T(), newTeamID, *user.TeamID)
+			assert.NotEqual(suite.T(), oldTeamID, *user.TeamID)
+			assert.Equal(suite.T(), updatedBy, user.UpdatedBy)
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID)
+	response, err := suite.userService.UpdateUserTeam(userID, newTeamID, updatedBy)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), existingUser.UserID, response.ID)
 }
 
-// TestRemoveFavoriteLinkByUserID_Idempotent tests that removing a non-existent link is idempotent
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_Idempotent() {
-	userID := "I123456"
-	existingLinkID := uuid.New()
-	nonExistentLinkID := uuid.New()
+// ===== Tests for RemoveUserFromTeam =====
 
-	existingMetadata := map[string]interface{}{
-		"favorites": []string{existingLinkID.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+// TestRemoveUserFromTeam_Success tests unassigning a user who has a team
+func (suite *UserServiceTestSuite) TestRemoveUserFromTeam_Success() {
+	userID := uuid.New()
+	teamID := uuid.New()
+	updatedBy := "I999999"
 
 	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.TeamID = &teamID
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
-
-			favorites, ok := meta["favorites"]
-			assert.True(suite.T(), ok)
-
-			favArray, ok := favorites.([]interface{})
-			assert.True(suite.T(), ok)
-			// Should still have the existing link
-			assert.Len(suite.T(), favArray, 1)
-			assert.Equal(suite.T(), existingLinkID.String(), favArray[0])
-
+			// Verify the team was cleared
+			assert.Nil(suite.T(), user.TeamID)
+			assert.Equal(suite.T(), updatedBy, user.UpdatedBy)
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, nonExistentLinkID)
+	response, err := suite.userService.RemoveUserFromTeam(userID, updatedBy)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
+	assert.Nil(suite.T(), response.TeamID)
 }
 
-// TestRemoveFavoriteLinkByUserID_EmptyUserID tests error when userID is empty
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_EmptyUserID() {
-	linkID := uuid.New()
+// TestRemoveUserFromTeam_AlreadyUnassigned tests unassigning a user without a team succeeds
+func (suite *UserServiceTestSuite) TestRemoveUserFromTeam_AlreadyUnassigned() {
+	userID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.TeamID = nil
+
+	suite.mockUserRepo.EXPECT().
+		GetByID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	response, err := suite.userService.RemoveUserFromTeam(userID, "I999999")
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Nil(suite.T(), response.TeamID)
+}
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID("", linkID)
+// TestRemoveUserFromTeam_EmptyUpdatedBy tests error when updatedBy is empty
+func (suite *UserServiceTestSuite) TestRemoveUserFromTeam_EmptyUpdatedBy() {
+	response, err := suite.userService.RemoveUserFromTeam(uuid.New(), "  ")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user_id is required")
+	assert.Contains(suite.T(), err.Error(), "updated_by is required")
 }
 
-// TestRemoveFavoriteLinkByUserID_NilLinkID tests error when linkID is nil
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_NilLinkID() {
-	userID := "I123456"
+// TestRemoveUserFromTeam_UpdateFails tests error when repository update fails
+func (suite *UserServiceTestSuite) TestRemoveUserFromTeam_UpdateFails() {
+	userID := uuid.New()
+	teamID := uuid.New()
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, uuid.Nil)
+	existingUser := suite.factories.User.Create()
+	existingUser.TeamID = &teamID
+
+	suite.mockUserRepo.EXPECT().
+		GetByID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		Return(gorm.ErrInvalidDB).
+		Times(1)
+
+	response, err := suite.userService.RemoveUserFromTeam(userID, "I999999")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "link_id is required")
+	assert.Contains(suite.T(), err.Error(), "failed to remove user from team")
 }
 
-// TestRemoveFavoriteLinkByUserID_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_UserNotFound() {
-	userID := "I123456"
-	linkID := uuid.New()
+// TestDeleteMemberNotFound tests deleting a member that doesn't exist
+func (suite *UserServiceTestSuite) TestDeleteMemberNotFound() {
+	userID := uuid.New()
 
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
+		GetByID(userID).
 		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID)
+	err := suite.userService.DeleteUser(userID)
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
 	assert.Contains(suite.T(), err.Error(), "user not found")
 }
 
-// TestRemoveFavoriteLinkByUserID_InvalidMetadata tests handling of invalid metadata JSON
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_InvalidMetadata() {
+// TestAddFavoriteLinkByUserID_Success tests successfully adding a favorite link to a user with no existing metadata
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_Success() {
 	userID := "I123456"
 	linkID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
 	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(`invalid json`) // Invalid JSON
+	existingUser.Metadata = nil // No existing metadata
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
@@ -1246,7 +1494,8 @@ func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_InvalidMetadat
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			// Should reset to empty object and create empty favorites array
+			// Verify metadata was updated correctly
+			assert.NotNil(suite.T(), user.Metadata)
 			var meta map[string]interface{}
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
@@ -1256,58 +1505,72 @@ func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_InvalidMetadat
 
 			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 0)
+			assert.Len(suite.T(), favArray, 1)
+			assert.Equal(suite.T(), linkID.String(), favArray[0])
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
 }
 
-// TestRemoveFavoriteLinkByUserID_UpdateFails tests error when repository update fails
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_UpdateFails() {
+// TestAddFavoriteLinkByUserID_MetadataTypeMismatch tests that an invalid key unrelated to the write is kept and does not reject it
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_MetadataTypeMismatch() {
 	userID := "I123456"
 	linkID := uuid.New()
 
-	existingMetadata := map[string]interface{}{
-		"favorites": []string{linkID.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
-
 	existingUser := suite.factories.User.Create()
 	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
-
-	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
-		Times(1)
+	existingUser.Metadata = json.RawMessage(`{"ai_instances":"team-a"}`)
 
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
-		Return(gorm.ErrInvalidDB).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			assert.NoError(suite.T(), json.Unmarshal(user.Metadata, &meta))
+			assert.Equal(suite.T(), "team-a", meta["ai_instances"])
+			assert.Equal(suite.T(), []interface{}{linkID.String()}, meta["favorites"])
+			return nil
+		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
 
-	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestAddFavoriteLinkByUserID_InvalidFavorites tests that a write is rejected when the written key fails validation
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_InvalidFavorites() {
+	userID := "I123456"
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"favorites":["not-a-uuid"]}`)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Times(0)
+
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, uuid.New(), userID)
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrInvalidMetadata)
 	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "failed to update user")
 }
 
-// TestRemoveFavoriteLinkByUserID_FavoritesAsInterfaceArray tests handling favorites as []interface{} type
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_FavoritesAsInterfaceArray() {
+// TestAddFavoriteLinkByUserID_WithExistingMetadata tests adding a favorite link to a user with existing metadata but no favorites
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_WithExistingMetadata() {
 	userID := "I123456"
-	linkToRemove := uuid.New()
-	linkToKeep := uuid.New()
+	linkID := uuid.New()
 
-	// Create metadata with favorites as []interface{}
 	existingMetadata := map[string]interface{}{
-		"favorites": []interface{}{linkToRemove.String(), linkToKeep.String()},
+		"portal_admin": true,
+		"other_field":  "value",
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -1327,34 +1590,37 @@ func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_FavoritesAsInt
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
+			// Verify existing fields are preserved
+			assert.Equal(suite.T(), true, meta["portal_admin"])
+			assert.Equal(suite.T(), "value", meta["other_field"])
+
+			// Verify favorites was added
 			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
 			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
 			assert.Len(suite.T(), favArray, 1)
-			assert.Equal(suite.T(), linkToKeep.String(), favArray[0])
+			assert.Equal(suite.T(), linkID.String(), favArray[0])
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkToRemove)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestRemoveFavoriteLinkByUserID_PreservesOtherMetadata tests that removing a link preserves other metadata fields
-func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_PreservesOtherMetadata() {
+// TestAddFavoriteLinkByUserID_WithExistingFavorites tests adding a favorite link to a user with existing favorites
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_WithExistingFavorites() {
 	userID := "I123456"
-	linkID := uuid.New()
+	existingLinkID := uuid.New()
+	newLinkID := uuid.New()
 
 	existingMetadata := map[string]interface{}{
-		"favorites":    []string{linkID.String()},
-		"portal_admin": true,
-		"subscribed":   []string{uuid.New().String()},
-		"custom_field": "custom_value",
+		"favorites": []string{existingLinkID.String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -1374,36 +1640,44 @@ func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_PreservesOther
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			// Verify all other fields are preserved
-			assert.Equal(suite.T(), true, meta["portal_admin"])
-			assert.NotNil(suite.T(), meta["subscribed"])
-			assert.Equal(suite.T(), "custom_value", meta["custom_field"])
-
-			// Verify favorites is now empty
 			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
+
 			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), favArray, 0)
+			assert.Len(suite.T(), favArray, 2)
+
+			// Verify both links are present
+			favStrings := make([]string, len(favArray))
+			for i, v := range favArray {
+				favStrings[i] = v.(string)
+			}
+			assert.Contains(suite.T(), favStrings, existingLinkID.String())
+			assert.Contains(suite.T(), favStrings, newLinkID.String())
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, newLinkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestAddSubscribedPluginByUserID_Success tests successfully adding a subscribed plugin to a user with no existing metadata
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_Success() {
+// TestAddFavoriteLinkByUserID_Deduplication tests that adding a duplicate link doesn't create duplicates
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_Deduplication() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"favorites": []string{linkID.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
 
 	existingUser := suite.factories.User.Create()
 	existingUser.UserID = userID
-	existingUser.Metadata = nil // No existing metadata
+	existingUser.Metadata = json.RawMessage(metadataBytes)
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
@@ -1413,45 +1687,76 @@ func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_Success() {
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			// Verify metadata was updated correctly
-			assert.NotNil(suite.T(), user.Metadata)
 			var meta map[string]interface{}
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 1)
-			assert.Equal(suite.T(), pluginID.String(), subArray[0])
+			// Should still be 1, not duplicated
+			assert.Len(suite.T(), favArray, 1)
+			assert.Equal(suite.T(), linkID.String(), favArray[0])
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
 }
 
-// TestAddSubscribedPluginByUserID_WithExistingMetadata tests adding a subscribed plugin to a user with existing metadata but no subscribed
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_WithExistingMetadata() {
+// TestAddFavoriteLinkByUserID_EmptyUserID tests error when userID is empty
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_EmptyUserID() {
+	linkID := uuid.New()
+
+	response, err := suite.userService.AddFavoriteLinkByUserID("", linkID, "I123456")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user_id is required")
+}
+
+// TestAddFavoriteLinkByUserID_NilLinkID tests error when linkID is nil
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_NilLinkID() {
 	userID := "I123456"
-	pluginID := uuid.New()
 
-	existingMetadata := map[string]interface{}{
-		"portal_admin": true,
-		"favorites":    []string{uuid.New().String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, uuid.Nil, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "link_id is required")
+}
+
+// TestAddFavoriteLinkByUserID_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_UserNotFound() {
+	userID := "I123456"
+	linkID := uuid.New()
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestAddFavoriteLinkByUserID_InvalidMetadata tests handling of invalid metadata JSON
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_InvalidMetadata() {
+	userID := "I123456"
+	linkID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
 	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.Metadata = json.RawMessage(`invalid json`) // Invalid JSON
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
@@ -1461,47 +1766,37 @@ func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_WithExistingM
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
+			// Should reset to empty object and add favorites
 			var meta map[string]interface{}
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			// Verify existing fields are preserved
-			assert.Equal(suite.T(), true, meta["portal_admin"])
-			assert.NotNil(suite.T(), meta["favorites"])
-
-			// Verify subscribed was added
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 1)
-			assert.Equal(suite.T(), pluginID.String(), subArray[0])
+			assert.Len(suite.T(), favArray, 1)
+			assert.Equal(suite.T(), linkID.String(), favArray[0])
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestAddSubscribedPluginByUserID_WithExistingSubscribed tests adding a subscribed plugin to a user with existing subscribed plugins
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_WithExistingSubscribed() {
+// TestAddFavoriteLinkByUserID_UpdateFails tests error when repository update fails
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_UpdateFails() {
 	userID := "I123456"
-	existingPluginID := uuid.New()
-	newPluginID := uuid.New()
-
-	existingMetadata := map[string]interface{}{
-		"subscribed": []string{existingPluginID.String()},
-	}
-	metadataBytes, _ := json.Marshal(existingMetadata)
+	linkID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
 	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.Metadata = nil
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
@@ -1510,43 +1805,25 @@ func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_WithExistingS
 
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
+		Return(gorm.ErrInvalidDB).
+		Times(1)
 
-			subscribed, ok := meta["subscribed"]
-			assert.True(suite.T(), ok)
-
-			subArray, ok := subscribed.([]interface{})
-			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 2)
-
-			// Verify both plugins are present
-			subStrings := make([]string, len(subArray))
-			for i, v := range subArray {
-				subStrings[i] = v.(string)
-			}
-			assert.Contains(suite.T(), subStrings, existingPluginID.String())
-			assert.Contains(suite.T(), subStrings, newPluginID.String())
-
-			return nil
-		}).
-		Times(1)
-
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, newPluginID)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
 
-	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "failed to update user")
 }
 
-// TestAddSubscribedPluginByUserID_Deduplication tests that adding a duplicate plugin doesn't create duplicates
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_Deduplication() {
+// TestAddFavoriteLinkByUserID_FavoritesAsInterfaceArray tests handling favorites as []interface{} type
+func (suite *UserServiceTestSuite) TestAddFavoriteLinkByUserID_FavoritesAsInterfaceArray() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	existingLinkID := uuid.New()
+	newLinkID := uuid.New()
 
+	// Create metadata with favorites as []interface{}
 	existingMetadata := map[string]interface{}{
-		"subscribed": []string{pluginID.String()},
+		"favorites": []interface{}{existingLinkID.String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -1566,138 +1843,31 @@ func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_Deduplication
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
-			assert.True(suite.T(), ok)
-
-			subArray, ok := subscribed.([]interface{})
-			assert.True(suite.T(), ok)
-			// Should still be 1, not duplicated
-			assert.Len(suite.T(), subArray, 1)
-			assert.Equal(suite.T(), pluginID.String(), subArray[0])
-
-			return nil
-		}).
-		Times(1)
-
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID)
-
-	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-}
-
-// TestAddSubscribedPluginByUserID_EmptyUserID tests error when userID is empty
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_EmptyUserID() {
-	pluginID := uuid.New()
-
-	response, err := suite.userService.AddSubscribedPluginByUserID("", pluginID)
-
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user_id is required")
-}
-
-// TestAddSubscribedPluginByUserID_NilPluginID tests error when pluginID is nil
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_NilPluginID() {
-	userID := "I123456"
-
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, uuid.Nil)
-
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "plugin_id is required")
-}
-
-// TestAddSubscribedPluginByUserID_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_UserNotFound() {
-	userID := "I123456"
-	pluginID := uuid.New()
-
-	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(nil, apperrors.ErrUserNotFound).
-		Times(1)
-
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID)
-
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
-}
-
-// TestAddSubscribedPluginByUserID_InvalidMetadata tests handling of invalid metadata JSON
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_InvalidMetadata() {
-	userID := "I123456"
-	pluginID := uuid.New()
-
-	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(`invalid json`) // Invalid JSON
-
-	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
-		Times(1)
-
-	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		DoAndReturn(func(user *models.User) error {
-			// Should reset to empty object and add subscribed
-			var meta map[string]interface{}
-			err := json.Unmarshal(user.Metadata, &meta)
-			assert.NoError(suite.T(), err)
-
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 1)
-			assert.Equal(suite.T(), pluginID.String(), subArray[0])
+			assert.Len(suite.T(), favArray, 2)
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.AddFavoriteLinkByUserID(userID, newLinkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestAddSubscribedPluginByUserID_UpdateFails tests error when repository update fails
-func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_UpdateFails() {
-	userID := "I123456"
-	pluginID := uuid.New()
-
-	existingUser := suite.factories.User.Create()
-	existingUser.UserID = userID
-	existingUser.Metadata = nil
-
-	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
-		Times(1)
-
-	suite.mockUserRepo.EXPECT().
-		Update(gomock.Any()).
-		Return(gorm.ErrInvalidDB).
-		Times(1)
-
-	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID)
-
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "failed to update user")
-}
-
-// TestRemoveSubscribedPluginByUserID_Success tests successfully removing a subscribed plugin from a user
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_Success() {
+// TestRemoveFavoriteLinkByUserID_Success tests successfully removing a favorite link from a user
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_Success() {
 	userID := "I123456"
-	pluginToRemove := uuid.New()
-	pluginToKeep := uuid.New()
+	linkToRemove := uuid.New()
+	linkToKeep := uuid.New()
 
 	existingMetadata := map[string]interface{}{
-		"subscribed": []string{pluginToRemove.String(), pluginToKeep.String()},
+		"favorites": []string{linkToRemove.String(), linkToKeep.String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -1719,34 +1889,34 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_Success()
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 1)
-			assert.Equal(suite.T(), pluginToKeep.String(), subArray[0])
-			// Verify removed plugin is not present
-			assert.NotContains(suite.T(), subArray, pluginToRemove.String())
+			assert.Len(suite.T(), favArray, 1)
+			assert.Equal(suite.T(), linkToKeep.String(), favArray[0])
+			// Verify removed link is not present
+			assert.NotContains(suite.T(), favArray, linkToRemove.String())
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginToRemove)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkToRemove, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 	assert.Equal(suite.T(), userID, response.ID)
 }
 
-// TestRemoveSubscribedPluginByUserID_RemoveLastPlugin tests removing the last subscribed plugin
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_RemoveLastPlugin() {
+// TestRemoveFavoriteLinkByUserID_RemoveLastLink tests removing the last favorite link
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_RemoveLastLink() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
 
 	existingMetadata := map[string]interface{}{
-		"subscribed": []string{pluginID.String()},
+		"favorites": []string{linkID.String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -1766,32 +1936,31 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_RemoveLast
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			// Should be empty array after removing last plugin
-			assert.Len(suite.T(), subArray, 0)
+			// Should be empty array after removing last link
+			assert.Len(suite.T(), favArray, 0)
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestRemoveSubscribedPluginByUserID_NoExistingSubscribed tests removing a plugin when no subscribed exist
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NoExistingSubscribed() {
+// TestRemoveFavoriteLinkByUserID_NoExistingFavorites tests removing a link when no favorites exist
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_NoExistingFavorites() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
 
 	existingMetadata := map[string]interface{}{
 		"portal_admin": true,
-		"favorites":    []string{uuid.New().String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -1813,30 +1982,29 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NoExisting
 
 			// Verify other metadata is preserved
 			assert.Equal(suite.T(), true, meta["portal_admin"])
-			assert.NotNil(suite.T(), meta["favorites"])
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
 			// Should be empty array
-			assert.Len(suite.T(), subArray, 0)
+			assert.Len(suite.T(), favArray, 0)
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestRemoveSubscribedPluginByUserID_NoMetadata tests removing a plugin when user has no metadata
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NoMetadata() {
+// TestRemoveFavoriteLinkByUserID_NoMetadata tests removing a link when user has no metadata
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_NoMetadata() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
 	existingUser.UserID = userID
@@ -1854,32 +2022,32 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NoMetadata
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
 			// Should be empty array
-			assert.Len(suite.T(), subArray, 0)
+			assert.Len(suite.T(), favArray, 0)
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestRemoveSubscribedPluginByUserID_Idempotent tests that removing a non-existent plugin is idempotent
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_Idempotent() {
+// TestRemoveFavoriteLinkByUserID_Idempotent tests that removing a non-existent link is idempotent
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_Idempotent() {
 	userID := "I123456"
-	existingPluginID := uuid.New()
-	nonExistentPluginID := uuid.New()
+	existingLinkID := uuid.New()
+	nonExistentLinkID := uuid.New()
 
 	existingMetadata := map[string]interface{}{
-		"subscribed": []string{existingPluginID.String()},
+		"favorites": []string{existingLinkID.String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -1899,68 +2067,68 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_Idempotent
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			// Should still have the existing plugin
-			assert.Len(suite.T(), subArray, 1)
-			assert.Equal(suite.T(), existingPluginID.String(), subArray[0])
+			// Should still have the existing link
+			assert.Len(suite.T(), favArray, 1)
+			assert.Equal(suite.T(), existingLinkID.String(), favArray[0])
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, nonExistentPluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, nonExistentLinkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestRemoveSubscribedPluginByUserID_EmptyUserID tests error when userID is empty
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_EmptyUserID() {
-	pluginID := uuid.New()
+// TestRemoveFavoriteLinkByUserID_EmptyUserID tests error when userID is empty
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_EmptyUserID() {
+	linkID := uuid.New()
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID("", pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID("", linkID, "I123456")
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
 	assert.Contains(suite.T(), err.Error(), "user_id is required")
 }
 
-// TestRemoveSubscribedPluginByUserID_NilPluginID tests error when pluginID is nil
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NilPluginID() {
+// TestRemoveFavoriteLinkByUserID_NilLinkID tests error when linkID is nil
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_NilLinkID() {
 	userID := "I123456"
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, uuid.Nil)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, uuid.Nil, userID)
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "plugin_id is required")
+	assert.Contains(suite.T(), err.Error(), "link_id is required")
 }
 
-// TestRemoveSubscribedPluginByUserID_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_UserNotFound() {
+// TestRemoveFavoriteLinkByUserID_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_UserNotFound() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
 		Return(nil, apperrors.ErrUserNotFound).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
 	assert.Contains(suite.T(), err.Error(), "user not found")
 }
 
-// TestRemoveSubscribedPluginByUserID_InvalidMetadata tests handling of invalid metadata JSON
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_InvalidMetadata() {
+// TestRemoveFavoriteLinkByUserID_InvalidMetadata tests handling of invalid metadata JSON
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_InvalidMetadata() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
 	existingUser.UserID = userID
@@ -1974,35 +2142,35 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_InvalidMet
 	suite.mockUserRepo.EXPECT().
 		Update(gomock.Any()).
 		DoAndReturn(func(user *models.User) error {
-			// Should reset to empty object and create empty subscribed array
+			// Should reset to empty object and create empty favorites array
 			var meta map[string]interface{}
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 0)
+			assert.Len(suite.T(), favArray, 0)
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestRemoveSubscribedPluginByUserID_UpdateFails tests error when repository update fails
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_UpdateFails() {
+// TestRemoveFavoriteLinkByUserID_UpdateFails tests error when repository update fails
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_UpdateFails() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
 
 	existingMetadata := map[string]interface{}{
-		"subscribed": []string{pluginID.String()},
+		"favorites": []string{linkID.String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -2020,22 +2188,22 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_UpdateFail
 		Return(gorm.ErrInvalidDB).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
 	assert.Contains(suite.T(), err.Error(), "failed to update user")
 }
 
-// TestRemoveSubscribedPluginByUserID_SubscribedAsInterfaceArray tests handling subscribed as []interface{} type
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_SubscribedAsInterfaceArray() {
+// TestRemoveFavoriteLinkByUserID_FavoritesAsInterfaceArray tests handling favorites as []interface{} type
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_FavoritesAsInterfaceArray() {
 	userID := "I123456"
-	pluginToRemove := uuid.New()
-	pluginToKeep := uuid.New()
+	linkToRemove := uuid.New()
+	linkToKeep := uuid.New()
 
-	// Create metadata with subscribed as []interface{}
+	// Create metadata with favorites as []interface{}
 	existingMetadata := map[string]interface{}{
-		"subscribed": []interface{}{pluginToRemove.String(), pluginToKeep.String()},
+		"favorites": []interface{}{linkToRemove.String(), linkToKeep.String()},
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
 
@@ -2055,33 +2223,33 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_Subscribed
 			err := json.Unmarshal(user.Metadata, &meta)
 			assert.NoError(suite.T(), err)
 
-			subscribed, ok := meta["subscribed"]
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
 
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 1)
-			assert.Equal(suite.T(), pluginToKeep.String(), subArray[0])
+			assert.Len(suite.T(), favArray, 1)
+			assert.Equal(suite.T(), linkToKeep.String(), favArray[0])
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginToRemove)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkToRemove, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestRemoveSubscribedPluginByUserID_PreservesOtherMetadata tests that removing a plugin preserves other metadata fields
-func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_PreservesOtherMetadata() {
+// TestRemoveFavoriteLinkByUserID_PreservesOtherMetadata tests that removing a link preserves other metadata fields
+func (suite *UserServiceTestSuite) TestRemoveFavoriteLinkByUserID_PreservesOtherMetadata() {
 	userID := "I123456"
-	pluginID := uuid.New()
+	linkID := uuid.New()
 
 	existingMetadata := map[string]interface{}{
-		"subscribed":   []string{pluginID.String()},
+		"favorites":    []string{linkID.String()},
 		"portal_admin": true,
-		"favorites":    []string{uuid.New().String()},
+		"subscribed":   []string{uuid.New().String()},
 		"custom_field": "custom_value",
 	}
 	metadataBytes, _ := json.Marshal(existingMetadata)
@@ -2104,471 +2272,1731 @@ func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_PreservesO
 
 			// Verify all other fields are preserved
 			assert.Equal(suite.T(), true, meta["portal_admin"])
-			assert.NotNil(suite.T(), meta["favorites"])
+			assert.NotNil(suite.T(), meta["subscribed"])
 			assert.Equal(suite.T(), "custom_value", meta["custom_field"])
 
-			// Verify subscribed is now empty
-			subscribed, ok := meta["subscribed"]
+			// Verify favorites is now empty
+			favorites, ok := meta["favorites"]
 			assert.True(suite.T(), ok)
-			subArray, ok := subscribed.([]interface{})
+			favArray, ok := favorites.([]interface{})
 			assert.True(suite.T(), ok)
-			assert.Len(suite.T(), subArray, 0)
+			assert.Len(suite.T(), favArray, 0)
 
 			return nil
 		}).
 		Times(1)
 
-	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID)
+	response, err := suite.userService.RemoveFavoriteLinkByUserID(userID, linkID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 }
 
-// TestGetUserByUserID_Success tests successfully getting a user by their string UserID
-func (suite *UserServiceTestSuite) TestGetUserByUserID_Success() {
+// TestIsFavoriteLink_Present tests a link stored in metadata.favorites is reported as favorite
+func (suite *UserServiceTestSuite) TestIsFavoriteLink_Present() {
 	userID := "I123456"
-	teamID := uuid.New()
+	linkID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"favorites": []string{uuid.New().String(), linkID.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
 
 	existingUser := suite.factories.User.Create()
-	existingUser.TeamID = &teamID
 	existingUser.UserID = userID
-	existingUser.Mobile = "+1-555-0123"
+	existingUser.Metadata = json.RawMessage(metadataBytes)
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
-	response, err := suite.userService.GetUserByUserID(userID)
+	isFavorite, err := suite.userService.IsFavoriteLink(userID, linkID)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.Equal(suite.T(), existingUser.ID.String(), response.UUID)
-	assert.Equal(suite.T(), existingUser.FirstName, response.FirstName)
-	assert.Equal(suite.T(), existingUser.LastName, response.LastName)
-	assert.Equal(suite.T(), existingUser.Email, response.Email)
-	assert.Equal(suite.T(), existingUser.Mobile, response.Mobile)
-	assert.Equal(suite.T(), string(existingUser.TeamDomain), response.TeamDomain)
-	assert.Equal(suite.T(), string(existingUser.TeamRole), response.TeamRole)
+	assert.True(suite.T(), isFavorite)
 }
 
-// TestGetUserByUserID_EmptyUserID tests error when userID is empty
-func (suite *UserServiceTestSuite) TestGetUserByUserID_EmptyUserID() {
-	response, err := suite.userService.GetUserByUserID("")
+// TestIsFavoriteLink_Absent tests a link missing from metadata.favorites is not reported as favorite
+func (suite *UserServiceTestSuite) TestIsFavoriteLink_Absent() {
+	userID := "I123456"
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user_id is required")
-}
+	existingMetadata := map[string]interface{}{
+		"favorites": []string{uuid.New().String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
 
-// TestGetUserByUserID_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestGetUserByUserID_UserNotFound() {
-	userID := "I999999"
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
-		Return(nil, apperrors.ErrUserNotFound).
+		Return(existingUser, nil).
 		Times(1)
 
-	response, err := suite.userService.GetUserByUserID(userID)
+	isFavorite, err := suite.userService.IsFavoriteLink(userID, uuid.New())
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), isFavorite)
 }
 
-// TestGetUserByName_Success tests successfully getting a user by their name
-func (suite *UserServiceTestSuite) TestGetUserByName_Success() {
-	name := "John Doe"
+// TestIsFavoriteLink_NoMetadata tests users without metadata have no favorites
+func (suite *UserServiceTestSuite) TestIsFavoriteLink_NoMetadata() {
 	userID := "I123456"
 
 	existingUser := suite.factories.User.Create()
-	existingUser.Name = name
 	existingUser.UserID = userID
-	existingUser.Mobile = "+1-555-0123"
+	existingUser.Metadata = nil
 
 	suite.mockUserRepo.EXPECT().
-		GetByName(name).
+		GetByUserID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
-	response, err := suite.userService.GetUserByName(name)
+	isFavorite, err := suite.userService.IsFavoriteLink(userID, uuid.New())
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.Equal(suite.T(), "John", response.FirstName)
-	assert.Equal(suite.T(), "Doe", response.LastName)
-	assert.Equal(suite.T(), existingUser.Email, response.Email)
+	assert.False(suite.T(), isFavorite)
 }
 
-// TestGetUserByName_EmptyName tests error when name is empty
-func (suite *UserServiceTestSuite) TestGetUserByName_EmptyName() {
-	response, err := suite.userService.GetUserByName("")
-
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "name is required")
-}
+// TestIsFavoriteLink_InvalidMetadata tests invalid metadata JSON is treated as no favorites
+func (suite *UserServiceTestSuite) TestIsFavoriteLink_InvalidMetadata() {
+	userID := "I123456"
 
-// TestGetUserByName_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestGetUserByName_UserNotFound() {
-	name := "NonExistent User"
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{invalid`)
 
 	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(nil, apperrors.ErrUserNotFound).
+		GetByUserID(userID).
+		Return(existingUser, nil).
 		Times(1)
 
-	response, err := suite.userService.GetUserByName(name)
+	isFavorite, err := suite.userService.IsFavoriteLink(userID, uuid.New())
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), isFavorite)
+}
+
+// TestIsFavoriteLink_EmptyUserID tests error when userID is empty
+func (suite *UserServiceTestSuite) TestIsFavoriteLink_EmptyUserID() {
+	isFavorite, err := suite.userService.IsFavoriteLink("", uuid.New())
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	assert.False(suite.T(), isFavorite)
+	assert.Contains(suite.T(), err.Error(), "user_id is required")
 }
 
-// TestGetUserByName_TrimsWhitespace tests that leading/trailing whitespace is trimmed
-func (suite *UserServiceTestSuite) TestGetUserByName_TrimsWhitespace() {
-	name := "  John Doe  "
-	trimmedName := "John Doe"
+// TestAddSubscribedPluginByUserID_Success tests successfully adding a subscribed plugin to a user with no existing metadata
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_Success() {
 	userID := "I123456"
+	pluginID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
-	existingUser.Name = trimmedName
 	existingUser.UserID = userID
+	existingUser.Metadata = nil // No existing metadata
 
 	suite.mockUserRepo.EXPECT().
-		GetByName(trimmedName).
+		GetByUserID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
-	response, err := suite.userService.GetUserByName(name)
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}}, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			// Verify metadata was updated correctly
+			assert.NotNil(suite.T(), user.Metadata)
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 1)
+			assert.Equal(suite.T(), pluginID.String(), subArray[0])
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 	assert.Equal(suite.T(), userID, response.ID)
 }
 
-// TestGetUserByNameWithLinks_Success tests successfully getting a user with links by name nil metadate
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_SuccessNilMetaData() {
-	name := "John Doe"
+// TestAddSubscribedPluginByUserID_OrganizationScoped tests subscribing to a plugin scoped to the user's own organization
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_OrganizationScoped() {
 	userID := "I123456"
-	userUUID := uuid.New()
+	pluginID := uuid.New()
+	teamID := uuid.New()
+	groupID := uuid.New()
+	orgID := uuid.New()
+
+	mockTeamRepo := mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	mockGroupRepo := mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.userService.SetOrganizationRepositories(mockTeamRepo, mockGroupRepo)
 
 	existingUser := suite.factories.User.Create()
-	existingUser.ID = userUUID
-	existingUser.Name = name
 	existingUser.UserID = userID
+	existingUser.TeamID = &teamID
 	existingUser.Metadata = nil
 
-	// First call to GetByName
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(existingUser, nil).
-		Times(1)
-
-	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
-	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
-		Times(1)
-
-	// Mock link repository calls
-	suite.mockLinkRepo.EXPECT().
-		GetByIDs(gomock.Any()).
-		Return([]models.Link{}, nil).
-		Times(1)
-
-	suite.mockLinkRepo.EXPECT().
-		GetByOwner(userUUID).
-		Return([]models.Link{}, nil).
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}, OrgID: &orgID}, nil).
 		Times(1)
+	mockTeamRepo.EXPECT().GetByID(teamID).Return(&models.Team{GroupID: groupID}, nil).Times(1)
+	mockGroupRepo.EXPECT().GetByID(groupID).Return(&models.Group{OrgID: orgID}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
 
-	response, err := suite.userService.GetUserByNameWithLinks(name)
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.Equal(suite.T(), "John", response.FirstName)
-	assert.Equal(suite.T(), "Doe", response.LastName)
-	assert.NotNil(suite.T(), response.Links)
-	assert.NotNil(suite.T(), response.Plugins)
 }
 
-// TestGetUserByNameWithLinks_EmptyName tests error when name is empty
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_EmptyName() {
-	response, err := suite.userService.GetUserByNameWithLinks("")
+// TestAddSubscribedPluginByUserID_CrossOrganizationRejected tests that a plugin scoped to another organization cannot be subscribed
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_CrossOrganizationRejected() {
+	userID := "I123456"
+	pluginID := uuid.New()
+	teamID := uuid.New()
+	groupID := uuid.New()
+	otherOrgID := uuid.New()
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "name is required")
-}
+	mockTeamRepo := mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	mockGroupRepo := mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.userService.SetOrganizationRepositories(mockTeamRepo, mockGroupRepo)
 
-// TestGetUserByNameWithLinks_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_UserNotFound() {
-	name := "NonExistent User"
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.TeamID = &teamID
 
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(nil, apperrors.ErrUserNotFound).
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}, OrgID: &otherOrgID}, nil).
 		Times(1)
+	mockTeamRepo.EXPECT().GetByID(teamID).Return(&models.Team{GroupID: groupID}, nil).Times(1)
+	mockGroupRepo.EXPECT().GetByID(groupID).Return(&models.Group{OrgID: uuid.New()}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Times(0)
 
-	response, err := suite.userService.GetUserByNameWithLinks(name)
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
 
-	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrPluginNotAvailable)
 	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
 }
 
-// TestGetUserByNameWithLinks_WithFavorites tests getting a user with favorite links in metadata
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithFavorites() {
-	name := "John Doe"
+// TestAddSubscribedPluginByUserID_OrganizationScopedWithoutTeam tests that users without an organization only see global plugins
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_OrganizationScopedWithoutTeam() {
 	userID := "I123456"
-	userUUID := uuid.New()
-	linkID1 := uuid.New()
-	linkID2 := uuid.New()
-
-	metadata := map[string]interface{}{
-		"favorites":    []string{linkID1.String(), linkID2.String()},
-		"portal_admin": "portal-test",
-	}
-	metadataBytes, _ := json.Marshal(metadata)
+	pluginID := uuid.New()
+	orgID := uuid.New()
 
 	existingUser := suite.factories.User.Create()
-	existingUser.ID = userUUID
-	existingUser.Name = name
 	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.TeamID = nil
 
-	link1 := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    linkID1,
-			Name:  "link1",
-			Title: "Link 1",
-		},
-		URL: "https://example.com/1",
-	}
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}, OrgID: &orgID}, nil).
+		Times(1)
 
-	link2 := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    linkID2,
-			Name:  "link2",
-			Title: "Link 2",
-		},
-		URL: "https://example.com/2",
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrPluginNotAvailable)
+	assert.Nil(suite.T(), response)
+}
+
+// TestAddSubscribedPluginByUserID_WithExistingMetadata tests adding a subscribed plugin to a user with existing metadata but no subscribed
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_WithExistingMetadata() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"portal_admin": true,
+		"favorites":    []string{uuid.New().String()},
 	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
 
-	// First call to GetByName
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(existingUser, nil).
-		Times(1)
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
 
-	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
-	// Mock link repository calls
-	suite.mockLinkRepo.EXPECT().
-		GetByIDs(gomock.Any()).
-		DoAndReturn(func(ids []uuid.UUID) ([]models.Link, error) {
-			// Verify the IDs passed
-			assert.Len(suite.T(), ids, 2)
-			assert.Contains(suite.T(), ids, linkID1)
-			assert.Contains(suite.T(), ids, linkID2)
-			return []models.Link{link1, link2}, nil
-		}).
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}}, nil).
 		Times(1)
 
-	suite.mockLinkRepo.EXPECT().
-		GetByOwner(userUUID).
-		Return([]models.Link{}, nil).
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			// Verify existing fields are preserved
+			assert.Equal(suite.T(), true, meta["portal_admin"])
+			assert.NotNil(suite.T(), meta["favorites"])
+
+			// Verify subscribed was added
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 1)
+			assert.Equal(suite.T(), pluginID.String(), subArray[0])
+
+			return nil
+		}).
 		Times(1)
 
-	response, err := suite.userService.GetUserByNameWithLinks(name)
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.NotNil(suite.T(), response.Links)
-	assert.Len(suite.T(), response.Links, 2)
-
-	// Verify links are marked as favorites and have correct IDs
-	linkIDs := make(map[string]bool)
-	for _, link := range response.Links {
-		assert.True(suite.T(), link.Favorite)
-		linkIDs[link.ID] = true
-	}
-	assert.True(suite.T(), linkIDs[linkID1.String()])
-	assert.True(suite.T(), linkIDs[linkID2.String()])
 }
 
-// TestGetUserByNameWithLinks_WithPortalAdmin tests getting a user with portal_admin in metadata
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithPortalAdmin() {
-	name := "Admin User"
+// TestAddSubscribedPluginByUserID_WithExistingSubscribed tests adding a subscribed plugin to a user with existing subscribed plugins
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_WithExistingSubscribed() {
 	userID := "I123456"
-	userUUID := uuid.New()
+	existingPluginID := uuid.New()
+	newPluginID := uuid.New()
 
-	metadata := map[string]interface{}{
-		"portal_admin": 1,
+	existingMetadata := map[string]interface{}{
+		"subscribed": []string{existingPluginID.String()},
 	}
-	metadataBytes, _ := json.Marshal(metadata)
+	metadataBytes, _ := json.Marshal(existingMetadata)
 
 	existingUser := suite.factories.User.Create()
-	existingUser.ID = userUUID
-	existingUser.Name = name
 	existingUser.UserID = userID
-	existingUser.FirstName = "Admin"
-	existingUser.LastName = "User"
-	existingUser.Email = "admin@example.com"
 	existingUser.Metadata = json.RawMessage(metadataBytes)
 
-	// First call to GetByName
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(existingUser, nil).
-		Times(1)
-
-	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
-	// Mock link repository calls
-	suite.mockLinkRepo.EXPECT().
-		GetByIDs(gomock.Any()).
-		Return([]models.Link{}, nil).
+	suite.mockPluginRepo.EXPECT().
+		GetByID(newPluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: newPluginID}}, nil).
 		Times(1)
 
-	suite.mockLinkRepo.EXPECT().
-		GetByOwner(userUUID).
-		Return([]models.Link{}, nil).
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 2)
+
+			// Verify both plugins are present
+			subStrings := make([]string, len(subArray))
+			for i, v := range subArray {
+				subStrings[i] = v.(string)
+			}
+			assert.Contains(suite.T(), subStrings, existingPluginID.String())
+			assert.Contains(suite.T(), subStrings, newPluginID.String())
+
+			return nil
+		}).
 		Times(1)
 
-	response, err := suite.userService.GetUserByNameWithLinks(name)
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, newPluginID, userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.True(suite.T(), response.PortalAdmin)
 }
 
-// ===== Tests for GetSubscribedPluginsFromUser =====
+// TestAddSubscribedPluginByUserID_Deduplication tests that adding a duplicate plugin doesn't create duplicates
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_Deduplication() {
+	userID := "I123456"
+	pluginID := uuid.New()
 
-// TestGetSubscribedPluginsFromUser_NoMetadata tests getting subscribed plugins when user has no metadata
-func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_NoMetadata() {
-	user := suite.factories.User.Create()
-	user.UserID = "I123456"
-	user.Metadata = nil
+	existingMetadata := map[string]interface{}{
+		"subscribed": []string{pluginID.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
 
-	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
 
-	assert.NotNil(suite.T(), plugins)
-	assert.Len(suite.T(), plugins, 0)
-}
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
 
-// TestGetSubscribedPluginsFromUser_EmptyMetadata tests getting subscribed plugins when user has empty metadata
-func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_EmptyMetadata() {
-	user := suite.factories.User.Create()
-	user.UserID = "I123456"
-	user.Metadata = json.RawMessage(`{}`)
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}}, nil).
+		Times(1)
 
-	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
 
-	assert.NotNil(suite.T(), plugins)
-	assert.Len(suite.T(), plugins, 0)
-}
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
 
-// TestGetSubscribedPluginsFromUser_InvalidJSON tests getting subscribed plugins when metadata is invalid JSON
-func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_InvalidJSON() {
-	user := suite.factories.User.Create()
-	user.UserID = "I123456"
-	user.Metadata = json.RawMessage(`invalid json`)
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			// Should still be 1, not duplicated
+			assert.Len(suite.T(), subArray, 1)
+			assert.Equal(suite.T(), pluginID.String(), subArray[0])
 
-	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+			return nil
+		}).
+		Times(1)
 
-	assert.NotNil(suite.T(), plugins)
-	assert.Len(suite.T(), plugins, 0)
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
 }
 
-// TestGetSubscribedPluginsFromUser_EmptySubscribedArray tests getting subscribed plugins when subscribed array is empty
-func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_EmptySubscribedArray() {
-	metadata := map[string]interface{}{
-		"subscribed": []string{},
-	}
-	metadataBytes, _ := json.Marshal(metadata)
+// TestAddSubscribedPluginByUserID_EmptyUserID tests error when userID is empty
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_EmptyUserID() {
+	pluginID := uuid.New()
 
-	user := suite.factories.User.Create()
-	user.UserID = "I123456"
-	user.Metadata = json.RawMessage(metadataBytes)
+	response, err := suite.userService.AddSubscribedPluginByUserID("", pluginID, "I123456")
 
-	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user_id is required")
+}
 
-	assert.NotNil(suite.T(), plugins)
-	assert.Len(suite.T(), plugins, 0)
+// TestAddSubscribedPluginByUserID_NilPluginID tests error when pluginID is nil
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_NilPluginID() {
+	userID := "I123456"
+
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, uuid.Nil, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "plugin_id is required")
 }
 
-// TestGetSubscribedPluginsFromUser_SinglePlugin tests getting a single subscribed plugin
-func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_SinglePlugin() {
+// TestAddSubscribedPluginByUserID_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_UserNotFound() {
+	userID := "I123456"
 	pluginID := uuid.New()
-	metadata := map[string]interface{}{
-		"subscribed": []string{pluginID.String()},
-	}
-	metadataBytes, _ := json.Marshal(metadata)
 
-	user := suite.factories.User.Create()
-	user.UserID = "I123456"
-	user.Metadata = json.RawMessage(metadataBytes)
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
 
-	plugin := &models.Plugin{
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestAddSubscribedPluginByUserID_InvalidMetadata tests handling of invalid metadata JSON
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_InvalidMetadata() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`invalid json`) // Invalid JSON
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}}, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			// Should reset to empty object and add subscribed
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 1)
+			assert.Equal(suite.T(), pluginID.String(), subArray[0])
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestAddSubscribedPluginByUserID_UpdateFails tests error when repository update fails
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_UpdateFails() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = nil
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(&models.Plugin{BaseModel: models.BaseModel{ID: pluginID}}, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		Return(gorm.ErrInvalidDB).
+		Times(1)
+
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "failed to update user")
+}
+
+// TestAddSubscribedPluginByUserID_PluginNotFound tests that subscribing to a nonexistent plugin is rejected
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_PluginNotFound() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = nil
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(nil, gorm.ErrRecordNotFound).
+		Times(1)
+
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrPluginNotFound)
+	assert.Nil(suite.T(), response)
+}
+
+// TestAddSubscribedPluginByUserID_PluginLookupFails tests error when the plugin lookup fails
+func (suite *UserServiceTestSuite) TestAddSubscribedPluginByUserID_PluginLookupFails() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(nil, gorm.ErrInvalidDB).
+		Times(1)
+
+	response, err := suite.userService.AddSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "failed to get plugin")
+}
+
+// TestRemoveSubscribedPluginByUserID_Success tests successfully removing a subscribed plugin from a user
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_Success() {
+	userID := "I123456"
+	pluginToRemove := uuid.New()
+	pluginToKeep := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"subscribed": []string{pluginToRemove.String(), pluginToKeep.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			// Verify metadata was updated correctly
+			assert.NotNil(suite.T(), user.Metadata)
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 1)
+			assert.Equal(suite.T(), pluginToKeep.String(), subArray[0])
+			// Verify removed plugin is not present
+			assert.NotContains(suite.T(), subArray, pluginToRemove.String())
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginToRemove, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+}
+
+// TestRemoveSubscribedPluginByUserID_RemoveLastPlugin tests removing the last subscribed plugin
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_RemoveLastPlugin() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"subscribed": []string{pluginID.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			// Should be empty array after removing last plugin
+			assert.Len(suite.T(), subArray, 0)
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestRemoveSubscribedPluginByUserID_NoExistingSubscribed tests removing a plugin when no subscribed exist
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NoExistingSubscribed() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"portal_admin": true,
+		"favorites":    []string{uuid.New().String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			// Verify other metadata is preserved
+			assert.Equal(suite.T(), true, meta["portal_admin"])
+			assert.NotNil(suite.T(), meta["favorites"])
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			// Should be empty array
+			assert.Len(suite.T(), subArray, 0)
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestRemoveSubscribedPluginByUserID_NoMetadata tests removing a plugin when user has no metadata
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NoMetadata() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = nil // No metadata
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			// Should be empty array
+			assert.Len(suite.T(), subArray, 0)
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestRemoveSubscribedPluginByUserID_Idempotent tests that removing a non-existent plugin is idempotent
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_Idempotent() {
+	userID := "I123456"
+	existingPluginID := uuid.New()
+	nonExistentPluginID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"subscribed": []string{existingPluginID.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			// Should still have the existing plugin
+			assert.Len(suite.T(), subArray, 1)
+			assert.Equal(suite.T(), existingPluginID.String(), subArray[0])
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, nonExistentPluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestRemoveSubscribedPluginByUserID_EmptyUserID tests error when userID is empty
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_EmptyUserID() {
+	pluginID := uuid.New()
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID("", pluginID, "I123456")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user_id is required")
+}
+
+// TestRemoveSubscribedPluginByUserID_NilPluginID tests error when pluginID is nil
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_NilPluginID() {
+	userID := "I123456"
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, uuid.Nil, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "plugin_id is required")
+}
+
+// TestRemoveSubscribedPluginByUserID_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_UserNotFound() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestRemoveSubscribedPluginByUserID_InvalidMetadata tests handling of invalid metadata JSON
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_InvalidMetadata() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`invalid json`) // Invalid JSON
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			// Should reset to empty object and create empty subscribed array
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 0)
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestRemoveSubscribedPluginByUserID_UpdateFails tests error when repository update fails
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_UpdateFails() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"subscribed": []string{pluginID.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		Return(gorm.ErrInvalidDB).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "failed to update user")
+}
+
+// TestRemoveSubscribedPluginByUserID_SubscribedAsInterfaceArray tests handling subscribed as []interface{} type
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_SubscribedAsInterfaceArray() {
+	userID := "I123456"
+	pluginToRemove := uuid.New()
+	pluginToKeep := uuid.New()
+
+	// Create metadata with subscribed as []interface{}
+	existingMetadata := map[string]interface{}{
+		"subscribed": []interface{}{pluginToRemove.String(), pluginToKeep.String()},
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 1)
+			assert.Equal(suite.T(), pluginToKeep.String(), subArray[0])
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginToRemove, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestRemoveSubscribedPluginByUserID_PreservesOtherMetadata tests that removing a plugin preserves other metadata fields
+func (suite *UserServiceTestSuite) TestRemoveSubscribedPluginByUserID_PreservesOtherMetadata() {
+	userID := "I123456"
+	pluginID := uuid.New()
+
+	existingMetadata := map[string]interface{}{
+		"subscribed":   []string{pluginID.String()},
+		"portal_admin": true,
+		"favorites":    []string{uuid.New().String()},
+		"custom_field": "custom_value",
+	}
+	metadataBytes, _ := json.Marshal(existingMetadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			err := json.Unmarshal(user.Metadata, &meta)
+			assert.NoError(suite.T(), err)
+
+			// Verify all other fields are preserved
+			assert.Equal(suite.T(), true, meta["portal_admin"])
+			assert.NotNil(suite.T(), meta["favorites"])
+			assert.Equal(suite.T(), "custom_value", meta["custom_field"])
+
+			// Verify subscribed is now empty
+			subscribed, ok := meta["subscribed"]
+			assert.True(suite.T(), ok)
+			subArray, ok := subscribed.([]interface{})
+			assert.True(suite.T(), ok)
+			assert.Len(suite.T(), subArray, 0)
+
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+}
+
+// TestGetUserByUserID_Success tests successfully getting a user by their string UserID
+func (suite *UserServiceTestSuite) TestGetUserByUserID_Success() {
+	userID := "I123456"
+	teamID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.TeamID = &teamID
+	existingUser.UserID = userID
+	existingUser.Mobile = "+1-555-0123"
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByUserID(userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.Equal(suite.T(), existingUser.ID.String(), response.UUID)
+	assert.Equal(suite.T(), existingUser.FirstName, response.FirstName)
+	assert.Equal(suite.T(), existingUser.LastName, response.LastName)
+	assert.Equal(suite.T(), existingUser.Email, response.Email)
+	assert.Equal(suite.T(), existingUser.Mobile, response.Mobile)
+	assert.Equal(suite.T(), string(existingUser.TeamDomain), response.TeamDomain)
+	assert.Equal(suite.T(), string(existingUser.TeamRole), response.TeamRole)
+}
+
+// TestGetUserByUserID_EmptyUserID tests error when userID is empty
+func (suite *UserServiceTestSuite) TestGetUserByUserID_EmptyUserID() {
+	response, err := suite.userService.GetUserByUserID("")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user_id is required")
+}
+
+// TestGetUserByUserID_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestGetUserByUserID_UserNotFound() {
+	userID := "I999999"
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	response, err := suite.userService.GetUserByUserID(userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestGetUserByName_Success tests successfully getting a user by their name
+func (suite *UserServiceTestSuite) TestGetUserByName_Success() {
+	name := "John Doe"
+	userID := "I123456"
+
+	existingUser := suite.factories.User.Create()
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.Mobile = "+1-555-0123"
+
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByName(name)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.Equal(suite.T(), "John", response.FirstName)
+	assert.Equal(suite.T(), "Doe", response.LastName)
+	assert.Equal(suite.T(), existingUser.Email, response.Email)
+}
+
+// TestGetUserByName_EmptyName tests error when name is empty
+func (suite *UserServiceTestSuite) TestGetUserByName_EmptyName() {
+	response, err := suite.userService.GetUserByName("")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "name is required")
+}
+
+// TestGetUserByName_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestGetUserByName_UserNotFound() {
+	name := "NonExistent User"
+
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	response, err := suite.userService.GetUserByName(name)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestGetUserByName_TrimsWhitespace tests that leading/trailing whitespace is trimmed
+func (suite *UserServiceTestSuite) TestGetUserByName_TrimsWhitespace() {
+	name := "  John Doe  "
+	trimmedName := "John Doe"
+	userID := "I123456"
+
+	existingUser := suite.factories.User.Create()
+	existingUser.Name = trimmedName
+	existingUser.UserID = userID
+
+	suite.mockUserRepo.EXPECT().
+		GetByName(trimmedName).
+		Return(existingUser, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByName(name)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+}
+
+// TestGetUserByNameWithLinks_Success tests successfully getting a user with links by name nil metadate
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_SuccessNilMetaData() {
+	name := "John Doe"
+	userID := "I123456"
+	userUUID := uuid.New()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userUUID
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.Metadata = nil
+
+	// First call to GetByName
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Mock link repository calls
+	suite.mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any()).
+		Return([]models.Link{}, nil).
+		Times(1)
+
+	suite.mockLinkRepo.EXPECT().
+		GetByOwner(userUUID).
+		Return([]models.Link{}, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByNameWithLinks(name)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.Equal(suite.T(), "John", response.FirstName)
+	assert.Equal(suite.T(), "Doe", response.LastName)
+	assert.NotNil(suite.T(), response.Links)
+	assert.NotNil(suite.T(), response.Plugins)
+}
+
+// TestGetUserByNameWithLinks_EmptyName tests error when name is empty
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_EmptyName() {
+	response, err := suite.userService.GetUserByNameWithLinks("")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "name is required")
+}
+
+// TestGetUserByNameWithLinks_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_UserNotFound() {
+	name := "NonExistent User"
+
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	response, err := suite.userService.GetUserByNameWithLinks(name)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestGetUserByNameWithLinks_WithFavorites tests getting a user with favorite links in metadata
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithFavorites() {
+	name := "John Doe"
+	userID := "I123456"
+	userUUID := uuid.New()
+	linkID1 := uuid.New()
+	linkID2 := uuid.New()
+
+	metadata := map[string]interface{}{
+		"favorites":    []string{linkID1.String(), linkID2.String()},
+		"portal_admin": "portal-test",
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userUUID
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	link1 := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    linkID1,
+			Name:  "link1",
+			Title: "Link 1",
+		},
+		URL: "https://example.com/1",
+	}
+
+	link2 := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    linkID2,
+			Name:  "link2",
+			Title: "Link 2",
+		},
+		URL: "https://example.com/2",
+	}
+
+	// First call to GetByName
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Mock link repository calls
+	suite.mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any()).
+		DoAndReturn(func(ids []uuid.UUID) ([]models.Link, error) {
+			// Verify the IDs passed
+			assert.Len(suite.T(), ids, 2)
+			assert.Contains(suite.T(), ids, linkID1)
+			assert.Contains(suite.T(), ids, linkID2)
+			return []models.Link{link1, link2}, nil
+		}).
+		Times(1)
+
+	suite.mockLinkRepo.EXPECT().
+		GetByOwner(userUUID).
+		Return([]models.Link{}, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByNameWithLinks(name)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.NotNil(suite.T(), response.Links)
+	assert.Len(suite.T(), response.Links, 2)
+
+	// Verify links are marked as favorites and have correct IDs
+	linkIDs := make(map[string]bool)
+	for _, link := range response.Links {
+		assert.True(suite.T(), link.Favorite)
+		linkIDs[link.ID] = true
+	}
+	assert.True(suite.T(), linkIDs[linkID1.String()])
+	assert.True(suite.T(), linkIDs[linkID2.String()])
+}
+
+// TestIsPortalAdmin tests each supported representation of the portal_admin metadata value
+func (suite *UserServiceTestSuite) TestIsPortalAdmin() {
+	testCases := []struct {
+		name     string
+		metadata map[string]interface{}
+		expected bool
+	}{
+		{"BoolTrue", map[string]interface{}{"portal_admin": true}, true},
+		{"BoolFalse", map[string]interface{}{"portal_admin": false}, false},
+		{"IntOne", map[string]interface{}{"portal_admin": 1}, true},
+		{"IntZero", map[string]interface{}{"portal_admin": 0}, false},
+		{"FloatNonZero", map[string]interface{}{"portal_admin": 2.5}, true},
+		{"StringTrue", map[string]interface{}{"portal_admin": "true"}, true},
+		{"StringArbitrary", map[string]interface{}{"portal_admin": "portal-test"}, true},
+		{"StringEmpty", map[string]interface{}{"portal_admin": ""}, false},
+		{"StringWhitespace", map[string]interface{}{"portal_admin": "   "}, false},
+		{"StringFalse", map[string]interface{}{"portal_admin": "false"}, false},
+		{"StringFalseUppercase", map[string]interface{}{"portal_admin": "FALSE"}, false},
+		{"StringZero", map[string]interface{}{"portal_admin": "0"}, false},
+		{"Null", map[string]interface{}{"portal_admin": nil}, false},
+		{"Missing", map[string]interface{}{"favorites": []string{}}, false},
+		{"UnsupportedType", map[string]interface{}{"portal_admin": []string{"yes"}}, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			metadataBytes, err := json.Marshal(tc.metadata)
+			suite.Require().NoError(err)
+
+			user := suite.factories.User.Create()
+			user.Metadata = json.RawMessage(metadataBytes)
+
+			assert.Equal(suite.T(), tc.expected, suite.userService.IsPortalAdmin(user))
+		})
+	}
+}
+
+// TestIsPortalAdmin_NoMetadata tests users without metadata are not admins
+func (suite *UserServiceTestSuite) TestIsPortalAdmin_NoMetadata() {
+	user := suite.factories.User.Create()
+	user.Metadata = nil
+
+	assert.False(suite.T(), suite.userService.IsPortalAdmin(user))
+	assert.False(suite.T(), suite.userService.IsPortalAdmin(nil))
+
+	user.Metadata = json.RawMessage(`not-json`)
+	assert.False(suite.T(), suite.userService.IsPortalAdmin(user))
+}
+
+// TestGetUserByNameWithLinks_WithPortalAdmin tests getting a user with portal_admin in metadata
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithPortalAdmin() {
+	name := "Admin User"
+	userID := "I123456"
+	userUUID := uuid.New()
+
+	metadata := map[string]interface{}{
+		"portal_admin": 1,
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userUUID
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.FirstName = "Admin"
+	existingUser.LastName = "User"
+	existingUser.Email = "admin@example.com"
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	// First call to GetByName
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Mock link repository calls
+	suite.mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any()).
+		Return([]models.Link{}, nil).
+		Times(1)
+
+	suite.mockLinkRepo.EXPECT().
+		GetByOwner(userUUID).
+		Return([]models.Link{}, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByNameWithLinks(name)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.True(suite.T(), response.PortalAdmin)
+}
+
+// ===== Tests for GetSubscribedPluginsFromUser =====
+
+// TestGetSubscribedPluginsFromUser_NoMetadata tests getting subscribed plugins when user has no metadata
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_NoMetadata() {
+	user := suite.factories.User.Create()
+	user.UserID = "I123456"
+	user.Metadata = nil
+
+	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+
+	assert.NotNil(suite.T(), plugins)
+	assert.Len(suite.T(), plugins, 0)
+}
+
+// TestGetSubscribedPluginsFromUser_EmptyMetadata tests getting subscribed plugins when user has empty metadata
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_EmptyMetadata() {
+	user := suite.factories.User.Create()
+	user.UserID = "I123456"
+	user.Metadata = json.RawMessage(`{}`)
+
+	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+
+	assert.NotNil(suite.T(), plugins)
+	assert.Len(suite.T(), plugins, 0)
+}
+
+// TestGetSubscribedPluginsFromUser_InvalidJSON tests getting subscribed plugins when metadata is invalid JSON
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_InvalidJSON() {
+	user := suite.factories.User.Create()
+	user.UserID = "I123456"
+	user.Metadata = json.RawMessage(`invalid json`)
+
+	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+
+	assert.NotNil(suite.T(), plugins)
+	assert.Len(suite.T(), plugins, 0)
+}
+
+// TestGetSubscribedPluginsFromUser_EmptySubscribedArray tests getting subscribed plugins when subscribed array is empty
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_EmptySubscribedArray() {
+	metadata := map[string]interface{}{
+		"subscribed": []string{},
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	user := suite.factories.User.Create()
+	user.UserID = "I123456"
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+
+	assert.NotNil(suite.T(), plugins)
+	assert.Len(suite.T(), plugins, 0)
+}
+
+// TestGetSubscribedPluginsFromUser_SinglePlugin tests getting a single subscribed plugin
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_SinglePlugin() {
+	pluginID := uuid.New()
+	metadata := map[string]interface{}{
+		"subscribed": []string{pluginID.String()},
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	user := suite.factories.User.Create()
+	user.UserID = "I123456"
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	plugin := &models.Plugin{
+		BaseModel: models.BaseModel{
+			ID:          pluginID,
+			Name:        "test-plugin",
+			Title:       "Test Plugin",
+			Description: "A test plugin",
+		},
+		Icon:               "test-icon",
+		ReactComponentPath: "/path/to/component.tsx",
+		BackendServerURL:   "https://backend.example.com",
+		Owner:              "test-owner",
+	}
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID).
+		Return(plugin, nil).
+		Times(1)
+
+	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+
+	assert.NotNil(suite.T(), plugins)
+	assert.Len(suite.T(), plugins, 1)
+	assert.Equal(suite.T(), pluginID, plugins[0].ID)
+	assert.Equal(suite.T(), "test-plugin", plugins[0].Name)
+	assert.Equal(suite.T(), "Test Plugin", plugins[0].Title)
+	assert.Equal(suite.T(), "A test plugin", plugins[0].Description)
+	assert.Equal(suite.T(), "test-icon", plugins[0].Icon)
+	assert.Equal(suite.T(), "/path/to/component.tsx", plugins[0].ReactComponentPath)
+	assert.Equal(suite.T(), "https://backend.example.com", plugins[0].BackendServerURL)
+	assert.Equal(suite.T(), "test-owner", plugins[0].Owner)
+}
+
+// TestGetSubscribedPluginsFromUser_MultiplePlugins tests getting multiple subscribed plugins
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_MultiplePlugins() {
+	pluginID1 := uuid.New()
+	pluginID2 := uuid.New()
+	pluginID3 := uuid.New()
+
+	metadata := map[string]interface{}{
+		"subscribed": []string{pluginID1.String(), pluginID2.String(), pluginID3.String()},
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	user := suite.factories.User.Create()
+	user.UserID = "I123456"
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	plugin1 := &models.Plugin{
+		BaseModel: models.BaseModel{
+			ID:          pluginID1,
+			Name:        "plugin-1",
+			Title:       "Plugin 1",
+			Description: "First plugin",
+		},
+		Icon:               "icon-1",
+		ReactComponentPath: "/path/to/plugin1.tsx",
+		BackendServerURL:   "https://backend1.example.com",
+		Owner:              "owner-1",
+	}
+
+	plugin2 := &models.Plugin{
+		BaseModel: models.BaseModel{
+			ID:          pluginID2,
+			Name:        "plugin-2",
+			Title:       "Plugin 2",
+			Description: "Second plugin",
+		},
+		Icon:               "icon-2",
+		ReactComponentPath: "/path/to/plugin2.tsx",
+		BackendServerURL:   "https://backend2.example.com",
+		Owner:              "owner-2",
+	}
+
+	plugin3 := &models.Plugin{
+		BaseModel: models.BaseModel{
+			ID:          pluginID3,
+			Name:        "plugin-3",
+			Title:       "Plugin 3",
+			Description: "Third plugin",
+		},
+		Icon:               "icon-3",
+		ReactComponentPath: "/path/to/plugin3.tsx",
+		BackendServerURL:   "https://backend3.example.com",
+		Owner:              "owner-3",
+	}
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID1).
+		Return(plugin1, nil).
+		Times(1)
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID2).
+		Return(plugin2, nil).
+		Times(1)
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID3).
+		Return(plugin3, nil).
+		Times(1)
+
+	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+
+	assert.NotNil(suite.T(), plugins)
+	assert.Len(suite.T(), plugins, 3)
+
+	// Verify all plugins are returned with correct data
+	pluginMap := make(map[uuid.UUID]service.PluginResponse)
+	for _, p := range plugins {
+		pluginMap[p.ID] = p
+	}
+
+	// Verify plugin IDs match the ones defined at the beginning
+	assert.Contains(suite.T(), pluginMap, pluginID1)
+	assert.Equal(suite.T(), pluginID1, pluginMap[pluginID1].ID)
+	assert.Equal(suite.T(), "plugin-1", pluginMap[pluginID1].Name)
+
+	assert.Contains(suite.T(), pluginMap, pluginID2)
+	assert.Equal(suite.T(), pluginID2, pluginMap[pluginID2].ID)
+	assert.Equal(suite.T(), "plugin-2", pluginMap[pluginID2].Name)
+
+	assert.Contains(suite.T(), pluginMap, pluginID3)
+	assert.Equal(suite.T(), pluginID3, pluginMap[pluginID3].ID)
+	assert.Equal(suite.T(), "plugin-3", pluginMap[pluginID3].Name)
+}
+
+// TestGetSubscribedPluginsFromUser_PluginNotFound tests when a subscribed plugin is not found in database
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_PluginNotFound() {
+	pluginID1 := uuid.New()
+	pluginID2 := uuid.New() // This one won't be found
+
+	metadata := map[string]interface{}{
+		"subscribed": []string{pluginID1.String(), pluginID2.String()},
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	user := suite.factories.User.Create()
+	user.UserID = "I123456"
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	plugin1 := &models.Plugin{
 		BaseModel: models.BaseModel{
-			ID:          pluginID,
-			Name:        "test-plugin",
-			Title:       "Test Plugin",
-			Description: "A test plugin",
+			ID:          pluginID1,
+			Name:        "plugin-1",
+			Title:       "Plugin 1",
+			Description: "First plugin",
 		},
-		Icon:               "test-icon",
-		ReactComponentPath: "/path/to/component.tsx",
-		BackendServerURL:   "https://backend.example.com",
-		Owner:              "test-owner",
+		Icon:               "icon-1",
+		ReactComponentPath: "/path/to/plugin1.tsx",
+		BackendServerURL:   "https://backend1.example.com",
+		Owner:              "owner-1",
 	}
 
 	suite.mockPluginRepo.EXPECT().
-		GetByID(pluginID).
-		Return(plugin, nil).
+		GetByID(pluginID1).
+		Return(plugin1, nil).
+		Times(1)
+
+	suite.mockPluginRepo.EXPECT().
+		GetByID(pluginID2).
+		Return(nil, gorm.ErrRecordNotFound).
 		Times(1)
 
 	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
 
 	assert.NotNil(suite.T(), plugins)
+	// Only the found plugin should be returned
 	assert.Len(suite.T(), plugins, 1)
-	assert.Equal(suite.T(), pluginID, plugins[0].ID)
-	assert.Equal(suite.T(), "test-plugin", plugins[0].Name)
-	assert.Equal(suite.T(), "Test Plugin", plugins[0].Title)
-	assert.Equal(suite.T(), "A test plugin", plugins[0].Description)
-	assert.Equal(suite.T(), "test-icon", plugins[0].Icon)
-	assert.Equal(suite.T(), "/path/to/component.tsx", plugins[0].ReactComponentPath)
-	assert.Equal(suite.T(), "https://backend.example.com", plugins[0].BackendServerURL)
-	assert.Equal(suite.T(), "test-owner", plugins[0].Owner)
+	assert.Equal(suite.T(), pluginID1, plugins[0].ID)
+	assert.Equal(suite.T(), "plugin-1", plugins[0].Name)
 }
 
-// TestGetSubscribedPluginsFromUser_MultiplePlugins tests getting multiple subscribed plugins
-func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_MultiplePlugins() {
+// TestGetSubscribedPluginIDsFromUser tests parsing of metadata.subscribed without touching the plugin repository
+func (suite *UserServiceTestSuite) TestGetSubscribedPluginIDsFromUser() {
+	pluginID1 := uuid.New()
+	pluginID2 := uuid.New()
+
+	testCases := []struct {
+		name     string
+		metadata json.RawMessage
+		expected []uuid.UUID
+	}{
+		{"NoMetadata", nil, []uuid.UUID{}},
+		{"InvalidJSON", json.RawMessage(`{invalid`), []uuid.UUID{}},
+		{"NotAnObject", json.RawMessage(`["a"]`), []uuid.UUID{}},
+		{"MissingSubscribed", json.RawMessage(`{"favorites": []}`), []uuid.UUID{}},
+		{"SubscribedNotArray", json.RawMessage(`{"subscribed": "x"}`), []uuid.UUID{}},
+		{
+			"SkipsInvalidEntries",
+			json.RawMessage(`{"subscribed": ["` + pluginID1.String() + `", "not-a-uuid", "", 42, " ` + pluginID2.String() + ` "]}`),
+			[]uuid.UUID{pluginID1, pluginID2},
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			user := suite.factories.User.Create()
+			user.Metadata = tc.metadata
+
+			assert.Equal(suite.T(), tc.expected, suite.userService.GetSubscribedPluginIDsFromUser(user))
+		})
+	}
+
+	assert.Empty(suite.T(), suite.userService.GetSubscribedPluginIDsFromUser(nil))
+}
+
+// TestIsSubscribedToPlugin tests membership checks against metadata.subscribed
+func (suite *UserServiceTestSuite) TestIsSubscribedToPlugin() {
+	userID := "I123456"
+	subscribedID := uuid.New()
+
+	metadataBytes, _ := json.Marshal(map[string]interface{}{
+		"subscribed": []string{subscribedID.String()},
+	})
+	user := suite.factories.User.Create()
+	user.UserID = userID
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(user, nil).
+		Times(2)
+
+	subscribed, err := suite.userService.IsSubscribedToPlugin(userID, subscribedID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), subscribed)
+
+	subscribed, err = suite.userService.IsSubscribedToPlugin(userID, uuid.New())
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), subscribed)
+}
+
+// TestIsSubscribedToPlugin_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestIsSubscribedToPlugin_UserNotFound() {
+	suite.mockUserRepo.EXPECT().
+		GetByUserID("missing").
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	subscribed, err := suite.userService.IsSubscribedToPlugin("missing", uuid.New())
+
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+	assert.False(suite.T(), subscribed)
+}
+
+// TestIsSubscribedToPlugin_InvalidInput tests validation of user_id and plugin_id
+func (suite *UserServiceTestSuite) TestIsSubscribedToPlugin_InvalidInput() {
+	_, err := suite.userService.IsSubscribedToPlugin("", uuid.New())
+	assert.Error(suite.T(), err)
+
+	_, err = suite.userService.IsSubscribedToPlugin("I123456", uuid.Nil)
+	assert.Error(suite.T(), err)
+}
+
+// TestGetUserByUserIDWithPlugins_Success tests successfully getting plugins for a user
+func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_Success() {
+	userID := "I123456"
 	pluginID1 := uuid.New()
 	pluginID2 := uuid.New()
-	pluginID3 := uuid.New()
 
 	metadata := map[string]interface{}{
-		"subscribed": []string{pluginID1.String(), pluginID2.String(), pluginID3.String()},
+		"subscribed": []string{pluginID1.String(), pluginID2.String()},
 	}
 	metadataBytes, _ := json.Marshal(metadata)
 
 	user := suite.factories.User.Create()
-	user.UserID = "I123456"
+	user.UserID = userID
 	user.Metadata = json.RawMessage(metadataBytes)
 
 	plugin1 := &models.Plugin{
@@ -2597,18 +4025,10 @@ func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_MultiplePlug
 		Owner:              "owner-2",
 	}
 
-	plugin3 := &models.Plugin{
-		BaseModel: models.BaseModel{
-			ID:          pluginID3,
-			Name:        "plugin-3",
-			Title:       "Plugin 3",
-			Description: "Third plugin",
-		},
-		Icon:               "icon-3",
-		ReactComponentPath: "/path/to/plugin3.tsx",
-		BackendServerURL:   "https://backend3.example.com",
-		Owner:              "owner-3",
-	}
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(user, nil).
+		Times(1)
 
 	suite.mockPluginRepo.EXPECT().
 		GetByID(pluginID1).
@@ -2620,96 +4040,276 @@ func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_MultiplePlug
 		Return(plugin2, nil).
 		Times(1)
 
-	suite.mockPluginRepo.EXPECT().
-		GetByID(pluginID3).
-		Return(plugin3, nil).
+	plugins, err := suite.userService.GetUserByUserIDWithPlugins(userID)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), plugins)
+	assert.Len(suite.T(), plugins, 2)
+
+	// Verify plugins are returned with correct data
+	pluginMap := make(map[uuid.UUID]service.PluginResponse)
+	for _, p := range plugins {
+		pluginMap[p.ID] = p
+	}
+
+	assert.Contains(suite.T(), pluginMap, pluginID1)
+	assert.Equal(suite.T(), "plugin-1", pluginMap[pluginID1].Name)
+	assert.Equal(suite.T(), "Plugin 1", pluginMap[pluginID1].Title)
+
+	assert.Contains(suite.T(), pluginMap, pluginID2)
+	assert.Equal(suite.T(), "plugin-2", pluginMap[pluginID2].Name)
+	assert.Equal(suite.T(), "Plugin 2", pluginMap[pluginID2].Title)
+}
+
+// TestGetUserByUserIDWithPlugins_EmptyUserID tests error when userID is empty
+func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_EmptyUserID() {
+	plugins, err := suite.userService.GetUserByUserIDWithPlugins("")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), plugins)
+	assert.Contains(suite.T(), err.Error(), "user_id is required")
+}
+
+// TestGetUserByUserIDWithPlugins_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_UserNotFound() {
+	userID := "I999999"
+
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	plugins, err := suite.userService.GetUserByUserIDWithPlugins(userID)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), plugins)
+	assert.Contains(suite.T(), err.Error(), "user not found")
+}
+
+// TestGetUserByNameWithLinks_WithSubscribed tests getting a user with subscribed plugins in metadata and both favorite and owned links
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithSubscribed() {
+	name := "John Doe"
+	userID := "I123456"
+	userUUID := uuid.New()
+	pluginID1 := uuid.New()
+	pluginID2 := uuid.New()
+	favoriteLinkID1 := uuid.New()
+	favoriteLinkID2 := uuid.New()
+	ownedLinkID1 := uuid.New()
+	ownedLinkID2 := uuid.New()
+
+	metadata := map[string]interface{}{
+		"subscribed": []string{pluginID1.String(), pluginID2.String()},
+		"favorites":  []string{favoriteLinkID1.String(), favoriteLinkID2.String()},
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userUUID
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	// Favorite links
+	favoriteLink1 := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    favoriteLinkID1,
+			Name:  "favorite-link1",
+			Title: "Favorite Link 1",
+		},
+		URL: "https://example.com/fav1",
+	}
+
+	favoriteLink2 := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    favoriteLinkID2,
+			Name:  "favorite-link2",
+			Title: "Favorite Link 2",
+		},
+		URL: "https://example.com/fav2",
+	}
+
+	// Links owned by the user
+	ownedLink1 := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    ownedLinkID1,
+			Name:  "owned-link1",
+			Title: "Owned Link 1",
+		},
+		Owner: userUUID,
+		URL:   "https://example.com/owned1",
+	}
+
+	ownedLink2 := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    ownedLinkID2,
+			Name:  "owned-link2",
+			Title: "Owned Link 2",
+		},
+		Owner: userUUID,
+		URL:   "https://example.com/owned2",
+	}
+
+	// First call to GetByName
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Mock link repository calls - GetByIDs for favorite links
+	suite.mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any()).
+		DoAndReturn(func(ids []uuid.UUID) ([]models.Link, error) {
+			// Verify the IDs passed are the favorite link IDs
+			assert.Len(suite.T(), ids, 2)
+			assert.Contains(suite.T(), ids, favoriteLinkID1)
+			assert.Contains(suite.T(), ids, favoriteLinkID2)
+			return []models.Link{favoriteLink1, favoriteLink2}, nil
+		}).
+		Times(1)
+
+	// Mock GetByOwner for owned links
+	suite.mockLinkRepo.EXPECT().
+		GetByOwner(userUUID).
+		Return([]models.Link{ownedLink1, ownedLink2}, nil).
 		Times(1)
 
-	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+	response, err := suite.userService.GetUserByNameWithLinks(name)
 
-	assert.NotNil(suite.T(), plugins)
-	assert.Len(suite.T(), plugins, 3)
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
 
-	// Verify all plugins are returned with correct data
-	pluginMap := make(map[uuid.UUID]service.PluginResponse)
-	for _, p := range plugins {
-		pluginMap[p.ID] = p
+	// Verify all links are returned (favorites + owned)
+	assert.NotNil(suite.T(), response.Links)
+	assert.Len(suite.T(), response.Links, 4) // 2 favorites + 2 owned
+
+	// Verify link IDs and favorite status
+	linkIDs := make(map[string]bool)
+	favoriteCount := 0
+	for _, link := range response.Links {
+		linkIDs[link.ID] = true
+		if link.Favorite {
+			favoriteCount++
+		}
 	}
 
-	// Verify plugin IDs match the ones defined at the beginning
-	assert.Contains(suite.T(), pluginMap, pluginID1)
-	assert.Equal(suite.T(), pluginID1, pluginMap[pluginID1].ID)
-	assert.Equal(suite.T(), "plugin-1", pluginMap[pluginID1].Name)
+	// Verify all link IDs are present
+	assert.True(suite.T(), linkIDs[favoriteLinkID1.String()])
+	assert.True(suite.T(), linkIDs[favoriteLinkID2.String()])
+	assert.True(suite.T(), linkIDs[ownedLinkID1.String()])
+	assert.True(suite.T(), linkIDs[ownedLinkID2.String()])
 
-	assert.Contains(suite.T(), pluginMap, pluginID2)
-	assert.Equal(suite.T(), pluginID2, pluginMap[pluginID2].ID)
-	assert.Equal(suite.T(), "plugin-2", pluginMap[pluginID2].Name)
+	// Verify only favorite links are marked as favorites
+	assert.Equal(suite.T(), 2, favoriteCount)
 
-	assert.Contains(suite.T(), pluginMap, pluginID3)
-	assert.Equal(suite.T(), pluginID3, pluginMap[pluginID3].ID)
-	assert.Equal(suite.T(), "plugin-3", pluginMap[pluginID3].Name)
+	// Note: GetUserByUserIDWithLinks returns empty plugins array
+	// Plugins are fetched separately via GetUserByUserIDWithPlugins
+	assert.NotNil(suite.T(), response.Plugins)
+	assert.Len(suite.T(), response.Plugins, 0)
 }
 
-// TestGetSubscribedPluginsFromUser_PluginNotFound tests when a subscribed plugin is not found in database
-func (suite *UserServiceTestSuite) TestGetSubscribedPluginsFromUser_PluginNotFound() {
-	pluginID1 := uuid.New()
-	pluginID2 := uuid.New() // This one won't be found
+// TestGetUserByNameWithLinks_WithAllMetadata tests getting a user with all metadata fields
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithAllMetadata() {
+	name := "John Doe"
+	userID := "I123456"
+	userUUID := uuid.New()
+	linkID := uuid.New()
+	pluginID := uuid.New()
 
 	metadata := map[string]interface{}{
-		"subscribed": []string{pluginID1.String(), pluginID2.String()},
+		"favorites":    []string{linkID.String()},
+		"portal_admin": true,
+		"subscribed":   []string{pluginID.String()},
 	}
 	metadataBytes, _ := json.Marshal(metadata)
 
-	user := suite.factories.User.Create()
-	user.UserID = "I123456"
-	user.Metadata = json.RawMessage(metadataBytes)
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userUUID
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
 
-	plugin1 := &models.Plugin{
+	link := models.Link{
 		BaseModel: models.BaseModel{
-			ID:          pluginID1,
-			Name:        "plugin-1",
-			Title:       "Plugin 1",
-			Description: "First plugin",
+			ID:    linkID,
+			Name:  "link1",
+			Title: "Link 1",
 		},
-		Icon:               "icon-1",
-		ReactComponentPath: "/path/to/plugin1.tsx",
-		BackendServerURL:   "https://backend1.example.com",
-		Owner:              "owner-1",
+		URL: "https://example.com/1",
 	}
 
-	suite.mockPluginRepo.EXPECT().
-		GetByID(pluginID1).
-		Return(plugin1, nil).
+	// First call to GetByName
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
 		Times(1)
 
-	suite.mockPluginRepo.EXPECT().
-		GetByID(pluginID2).
-		Return(nil, gorm.ErrRecordNotFound).
+	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
+	suite.mockUserRepo.EXPECT().
+		GetByUserID(userID).
+		Return(existingUser, nil).
 		Times(1)
 
-	plugins := suite.userService.GetSubscribedPluginsFromUser(user)
+	// Mock link repository calls
+	suite.mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any()).
+		Return([]models.Link{link}, nil).
+		Times(1)
 
-	assert.NotNil(suite.T(), plugins)
-	// Only the found plugin should be returned
-	assert.Len(suite.T(), plugins, 1)
-	assert.Equal(suite.T(), pluginID1, plugins[0].ID)
-	assert.Equal(suite.T(), "plugin-1", plugins[0].Name)
+	suite.mockLinkRepo.EXPECT().
+		GetByOwner(userUUID).
+		Return([]models.Link{}, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByNameWithLinks(name)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.True(suite.T(), response.PortalAdmin)
+	assert.Len(suite.T(), response.Links, 1)
+	assert.True(suite.T(), response.Links[0].Favorite)
+	assert.NotNil(suite.T(), response.Plugins)
 }
 
-// TestGetUserByUserIDWithPlugins_Success tests successfully getting plugins for a user
-func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_Success() {
+// ===== Tests for GetUserByNameWithLinksAndPlugins =====
+
+// TestGetUserByNameWithLinksAndPlugins_Success tests successfully getting a user with both links and plugins
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_Success() {
+	name := "John Doe"
 	userID := "I123456"
+	userUUID := uuid.New()
+	linkID := uuid.New()
 	pluginID1 := uuid.New()
 	pluginID2 := uuid.New()
 
 	metadata := map[string]interface{}{
+		"favorites":  []string{linkID.String()},
 		"subscribed": []string{pluginID1.String(), pluginID2.String()},
 	}
 	metadataBytes, _ := json.Marshal(metadata)
 
-	user := suite.factories.User.Create()
-	user.UserID = userID
-	user.Metadata = json.RawMessage(metadataBytes)
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userUUID
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	link := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    linkID,
+			Name:  "link1",
+			Title: "Link 1",
+		},
+		URL: "https://example.com/1",
+	}
 
 	plugin1 := &models.Plugin{
 		BaseModel: models.BaseModel{
@@ -2737,11 +4337,30 @@ func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_Success() {
 		Owner:              "owner-2",
 	}
 
+	// First call to GetByName
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
-		Return(user, nil).
+		Return(existingUser, nil).
+		Times(1)
+
+	// Mock link repository calls
+	suite.mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any()).
+		Return([]models.Link{link}, nil).
+		Times(1)
+
+	suite.mockLinkRepo.EXPECT().
+		GetByOwner(userUUID).
+		Return([]models.Link{}, nil).
 		Times(1)
 
+	// Mock plugin repository calls
 	suite.mockPluginRepo.EXPECT().
 		GetByID(pluginID1).
 		Return(plugin1, nil).
@@ -2752,501 +4371,725 @@ func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_Success() {
 		Return(plugin2, nil).
 		Times(1)
 
-	plugins, err := suite.userService.GetUserByUserIDWithPlugins(userID)
+	response, err := suite.userService.GetUserByNameWithLinksAndPlugins(name)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), plugins)
-	assert.Len(suite.T(), plugins, 2)
-
-	// Verify plugins are returned with correct data
-	pluginMap := make(map[uuid.UUID]service.PluginResponse)
-	for _, p := range plugins {
-		pluginMap[p.ID] = p
-	}
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.Len(suite.T(), response.Links, 1)
+	assert.Len(suite.T(), response.Plugins, 2)
+	assert.True(suite.T(), response.Links[0].Favorite)
+}
 
-	assert.Contains(suite.T(), pluginMap, pluginID1)
-	assert.Equal(suite.T(), "plugin-1", pluginMap[pluginID1].Name)
-	assert.Equal(suite.T(), "Plugin 1", pluginMap[pluginID1].Title)
+// TestGetUserByNameWithLinksAndPlugins_EmptyName tests error when name is empty
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_EmptyName() {
+	response, err := suite.userService.GetUserByNameWithLinksAndPlugins("")
 
-	assert.Contains(suite.T(), pluginMap, pluginID2)
-	assert.Equal(suite.T(), "plugin-2", pluginMap[pluginID2].Name)
-	assert.Equal(suite.T(), "Plugin 2", pluginMap[pluginID2].Title)
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "name is required")
 }
 
-// TestGetUserByUserIDWithPlugins_EmptyUserID tests error when userID is empty
-func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_EmptyUserID() {
-	plugins, err := suite.userService.GetUserByUserIDWithPlugins("")
+// TestGetUserByNameWithLinksAndPlugins_UserNotFound tests error when user is not found
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_UserNotFound() {
+	name := "NonExistent User"
+
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(nil, apperrors.ErrUserNotFound).
+		Times(1)
+
+	response, err := suite.userService.GetUserByNameWithLinksAndPlugins(name)
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), plugins)
-	assert.Contains(suite.T(), err.Error(), "user_id is required")
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user not found")
 }
 
-// TestGetUserByUserIDWithPlugins_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestGetUserByUserIDWithPlugins_UserNotFound() {
-	userID := "I999999"
+// TestGetUserByNameWithLinksAndPlugins_NoPlugins tests getting user with links but no plugins
+func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_NoPlugins() {
+	name := "John Doe"
+	userID := "I123456"
+	userUUID := uuid.New()
+	linkID := uuid.New()
+
+	metadata := map[string]interface{}{
+		"favorites": []string{linkID.String()},
+	}
+	metadataBytes, _ := json.Marshal(metadata)
+
+	existingUser := suite.factories.User.Create()
+	existingUser.ID = userUUID
+	existingUser.Name = name
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+
+	link := models.Link{
+		BaseModel: models.BaseModel{
+			ID:    linkID,
+			Name:  "link1",
+			Title: "Link 1",
+		},
+		URL: "https://example.com/1",
+	}
+
+	suite.mockUserRepo.EXPECT().
+		GetByName(name).
+		Return(existingUser, nil).
+		Times(1)
 
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
-		Return(nil, apperrors.ErrUserNotFound).
+		Return(existingUser, nil).
+		Times(1)
+
+	suite.mockLinkRepo.EXPECT().
+		GetByIDs(gomock.Any()).
+		Return([]models.Link{link}, nil).
 		Times(1)
 
-	plugins, err := suite.userService.GetUserByUserIDWithPlugins(userID)
+	suite.mockLinkRepo.EXPECT().
+		GetByOwner(userUUID).
+		Return([]models.Link{}, nil).
+		Times(1)
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), plugins)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	response, err := suite.userService.GetUserByNameWithLinksAndPlugins(name)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response)
+	assert.Equal(suite.T(), userID, response.ID)
+	assert.Len(suite.T(), response.Links, 1)
+	assert.Len(suite.T(), response.Plugins, 0)
 }
 
-// TestGetUserByNameWithLinks_WithSubscribed tests getting a user with subscribed plugins in metadata and both favorite and owned links
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithSubscribed() {
-	name := "John Doe"
+// TestGetUserByUserIDWithLinksAndPlugins_Success tests that links and plugins populate from one user lookup
+func (suite *UserServiceTestSuite) TestGetUserByUserIDWithLinksAndPlugins_Success() {
 	userID := "I123456"
 	userUUID := uuid.New()
+	favoriteLinkID := uuid.New()
+	ownedLinkID := uuid.New()
 	pluginID1 := uuid.New()
 	pluginID2 := uuid.New()
-	favoriteLinkID1 := uuid.New()
-	favoriteLinkID2 := uuid.New()
-	ownedLinkID1 := uuid.New()
-	ownedLinkID2 := uuid.New()
+	missingPluginID := uuid.New()
 
 	metadata := map[string]interface{}{
-		"subscribed": []string{pluginID1.String(), pluginID2.String()},
-		"favorites":  []string{favoriteLinkID1.String(), favoriteLinkID2.String()},
+		"favorites":  []string{favoriteLinkID.String(), ownedLinkID.String()},
+		"subscribed": []string{pluginID2.String(), missingPluginID.String(), pluginID1.String()},
 	}
 	metadataBytes, _ := json.Marshal(metadata)
 
 	existingUser := suite.factories.User.Create()
 	existingUser.ID = userUUID
-	existingUser.Name = name
 	existingUser.UserID = userID
 	existingUser.Metadata = json.RawMessage(metadataBytes)
 
-	// Favorite links
-	favoriteLink1 := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    favoriteLinkID1,
-			Name:  "favorite-link1",
-			Title: "Favorite Link 1",
-		},
-		URL: "https://example.com/fav1",
-	}
-
-	favoriteLink2 := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    favoriteLinkID2,
-			Name:  "favorite-link2",
-			Title: "Favorite Link 2",
-		},
-		URL: "https://example.com/fav2",
-	}
-
-	// Links owned by the user
-	ownedLink1 := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    ownedLinkID1,
-			Name:  "owned-link1",
-			Title: "Owned Link 1",
-		},
-		Owner: userUUID,
-		URL:   "https://example.com/owned1",
-	}
-
-	ownedLink2 := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    ownedLinkID2,
-			Name:  "owned-link2",
-			Title: "Owned Link 2",
-		},
-		Owner: userUUID,
-		URL:   "https://example.com/owned2",
-	}
+	favoriteLink := models.Link{BaseModel: models.BaseModel{ID: favoriteLinkID, Name: "favorite", Title: "Favorite"}, URL: "https://example.com/favorite"}
+	ownedLink := models.Link{BaseModel: models.BaseModel{ID: ownedLinkID, Name: "owned", Title: "Owned"}, URL: "https://example.com/owned", Owner: userUUID}
+	ownedOnlyLink := models.Link{BaseModel: models.BaseModel{ID: uuid.New(), Name: "owned-only", Title: "Owned only"}, URL: "https://example.com/owned-only", Owner: userUUID}
 
-	// First call to GetByName
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(existingUser, nil).
-		Times(1)
+	plugin1 := models.Plugin{BaseModel: models.BaseModel{ID: pluginID1, Name: "plugin-1", Title: "Plugin 1"}, Owner: "owner-1"}
+	plugin2 := models.Plugin{BaseModel: models.BaseModel{ID: pluginID2, Name: "plugin-2", Title: "Plugin 2"}, Owner: "owner-2"}
 
-	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
 	suite.mockUserRepo.EXPECT().
 		GetByUserID(userID).
 		Return(existingUser, nil).
 		Times(1)
 
-	// Mock link repository calls - GetByIDs for favorite links
 	suite.mockLinkRepo.EXPECT().
 		GetByIDs(gomock.Any()).
-		DoAndReturn(func(ids []uuid.UUID) ([]models.Link, error) {
-			// Verify the IDs passed are the favorite link IDs
-			assert.Len(suite.T(), ids, 2)
-			assert.Contains(suite.T(), ids, favoriteLinkID1)
-			assert.Contains(suite.T(), ids, favoriteLinkID2)
-			return []models.Link{favoriteLink1, favoriteLink2}, nil
-		}).
+		Return([]models.Link{favoriteLink, ownedLink}, nil).
 		Times(1)
 
-	// Mock GetByOwner for owned links
 	suite.mockLinkRepo.EXPECT().
 		GetByOwner(userUUID).
-		Return([]models.Link{ownedLink1, ownedLink2}, nil).
+		Return([]models.Link{ownedLink, ownedOnlyLink}, nil).
 		Times(1)
 
-	response, err := suite.userService.GetUserByNameWithLinks(name)
+	suite.mockPluginRepo.EXPECT().
+		GetByIDs([]uuid.UUID{pluginID2, missingPluginID, pluginID1}).
+		Return([]models.Plugin{plugin1, plugin2}, nil).
+		Times(1)
+
+	response, err := suite.userService.GetUserByUserIDWithLinksAndPlugins(userID)
 
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), response)
 	assert.Equal(suite.T(), userID, response.ID)
 
-	// Verify all links are returned (favorites + owned)
-	assert.NotNil(suite.T(), response.Links)
-	assert.Len(suite.T(), response.Links, 4) // 2 favorites + 2 owned
-
-	// Verify link IDs and favorite status
-	linkIDs := make(map[string]bool)
-	favoriteCount := 0
+	assert.Len(suite.T(), response.Links, 3)
+	favorites := make(map[string]bool)
 	for _, link := range response.Links {
-		linkIDs[link.ID] = true
-		if link.Favorite {
-			favoriteCount++
-		}
+		favorites[link.ID] = link.Favorite
 	}
+	assert.True(suite.T(), favorites[favoriteLinkID.String()])
+	assert.True(suite.T(), favorites[ownedLinkID.String()])
+	assert.False(suite.T(), favorites[ownedOnlyLink.ID.String()])
 
-	// Verify all link IDs are present
-	assert.True(suite.T(), linkIDs[favoriteLinkID1.String()])
-	assert.True(suite.T(), linkIDs[favoriteLinkID2.String()])
-	assert.True(suite.T(), linkIDs[ownedLinkID1.String()])
-	assert.True(suite.T(), linkIDs[ownedLinkID2.String()])
+	// Subscription order is kept and the deleted plugin is skipped
+	assert.Len(suite.T(), response.Plugins, 2)
+	assert.Equal(suite.T(), pluginID2, response.Plugins[0].ID)
+	assert.Equal(suite.T(), pluginID1, response.Plugins[1].ID)
+	assert.Equal(suite.T(), "owner-2", response.Plugins[0].Owner)
+}
 
-	// Verify only favorite links are marked as favorites
-	assert.Equal(suite.T(), 2, favoriteCount)
+// TestGetUserByUserIDWithLinksAndPlugins_NoSubscriptions tests that plugins are not queried without subscriptions
+func (suite *UserServiceTestSuite) TestGetUserByUserIDWithLinksAndPlugins_NoSubscriptions() {
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = "I123456"
 
-	// Note: GetUserByUserIDWithLinks returns empty plugins array
-	// Plugins are fetched separately via GetUserByUserIDWithPlugins
+	suite.mockUserRepo.EXPECT().GetByUserID("I123456").Return(existingUser, nil).Times(1)
+	suite.mockLinkRepo.EXPECT().GetByIDs(gomock.Any()).Return([]models.Link{}, nil).Times(1)
+	suite.mockLinkRepo.EXPECT().GetByOwner(existingUser.ID).Return([]models.Link{}, nil).Times(1)
+
+	response, err := suite.userService.GetUserByUserIDWithLinksAndPlugins("I123456")
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), response.Links)
 	assert.NotNil(suite.T(), response.Plugins)
 	assert.Len(suite.T(), response.Plugins, 0)
 }
 
-// TestGetUserByNameWithLinks_WithAllMetadata tests getting a user with all metadata fields
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinks_WithAllMetadata() {
-	name := "John Doe"
-	userID := "I123456"
-	userUUID := uuid.New()
-	linkID := uuid.New()
+// TestGetUserByUserIDWithLinksAndPlugins_PluginLookupFails tests that a plugin query failure still returns links
+func (suite *UserServiceTestSuite) TestGetUserByUserIDWithLinksAndPlugins_PluginLookupFails() {
 	pluginID := uuid.New()
+	metadataBytes, _ := json.Marshal(map[string]interface{}{"subscribed": []string{pluginID.String()}})
 
-	metadata := map[string]interface{}{
-		"favorites":    []string{linkID.String()},
-		"portal_admin": true,
-		"subscribed":   []string{pluginID.String()},
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = "I123456"
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+	ownedLink := models.Link{BaseModel: models.BaseModel{ID: uuid.New(), Name: "owned", Title: "Owned"}, URL: "https://example.com/owned"}
+
+	suite.mockUserRepo.EXPECT().GetByUserID("I123456").Return(existingUser, nil).Times(1)
+	suite.mockLinkRepo.EXPECT().GetByIDs(gomock.Any()).Return([]models.Link{}, nil).Times(1)
+	suite.mockLinkRepo.EXPECT().GetByOwner(existingUser.ID).Return([]models.Link{ownedLink}, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().GetByIDs([]uuid.UUID{pluginID}).Return(nil, errors.New("db down")).Times(1)
+
+	response, err := suite.userService.GetUserByUserIDWithLinksAndPlugins("I123456")
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Links, 1)
+	assert.Len(suite.T(), response.Plugins, 0)
+}
+
+// TestGetUserByUserIDWithLinksAndPlugins_Validation tests empty user ID and unknown user handling
+func (suite *UserServiceTestSuite) TestGetUserByUserIDWithLinksAndPlugins_Validation() {
+	response, err := suite.userService.GetUserByUserIDWithLinksAndPlugins("")
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+	assert.Contains(suite.T(), err.Error(), "user_id is required")
+
+	suite.mockUserRepo.EXPECT().GetByUserID("missing").Return(nil, apperrors.ErrUserNotFound).Times(1)
+
+	response, err = suite.userService.GetUserByUserIDWithLinksAndPlugins("missing")
+	assert.Nil(suite.T(), response)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+}
+
+// ===== Tests for GetSubscribedPlugins =====
+
+// TestGetSubscribedPlugins_SplitsResolvedAndUnresolved tests that a deleted plugin is reported by ID instead of dropped
+func (suite *UserServiceTestSuite) TestGetSubscribedPlugins_SplitsResolvedAndUnresolved() {
+	validID := uuid.New()
+	deletedID := uuid.New()
+	metadataBytes, _ := json.Marshal(map[string]interface{}{"subscribed": []string{deletedID.String(), validID.String()}})
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = "I123456"
+	existingUser.Metadata = json.RawMessage(metadataBytes)
+	plugin := models.Plugin{BaseModel: models.BaseModel{ID: validID, Name: "valid-plugin", Title: "Valid Plugin"}}
+
+	suite.mockUserRepo.EXPECT().GetByUserID("I123456").Return(existingUser, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().GetByIDs([]uuid.UUID{deletedID, validID}).Return([]models.Plugin{plugin}, nil).Times(1)
+
+	response, err := suite.userService.GetSubscribedPlugins("I123456")
+
+	assert.NoError(suite.T(), err)
+	if assert.Len(suite.T(), response.Plugins, 1) {
+		assert.Equal(suite.T(), validID, response.Plugins[0].ID)
 	}
-	metadataBytes, _ := json.Marshal(metadata)
+	assert.Equal(suite.T(), []uuid.UUID{deletedID}, response.UnresolvedIDs)
+}
+
+// TestGetSubscribedPlugins_LookupFails tests that a failing batch lookup is returned rather than reporting every ID as unresolved
+func (suite *UserServiceTestSuite) TestGetSubscribedPlugins_LookupFails() {
+	pluginID := uuid.New()
+	metadataBytes, _ := json.Marshal(map[string]interface{}{"subscribed": []string{pluginID.String()}})
 
 	existingUser := suite.factories.User.Create()
-	existingUser.ID = userUUID
-	existingUser.Name = name
-	existingUser.UserID = userID
+	existingUser.UserID = "I123456"
 	existingUser.Metadata = json.RawMessage(metadataBytes)
 
-	link := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    linkID,
-			Name:  "link1",
-			Title: "Link 1",
+	suite.mockUserRepo.EXPECT().GetByUserID("I123456").Return(existingUser, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().GetByIDs([]uuid.UUID{pluginID}).Return(nil, errors.New("db down")).Times(1)
+
+	response, err := suite.userService.GetSubscribedPlugins("I123456")
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), response)
+}
+
+// ===== Tests for GetAllUsers =====
+
+// TestGetAllUsers_Success tests successfully getting all users
+func (suite *UserServiceTestSuite) TestGetAllUsers_Success() {
+	limit, offset := 20, 0
+	users := []models.User{
+		{
+			UserID:     "I123456",
+			FirstName:  "John",
+			LastName:   "Doe",
+			Email:      "john@example.com",
+			TeamDomain: models.TeamDomainDeveloper,
+			TeamRole:   models.TeamRoleMember,
+		},
+		{
+			UserID:     "I789012",
+			FirstName:  "Jane",
+			LastName:   "Smith",
+			Email:      "jane@example.com",
+			TeamDomain: models.TeamDomainPO,
+			TeamRole:   models.TeamRoleManager,
 		},
-		URL: "https://example.com/1",
 	}
+	expectedTotal := int64(2)
+
+	suite.mockUserRepo.EXPECT().
+		GetAll(limit, offset).
+		Return(users, expectedTotal, nil).
+		Times(1)
+
+	responses, total, err := suite.userService.GetAllUsers(limit, offset)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedTotal, total)
+	assert.Len(suite.T(), responses, 2)
+	assert.Equal(suite.T(), "I123456", responses[0].ID)
+	assert.Equal(suite.T(), "John", responses[0].FirstName)
+	assert.Equal(suite.T(), "I789012", responses[1].ID)
+	assert.Equal(suite.T(), "Jane", responses[1].FirstName)
+}
+
+// TestGetAllUsers_EmptyResult tests getting all users when no users exist
+func (suite *UserServiceTestSuite) TestGetAllUsers_EmptyResult() {
+	limit, offset := 20, 0
+	users := []models.User{}
+	expectedTotal := int64(0)
+
+	suite.mockUserRepo.EXPECT().
+		GetAll(limit, offset).
+		Return(users, expectedTotal, nil).
+		Times(1)
+
+	responses, total, err := suite.userService.GetAllUsers(limit, offset)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedTotal, total)
+	assert.Len(suite.T(), responses, 0)
+}
+
+// TestGetAllUsers_RepositoryError tests error when repository fails
+func (suite *UserServiceTestSuite) TestGetAllUsers_RepositoryError() {
+	limit, offset := 20, 0
+
+	suite.mockUserRepo.EXPECT().
+		GetAll(limit, offset).
+		Return(nil, int64(0), gorm.ErrInvalidDB).
+		Times(1)
+
+	responses, total, err := suite.userService.GetAllUsers(limit, offset)
+
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), responses)
+	assert.Equal(suite.T(), int64(0), total)
+	assert.Contains(suite.T(), err.Error(), "failed to get users")
+}
+
+// TestGetAllUsers_ClampsPageSize tests that zero and oversized limits are clamped before querying
+func (suite *UserServiceTestSuite) TestGetAllUsers_ClampsPageSize() {
+	suite.mockUserRepo.EXPECT().
+		GetAll(service.DefaultPageSize, 0).
+		Return([]models.User{}, int64(0), nil).
+		Times(1)
+	suite.mockUserRepo.EXPECT().
+		GetAll(service.MaxPageSize, 0).
+		Return([]models.User{}, int64(0), nil).
+		Times(1)
+
+	_, _, err := suite.userService.GetAllUsers(0, 0)
+	assert.NoError(suite.T(), err)
+
+	_, _, err = suite.userService.GetAllUsers(100000, 0)
+	assert.NoError(suite.T(), err)
+}
+
+// ===== Tests for RepairUserMetadata =====
+
+// TestRepairUserMetadata_MixedEntries tests that valid UUIDs survive while invalid entries are dropped and unknown keys kept
+func (suite *UserServiceTestSuite) TestRepairUserMetadata_MixedEntries() {
+	userID := "I123456"
+	validA := uuid.New().String()
+	validB := uuid.New().String()
+	plugin := uuid.New().String()
 
-	// First call to GetByName
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(existingUser, nil).
-		Times(1)
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"favorites":["` + validA + `","not-a-uuid",42,"` + validB + `"],"subscribed":"` + plugin + `","custom_field":"keep-me"}`)
 
-	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
 	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			assert.NoError(suite.T(), json.Unmarshal(user.Metadata, &meta))
+			assert.Equal(suite.T(), []interface{}{validA, validB}, meta["favorites"])
+			assert.Equal(suite.T(), []interface{}{plugin}, meta["subscribed"])
+			assert.Equal(suite.T(), "keep-me", meta["custom_field"])
+			return nil
+		}).
 		Times(1)
 
-	// Mock link repository calls
-	suite.mockLinkRepo.EXPECT().
-		GetByIDs(gomock.Any()).
-		Return([]models.Link{link}, nil).
-		Times(1)
+	report, err := suite.userService.RepairUserMetadata(userID)
 
-	suite.mockLinkRepo.EXPECT().
-		GetByOwner(userUUID).
-		Return([]models.Link{}, nil).
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), report.Repaired)
+	assert.False(suite.T(), report.Reset)
+	assert.Equal(suite.T(), []string{validA, validB}, report.Recovered["favorites"])
+	assert.Equal(suite.T(), []string{`"not-a-uuid"`, "42"}, report.Dropped["favorites"])
+	assert.Equal(suite.T(), []string{plugin}, report.Recovered["subscribed"])
+}
+
+// TestRepairUserMetadata_CorruptJSON tests salvaging UUIDs from a truncated favorites array
+func (suite *UserServiceTestSuite) TestRepairUserMetadata_CorruptJSON() {
+	userID := "I123456"
+	validA := uuid.New().String()
+	validB := uuid.New().String()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"portal_admin":true,"favorites":["` + validA + `", "broken, "` + validB)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			assert.NoError(suite.T(), json.Unmarshal(user.Metadata, &meta))
+			assert.Equal(suite.T(), []interface{}{validA, validB}, meta["favorites"])
+			return nil
+		}).
 		Times(1)
 
-	response, err := suite.userService.GetUserByNameWithLinks(name)
+	report, err := suite.userService.RepairUserMetadata(userID)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.True(suite.T(), response.PortalAdmin)
-	assert.Len(suite.T(), response.Links, 1)
-	assert.True(suite.T(), response.Links[0].Favorite)
-	assert.NotNil(suite.T(), response.Plugins)
+	assert.True(suite.T(), report.Repaired)
+	assert.True(suite.T(), report.Reset)
+	assert.Equal(suite.T(), []string{validA, validB}, report.Recovered["favorites"])
+	assert.Equal(suite.T(), []string{"broken"}, report.Dropped["favorites"])
 }
 
-// ===== Tests for GetUserByNameWithLinksAndPlugins =====
-
-// TestGetUserByNameWithLinksAndPlugins_Success tests successfully getting a user with both links and plugins
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_Success() {
-	name := "John Doe"
+// TestRepairUserMetadata_AlreadyValid tests that valid metadata is not rewritten
+func (suite *UserServiceTestSuite) TestRepairUserMetadata_AlreadyValid() {
 	userID := "I123456"
-	userUUID := uuid.New()
-	linkID := uuid.New()
-	pluginID1 := uuid.New()
-	pluginID2 := uuid.New()
-
-	metadata := map[string]interface{}{
-		"favorites":  []string{linkID.String()},
-		"subscribed": []string{pluginID1.String(), pluginID2.String()},
-	}
-	metadataBytes, _ := json.Marshal(metadata)
 
 	existingUser := suite.factories.User.Create()
-	existingUser.ID = userUUID
-	existingUser.Name = name
 	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.Metadata = json.RawMessage(`{"favorites":["` + uuid.New().String() + `"]}`)
 
-	link := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    linkID,
-			Name:  "link1",
-			Title: "Link 1",
-		},
-		URL: "https://example.com/1",
-	}
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Times(0)
 
-	plugin1 := &models.Plugin{
-		BaseModel: models.BaseModel{
-			ID:          pluginID1,
-			Name:        "plugin-1",
-			Title:       "Plugin 1",
-			Description: "First plugin",
-		},
-		Icon:               "icon-1",
-		ReactComponentPath: "/path/to/plugin1.tsx",
-		BackendServerURL:   "https://backend1.example.com",
-		Owner:              "owner-1",
-	}
+	report, err := suite.userService.RepairUserMetadata(userID)
 
-	plugin2 := &models.Plugin{
-		BaseModel: models.BaseModel{
-			ID:          pluginID2,
-			Name:        "plugin-2",
-			Title:       "Plugin 2",
-			Description: "Second plugin",
-		},
-		Icon:               "icon-2",
-		ReactComponentPath: "/path/to/plugin2.tsx",
-		BackendServerURL:   "https://backend2.example.com",
-		Owner:              "owner-2",
-	}
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), report.Repaired)
+	assert.Empty(suite.T(), report.Dropped)
+}
 
-	// First call to GetByName
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(existingUser, nil).
-		Times(1)
+// TestRepairUserMetadata_UserNotFound tests repairing metadata of a missing user
+func (suite *UserServiceTestSuite) TestRepairUserMetadata_UserNotFound() {
+	suite.mockUserRepo.EXPECT().GetByUserID("missing").Return(nil, apperrors.ErrUserNotFound).Times(1)
 
-	// Second call to GetByUserID (from GetUserByUserIDWithLinks)
-	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
-		Times(1)
+	report, err := suite.userService.RepairUserMetadata("missing")
 
-	// Mock link repository calls
-	suite.mockLinkRepo.EXPECT().
-		GetByIDs(gomock.Any()).
-		Return([]models.Link{link}, nil).
-		Times(1)
+	assert.Nil(suite.T(), report)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+}
 
-	suite.mockLinkRepo.EXPECT().
-		GetByOwner(userUUID).
-		Return([]models.Link{}, nil).
-		Times(1)
+// ===== Tests for PruneUserMetadata =====
 
-	// Mock plugin repository calls
-	suite.mockPluginRepo.EXPECT().
-		GetByID(pluginID1).
-		Return(plugin1, nil).
-		Times(1)
+// TestPruneUserMetadata_RemovesOnlyDanglingIDs tests that IDs of deleted links and plugins are removed while resolvable ones and other keys stay
+func (suite *UserServiceTestSuite) TestPruneUserMetadata_RemovesOnlyDanglingIDs() {
+	userID := "I123456"
+	liveLink, deadLinkA, deadLinkB := uuid.New(), uuid.New(), uuid.New()
+	livePlugin, deadPlugin := uuid.New(), uuid.New()
 
-	suite.mockPluginRepo.EXPECT().
-		GetByID(pluginID2).
-		Return(plugin2, nil).
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"favorites":["` + deadLinkA.String() + `","` + liveLink.String() + `","` + deadLinkB.String() + `"],` +
+		`"subscribed":["` + livePlugin.String() + `","` + deadPlugin.String() + `"],"portal_admin":true}`)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockLinkRepo.EXPECT().GetByIDs([]uuid.UUID{deadLinkA, liveLink, deadLinkB}).
+		Return([]models.Link{{BaseModel: models.BaseModel{ID: liveLink}}}, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().GetByIDs([]uuid.UUID{livePlugin, deadPlugin}).
+		Return([]models.Plugin{{BaseModel: models.BaseModel{ID: livePlugin}}}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			assert.NoError(suite.T(), json.Unmarshal(user.Metadata, &meta))
+			assert.Equal(suite.T(), []interface{}{liveLink.String()}, meta["favorites"])
+			assert.Equal(suite.T(), []interface{}{livePlugin.String()}, meta["subscribed"])
+			assert.Equal(suite.T(), true, meta["portal_admin"])
+			return nil
+		}).
 		Times(1)
 
-	response, err := suite.userService.GetUserByNameWithLinksAndPlugins(name)
+	report, err := suite.userService.PruneUserMetadata(userID)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.Len(suite.T(), response.Links, 1)
-	assert.Len(suite.T(), response.Plugins, 2)
-	assert.True(suite.T(), response.Links[0].Favorite)
+	assert.Equal(suite.T(), 2, report.PrunedFavorites)
+	assert.Equal(suite.T(), 1, report.PrunedSubscribed)
 }
 
-// TestGetUserByNameWithLinksAndPlugins_EmptyName tests error when name is empty
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_EmptyName() {
-	response, err := suite.userService.GetUserByNameWithLinksAndPlugins("")
+// TestPruneUserMetadata_NothingDangling tests that metadata whose IDs all resolve is not rewritten
+func (suite *UserServiceTestSuite) TestPruneUserMetadata_NothingDangling() {
+	userID := "I123456"
+	link := uuid.New()
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "name is required")
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"favorites":["` + link.String() + `"]}`)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockLinkRepo.EXPECT().GetByIDs([]uuid.UUID{link}).Return([]models.Link{{BaseModel: models.BaseModel{ID: link}}}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Times(0)
+
+	report, err := suite.userService.PruneUserMetadata(userID)
+
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), report.PrunedFavorites)
+	assert.Zero(suite.T(), report.PrunedSubscribed)
 }
 
-// TestGetUserByNameWithLinksAndPlugins_UserNotFound tests error when user is not found
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_UserNotFound() {
-	name := "NonExistent User"
+// TestPruneUserMetadata_LookupFails tests that a failing lookup aborts without removing any ID
+func (suite *UserServiceTestSuite) TestPruneUserMetadata_LookupFails() {
+	userID := "I123456"
+	plugin := uuid.New()
 
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(nil, apperrors.ErrUserNotFound).
-		Times(1)
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"subscribed":["` + plugin.String() + `"]}`)
 
-	response, err := suite.userService.GetUserByNameWithLinksAndPlugins(name)
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().GetByIDs([]uuid.UUID{plugin}).Return(nil, errors.New("db down")).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Times(0)
+
+	report, err := suite.userService.PruneUserMetadata(userID)
 
 	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), response)
-	assert.Contains(suite.T(), err.Error(), "user not found")
+	assert.Nil(suite.T(), report)
 }
 
-// TestGetUserByNameWithLinksAndPlugins_NoPlugins tests getting user with links but no plugins
-func (suite *UserServiceTestSuite) TestGetUserByNameWithLinksAndPlugins_NoPlugins() {
-	name := "John Doe"
+// TestPruneUserMetadata_InvalidMetadata tests that invalid metadata is rejected rather than pruned
+func (suite *UserServiceTestSuite) TestPruneUserMetadata_InvalidMetadata() {
 	userID := "I123456"
-	userUUID := uuid.New()
-	linkID := uuid.New()
-
-	metadata := map[string]interface{}{
-		"favorites": []string{linkID.String()},
-	}
-	metadataBytes, _ := json.Marshal(metadata)
 
 	existingUser := suite.factories.User.Create()
-	existingUser.ID = userUUID
-	existingUser.Name = name
 	existingUser.UserID = userID
-	existingUser.Metadata = json.RawMessage(metadataBytes)
+	existingUser.Metadata = json.RawMessage(`{"favorites":["not-a-uuid"]}`)
 
-	link := models.Link{
-		BaseModel: models.BaseModel{
-			ID:    linkID,
-			Name:  "link1",
-			Title: "Link 1",
-		},
-		URL: "https://example.com/1",
-	}
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Times(0)
 
-	suite.mockUserRepo.EXPECT().
-		GetByName(name).
-		Return(existingUser, nil).
-		Times(1)
+	report, err := suite.userService.PruneUserMetadata(userID)
 
-	suite.mockUserRepo.EXPECT().
-		GetByUserID(userID).
-		Return(existingUser, nil).
-		Times(1)
+	assert.Nil(suite.T(), report)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrInvalidMetadata)
+}
 
-	suite.mockLinkRepo.EXPECT().
-		GetByIDs(gomock.Any()).
-		Return([]models.Link{link}, nil).
-		Times(1)
+// ===== Tests for ClearFavoriteLinksByUserID / ClearSubscribedPluginsByUserID =====
 
-	suite.mockLinkRepo.EXPECT().
-		GetByOwner(userUUID).
-		Return([]models.Link{}, nil).
+// TestClearFavoriteLinksByUserID_PreservesOtherMetadata tests that favorites are emptied while other keys survive
+func (suite *UserServiceTestSuite) TestClearFavoriteLinksByUserID_PreservesOtherMetadata() {
+	userID := "I123456"
+	plugin := uuid.New().String()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"favorites":["` + uuid.New().String() + `","` + uuid.New().String() + `"],"subscribed":["` + plugin + `"],"portal_admin":true,"custom_field":"keep-me"}`)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			assert.NoError(suite.T(), json.Unmarshal(user.Metadata, &meta))
+			assert.Equal(suite.T(), []interface{}{}, meta["favorites"])
+			assert.Equal(suite.T(), []interface{}{plugin}, meta["subscribed"])
+			assert.Equal(suite.T(), true, meta["portal_admin"])
+			assert.Equal(suite.T(), "keep-me", meta["custom_field"])
+			return nil
+		}).
 		Times(1)
 
-	response, err := suite.userService.GetUserByNameWithLinksAndPlugins(name)
+	result, err := suite.userService.ClearFavoriteLinksByUserID(userID, userID)
 
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), response)
-	assert.Equal(suite.T(), userID, response.ID)
-	assert.Len(suite.T(), response.Links, 1)
-	assert.Len(suite.T(), response.Plugins, 0)
+	assert.NotNil(suite.T(), result)
 }
 
-// ===== Tests for GetAllUsers =====
-
-// TestGetAllUsers_Success tests successfully getting all users
-func (suite *UserServiceTestSuite) TestGetAllUsers_Success() {
-	limit, offset := 20, 0
-	users := []models.User{
-		{
-			UserID:     "I123456",
-			FirstName:  "John",
-			LastName:   "Doe",
-			Email:      "john@example.com",
-			TeamDomain: models.TeamDomainDeveloper,
-			TeamRole:   models.TeamRoleMember,
-		},
-		{
-			UserID:     "I789012",
-			FirstName:  "Jane",
-			LastName:   "Smith",
-			Email:      "jane@example.com",
-			TeamDomain: models.TeamDomainPO,
-			TeamRole:   models.TeamRoleManager,
-		},
+// TestClearFavoriteLinksByUserID_NoOrInvalidMetadata tests that missing or corrupt metadata becomes a clean empty-favorites object
+func (suite *UserServiceTestSuite) TestClearFavoriteLinksByUserID_NoOrInvalidMetadata() {
+	for name, metadata := range map[string]json.RawMessage{
+		"no metadata":  nil,
+		"invalid json": json.RawMessage(`{"favorites":["broken`),
+	} {
+		suite.Run(name, func() {
+			userID := "I123456"
+			existingUser := suite.factories.User.Create()
+			existingUser.UserID = userID
+			existingUser.Metadata = metadata
+
+			suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
+			suite.mockUserRepo.EXPECT().
+				Update(gomock.Any()).
+				DoAndReturn(func(user *models.User) error {
+					assert.JSONEq(suite.T(), `{"favorites":[]}`, string(user.Metadata))
+					return nil
+				}).
+				Times(1)
+
+			_, err := suite.userService.ClearFavoriteLinksByUserID(userID, userID)
+			assert.NoError(suite.T(), err)
+		})
 	}
-	expectedTotal := int64(2)
+}
 
+// TestClearSubscribedPluginsByUserID_PreservesOtherMetadata tests that subscribed is emptied while favorites survive
+func (suite *UserServiceTestSuite) TestClearSubscribedPluginsByUserID_PreservesOtherMetadata() {
+	userID := "I123456"
+	favorite := uuid.New().String()
+
+	existingUser := suite.factories.User.Create()
+	existingUser.UserID = userID
+	existingUser.Metadata = json.RawMessage(`{"favorites":["` + favorite + `"],"subscribed":["` + uuid.New().String() + `"],"portal_admin":"true"}`)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(existingUser, nil).Times(1)
 	suite.mockUserRepo.EXPECT().
-		GetAll(limit, offset).
-		Return(users, expectedTotal, nil).
+		Update(gomock.Any()).
+		DoAndReturn(func(user *models.User) error {
+			var meta map[string]interface{}
+			assert.NoError(suite.T(), json.Unmarshal(user.Metadata, &meta))
+			assert.Equal(suite.T(), []interface{}{}, meta["subscribed"])
+			assert.Equal(suite.T(), []interface{}{favorite}, meta["favorites"])
+			assert.Equal(suite.T(), "true", meta["portal_admin"])
+			return nil
+		}).
 		Times(1)
 
-	responses, total, err := suite.userService.GetAllUsers(limit, offset)
+	_, err := suite.userService.ClearSubscribedPluginsByUserID(userID, userID)
 
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), expectedTotal, total)
-	assert.Len(suite.T(), responses, 2)
-	assert.Equal(suite.T(), "I123456", responses[0].ID)
-	assert.Equal(suite.T(), "John", responses[0].FirstName)
-	assert.Equal(suite.T(), "I789012", responses[1].ID)
-	assert.Equal(suite.T(), "Jane", responses[1].FirstName)
 }
 
-// TestGetAllUsers_EmptyResult tests getting all users when no users exist
-func (suite *UserServiceTestSuite) TestGetAllUsers_EmptyResult() {
-	limit, offset := 20, 0
-	users := []models.User{}
-	expectedTotal := int64(0)
+// TestClearSubscribedPluginsByUserID_UserNotFound tests clearing subscriptions of a missing user
+func (suite *UserServiceTestSuite) TestClearSubscribedPluginsByUserID_UserNotFound() {
+	suite.mockUserRepo.EXPECT().GetByUserID("missing").Return(nil, apperrors.ErrUserNotFound).Times(1)
+
+	result, err := suite.userService.ClearSubscribedPluginsByUserID("missing", "missing")
+
+	assert.Nil(suite.T(), result)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+}
+
+// ===== Tests for GetAllUsersAfter =====
+
+// TestGetAllUsersAfter_StableAcrossWrites tests that rows written between page fetches are neither duplicated nor skipped
+func (suite *UserServiceTestSuite) TestGetAllUsersAfter_StableAcrossWrites() {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newUser := func(userID string, minutes int) models.User {
+		return models.User{
+			BaseModel: models.BaseModel{ID: uuid.New(), CreatedAt: base.Add(time.Duration(minutes) * time.Minute)},
+			UserID:    userID,
+		}
+	}
+	table := []models.User{newUser("I000001", 1), newUser("I000002", 2), newUser("I000003", 3), newUser("I000004", 4)}
+
+	// Emulate the repository's keyset query over the in-memory table, which is kept in (created_at, id) order
+	suite.mockUserRepo.EXPECT().
+		GetAllAfter(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(createdAt time.Time, id uuid.UUID, limit int) ([]models.User, error) {
+			var page []models.User
+			for _, user := range table {
+				after := user.CreatedAt.After(createdAt) ||
+					(user.CreatedAt.Equal(createdAt) && user.ID.String() > id.String())
+				if after && len(page) < limit {
+					page = append(page, user)
+				}
+			}
+			return page, nil
+		}).
+		AnyTimes()
+
+	first, err := suite.userService.GetAllUsersAfter("", 2)
+	suite.Require().NoError(err)
+	suite.Require().Len(first.Users, 2)
+	suite.Require().NotEmpty(first.NextCursor)
+
+	// Between pages: a user already returned is deleted and a new one is created
+	table = append(table[1:], newUser("I000005", 5))
+
+	second, err := suite.userService.GetAllUsersAfter(first.NextCursor, 2)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(second.NextCursor)
+
+	third, err := suite.userService.GetAllUsersAfter(second.NextCursor, 2)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), third.NextCursor)
+
+	var seen []string
+	for _, page := range []*service.UsersCursorPage{first, second, third} {
+		for _, user := range page.Users {
+			seen = append(seen, user.ID)
+		}
+	}
+	assert.Equal(suite.T(), []string{"I000001", "I000002", "I000003", "I000004", "I000005"}, seen)
+}
 
+// TestGetAllUsersAfter_LastPage tests that a short page carries no next cursor
+func (suite *UserServiceTestSuite) TestGetAllUsersAfter_LastPage() {
 	suite.mockUserRepo.EXPECT().
-		GetAll(limit, offset).
-		Return(users, expectedTotal, nil).
+		GetAllAfter(time.Time{}, uuid.Nil, 21).
+		Return([]models.User{{UserID: "I123456"}}, nil).
 		Times(1)
 
-	responses, total, err := suite.userService.GetAllUsers(limit, offset)
+	page, err := suite.userService.GetAllUsersAfter("", 0)
 
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), expectedTotal, total)
-	assert.Len(suite.T(), responses, 0)
+	assert.Len(suite.T(), page.Users, 1)
+	assert.Empty(suite.T(), page.NextCursor)
 }
 
-// TestGetAllUsers_RepositoryError tests error when repository fails
-func (suite *UserServiceTestSuite) TestGetAllUsers_RepositoryError() {
-	limit, offset := 20, 0
+// TestGetAllUsersAfter_InvalidCursor tests that a malformed cursor is rejected without querying
+func (suite *UserServiceTestSuite) TestGetAllUsersAfter_InvalidCursor() {
+	page, err := suite.userService.GetAllUsersAfter("not-a-cursor", 10)
+
+	assert.Nil(suite.T(), page)
+	assert.True(suite.T(), apperrors.IsValidation(err))
+}
 
+// TestGetAllUsersAfter_RepositoryError tests error when repository fails
+func (suite *UserServiceTestSuite) TestGetAllUsersAfter_RepositoryError() {
 	suite.mockUserRepo.EXPECT().
-		GetAll(limit, offset).
-		Return(nil, int64(0), gorm.ErrInvalidDB).
+		GetAllAfter(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, gorm.ErrInvalidDB).
 		Times(1)
 
-	responses, total, err := suite.userService.GetAllUsers(limit, offset)
+	page, err := suite.userService.GetAllUsersAfter("", 10)
 
-	assert.Error(suite.T(), err)
-	assert.Nil(suite.T(), responses)
-	assert.Equal(suite.T(), int64(0), total)
+	assert.Nil(suite.T(), page)
 	assert.Contains(suite.T(), err.Error(), "failed to get users")
 }
 
@@ -3285,11 +5128,11 @@ func (suite *UserServiceTestSuite) TestSearchUsersGlobal_Success() {
 	expectedTotal := int64(2)
 
 	suite.mockUserRepo.EXPECT().
-		SearchByNameOrTitleGlobal(query, limit, offset).
+		SearchByNameOrTitleGlobal(query, false, limit, offset).
 		Return(users, expectedTotal, nil).
 		Times(1)
 
-	responses, total, err := suite.userService.SearchUsersGlobal(query, limit, offset)
+	responses, total, err := suite.userService.SearchUsersGlobal(query, false, limit, offset)
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedTotal, total)
@@ -3308,11 +5151,11 @@ func (suite *UserServiceTestSuite) TestSearchUsersGlobal_EmptyQuery() {
 	expectedTotal := int64(0)
 
 	suite.mockUserRepo.EXPECT().
-		SearchByNameOrTitleGlobal(query, limit, offset).
+		SearchByNameOrTitleGlobal(query, false, limit, offset).
 		Return(users, expectedTotal, nil).
 		Times(1)
 
-	responses, total, err := suite.userService.SearchUsersGlobal(query, limit, offset)
+	responses, total, err := suite.userService.SearchUsersGlobal(query, false, limit, offset)
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedTotal, total)
@@ -3325,11 +5168,11 @@ func (suite *UserServiceTestSuite) TestSearchUsersGlobal_RepositoryError() {
 	limit, offset := 20, 0
 
 	suite.mockUserRepo.EXPECT().
-		SearchByNameOrTitleGlobal(query, limit, offset).
+		SearchByNameOrTitleGlobal(query, false, limit, offset).
 		Return(nil, int64(0), gorm.ErrInvalidDB).
 		Times(1)
 
-	responses, total, err := suite.userService.SearchUsersGlobal(query, limit, offset)
+	responses, total, err := suite.userService.SearchUsersGlobal(query, false, limit, offset)
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), responses)
@@ -3337,6 +5180,58 @@ func (suite *UserServiceTestSuite) TestSearchUsersGlobal_RepositoryError() {
 	assert.Contains(suite.T(), err.Error(), "failed to search users")
 }
 
+// TestSearchUsersGlobal_ByEmailFragment tests an email fragment is passed through with the email-only flag
+func (suite *UserServiceTestSuite) TestSearchUsersGlobal_ByEmailFragment() {
+	query := "john@"
+	limit, offset := 20, 0
+	users := []models.User{
+		{
+			BaseModel: models.BaseModel{Name: "John Doe"},
+			UserID:    "I123456",
+			FirstName: "John",
+			Email:     "john@example.com",
+		},
+	}
+
+	suite.mockUserRepo.EXPECT().
+		SearchByNameOrTitleGlobal(query, true, limit, offset).
+		Return(users, int64(1), nil).
+		Times(1)
+
+	responses, total, err := suite.userService.SearchUsersGlobal(query, true, limit, offset)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Len(suite.T(), responses, 1)
+	assert.Equal(suite.T(), "john@example.com", responses[0].Email)
+}
+
+// TestSearchUsersGlobal_ByNameFragment tests a name fragment still matches when email matching is enabled
+func (suite *UserServiceTestSuite) TestSearchUsersGlobal_ByNameFragment() {
+	query := "Doe"
+	limit, offset := 20, 0
+	users := []models.User{
+		{
+			BaseModel: models.BaseModel{Name: "John Doe"},
+			UserID:    "I123456",
+			FirstName: "John",
+			Email:     "jd@example.com",
+		},
+	}
+
+	suite.mockUserRepo.EXPECT().
+		SearchByNameOrTitleGlobal(query, false, limit, offset).
+		Return(users, int64(1), nil).
+		Times(1)
+
+	responses, total, err := suite.userService.SearchUsersGlobal(query, false, limit, offset)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Len(suite.T(), responses, 1)
+	assert.Equal(suite.T(), "I123456", responses[0].ID)
+}
+
 // ===== Tests for GetQuickLinks =====
 
 // TestGetQuickLinks_Success tests successfully getting quick links (returns empty array)
@@ -3442,6 +5337,7 @@ func (suite *UserServiceTestSuite) TestAddQuickLink_ValidationError_MissingURL()
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
+	assert.True(suite.T(), apperrors.IsValidation(err))
 	assert.Contains(suite.T(), err.Error(), "validation failed")
 }
 
@@ -3458,6 +5354,7 @@ func (suite *UserServiceTestSuite) TestAddQuickLink_ValidationError_InvalidURL()
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
+	assert.True(suite.T(), apperrors.IsValidation(err))
 	assert.Contains(suite.T(), err.Error(), "validation failed")
 }
 
@@ -3475,6 +5372,7 @@ func (suite *UserServiceTestSuite) TestAddQuickLink_ValidationError_MissingTitle
 
 	assert.Error(suite.T(), err)
 	assert.Nil(suite.T(), response)
+	assert.True(suite.T(), apperrors.IsValidation(err))
 	assert.Contains(suite.T(), err.Error(), "validation failed")
 }
 
@@ -3620,6 +5518,418 @@ func TestAddQuickLinkValidation(t *testing.T) {
 	}
 }
 
+// recordingPublisher is a fake EventPublisher that records published events
+type recordingPublisher struct {
+	events []service.Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event service.Event) error {
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func (p *recordingPublisher) types() []service.EventType {
+	types := make([]service.EventType, 0, len(p.events))
+	for _, e := range p.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+// TestEvents_CreateUser tests that creating a user publishes UserCreated
+func (suite *UserServiceTestSuite) TestEvents_CreateUser() {
+	publisher := &recordingPublisher{}
+	suite.userService.SetEventPublisher(publisher)
+
+	req := &service.CreateUserRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		IUser:     "I123456",
+		CreatedBy: "I123456",
+	}
+	suite.mockUserRepo.EXPECT().GetByEmail(req.Email).Return(nil, apperrors.ErrUserNotFound).Times(1)
+	suite.mockUserRepo.EXPECT().Create(gomock.Any()).Return(nil).Times(1)
+
+	_, err := suite.userService.CreateUser(req)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(publisher.events, 1)
+	event := publisher.events[0]
+	assert.Equal(suite.T(), service.EventUserCreated, event.Type)
+	assert.Equal(suite.T(), service.FieldChange{Old: nil, New: "john@example.com"}, event.Changes["email"])
+	assert.False(suite.T(), event.OccurredAt.IsZero())
+}
+
+// TestEvents_UpdateUser tests that updating a user publishes UserUpdated with only the changed fields
+func (suite *UserServiceTestSuite) TestEvents_UpdateUser() {
+	publisher := &recordingPublisher{}
+	suite.userService.SetEventPublisher(publisher)
+
+	user := suite.factories.User.Create()
+	user.ID = uuid.New()
+	user.FirstName = "John"
+	newFirstName := "Johnny"
+
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+
+	_, err := suite.userService.UpdateUser(user.ID, &service.UpdateUserRequest{FirstName: &newFirstName})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []service.EventType{service.EventUserUpdated}, publisher.types())
+	assert.Equal(suite.T(), user.ID, publisher.events[0].UserID)
+	assert.Equal(suite.T(), map[string]service.FieldChange{
+		"first_name": {Old: "John", New: "Johnny"},
+	}, publisher.events[0].Changes)
+}
+
+// TestEvents_UpdateUserTeam tests that moving a user to another team publishes UserTeamChanged
+func (suite *UserServiceTestSuite) TestEvents_UpdateUserTeam() {
+	publisher := &recordingPublisher{}
+	suite.userService.SetEventPublisher(publisher)
+
+	oldTeamID := uuid.New()
+	newTeamID := uuid.New()
+	user := suite.factories.User.Create()
+	user.ID = uuid.New()
+	user.TeamID = &oldTeamID
+
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+
+	_, err := suite.userService.UpdateUserTeam(user.ID, newTeamID, "I999999")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []service.EventType{service.EventUserUpdated, service.EventUserTeamChanged}, publisher.types())
+	assert.Equal(suite.T(), map[string]service.FieldChange{
+		"team_id": {Old: oldTeamID.String(), New: newTeamID.String()},
+	}, publisher.events[1].Changes)
+}
+
+// TestEvents_DeleteUser tests that deleting a user publishes UserDeleted
+func (suite *UserServiceTestSuite) TestEvents_DeleteUser() {
+	publisher := &recordingPublisher{}
+	suite.userService.SetEventPublisher(publisher)
+
+	user := suite.factories.User.Create()
+	user.ID = uuid.New()
+
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Delete(user.ID).Return(nil).Times(1)
+
+	err := suite.userService.DeleteUser(user.ID)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []service.EventType{service.EventUserDeleted}, publisher.types())
+	assert.Equal(suite.T(), user.ID, publisher.events[0].UserID)
+	assert.Equal(suite.T(), user.Email, publisher.events[0].Changes["email"].Old)
+}
+
+// TestEvents_NotPublishedOnFailedWrite tests that no event fires when the repository write fails
+func (suite *UserServiceTestSuite) TestEvents_NotPublishedOnFailedWrite() {
+	publisher := &recordingPublisher{}
+	suite.userService.SetEventPublisher(publisher)
+
+	user := suite.factories.User.Create()
+	user.ID = uuid.New()
+
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Delete(user.ID).Return(gorm.ErrInvalidDB).Times(1)
+
+	err := suite.userService.DeleteUser(user.ID)
+
+	assert.Error(suite.T(), err)
+	assert.Empty(suite.T(), publisher.events)
+}
+
+// TestEvents_PublishErrorDoesNotFailWrite tests that a failing publisher does not fail the operation
+func (suite *UserServiceTestSuite) TestEvents_PublishErrorDoesNotFailWrite() {
+	publisher := &recordingPublisher{err: errors.New("broker unavailable")}
+	suite.userService.SetEventPublisher(publisher)
+
+	user := suite.factories.User.Create()
+	user.ID = uuid.New()
+
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Delete(user.ID).Return(nil).Times(1)
+
+	err := suite.userService.DeleteUser(user.ID)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), publisher.events, 1)
+}
+
+// TestMetadataAudit_AddFavoriteLink tests that adding a favorite writes an audit entry
+func (suite *UserServiceTestSuite) TestMetadataAudit_AddFavoriteLink() {
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	userID := "I123456"
+	linkID := uuid.New()
+	user := suite.factories.User.Create()
+	user.UserID = userID
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+	auditRepo.EXPECT().
+		Create(gomock.Any()).
+		DoAndReturn(func(entry *models.MetadataAudit) error {
+			assert.Equal(suite.T(), userID, entry.UserID)
+			assert.Equal(suite.T(), models.MetadataAuditFieldFavorites, entry.Field)
+			assert.Equal(suite.T(), models.MetadataAuditOperationAdd, entry.Operation)
+			assert.Equal(suite.T(), linkID, entry.TargetID)
+			assert.Equal(suite.T(), "I999999", entry.Actor)
+			assert.False(suite.T(), entry.CreatedAt.IsZero())
+			return nil
+		}).
+		Times(1)
+
+	_, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, "I999999")
+
+	assert.NoError(suite.T(), err)
+}
+
+// TestMetadataAudit_RemoveSubscribedPlugin tests that removing a plugin subscription writes an audit entry
+func (suite *UserServiceTestSuite) TestMetadataAudit_RemoveSubscribedPlugin() {
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	userID := "I123456"
+	pluginID := uuid.New()
+	metadataBytes, _ := json.Marshal(map[string]interface{}{"subscribed": []string{pluginID.String()}})
+	user := suite.factories.User.Create()
+	user.UserID = userID
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+	auditRepo.EXPECT().
+		Create(gomock.Any()).
+		DoAndReturn(func(entry *models.MetadataAudit) error {
+			assert.Equal(suite.T(), models.MetadataAuditFieldSubscribed, entry.Field)
+			assert.Equal(suite.T(), models.MetadataAuditOperationRemove, entry.Operation)
+			assert.Equal(suite.T(), pluginID, entry.TargetID)
+			assert.Equal(suite.T(), userID, entry.Actor)
+			return nil
+		}).
+		Times(1)
+
+	_, err := suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+
+	assert.NoError(suite.T(), err)
+}
+
+// TestMetadataAudit_ClearFavoriteLinks tests that clearing favorites writes a remove entry per removed link
+func (suite *UserServiceTestSuite) TestMetadataAudit_ClearFavoriteLinks() {
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	userID := "I123456"
+	first, second := uuid.New(), uuid.New()
+	metadataBytes, _ := json.Marshal(map[string]interface{}{
+		"favorites":    []string{first.String(), second.String(), "not-a-uuid"},
+		"ai_instances": 5, // unrelated invalid key must not block the clear
+	})
+	user := suite.factories.User.Create()
+	user.UserID = userID
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+	var removed []uuid.UUID
+	auditRepo.EXPECT().
+		Create(gomock.Any()).
+		DoAndReturn(func(entry *models.MetadataAudit) error {
+			assert.Equal(suite.T(), models.MetadataAuditFieldFavorites, entry.Field)
+			assert.Equal(suite.T(), models.MetadataAuditOperationRemove, entry.Operation)
+			assert.Equal(suite.T(), "I999999", entry.Actor)
+			removed = append(removed, entry.TargetID)
+			return nil
+		}).
+		Times(2)
+
+	_, err := suite.userService.ClearFavoriteLinksByUserID(userID, "I999999")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []uuid.UUID{first, second}, removed)
+}
+
+// TestMetadataAudit_PruneUserMetadata tests that pruning writes a system remove entry per dangling ID,
+// even when an unrelated metadata key is invalid
+func (suite *UserServiceTestSuite) TestMetadataAudit_PruneUserMetadata() {
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	userID := "I123456"
+	liveLink, deadLink, deadPlugin := uuid.New(), uuid.New(), uuid.New()
+	user := suite.factories.User.Create()
+	user.UserID = userID
+	user.Metadata = json.RawMessage(`{"favorites":["` + liveLink.String() + `","` + deadLink.String() + `"],` +
+		`"subscribed":["` + deadPlugin.String() + `"],"ai_instances":"legacy"}`)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(user, nil).Times(1)
+	suite.mockLinkRepo.EXPECT().GetByIDs([]uuid.UUID{liveLink, deadLink}).
+		Return([]models.Link{{BaseModel: models.BaseModel{ID: liveLink}}}, nil).Times(1)
+	suite.mockPluginRepo.EXPECT().GetByIDs([]uuid.UUID{deadPlugin}).Return([]models.Plugin{}, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+	removed := map[models.MetadataAuditField]uuid.UUID{}
+	auditRepo.EXPECT().
+		Create(gomock.Any()).
+		DoAndReturn(func(entry *models.MetadataAudit) error {
+			assert.Equal(suite.T(), models.MetadataAuditOperationRemove, entry.Operation)
+			assert.Equal(suite.T(), "system", entry.Actor)
+			removed[entry.Field] = entry.TargetID
+			return nil
+		}).
+		Times(2)
+
+	report, err := suite.userService.PruneUserMetadata(userID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, report.PrunedFavorites)
+	assert.Equal(suite.T(), 1, report.PrunedSubscribed)
+	assert.Equal(suite.T(), map[models.MetadataAuditField]uuid.UUID{
+		models.MetadataAuditFieldFavorites:  deadLink,
+		models.MetadataAuditFieldSubscribed: deadPlugin,
+	}, removed)
+}
+
+// TestMetadataAudit_NoChangeNotAudited tests that re-adding a present ID or removing an absent one writes no audit entry
+func (suite *UserServiceTestSuite) TestMetadataAudit_NoChangeNotAudited() {
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	userID := "I123456"
+	linkID, pluginID := uuid.New(), uuid.New()
+	metadataBytes, _ := json.Marshal(map[string]interface{}{"favorites": []string{linkID.String()}})
+	user := suite.factories.User.Create()
+	user.UserID = userID
+	user.Metadata = json.RawMessage(metadataBytes)
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(user, nil).Times(2)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(2)
+	auditRepo.EXPECT().Create(gomock.Any()).Times(0)
+
+	_, err := suite.userService.AddFavoriteLinkByUserID(userID, linkID, userID)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.userService.RemoveSubscribedPluginByUserID(userID, pluginID, userID)
+	assert.NoError(suite.T(), err)
+}
+
+// TestMetadataAudit_NotWrittenOnFailedUpdate tests that no audit entry is written when the metadata update fails
+func (suite *UserServiceTestSuite) TestMetadataAudit_NotWrittenOnFailedUpdate() {
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	userID := "I123456"
+	user := suite.factories.User.Create()
+	user.UserID = userID
+
+	suite.mockUserRepo.EXPECT().GetByUserID(userID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(gorm.ErrInvalidDB).Times(1)
+	auditRepo.EXPECT().Create(gomock.Any()).Times(0)
+
+	_, err := suite.userService.AddFavoriteLinkByUserID(userID, uuid.New(), userID)
+
+	assert.Error(suite.T(), err)
+}
+
+// TestGetMetadataAudit tests retrieving a user's metadata audit log
+func (suite *UserServiceTestSuite) TestGetMetadataAudit() {
+	auditRepo := mocks.NewMockMetadataAuditRepositoryInterface(suite.ctrl)
+	suite.userService.SetMetadataAuditRepository(auditRepo)
+
+	entries := []models.MetadataAudit{{UserID: "I123456", Field: models.MetadataAuditFieldFavorites}}
+	auditRepo.EXPECT().GetByUserID("I123456", 10, 0).Return(entries, int64(1), nil).Times(1)
+
+	result, total, err := suite.userService.GetMetadataAudit("I123456", 10, 0)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entries, result)
+	assert.Equal(suite.T(), int64(1), total)
+
+	_, _, err = suite.userService.GetMetadataAudit("", 10, 0)
+	assert.Error(suite.T(), err)
+}
+
+// TestChangeUserRole_Success tests changing a user's domain and team role
+func (suite *UserServiceTestSuite) TestChangeUserRole_Success() {
+	user := suite.factories.User.Create()
+	user.ID = uuid.New()
+
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(updated *models.User) error {
+			assert.Equal(suite.T(), models.TeamDomainDevOps, updated.TeamDomain)
+			assert.Equal(suite.T(), models.TeamRoleManager, updated.TeamRole)
+			assert.Equal(suite.T(), "I999999", updated.UpdatedBy)
+			return nil
+		}).
+		Times(1)
+
+	response, err := suite.userService.ChangeUserRole(user.ID, "devops", "manager", "I999999")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "devops", response.TeamDomain)
+	assert.Equal(suite.T(), "manager", response.TeamRole)
+}
+
+// TestChangeUserRole_InvalidatesUserCaches tests that a role change drops the user's cached team expansion
+func (suite *UserServiceTestSuite) TestChangeUserRole_InvalidatesUserCaches() {
+	user := suite.factories.User.Create()
+	user.ID = uuid.New()
+	invalidator := mocks.NewMockAICoreServiceInterface(suite.ctrl)
+	suite.userService.RegisterCacheInvalidator(invalidator)
+
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil).Times(1)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(nil).Times(1)
+	invalidator.EXPECT().InvalidateTeamExpansion(user.Name).Return(nil).Times(1)
+
+	_, err := suite.userService.ChangeUserRole(user.ID, "devops", "manager", "I999999")
+
+	assert.NoError(suite.T(), err)
+}
+
+// TestChangeUserRole_InvalidRole tests that unknown domain or team role values are rejected
+func (suite *UserServiceTestSuite) TestChangeUserRole_InvalidRole() {
+	var validationErr *apperrors.ValidationError
+
+	response, err := suite.userService.ChangeUserRole(uuid.New(), "wizard", "member", "I999999")
+	assert.Nil(suite.T(), response)
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), "role", validationErr.Field)
+
+	response, err = suite.userService.ChangeUserRole(uuid.New(), "developer", "overlord", "I999999")
+	assert.Nil(suite.T(), response)
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), "team_role", validationErr.Field)
+}
+
+// TestChangeUserRole_MissingChangedBy tests that changed_by is required
+func (suite *UserServiceTestSuite) TestChangeUserRole_MissingChangedBy() {
+	response, err := suite.userService.ChangeUserRole(uuid.New(), "devops", "manager", "  ")
+
+	assert.Nil(suite.T(), response)
+	var validationErr *apperrors.ValidationError
+	suite.Require().ErrorAs(err, &validationErr)
+	assert.Equal(suite.T(), "changed_by", validationErr.Field)
+}
+
+// TestChangeUserRole_UserNotFound tests error when the user does not exist
+func (suite *UserServiceTestSuite) TestChangeUserRole_UserNotFound() {
+	userID := uuid.New()
+	suite.mockUserRepo.EXPECT().GetByID(userID).Return(nil, apperrors.ErrUserNotFound).Times(1)
+
+	response, err := suite.userService.ChangeUserRole(userID, "devops", "manager", "I999999")
+
+	assert.Nil(suite.T(), response)
+	assert.ErrorIs(suite.T(), err, apperrors.ErrUserNotFound)
+}
+
 func TestUserServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(UserServiceTestSuite))
 }
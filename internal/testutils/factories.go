@@ -69,8 +69,8 @@ func (f *UserFactory) Create() *models.User {
 		LastName:   "Doe",
 		Email:      "john.doe@test.com",
 		Mobile:     "+1-555-0123",
-		TeamDomain: models.TeamDomainDeveloper,
-		TeamRole:   models.TeamRoleMember,
+		TeamDomain: models.DefaultTeamDomain,
+		TeamRole:   models.DefaultTeamRole,
 	}
 }
 
@@ -122,6 +122,7 @@ func (s *BaseTestSuite) CleanTestDB() {
 		return
 	}
 	tables := []string{
+		"metadata_audit",
 		"plugins",
 		"links",
 		"components",